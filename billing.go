@@ -2,17 +2,21 @@ package shopify
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/gempages/go-shopify-graphql/graphql"
 )
 
 type BillingService interface {
 	AppCreditCreate(input *AppCreditCreateInput) (*AppCreditCreateResult, error)
+	AppCreditCreateWithContext(ctx context.Context, input *AppCreditCreateInput) (*AppCreditCreateResult, error)
 	AppPurchaseOneTimeCreate(input *AppPurchaseOneTimeCreateInput) (*AppPurchaseOneTimeCreateResult, error)
+	AppPurchaseOneTimeCreateWithContext(ctx context.Context, input *AppPurchaseOneTimeCreateInput) (*AppPurchaseOneTimeCreateResult, error)
 	AppSubscriptionCancel(id graphql.ID, prorate graphql.Boolean) (*AppSubscriptionCancelResult, error)
+	AppSubscriptionCancelWithContext(ctx context.Context, id graphql.ID, prorate graphql.Boolean) (*AppSubscriptionCancelResult, error)
 	AppSubscriptionCreate(input *AppSubscriptionCreateInput) (*AppSubscriptionCreateResult, error)
+	AppSubscriptionCreateWithContext(ctx context.Context, input *AppSubscriptionCreateInput) (*AppSubscriptionCreateResult, error)
 	AppSubscriptionTrialExtend(input *AppSubscriptionTrailExtendInput) (*AppSubscriptionTrailExtendResult, error)
+	AppSubscriptionTrialExtendWithContext(ctx context.Context, input *AppSubscriptionTrailExtendInput) (*AppSubscriptionTrailExtendResult, error)
 }
 
 type BillingServiceOp struct {
@@ -162,6 +166,10 @@ type MutationAppSubscriptionTrailExtendCreate struct {
 }
 
 func (instance *BillingServiceOp) AppCreditCreate(input *AppCreditCreateInput) (*AppCreditCreateResult, error) {
+	return instance.AppCreditCreateWithContext(instance.client.gql.Context(), input)
+}
+
+func (instance *BillingServiceOp) AppCreditCreateWithContext(ctx context.Context, input *AppCreditCreateInput) (*AppCreditCreateResult, error) {
 	m := MutationAppCreditCreate{}
 
 	if input != nil {
@@ -170,19 +178,23 @@ func (instance *BillingServiceOp) AppCreditCreate(input *AppCreditCreateInput) (
 			"test":        input.Test,
 			"description": input.Description,
 		}
-		err := instance.client.gql.Mutate(context.Background(), &m, vars)
+		err := instance.client.gql.Mutate(ctx, &m, vars)
 		if err != nil {
 			return nil, err
 		}
 
 		if len(m.AppCreditCreateResult.UserErrors) > 0 {
-			return nil, fmt.Errorf("%+v", m.AppCreditCreateResult.UserErrors)
+			return nil, &UserErrorsError{UserErrors: m.AppCreditCreateResult.UserErrors}
 		}
 	}
 	return &m.AppCreditCreateResult, nil
 }
 
 func (instance *BillingServiceOp) AppSubscriptionTrialExtend(input *AppSubscriptionTrailExtendInput) (*AppSubscriptionTrailExtendResult, error) {
+	return instance.AppSubscriptionTrialExtendWithContext(instance.client.gql.Context(), input)
+}
+
+func (instance *BillingServiceOp) AppSubscriptionTrialExtendWithContext(ctx context.Context, input *AppSubscriptionTrailExtendInput) (*AppSubscriptionTrailExtendResult, error) {
 	m := MutationAppSubscriptionTrailExtendCreate{}
 
 	if input != nil {
@@ -190,19 +202,23 @@ func (instance *BillingServiceOp) AppSubscriptionTrialExtend(input *AppSubscript
 			"days": input.Days,
 			"id":   input.ID,
 		}
-		err := instance.client.gql.Mutate(context.Background(), &m, vars)
+		err := instance.client.gql.Mutate(ctx, &m, vars)
 		if err != nil {
 			return nil, err
 		}
 
 		if len(m.AppSubscriptionTrailExtendResult.UserErrors) > 0 {
-			return nil, fmt.Errorf("%+v", m.AppSubscriptionTrailExtendResult.UserErrors)
+			return nil, &UserErrorsError{UserErrors: m.AppSubscriptionTrailExtendResult.UserErrors}
 		}
 	}
 	return &m.AppSubscriptionTrailExtendResult, nil
 }
 
 func (instance *BillingServiceOp) AppPurchaseOneTimeCreate(input *AppPurchaseOneTimeCreateInput) (*AppPurchaseOneTimeCreateResult, error) {
+	return instance.AppPurchaseOneTimeCreateWithContext(instance.client.gql.Context(), input)
+}
+
+func (instance *BillingServiceOp) AppPurchaseOneTimeCreateWithContext(ctx context.Context, input *AppPurchaseOneTimeCreateInput) (*AppPurchaseOneTimeCreateResult, error) {
 	m := MutationAppPurchaseOneTimeCreate{}
 
 	if input != nil {
@@ -212,37 +228,45 @@ func (instance *BillingServiceOp) AppPurchaseOneTimeCreate(input *AppPurchaseOne
 			"returnUrl": input.ReturnUrl,
 			"test":      input.Test,
 		}
-		err := instance.client.gql.Mutate(context.Background(), &m, vars)
+		err := instance.client.gql.Mutate(ctx, &m, vars)
 		if err != nil {
 			return nil, err
 		}
 
 		if len(m.AppPurchaseOneTimeCreateResult.UserErrors) > 0 {
-			return nil, fmt.Errorf("%+v", m.AppPurchaseOneTimeCreateResult.UserErrors)
+			return nil, &UserErrorsError{UserErrors: m.AppPurchaseOneTimeCreateResult.UserErrors}
 		}
 	}
 	return &m.AppPurchaseOneTimeCreateResult, nil
 }
 
 func (instance *BillingServiceOp) AppSubscriptionCancel(id graphql.ID, prorate graphql.Boolean) (*AppSubscriptionCancelResult, error) {
+	return instance.AppSubscriptionCancelWithContext(instance.client.gql.Context(), id, prorate)
+}
+
+func (instance *BillingServiceOp) AppSubscriptionCancelWithContext(ctx context.Context, id graphql.ID, prorate graphql.Boolean) (*AppSubscriptionCancelResult, error) {
 	m := MutationAppSubscriptionCancel{}
 
 	vars := map[string]interface{}{
 		"id":      id,
 		"prorate": prorate,
 	}
-	err := instance.client.gql.Mutate(context.Background(), &m, vars)
+	err := instance.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(m.AppSubscriptionCancelResult.UserErrors) > 0 {
-		return nil, fmt.Errorf("%+v", m.AppSubscriptionCancelResult.UserErrors)
+		return nil, &UserErrorsError{UserErrors: m.AppSubscriptionCancelResult.UserErrors}
 	}
 	return &m.AppSubscriptionCancelResult, nil
 }
 
 func (instance *BillingServiceOp) AppSubscriptionCreate(input *AppSubscriptionCreateInput) (*AppSubscriptionCreateResult, error) {
+	return instance.AppSubscriptionCreateWithContext(instance.client.gql.Context(), input)
+}
+
+func (instance *BillingServiceOp) AppSubscriptionCreateWithContext(ctx context.Context, input *AppSubscriptionCreateInput) (*AppSubscriptionCreateResult, error) {
 	m := MutationAppSubscriptionCreate{}
 
 	if input != nil {
@@ -253,13 +277,13 @@ func (instance *BillingServiceOp) AppSubscriptionCreate(input *AppSubscriptionCr
 			"test":      input.Test,
 			"trialDays": input.TrialDays,
 		}
-		err := instance.client.gql.Mutate(context.Background(), &m, vars)
+		err := instance.client.gql.Mutate(ctx, &m, vars)
 		if err != nil {
 			return nil, err
 		}
 
 		if len(m.AppSubscriptionCreateResult.UserErrors) > 0 {
-			return nil, fmt.Errorf("%+v", m.AppSubscriptionCreateResult.UserErrors)
+			return nil, &UserErrorsError{UserErrors: m.AppSubscriptionCreateResult.UserErrors}
 		}
 	}
 
@@ -1,46 +1,109 @@
 package shopify
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
-	"reflect"
+	"net/http"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/gempages/go-helper/tracing"
 	"github.com/gempages/go-shopify-graphql/graphql"
-	"github.com/gempages/go-shopify-graphql/rand"
 	"github.com/gempages/go-shopify-graphql/utils"
 	"github.com/getsentry/sentry-go"
-	jsoniter "github.com/json-iterator/go"
 	log "github.com/sirupsen/logrus"
 )
 
 type BulkOperationService interface {
 	BulkQuery(query string, v interface{}) error
+	BulkQueryWithContext(ctx context.Context, query string, v interface{}) error
+	// RunBulkQuery runs query as a bulk operation and returns the URL of its result once completed.
+	RunBulkQuery(query string) (url string, err error)
+	RunBulkQueryWithContext(ctx context.Context, query string) (url string, err error)
+	// OpenBulkResult streams the JSONL result at url, see NewBulkResultReader to decode it.
+	OpenBulkResult(url string) (io.ReadCloser, error)
+	OpenBulkResultWithContext(ctx context.Context, url string) (io.ReadCloser, error)
 
 	PostBulkQuery(query string) (graphql.ID, error)
+	PostBulkQueryWithContext(ctx context.Context, query string) (graphql.ID, error)
 	GetCurrentBulkQuery() (CurrentBulkOperation, error)
+	GetCurrentBulkQueryWithContext(ctx context.Context) (CurrentBulkOperation, error)
 	GetCurrentBulkQueryResultURL() (string, error)
+	GetCurrentBulkQueryResultURLWithContext(ctx context.Context) (string, error)
 	WaitForCurrentBulkQuery(interval time.Duration) (CurrentBulkOperation, error)
+	WaitForCurrentBulkQueryWithContext(ctx context.Context, interval time.Duration) (CurrentBulkOperation, error)
 	ShouldGetBulkQueryResultURL(id graphql.ID) (string, error)
+	ShouldGetBulkQueryResultURLWithContext(ctx context.Context, id graphql.ID) (string, error)
 	CancelRunningBulkQuery() error
+	CancelRunningBulkQueryWithContext(ctx context.Context) error
 	BulkQueryRunOnly(query string, out interface{}) (id graphql.ID, err error)
+	BulkQueryRunOnlyWithContext(ctx context.Context, query string, out interface{}) (id graphql.ID, err error)
 	GetBulkQueryResult(id graphql.ID) (bulkOperation CurrentBulkOperation, err error)
+	GetBulkQueryResultWithContext(ctx context.Context, id graphql.ID) (bulkOperation CurrentBulkOperation, err error)
 	MarshalBulkResult(url string, out interface{}) error
+	MarshalBulkResultWithContext(ctx context.Context, url string, out interface{}) error
+	// Get returns the bulk operation id, e.g. one started by ProductService.TriggerListAll before a restart.
+	Get(id graphql.ID) (CurrentBulkOperation, error)
+	GetWithContext(ctx context.Context, id graphql.ID) (CurrentBulkOperation, error)
+	// Wait waits for the bulk operation id to finish and returns it. Its URL is set when it completed with objects.
+	Wait(id graphql.ID) (CurrentBulkOperation, error)
+	WaitWithContext(ctx context.Context, id graphql.ID) (CurrentBulkOperation, error)
+
+	// RunBulkMutation runs mutation once per variables of vars as a bulk mutation and returns the URL of
+	// its result once completed. See BulkMutate to decode the result.
+	RunBulkMutation(mutation string, vars []map[string]interface{}) (url string, err error)
+	RunBulkMutationWithContext(ctx context.Context, mutation string, vars []map[string]interface{}) (url string, err error)
+	// StageBulkMutationVariables uploads the JSONL variables of a bulk mutation and returns their staged upload path.
+	StageBulkMutationVariables(r io.Reader) (path string, err error)
+	StageBulkMutationVariablesWithContext(ctx context.Context, r io.Reader) (path string, err error)
+	// PostBulkMutation starts a bulk mutation running mutation with each line of the variables staged at path.
+	PostBulkMutation(mutation string, path string) (graphql.ID, error)
+	PostBulkMutationWithContext(ctx context.Context, mutation string, path string) (graphql.ID, error)
+	GetCurrentBulkMutation() (CurrentBulkOperation, error)
+	GetCurrentBulkMutationWithContext(ctx context.Context) (CurrentBulkOperation, error)
+	// UseWebhook subscribes callbackURL to the BULK_OPERATIONS_FINISH webhook, whose handler notifier then
+	// ends the waits for bulk operations without waiting for their next poll.
+	UseWebhook(callbackURL string, notifier *BulkOperationNotifier) error
+	UseWebhookWithContext(ctx context.Context, callbackURL string, notifier *BulkOperationNotifier) error
+	WaitForCurrentBulkMutation(interval time.Duration) (CurrentBulkOperation, error)
+	WaitForCurrentBulkMutationWithContext(ctx context.Context, interval time.Duration) (CurrentBulkOperation, error)
+	// DisablePollBackoff makes the waits for bulk operations poll at the interval they're given, rather
+	// than double it after each poll up to 30 seconds.
+	DisablePollBackoff()
 }
 
 type BulkOperationServiceOp struct {
 	client *Client
+
+	// mu guards the options of the waits for bulk operations
+	mu sync.Mutex
+	// notifier wakes the waits for bulk operations, see UseWebhook
+	notifier *BulkOperationNotifier
+	// fixedPollInterval keeps the interval between polls from doubling, see DisablePollBackoff
+	fixedPollInterval bool
 }
 
+// bulkOperationMaxPollInterval caps the exponential backoff of the polling of bulk operations.
+const bulkOperationMaxPollInterval = 30 * time.Second
+
 type queryCurrentBulkOperation struct {
 	CurrentBulkOperation CurrentBulkOperation
 }
 
+type queryCurrentBulkMutation struct {
+	CurrentBulkOperation CurrentBulkOperation `graphql:"currentBulkOperation(type: MUTATION)" json:"currentBulkOperation"`
+}
+
+// BulkOperationType is the type of a bulk operation. A shop runs at most one bulk operation of each type at a time.
+type BulkOperationType string
+
+const (
+	BulkOperationTypeQuery    BulkOperationType = "QUERY"
+	BulkOperationTypeMutation BulkOperationType = "MUTATION"
+)
+
 type CurrentBulkOperation struct {
 	ID             graphql.ID     `json:"id"`
 	Status         graphql.String `json:"status"`
@@ -76,6 +139,24 @@ type mutationBulkOperationRunQueryCancel struct {
 	BulkOperationCancelResult bulkOperationCancelResult `graphql:"bulkOperationCancel(id: $id)" json:"bulkOperationCancel"`
 }
 
+const bulkOperationQuery = `
+query bulkOperation($id: ID!) {
+	node(id: $id) {
+		... on BulkOperation {
+			id
+			status
+			errorCode
+			createdAt
+			completedAt
+			objectCount
+			fileSize
+			url
+			partialDataUrl
+			query
+		}
+	}
+}`
+
 var gidRegex *regexp.Regexp
 
 func init() {
@@ -83,25 +164,48 @@ func init() {
 }
 
 func (s *BulkOperationServiceOp) PostBulkQuery(query string) (graphql.ID, error) {
+	return s.PostBulkQueryWithContext(s.client.gql.Context(), query)
+}
+
+func (s *BulkOperationServiceOp) PostBulkQueryWithContext(ctx context.Context, query string) (graphql.ID, error) {
 	m := mutationBulkOperationRunQuery{}
 	vars := map[string]interface{}{
 		"query": graphql.String(query),
 	}
 
-	err := s.client.gql.Mutate(s.client.gql.Context(), &m, vars)
+	err := s.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return nil, err
 	}
 	if len(m.BulkOperationRunQueryResult.UserErrors) > 0 {
-		return nil, fmt.Errorf("%+v", m.BulkOperationRunQueryResult.UserErrors)
+		return nil, &UserErrorsError{UserErrors: m.BulkOperationRunQueryResult.UserErrors}
 	}
 
 	return m.BulkOperationRunQueryResult.BulkOperation.ID, nil
 }
 
 func (s *BulkOperationServiceOp) GetCurrentBulkQuery() (CurrentBulkOperation, error) {
+	return s.GetCurrentBulkQueryWithContext(s.client.gql.Context())
+}
+
+func (s *BulkOperationServiceOp) GetCurrentBulkQueryWithContext(ctx context.Context) (CurrentBulkOperation, error) {
 	q := queryCurrentBulkOperation{}
-	err := s.client.gql.Query(s.client.gql.Context(), &q, nil)
+	err := s.client.gql.Query(ctx, &q, nil)
+	if err != nil {
+		return CurrentBulkOperation{}, err
+	}
+
+	return q.CurrentBulkOperation, nil
+}
+
+// currentBulkOperation returns the last bulk operation of type typ started by the app.
+func (s *BulkOperationServiceOp) currentBulkOperation(ctx context.Context, typ BulkOperationType) (CurrentBulkOperation, error) {
+	if typ != BulkOperationTypeMutation {
+		return s.GetCurrentBulkQueryWithContext(ctx)
+	}
+
+	q := queryCurrentBulkMutation{}
+	err := s.client.gql.Query(ctx, &q, nil)
 	if err != nil {
 		return CurrentBulkOperation{}, err
 	}
@@ -110,21 +214,34 @@ func (s *BulkOperationServiceOp) GetCurrentBulkQuery() (CurrentBulkOperation, er
 }
 
 func (s *BulkOperationServiceOp) GetCurrentBulkQueryResultURL() (url string, err error) {
-	return s.ShouldGetBulkQueryResultURL(nil)
+	return s.GetCurrentBulkQueryResultURLWithContext(s.client.gql.Context())
+}
+
+func (s *BulkOperationServiceOp) GetCurrentBulkQueryResultURLWithContext(ctx context.Context) (url string, err error) {
+	return s.ShouldGetBulkQueryResultURLWithContext(ctx, nil)
 }
 
 func (s *BulkOperationServiceOp) ShouldGetBulkQueryResultURL(id graphql.ID) (url string, err error) {
-	q, err := s.GetCurrentBulkQuery()
-	if err != nil {
-		return
-	}
+	return s.ShouldGetBulkQueryResultURLWithContext(s.client.gql.Context(), id)
+}
+
+func (s *BulkOperationServiceOp) ShouldGetBulkQueryResultURLWithContext(ctx context.Context, id graphql.ID) (url string, err error) {
+	return s.bulkOperationResultURL(ctx, BulkOperationTypeQuery, id)
+}
 
-	if id != nil && q.ID != id {
-		err = fmt.Errorf("Bulk operation ID doesn't match, got=%v, want=%v", q.ID, id)
+// bulkOperationResultURL waits for the bulk operation id, or the current one of type typ when id is nil,
+// to complete and returns the URL of its result, empty when there is no object.
+func (s *BulkOperationServiceOp) bulkOperationResultURL(ctx context.Context, typ BulkOperationType, id graphql.ID) (url string, err error) {
+	var q CurrentBulkOperation
+	if id == nil {
+		q, err = s.waitForBulkOperation(ctx, typ, 1*time.Second)
+	} else {
+		q, err = s.WaitWithContext(ctx, id)
+	}
+	if err != nil {
 		return
 	}
 
-	q, err = s.WaitForCurrentBulkQuery(1 * time.Second)
 	if q.Status != "COMPLETED" {
 		err = fmt.Errorf("Bulk operation didn't complete, status=%s, error_code=%s", q.Status, q.ErrorCode)
 		return
@@ -144,28 +261,78 @@ func (s *BulkOperationServiceOp) ShouldGetBulkQueryResultURL(id graphql.ID) (url
 }
 
 func (s *BulkOperationServiceOp) WaitForCurrentBulkQuery(interval time.Duration) (CurrentBulkOperation, error) {
-	q, err := s.GetCurrentBulkQuery()
-	if err != nil {
-		return q, fmt.Errorf("CurrentBulkOperation query error: %s", err)
-	}
+	return s.WaitForCurrentBulkQueryWithContext(s.client.gql.Context(), interval)
+}
 
-	for q.Status == "CREATED" || q.Status == "RUNNING" || q.Status == "CANCELING" {
-		span := sentry.StartSpan(s.client.gql.Context(), "time.sleep")
-		span.Description = "interval"
-		time.Sleep(interval)
-		tracing.FinishSpan(span, s.client.gql.Context().Err())
+func (s *BulkOperationServiceOp) WaitForCurrentBulkQueryWithContext(ctx context.Context, interval time.Duration) (CurrentBulkOperation, error) {
+	return s.waitForBulkOperation(ctx, BulkOperationTypeQuery, interval)
+}
 
-		q, err = s.GetCurrentBulkQuery()
+func (s *BulkOperationServiceOp) waitForBulkOperation(ctx context.Context, typ BulkOperationType, interval time.Duration) (CurrentBulkOperation, error) {
+	return s.pollBulkOperation(ctx, nil, interval, func() (CurrentBulkOperation, error) {
+		q, err := s.currentBulkOperation(ctx, typ)
 		if err != nil {
-			return q, fmt.Errorf("CurrentBulkOperation query error: %s", err)
+			return q, fmt.Errorf("CurrentBulkOperation query error: %w", err)
+		}
+		return q, nil
+	})
+}
+
+// pollBulkOperation calls get until the bulk operation it returns is no longer created, running or canceling.
+// The interval between calls doubles up to bulkOperationMaxPollInterval, unless DisablePollBackoff was called,
+// and a wait ends early when the notifier receives the webhook of the operation, id or else the one returned
+// by the first call.
+func (s *BulkOperationServiceOp) pollBulkOperation(ctx context.Context, id graphql.ID, interval time.Duration, get func() (CurrentBulkOperation, error)) (CurrentBulkOperation, error) {
+	s.mu.Lock()
+	notifier, fixed := s.notifier, s.fixedPollInterval
+	s.mu.Unlock()
+
+	for {
+		var (
+			finished <-chan struct{}
+			release  = func() {}
+		)
+		if notifier != nil && id != nil {
+			// subscribe before polling, so a webhook received in between isn't missed
+			finished, release = notifier.subscribe(fmt.Sprint(id))
 		}
-	}
 
-	return q, nil
+		q, err := get()
+		if err != nil || (q.Status != "CREATED" && q.Status != "RUNNING" && q.Status != "CANCELING") {
+			release()
+			return q, err
+		}
+		if id == nil && q.ID != nil && notifier != nil {
+			// poll again right away, subscribed to the webhook of the operation
+			id = q.ID
+			release()
+			continue
+		}
+
+		span := sentry.StartSpan(ctx, "time.sleep")
+		span.Description = "interval"
+		err = sleepUntil(ctx, interval, finished)
+		tracing.FinishSpan(span, err)
+		release()
+		if err != nil {
+			return q, err
+		}
+
+		if !fixed && interval < bulkOperationMaxPollInterval {
+			interval *= 2
+			if interval > bulkOperationMaxPollInterval {
+				interval = bulkOperationMaxPollInterval
+			}
+		}
+	}
 }
 
 func (s *BulkOperationServiceOp) CancelRunningBulkQuery() (err error) {
-	q, err := s.GetCurrentBulkQuery()
+	return s.CancelRunningBulkQueryWithContext(s.client.gql.Context())
+}
+
+func (s *BulkOperationServiceOp) CancelRunningBulkQueryWithContext(ctx context.Context) (err error) {
+	q, err := s.GetCurrentBulkQueryWithContext(ctx)
 	if err != nil {
 		return
 	}
@@ -179,25 +346,18 @@ func (s *BulkOperationServiceOp) CancelRunningBulkQuery() (err error) {
 			"id": operationID,
 		}
 
-		err = s.client.gql.Mutate(s.client.gql.Context(), &m, vars)
+		err = s.client.gql.Mutate(ctx, &m, vars)
 		if err != nil {
 			return err
 		}
 		if len(m.BulkOperationCancelResult.UserErrors) > 0 {
-			return fmt.Errorf("%+v", m.BulkOperationCancelResult.UserErrors)
+			return &UserErrorsError{UserErrors: m.BulkOperationCancelResult.UserErrors}
 		}
 
-		q, err = s.GetCurrentBulkQuery()
+		_, err = s.waitForBulkOperation(ctx, BulkOperationTypeQuery, 1*time.Second)
 		if err != nil {
 			return
 		}
-		for q.Status == "CREATED" || q.Status == "RUNNING" || q.Status == "CANCELING" {
-			log.Tracef("Bulk operation still %s...", q.Status)
-			q, err = s.GetCurrentBulkQuery()
-			if err != nil {
-				return
-			}
-		}
 		log.Debugln("Bulk operation cancelled")
 	}
 
@@ -205,13 +365,14 @@ func (s *BulkOperationServiceOp) CancelRunningBulkQuery() (err error) {
 }
 
 func (s *BulkOperationServiceOp) BulkQuery(query string, out interface{}) error {
-	var (
-		id  graphql.ID
-		err error
-	)
+	return s.BulkQueryWithContext(s.client.gql.Context(), query, out)
+}
+
+func (s *BulkOperationServiceOp) BulkQueryWithContext(ctx context.Context, query string, out interface{}) error {
+	var err error
 
 	// sentry tracing
-	span := sentry.StartSpan(s.client.gql.Context(), "shopify_graphql.bulk_query")
+	span := sentry.StartSpan(ctx, "shopify_graphql.bulk_query")
 	span.Data = map[string]interface{}{
 		"GraphQL Query": query,
 	}
@@ -220,75 +381,102 @@ func (s *BulkOperationServiceOp) BulkQuery(query string, out interface{}) error
 	}()
 	// end sentry tracing
 
-	_, err = s.WaitForCurrentBulkQuery(1 * time.Second)
+	url, err := s.RunBulkQueryWithContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
+	if url == "" {
+		return fmt.Errorf("Operation result URL is empty")
+	}
+
+	err = s.MarshalBulkResultWithContext(ctx, url, out)
+	return err
+}
+
+// RunBulkQuery waits for the running bulk query to finish, runs query as a bulk operation and waits
+// for it to complete. It returns the URL of the result, which is empty when no object matched.
+func (s *BulkOperationServiceOp) RunBulkQuery(query string) (url string, err error) {
+	return s.RunBulkQueryWithContext(s.client.gql.Context(), query)
+}
+
+func (s *BulkOperationServiceOp) RunBulkQueryWithContext(ctx context.Context, query string) (url string, err error) {
+	_, err = s.WaitForCurrentBulkQueryWithContext(ctx, 1*time.Second)
+	if err != nil {
+		return "", err
+	}
+
+	var id graphql.ID
 	err = utils.ExecWithRetries(s.client.retries, func() error {
-		id, err = s.PostBulkQuery(query)
+		id, err = s.PostBulkQueryWithContext(ctx, query)
 		return err
 	})
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if id == nil {
-		return fmt.Errorf("Posted operation ID is nil")
+		return "", fmt.Errorf("Posted operation ID is nil")
 	}
 
-	url, err := s.ShouldGetBulkQueryResultURL(id)
-	if err != nil {
-		return err
-	}
+	return s.ShouldGetBulkQueryResultURLWithContext(ctx, id)
+}
 
-	if url == "" {
-		return fmt.Errorf("Operation result URL is empty")
-	}
+// OpenBulkResult starts downloading the JSONL result at url. The caller must close the returned body.
+func (s *BulkOperationServiceOp) OpenBulkResult(url string) (body io.ReadCloser, err error) {
+	return s.OpenBulkResultWithContext(s.client.gql.Context(), url)
+}
 
-	filename := fmt.Sprintf("%s%s", rand.String(10), ".jsonl")
-	resultFile := filepath.Join(os.TempDir(), filename)
-	err = utils.DownloadFile(s.client.gql.Context(), resultFile, url)
+func (s *BulkOperationServiceOp) OpenBulkResultWithContext(ctx context.Context, url string) (body io.ReadCloser, err error) {
+	span := sentry.StartSpan(ctx, "shopify_graphql.open_bulk_result")
+	span.Description = url
+	defer func() {
+		tracing.FinishSpan(span, err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	err = parseBulkQueryResult(resultFile, out)
+	resp, err := s.client.gql.HTTPClient().Do(req)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err = &graphql.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Header: resp.Header, Body: b}
+		return nil, err
 	}
 
-	// Clean up to avoid storage build up
-	_ = os.Remove(resultFile)
-
-	return nil
+	return resp.Body, nil
 }
 
 func (s *BulkOperationServiceOp) MarshalBulkResult(url string, out interface{}) error {
-	filename := fmt.Sprintf("%s%s", rand.String(10), ".jsonl")
-	resultFile := filepath.Join(os.TempDir(), filename)
-	err := utils.DownloadFile(s.client.gql.Context(), resultFile, url)
-	if err != nil {
-		return err
-	}
+	return s.MarshalBulkResultWithContext(s.client.gql.Context(), url, out)
+}
 
-	err = parseBulkQueryResult(resultFile, out)
+func (s *BulkOperationServiceOp) MarshalBulkResultWithContext(ctx context.Context, url string, out interface{}) error {
+	body, err := s.OpenBulkResultWithContext(ctx, url)
 	if err != nil {
 		return err
 	}
+	defer body.Close()
 
-	// Clean up to avoid storage build up
-	_ = os.Remove(resultFile)
-
-	return nil
+	return parseBulkQueryResult(body, out)
 }
+
 func (s *BulkOperationServiceOp) BulkQueryRunOnly(query string, out interface{}) (id graphql.ID, err error) {
-	_, err = s.WaitForCurrentBulkQuery(1 * time.Second)
+	return s.BulkQueryRunOnlyWithContext(s.client.gql.Context(), query, out)
+}
+
+func (s *BulkOperationServiceOp) BulkQueryRunOnlyWithContext(ctx context.Context, query string, out interface{}) (id graphql.ID, err error) {
+	_, err = s.WaitForCurrentBulkQueryWithContext(ctx, 1*time.Second)
 	if err != nil {
 		return "", err
 	}
 
-	id, err = s.PostBulkQuery(query)
+	id, err = s.PostBulkQueryWithContext(ctx, query)
 	if err != nil {
 		return "", err
 	}
@@ -298,205 +486,82 @@ func (s *BulkOperationServiceOp) BulkQueryRunOnly(query string, out interface{})
 	}
 
 	return id, nil
-	/////////////
-	// url, err := s.ShouldGetBulkQueryResultURL(id)
-	// if err != nil {
-	// 	return err
-	// }
-
-	// if url == "" {
-	// 	return fmt.Errorf("Operation result URL is empty")
-	// }
-
-	// fmt.Println(url)
-	// filename := fmt.Sprintf("%s%s", rand.String(10), ".jsonl")
-	// resultFile := filepath.Join(os.TempDir(), filename)
-	// err = utils.DownloadFile(resultFile, url)
-	// if err != nil {
-	// 	return err
-	// }
-
-	// err = parseBulkQueryResult(resultFile, out)
-	// if err != nil {
-	// 	return err
-	// }
-
-	// return nil
 }
 
-// GetBulkQueryResult get current status of bulk querry id
+// GetBulkQueryResult returns the bulk operation id, or the current bulk query when id is nil.
 func (s *BulkOperationServiceOp) GetBulkQueryResult(id graphql.ID) (bulkOperation CurrentBulkOperation, err error) {
-	q, err := s.GetCurrentBulkQuery()
-	if err != nil {
-		return
-	}
-
-	if id != nil && q.ID != id {
-		err = fmt.Errorf("Bulk operation ID doesn't match, got=%v, want=%v", q.ID, id)
-		return q, err
-	}
-	return q, nil
+	return s.GetBulkQueryResultWithContext(s.client.gql.Context(), id)
 }
 
-func parseBulkQueryResult(resultFile string, out interface{}) (err error) {
-	if reflect.TypeOf(out).Kind() != reflect.Ptr {
-		err = fmt.Errorf("the out arg is not a pointer")
-		return
+func (s *BulkOperationServiceOp) GetBulkQueryResultWithContext(ctx context.Context, id graphql.ID) (bulkOperation CurrentBulkOperation, err error) {
+	if id == nil {
+		return s.GetCurrentBulkQueryWithContext(ctx)
 	}
+	return s.GetWithContext(ctx, id)
+}
 
-	outValue := reflect.ValueOf(out)
-	outSlice := outValue.Elem()
-	if outSlice.Kind() != reflect.Slice {
-		err = fmt.Errorf("the out arg is not a pointer to a slice interface")
-		return
-	}
+// Get returns the bulk operation id, whether or not it is the current one.
+func (s *BulkOperationServiceOp) Get(id graphql.ID) (CurrentBulkOperation, error) {
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
 
-	sliceItemType := outSlice.Type().Elem() // slice item type
-	sliceItemKind := sliceItemType.Kind()
-	itemType := sliceItemType // slice item underlying type
-	if sliceItemKind == reflect.Ptr {
-		itemType = itemType.Elem()
+func (s *BulkOperationServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (CurrentBulkOperation, error) {
+	vars := map[string]interface{}{
+		"id": id,
 	}
-
-	f, err := os.Open(resultFile)
+	out := struct {
+		Node *CurrentBulkOperation `json:"node"`
+	}{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, bulkOperationQuery, vars, &out)
+	})
 	if err != nil {
-		return
+		return CurrentBulkOperation{}, err
+	}
+	if out.Node == nil {
+		return CurrentBulkOperation{}, fmt.Errorf("bulk operation %v not found", id)
 	}
-	defer utils.CloseFile(f)
-
-	reader := bufio.NewReader(f)
-	json := jsoniter.ConfigFastest
-
-	childrenLookup := make(map[string]interface{})
-
-	for {
-		var line []byte
-		line, err = reader.ReadBytes('\n')
-		if err != nil {
-			break
-		}
-
-		parentID := json.Get(line, "__parentId")
-		if parentID.LastError() == nil {
-			gid := json.Get(line, "id")
-			if gid.LastError() != nil {
-				// get connection without ID => skip step, continue to other connection
-				continue
-			}
-			childObjType, childrenFieldName, err := concludeObjectType(gid.ToString())
-			if err != nil {
-				return err
-			}
-			childItem := reflect.New(childObjType).Interface()
-			err = json.Unmarshal(line, &childItem)
-			if err != nil {
-				return err
-			}
-			childItemVal := reflect.ValueOf(childItem).Elem()
-
-			var childrenSlice reflect.Value
-			var children map[string]interface{}
-			if val, ok := childrenLookup[parentID.ToString()]; ok {
-				children = val.(map[string]interface{})
-			} else {
-				children = make(map[string]interface{})
-			}
-
-			if val, ok := children[childrenFieldName]; ok {
-				childrenSlice = reflect.ValueOf(val)
-			} else {
-				childrenSlice = reflect.MakeSlice(reflect.SliceOf(childObjType), 0, 10)
-			}
-
-			childrenSlice = reflect.Append(childrenSlice, childItemVal)
-
-			children[childrenFieldName] = childrenSlice.Interface()
-			childrenLookup[parentID.ToString()] = children
-
-			continue
-		}
 
-		item := reflect.New(itemType).Interface()
-		err = json.Unmarshal(line, &item)
-		if err != nil {
-			return
-		}
-		itemVal := reflect.ValueOf(item)
+	return *out.Node, nil
+}
 
-		if sliceItemKind == reflect.Ptr {
-			outSlice.Set(reflect.Append(outSlice, itemVal))
-		} else {
-			outSlice.Set(reflect.Append(outSlice, itemVal.Elem()))
-		}
-	}
+// Wait polls the bulk operation id until it is no longer created, running or canceling, and returns it.
+// The polling backs off exponentially unless DisablePollBackoff was called, and ends as soon as the
+// BULK_OPERATIONS_FINISH webhook is received when UseWebhook was called.
+func (s *BulkOperationServiceOp) Wait(id graphql.ID) (CurrentBulkOperation, error) {
+	return s.WaitWithContext(s.client.gql.Context(), id)
+}
 
-	if len(childrenLookup) > 0 {
-		for i := 0; i < outSlice.Len(); i++ {
-			parent := outSlice.Index(i)
-			if parent.Kind() == reflect.Ptr {
-				parent = parent.Elem()
-			}
-			parentIDField := parent.FieldByName("ID")
-			if parentIDField.IsZero() {
-				return fmt.Errorf("No ID field on the first level")
-			}
-			parentID := parentIDField.Interface().(string)
-			if children, ok := childrenLookup[parentID]; ok {
-				childrenVal := reflect.ValueOf(children)
-				iter := childrenVal.MapRange()
-				for iter.Next() {
-					k := iter.Key()
-					v := reflect.ValueOf(iter.Value().Interface())
-					field := parent.FieldByName(k.String())
-					if !field.IsValid() {
-						return fmt.Errorf("Field '%s' not defined on the parent type %s", k.String(), parent.Type().String())
-					}
-					field.Set(v)
-				}
-			}
-		}
-	}
+func (s *BulkOperationServiceOp) WaitWithContext(ctx context.Context, id graphql.ID) (CurrentBulkOperation, error) {
+	return s.pollBulkOperation(ctx, id, 1*time.Second, func() (CurrentBulkOperation, error) {
+		return s.GetWithContext(ctx, id)
+	})
+}
 
-	if err != nil && err != io.EOF {
-		return
-	}
+// DisablePollBackoff makes the waits for bulk operations poll at the interval they're given, rather than
+// double it after each poll up to bulkOperationMaxPollInterval. It should be called before waiting for
+// operations.
+func (s *BulkOperationServiceOp) DisablePollBackoff() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixedPollInterval = true
+}
 
-	err = nil
-	return
+// sleepContext pauses for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	return sleepUntil(ctx, d, nil)
 }
 
-func concludeObjectType(gid string) (reflect.Type, string, error) {
-	submatches := gidRegex.FindStringSubmatch(gid)
-	if len(submatches) != 2 {
-		return reflect.TypeOf(nil), "", fmt.Errorf("malformed gid=`%s`", gid)
-	}
-	resource := submatches[1]
-	switch resource {
-	case "LineItem":
-		return reflect.TypeOf(LineItem{}), fmt.Sprintf("%ss", resource), nil
-	case "FulfillmentOrderLineItem":
-		return reflect.TypeOf(FulfillmentOrderLineItem{}), fmt.Sprintf("%ss", resource), nil
-	case "Metafield":
-		return reflect.TypeOf(Metafield{}), fmt.Sprintf("%ss", resource), nil
-	case "Order":
-		return reflect.TypeOf(Order{}), fmt.Sprintf("%ss", resource), nil
-	case "Product":
-		return reflect.TypeOf(ProductBulkResult{}), fmt.Sprintf("%ss", resource), nil
-	case "ProductVariant":
-		return reflect.TypeOf(ProductVariant{}), fmt.Sprintf("%ss", resource), nil
-	case "Collection":
-		return reflect.TypeOf(Collection{}), fmt.Sprintf("%ss", resource), nil
-	case "ProductImage":
-		return reflect.TypeOf(ProductImage{}), fmt.Sprintf("%ss", resource), nil
-	case "MediaImage":
-		return reflect.TypeOf(Media{}), "Media", nil
-	case "Video":
-		return reflect.TypeOf(Media{}), "Media", nil
-	case "Model3d":
-		return reflect.TypeOf(Media{}), "Media", nil
-	case "ExternalVideo":
-		return reflect.TypeOf(Media{}), "Media", nil
-	default:
-		return reflect.TypeOf(nil), "", fmt.Errorf("`%s` not implemented type", resource)
+// sleepUntil pauses for d, until wake is closed or until ctx is done, whichever comes first.
+func sleepUntil(ctx context.Context, d time.Duration, wake <-chan struct{}) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	case <-wake:
+		return nil
 	}
 }
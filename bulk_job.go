@@ -0,0 +1,210 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// BulkJobStatus is the processing status of a BulkJob.
+type BulkJobStatus string
+
+const (
+	// BulkJobStatusPending is set until the result of the operation has been handled.
+	BulkJobStatusPending BulkJobStatus = "PENDING"
+	// BulkJobStatusDone is set once the handler of the job returned without error.
+	BulkJobStatusDone BulkJobStatus = "DONE"
+	// BulkJobStatusFailed is set when the operation didn't complete, e.g. it failed or was canceled.
+	BulkJobStatusFailed BulkJobStatus = "FAILED"
+)
+
+// BulkJob is a bulk query started by BulkJobs and recorded in a BulkJobStore.
+type BulkJob struct {
+	// ID is the ID of the bulk operation.
+	ID string `json:"id"`
+	// Target names the handler of the result, see BulkJobs.Handle.
+	Target string        `json:"target"`
+	Query  string        `json:"query"`
+	Status BulkJobStatus `json:"status"`
+	// OperationStatus is the status of the bulk operation when it was last polled.
+	OperationStatus string `json:"operationStatus,omitempty"`
+	URL             string `json:"url,omitempty"`
+	// Error is the last error met processing the job.
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// BulkJobHandler processes the result of a completed bulk job. result is empty when the operation found no object.
+type BulkJobHandler func(ctx context.Context, job *BulkJob, result io.Reader) error
+
+// HandleBulkResult returns a BulkJobHandler calling fn with each top-level object of the result, decoded into T.
+func HandleBulkResult[T any](fn func(ctx context.Context, job *BulkJob, item *T) error) BulkJobHandler {
+	return func(ctx context.Context, job *BulkJob, result io.Reader) error {
+		r := NewBulkResultReader[T](result)
+		for {
+			item, err := r.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err = fn(ctx, job, item); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// BulkJobs runs bulk queries whose results are handled even if the process restarts while they run:
+// Start records each operation in a store, and Resume, called at startup, waits for the pending ones
+// and passes their result to the handler registered for their target.
+//
+//	jobs := shopify.NewBulkJobs(client.BulkOperation, shopify.NewFileBulkJobStore("bulk_jobs.json"))
+//	jobs.Handle("products", shopify.HandleBulkResult(func(ctx context.Context, job *shopify.BulkJob, p *shopify.ProductBulkResult) error {
+//		return save(p)
+//	}))
+//	go jobs.Resume(ctx)
+//	_, err := jobs.Start(ctx, "products", query)
+//
+// A handler is called at least once per completed job: when it fails, or the process stops before the
+// job is marked done, the next Resume calls it again.
+type BulkJobs struct {
+	bulk  BulkOperationService
+	store BulkJobStore
+
+	mu       sync.RWMutex
+	handlers map[string]BulkJobHandler
+}
+
+func NewBulkJobs(bulk BulkOperationService, store BulkJobStore) *BulkJobs {
+	return &BulkJobs{bulk: bulk, store: store, handlers: map[string]BulkJobHandler{}}
+}
+
+// Handle registers h as the handler of the results of the jobs of target.
+func (j *BulkJobs) Handle(target string, h BulkJobHandler) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.handlers[target] = h
+}
+
+// Start waits for the current bulk query to finish, runs query as a bulk operation and records it
+// as a pending job of target. Its result is handled by Resume or Process.
+func (j *BulkJobs) Start(ctx context.Context, target string, query string) (*BulkJob, error) {
+	_, err := j.bulk.WaitForCurrentBulkQueryWithContext(ctx, 1*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := j.bulk.PostBulkQueryWithContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		return nil, fmt.Errorf("Posted operation ID is nil")
+	}
+
+	now := time.Now().UTC()
+	job := &BulkJob{
+		ID:        fmt.Sprint(id),
+		Target:    target,
+		Query:     query,
+		Status:    BulkJobStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err = j.store.Save(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Resume processes the pending jobs of the store one after the other, see Process. It goes on when a
+// job fails and returns the first error.
+func (j *BulkJobs) Resume(ctx context.Context) error {
+	jobs, err := j.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, job := range jobs {
+		if job.Status != BulkJobStatusPending {
+			continue
+		}
+		if err = j.Process(ctx, job); err != nil {
+			log.Warnf("Couldn't process bulk job %s: %s", job.ID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+	}
+
+	return firstErr
+}
+
+// Process waits for the operation of job to finish, then downloads its result and passes it to the
+// handler of the job's target. The job is saved as done once the handler returns without error,
+// or as failed when the operation didn't complete.
+func (j *BulkJobs) Process(ctx context.Context, job *BulkJob) error {
+	j.mu.RLock()
+	handler, ok := j.handlers[job.Target]
+	j.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler registered for bulk job target `%s`", job.Target)
+	}
+
+	op, err := j.bulk.WaitWithContext(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	job.OperationStatus = string(op.Status)
+	job.URL = string(op.URL)
+	if op.Status != "COMPLETED" || op.ErrorCode != "" {
+		job.Status = BulkJobStatusFailed
+		job.Error = fmt.Sprintf("Bulk operation didn't complete, status=%s, error_code=%s", op.Status, op.ErrorCode)
+		return j.save(ctx, job)
+	}
+
+	err = j.handle(ctx, job, handler)
+	if err != nil {
+		job.Error = err.Error()
+		if saveErr := j.save(ctx, job); saveErr != nil {
+			log.Warnf("Couldn't save bulk job %s: %s", job.ID, saveErr)
+		}
+		return err
+	}
+
+	job.Status = BulkJobStatusDone
+	job.Error = ""
+	return j.save(ctx, job)
+}
+
+func (j *BulkJobs) handle(ctx context.Context, job *BulkJob, handler BulkJobHandler) error {
+	if job.URL == "" {
+		return handler(ctx, job, strings.NewReader(""))
+	}
+
+	body, err := j.bulk.OpenBulkResultWithContext(ctx, job.URL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	return handler(ctx, job, body)
+}
+
+func (j *BulkJobs) save(ctx context.Context, job *BulkJob) error {
+	job.UpdatedAt = time.Now().UTC()
+	return j.store.Save(ctx, job)
+}
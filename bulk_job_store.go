@@ -0,0 +1,196 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ErrBulkJobNotFound is returned by a BulkJobStore when no job has the requested ID.
+var ErrBulkJobNotFound = errors.New("bulk job not found")
+
+// BulkJobStore persists the bulk jobs tracked by BulkJobs, so they survive a restart.
+// Implementations must be safe for concurrent use.
+type BulkJobStore interface {
+	// Save inserts job, or replaces the job with the same ID.
+	Save(ctx context.Context, job *BulkJob) error
+	// Get returns the job id, or ErrBulkJobNotFound.
+	Get(ctx context.Context, id string) (*BulkJob, error)
+	// List returns all the jobs, oldest first.
+	List(ctx context.Context) ([]*BulkJob, error)
+	// Delete removes the job id. Deleting a missing job is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// MemoryBulkJobStore is a BulkJobStore keeping jobs in memory, for tests and short-lived processes.
+type MemoryBulkJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]BulkJob
+}
+
+func NewMemoryBulkJobStore() *MemoryBulkJobStore {
+	return &MemoryBulkJobStore{jobs: map[string]BulkJob{}}
+}
+
+func (s *MemoryBulkJobStore) Save(ctx context.Context, job *BulkJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = *job
+	return nil
+}
+
+func (s *MemoryBulkJobStore) Get(ctx context.Context, id string) (*BulkJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrBulkJobNotFound
+	}
+	return &job, nil
+}
+
+func (s *MemoryBulkJobStore) List(ctx context.Context) ([]*BulkJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedBulkJobs(s.jobs), nil
+}
+
+func (s *MemoryBulkJobStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, id)
+	return nil
+}
+
+// FileBulkJobStore is a BulkJobStore keeping jobs in a JSON file. The file is rewritten atomically on
+// each change, so it is suited to the handful of jobs a worker runs at a time.
+type FileBulkJobStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileBulkJobStore returns a store using the file at path, which is created on the first Save.
+func NewFileBulkJobStore(path string) *FileBulkJobStore {
+	return &FileBulkJobStore{path: path}
+}
+
+func (s *FileBulkJobStore) Save(ctx context.Context, job *BulkJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs, err := s.read()
+	if err != nil {
+		return err
+	}
+	jobs[job.ID] = *job
+	return s.write(jobs)
+}
+
+func (s *FileBulkJobStore) Get(ctx context.Context, id string) (*BulkJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	job, ok := jobs[id]
+	if !ok {
+		return nil, ErrBulkJobNotFound
+	}
+	return &job, nil
+}
+
+func (s *FileBulkJobStore) List(ctx context.Context) ([]*BulkJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return sortedBulkJobs(jobs), nil
+}
+
+func (s *FileBulkJobStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := jobs[id]; !ok {
+		return nil
+	}
+	delete(jobs, id)
+	return s.write(jobs)
+}
+
+func (s *FileBulkJobStore) read() (map[string]BulkJob, error) {
+	jobs := map[string]BulkJob{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return jobs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []BulkJob
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	for _, job := range list {
+		jobs[job.ID] = job
+	}
+	return jobs, nil
+}
+
+// write replaces the file with jobs through a temporary file, so a crash never leaves it truncated.
+func (s *FileBulkJobStore) write(jobs map[string]BulkJob) error {
+	list := make([]BulkJob, 0, len(jobs))
+	for _, job := range sortedBulkJobs(jobs) {
+		list = append(list, *job)
+	}
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+func sortedBulkJobs(jobs map[string]BulkJob) []*BulkJob {
+	list := make([]*BulkJob, 0, len(jobs))
+	for id := range jobs {
+		job := jobs[id]
+		list = append(list, &job)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
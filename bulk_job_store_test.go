@@ -0,0 +1,72 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBulkJobStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) BulkJobStore{
+		"memory": func(t *testing.T) BulkJobStore { return NewMemoryBulkJobStore() },
+		"file": func(t *testing.T) BulkJobStore {
+			return NewFileBulkJobStore(filepath.Join(t.TempDir(), "jobs", "bulk_jobs.json"))
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newStore(t)
+
+			jobs, err := s.List(ctx)
+			if err != nil || len(jobs) != 0 {
+				t.Fatalf("expected no job, got (%v, %v)", jobs, err)
+			}
+			if _, err = s.Get(ctx, "gid://shopify/BulkOperation/1"); !errors.Is(err, ErrBulkJobNotFound) {
+				t.Errorf("expected (%v), got (%v)", ErrBulkJobNotFound, err)
+			}
+
+			created := time.Date(2022, 9, 1, 10, 0, 0, 0, time.UTC)
+			second := &BulkJob{ID: "gid://shopify/BulkOperation/2", Target: "products", Status: BulkJobStatusPending, CreatedAt: created.Add(time.Minute)}
+			first := &BulkJob{ID: "gid://shopify/BulkOperation/1", Target: "orders", Status: BulkJobStatusPending, CreatedAt: created}
+			for _, job := range []*BulkJob{second, first} {
+				if err = s.Save(ctx, job); err != nil {
+					t.Fatalf("save: %v", err)
+				}
+			}
+
+			// saved jobs are copies
+			second.Status = BulkJobStatusDone
+			got, err := s.Get(ctx, second.ID)
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+			if got.Status != BulkJobStatusPending || got.Target != "products" {
+				t.Errorf("unexpected job %+v", got)
+			}
+
+			if err = s.Save(ctx, second); err != nil {
+				t.Fatalf("save: %v", err)
+			}
+			jobs, err = s.List(ctx)
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			if len(jobs) != 2 || jobs[0].ID != first.ID || jobs[1].Status != BulkJobStatusDone {
+				t.Errorf("unexpected jobs %+v", jobs)
+			}
+
+			if err = s.Delete(ctx, first.ID); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if err = s.Delete(ctx, first.ID); err != nil {
+				t.Errorf("delete a missing job: %v", err)
+			}
+			if jobs, _ = s.List(ctx); len(jobs) != 1 {
+				t.Errorf("expected 1 job left, got %d", len(jobs))
+			}
+		})
+	}
+}
@@ -0,0 +1,407 @@
+package shopify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/gempages/go-helper/tracing"
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
+	"github.com/getsentry/sentry-go"
+)
+
+const (
+	bulkMutationVariablesFilename = "bulk_op_vars.jsonl"
+	bulkMutationVariablesMimeType = "text/jsonl"
+)
+
+type StagedUploadTargetGenerateUploadResource string
+
+const (
+	StagedUploadTargetGenerateUploadResourceBulkMutationVariables StagedUploadTargetGenerateUploadResource = "BULK_MUTATION_VARIABLES"
+	StagedUploadTargetGenerateUploadResourceFile                  StagedUploadTargetGenerateUploadResource = "FILE"
+	StagedUploadTargetGenerateUploadResourceImage                 StagedUploadTargetGenerateUploadResource = "IMAGE"
+)
+
+type StagedUploadHttpMethodType string
+
+const (
+	StagedUploadHttpMethodTypePost StagedUploadHttpMethodType = "POST"
+	StagedUploadHttpMethodTypePut  StagedUploadHttpMethodType = "PUT"
+)
+
+type StagedUploadInput struct {
+	Resource   StagedUploadTargetGenerateUploadResource `json:"resource"`
+	Filename   string                                   `json:"filename"`
+	MimeType   string                                   `json:"mimeType"`
+	HttpMethod StagedUploadHttpMethodType               `json:"httpMethod,omitempty"`
+	FileSize   string                                   `json:"fileSize,omitempty"`
+}
+
+type StagedUploadParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type StagedMediaUploadTarget struct {
+	URL         string                  `json:"url"`
+	ResourceURL string                  `json:"resourceUrl"`
+	Parameters  []StagedUploadParameter `json:"parameters"`
+}
+
+type stagedUploadsCreateResult struct {
+	StagedTargets []StagedMediaUploadTarget `json:"stagedTargets"`
+	UserErrors    []UserErrors              `json:"userErrors"`
+}
+
+type bulkOperationRunMutationResult struct {
+	BulkOperation struct {
+		ID graphql.ID `json:"id"`
+	} `json:"bulkOperation"`
+	UserErrors []UserErrors `json:"userErrors"`
+}
+
+const stagedUploadsCreateMutation = `
+mutation stagedUploadsCreate($input: [StagedUploadInput!]!) {
+	stagedUploadsCreate(input: $input) {
+		stagedTargets {
+			url
+			resourceUrl
+			parameters {
+				name
+				value
+			}
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+const bulkOperationRunMutationMutation = `
+mutation bulkOperationRunMutation($mutation: String!, $stagedUploadPath: String!) {
+	bulkOperationRunMutation(mutation: $mutation, stagedUploadPath: $stagedUploadPath) {
+		bulkOperation {
+			id
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+// BulkMutationResult is the result of a bulk mutation for the variables at Index.
+type BulkMutationResult[T any] struct {
+	Index int
+	// Data is the data of the mutation response, e.g. a struct with a productCreate field.
+	Data T
+	// Err holds the GraphQL errors of the mutation, as graphql.Errors, or reports a missing result.
+	Err error
+}
+
+// BulkMutationError is returned by the *Bulk methods when the mutation failed for some of their inputs.
+type BulkMutationError struct {
+	// Failures are sorted by input index.
+	Failures []BulkMutationFailure
+}
+
+// BulkMutationFailure is the error of the mutation run for the input at Index.
+type BulkMutationFailure struct {
+	Index int
+	Err   error
+}
+
+// Error implements error interface.
+func (e *BulkMutationError) Error() string {
+	f := e.Failures[0]
+	return fmt.Sprintf("bulk mutation failed for %d input(s), input %d: %s", len(e.Failures), f.Index, f.Err)
+}
+
+// Is reports whether the error of any failure matches target, e.g. ErrUserErrors.
+func (e *BulkMutationError) Is(target error) bool {
+	for _, f := range e.Failures {
+		if errors.Is(f.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// BulkMutate runs mutation as a bulk mutation once per variables of vars and returns the result of each
+// run, in the order of vars. The data of each run is decoded into T.
+func BulkMutate[T any](ctx context.Context, s BulkOperationService, mutation string, vars []map[string]interface{}) ([]BulkMutationResult[T], error) {
+	if len(vars) == 0 {
+		return nil, nil
+	}
+
+	url, err := s.RunBulkMutationWithContext(ctx, mutation, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BulkMutationResult[T], len(vars))
+	for i := range results {
+		results[i].Index = i
+		results[i].Err = fmt.Errorf("no bulk mutation result for input %d", i)
+	}
+	if url == "" {
+		return results, nil
+	}
+
+	body, err := s.OpenBulkResultWithContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	reader := bufio.NewReader(body)
+	for n := 0; ; n++ {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, readErr
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var out struct {
+				Data       *T             `json:"data"`
+				Errors     graphql.Errors `json:"errors"`
+				LineNumber *int           `json:"__lineNumber"`
+			}
+			if err = json.Unmarshal(line, &out); err != nil {
+				return nil, err
+			}
+
+			// older API versions don't number the lines, which are then in the order of the variables
+			i := n
+			if out.LineNumber != nil {
+				i = *out.LineNumber
+			}
+			if i < 0 || i >= len(results) {
+				return nil, fmt.Errorf("bulk mutation result line %d out of range", i)
+			}
+
+			results[i].Err = nil
+			if out.Data != nil {
+				results[i].Data = *out.Data
+			}
+			if len(out.Errors) > 0 {
+				results[i].Err = out.Errors
+			}
+		}
+
+		if readErr == io.EOF {
+			return results, nil
+		}
+	}
+}
+
+// bulkMutationError returns a *BulkMutationError listing the results with errors, including the user errors
+// returned by userErrors, or nil when all the mutations succeeded.
+func bulkMutationError[T any](results []BulkMutationResult[T], userErrors func(*T) []UserErrors) error {
+	var failures []BulkMutationFailure
+	for i := range results {
+		r := &results[i]
+		err := r.Err
+		if err == nil {
+			if errs := userErrors(&r.Data); len(errs) > 0 {
+				err = &UserErrorsError{UserErrors: errs}
+			}
+		}
+		if err != nil {
+			failures = append(failures, BulkMutationFailure{Index: r.Index, Err: err})
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+	return &BulkMutationError{Failures: failures}
+}
+
+// RunBulkMutation waits for the running bulk mutation to finish, stages vars as JSONL, runs mutation with them
+// and waits for it to complete. It returns the URL of the result, which is empty when there is no result.
+func (s *BulkOperationServiceOp) RunBulkMutation(mutation string, vars []map[string]interface{}) (url string, err error) {
+	return s.RunBulkMutationWithContext(s.client.gql.Context(), mutation, vars)
+}
+
+func (s *BulkOperationServiceOp) RunBulkMutationWithContext(ctx context.Context, mutation string, vars []map[string]interface{}) (url string, err error) {
+	span := sentry.StartSpan(ctx, "shopify_graphql.bulk_mutation")
+	span.Description = graphql.QueryDescription(mutation)
+	defer func() {
+		tracing.FinishSpan(span, err)
+	}()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, v := range vars {
+		if err = enc.Encode(v); err != nil {
+			return "", err
+		}
+	}
+
+	_, err = s.WaitForCurrentBulkMutationWithContext(ctx, 1*time.Second)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := s.StageBulkMutationVariablesWithContext(ctx, &buf)
+	if err != nil {
+		return "", err
+	}
+
+	var id graphql.ID
+	err = utils.ExecWithThrottleRetries(s.client.retries, func() error {
+		id, err = s.PostBulkMutationWithContext(ctx, mutation, path)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if id == nil {
+		return "", fmt.Errorf("Posted operation ID is nil")
+	}
+
+	return s.bulkOperationResultURL(ctx, BulkOperationTypeMutation, id)
+}
+
+// StageBulkMutationVariables creates a staged upload target for the variables of a bulk mutation and uploads r to it.
+func (s *BulkOperationServiceOp) StageBulkMutationVariables(r io.Reader) (path string, err error) {
+	return s.StageBulkMutationVariablesWithContext(s.client.gql.Context(), r)
+}
+
+func (s *BulkOperationServiceOp) StageBulkMutationVariablesWithContext(ctx context.Context, r io.Reader) (path string, err error) {
+	input := []StagedUploadInput{{
+		Resource:   StagedUploadTargetGenerateUploadResourceBulkMutationVariables,
+		Filename:   bulkMutationVariablesFilename,
+		MimeType:   bulkMutationVariablesMimeType,
+		HttpMethod: StagedUploadHttpMethodTypePost,
+	}}
+	out := struct {
+		StagedUploadsCreateResult stagedUploadsCreateResult `json:"stagedUploadsCreate"`
+	}{}
+	err = utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, stagedUploadsCreateMutation, map[string]interface{}{"input": input}, &out)
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(out.StagedUploadsCreateResult.UserErrors) > 0 {
+		return "", &UserErrorsError{UserErrors: out.StagedUploadsCreateResult.UserErrors}
+	}
+	if len(out.StagedUploadsCreateResult.StagedTargets) == 0 {
+		return "", fmt.Errorf("no staged upload target")
+	}
+
+	target := out.StagedUploadsCreateResult.StagedTargets[0]
+	for _, p := range target.Parameters {
+		if p.Name == "key" {
+			path = p.Value
+		}
+	}
+	if path == "" {
+		return "", fmt.Errorf("staged upload target has no key parameter")
+	}
+
+	err = s.uploadStagedFile(ctx, target, bulkMutationVariablesFilename, r)
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// uploadStagedFile posts r as a multipart form with the parameters of target followed by the file.
+func (s *BulkOperationServiceOp) uploadStagedFile(ctx context.Context, target StagedMediaUploadTarget, filename string, r io.Reader) (err error) {
+	span := sentry.StartSpan(ctx, "shopify_graphql.upload_staged_file")
+	span.Description = target.URL
+	defer func() {
+		tracing.FinishSpan(span, err)
+	}()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, p := range target.Parameters {
+		if err = w.WriteField(p.Name, p.Value); err != nil {
+			return err
+		}
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(part, r); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := s.client.gql.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err = &graphql.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Header: resp.Header, Body: b}
+		return err
+	}
+
+	return nil
+}
+
+func (s *BulkOperationServiceOp) PostBulkMutation(mutation string, path string) (graphql.ID, error) {
+	return s.PostBulkMutationWithContext(s.client.gql.Context(), mutation, path)
+}
+
+func (s *BulkOperationServiceOp) PostBulkMutationWithContext(ctx context.Context, mutation string, path string) (graphql.ID, error) {
+	out := struct {
+		BulkOperationRunMutationResult bulkOperationRunMutationResult `json:"bulkOperationRunMutation"`
+	}{}
+	vars := map[string]interface{}{
+		"mutation":         mutation,
+		"stagedUploadPath": path,
+	}
+
+	err := s.client.gql.QueryString(ctx, bulkOperationRunMutationMutation, vars, &out)
+	if err != nil {
+		return nil, err
+	}
+	if len(out.BulkOperationRunMutationResult.UserErrors) > 0 {
+		return nil, &UserErrorsError{UserErrors: out.BulkOperationRunMutationResult.UserErrors}
+	}
+
+	return out.BulkOperationRunMutationResult.BulkOperation.ID, nil
+}
+
+func (s *BulkOperationServiceOp) GetCurrentBulkMutation() (CurrentBulkOperation, error) {
+	return s.GetCurrentBulkMutationWithContext(s.client.gql.Context())
+}
+
+func (s *BulkOperationServiceOp) GetCurrentBulkMutationWithContext(ctx context.Context) (CurrentBulkOperation, error) {
+	return s.currentBulkOperation(ctx, BulkOperationTypeMutation)
+}
+
+func (s *BulkOperationServiceOp) WaitForCurrentBulkMutation(interval time.Duration) (CurrentBulkOperation, error) {
+	return s.WaitForCurrentBulkMutationWithContext(s.client.gql.Context(), interval)
+}
+
+func (s *BulkOperationServiceOp) WaitForCurrentBulkMutationWithContext(ctx context.Context, interval time.Duration) (CurrentBulkOperation, error) {
+	return s.waitForBulkOperation(ctx, BulkOperationTypeMutation, interval)
+}
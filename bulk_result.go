@@ -0,0 +1,232 @@
+package shopify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// BulkResultReader decodes the JSONL result of a bulk operation one top-level object at a time.
+// Children lines, which follow their parent in the result, are appended to the slice field of
+// their parent, or grandparent and so on, matching their type (see RegisterBulkChildType), so
+// memory use is bounded by the size of a single object.
+type BulkResultReader[T any] struct {
+	d *bulkResultDecoder
+}
+
+// NewBulkResultReader returns a BulkResultReader decoding r into values of the struct type T.
+func NewBulkResultReader[T any](r io.Reader) *BulkResultReader[T] {
+	return &BulkResultReader[T]{d: newBulkResultDecoder(r, reflect.TypeOf((*T)(nil)).Elem())}
+}
+
+// Read returns the next top-level object with its children, or io.EOF when the result has been read.
+func (r *BulkResultReader[T]) Read() (*T, error) {
+	v, err := r.d.decode()
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface().(*T), nil
+}
+
+// StreamBulkQuery runs query as a bulk operation and calls fn with each top-level object of its
+// result, decoded into T as the result is downloaded. It stops at the first error returned by fn.
+func StreamBulkQuery[T any](ctx context.Context, s BulkOperationService, query string, fn func(*T) error) error {
+	url, err := s.RunBulkQueryWithContext(ctx, query)
+	if err != nil || url == "" {
+		return err
+	}
+
+	body, err := s.OpenBulkResultWithContext(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	r := NewBulkResultReader[T](body)
+	for {
+		item, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = fn(item); err != nil {
+			return err
+		}
+	}
+}
+
+// bulkResultDecoder assembles the objects of a bulk operation result, relying on descendant lines
+// following their top-level object: an object is complete when the next top-level line is read.
+type bulkResultDecoder struct {
+	reader   *bufio.Reader
+	itemType reflect.Type
+
+	// current is the top-level object being assembled and nodes its descendants by ID
+	current *bulkResultNode
+	nodes   map[string]*bulkResultNode
+}
+
+// bulkResultNode is a decoded object whose children are attached once they have all been read,
+// since appending a child to its parent's slice copies it.
+type bulkResultNode struct {
+	value    reflect.Value // pointer to the object
+	children []bulkResultChild
+}
+
+type bulkResultChild struct {
+	field reflect.StructField
+	node  *bulkResultNode
+}
+
+func newBulkResultDecoder(r io.Reader, itemType reflect.Type) *bulkResultDecoder {
+	return &bulkResultDecoder{reader: bufio.NewReader(r), itemType: itemType}
+}
+
+// decode returns a pointer to the next top-level object, or io.EOF.
+func (d *bulkResultDecoder) decode() (reflect.Value, error) {
+	for {
+		line, readErr := d.reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return reflect.Value{}, readErr
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			item, err := d.decodeLine(line)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if item != nil {
+				return item.assemble()
+			}
+		}
+
+		if readErr == io.EOF {
+			if d.current == nil {
+				return reflect.Value{}, io.EOF
+			}
+			item := d.current
+			d.current, d.nodes = nil, nil
+			return item.assemble()
+		}
+	}
+}
+
+// decodeLine decodes a line of the result. It returns the previous top-level object when line starts a new one.
+func (d *bulkResultDecoder) decodeLine(line []byte) (*bulkResultNode, error) {
+	json := jsoniter.ConfigFastest
+
+	parentID := json.Get(line, "__parentId")
+	if parentID.LastError() == nil {
+		return nil, d.addChild(line, parentID.ToString())
+	}
+
+	item := reflect.New(d.itemType)
+	if err := json.Unmarshal(line, item.Interface()); err != nil {
+		return nil, err
+	}
+
+	previous := d.current
+	d.current = &bulkResultNode{value: item}
+	d.nodes = map[string]*bulkResultNode{json.Get(line, "id").ToString(): d.current}
+	return previous, nil
+}
+
+func (d *bulkResultDecoder) addChild(line []byte, parentID string) error {
+	json := jsoniter.ConfigFastest
+
+	gid := json.Get(line, "id")
+	if gid.LastError() != nil {
+		// get connection without ID => skip step, continue to other connection
+		return nil
+	}
+	parent, ok := d.nodes[parentID]
+	if !ok {
+		return fmt.Errorf("parent `%s` of `%s` not found", parentID, gid.ToString())
+	}
+
+	resource, err := bulkResourceType(gid.ToString())
+	if err != nil {
+		return err
+	}
+	field, err := bulkChildField(parent.value.Elem().Type(), resource)
+	if err != nil {
+		return err
+	}
+
+	childType := field.Type.Elem()
+	if childType.Kind() == reflect.Ptr {
+		childType = childType.Elem()
+	}
+	child := &bulkResultNode{value: reflect.New(childType)}
+	if err = json.Unmarshal(line, child.value.Interface()); err != nil {
+		return err
+	}
+
+	parent.children = append(parent.children, bulkResultChild{field: field, node: child})
+	d.nodes[gid.ToString()] = child
+
+	return nil
+}
+
+// assemble appends the descendants of n to their parent's slice field and returns n's pointer.
+func (n *bulkResultNode) assemble() (reflect.Value, error) {
+	for _, c := range n.children {
+		child, err := c.node.assemble()
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		field, err := n.value.Elem().FieldByIndexErr(c.field.Index)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if c.field.Type.Elem().Kind() == reflect.Ptr {
+			field.Set(reflect.Append(field, child))
+		} else {
+			field.Set(reflect.Append(field, child.Elem()))
+		}
+	}
+	n.children = nil
+	return n.value, nil
+}
+
+// parseBulkQueryResult decodes the result read from r into out, a pointer to a slice.
+func parseBulkQueryResult(r io.Reader, out interface{}) error {
+	if reflect.TypeOf(out).Kind() != reflect.Ptr {
+		return fmt.Errorf("the out arg is not a pointer")
+	}
+
+	outSlice := reflect.ValueOf(out).Elem()
+	if outSlice.Kind() != reflect.Slice {
+		return fmt.Errorf("the out arg is not a pointer to a slice interface")
+	}
+
+	sliceItemType := outSlice.Type().Elem() // slice item type
+	itemType := sliceItemType               // slice item underlying type
+	if sliceItemType.Kind() == reflect.Ptr {
+		itemType = itemType.Elem()
+	}
+
+	d := newBulkResultDecoder(r, itemType)
+	for {
+		item, err := d.decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if sliceItemType.Kind() == reflect.Ptr {
+			outSlice.Set(reflect.Append(outSlice, item))
+		} else {
+			outSlice.Set(reflect.Append(outSlice, item.Elem()))
+		}
+	}
+}
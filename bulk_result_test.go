@@ -0,0 +1,187 @@
+package shopify
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+const productsBulkResult = `{"id":"gid://shopify/Product/1","title":"Snowboard"}
+{"id":"gid://shopify/ProductVariant/11","sku":"SNOW-S","__parentId":"gid://shopify/Product/1"}
+{"id":"gid://shopify/ProductVariant/12","sku":"SNOW-M","__parentId":"gid://shopify/Product/1"}
+{"id":"gid://shopify/Metafield/21","key":"size","__parentId":"gid://shopify/Product/1"}
+
+{"id":"gid://shopify/Product/2","title":"Ski"}
+{"id":"gid://shopify/ProductVariant/13","sku":"SKI","__parentId":"gid://shopify/Product/2"}`
+
+func TestParseBulkQueryResult(t *testing.T) {
+	var products []*ProductBulkResult
+	err := parseBulkQueryResult(strings.NewReader(productsBulkResult), &products)
+	if err != nil {
+		t.Fatalf("parse result: %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("expected 2 products, got %d", len(products))
+	}
+	if len(products[0].ProductVariants) != 2 || len(products[0].Metafields) != 1 {
+		t.Errorf("expected 2 variants and 1 metafield, got %d and %d", len(products[0].ProductVariants), len(products[0].Metafields))
+	}
+	if products[1].Title != "Ski" {
+		t.Errorf("expected title (%v), got (%v)", "Ski", products[1].Title)
+	}
+	// the last line has no trailing newline
+	if len(products[1].ProductVariants) != 1 || products[1].ProductVariants[0].SKU != "SKI" {
+		t.Errorf("unexpected variants of the last product %v", products[1].ProductVariants)
+	}
+
+	var values []ProductBulkResult
+	err = parseBulkQueryResult(strings.NewReader(productsBulkResult), &values)
+	if err != nil {
+		t.Fatalf("parse result: %v", err)
+	}
+	if len(values) != 2 || len(values[0].ProductVariants) != 2 {
+		t.Errorf("unexpected result %v", values)
+	}
+}
+
+func TestParseBulkQueryResultErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		result string
+		out    interface{}
+	}{
+		{name: "not a pointer", out: []ProductBulkResult{}},
+		{name: "not a slice", out: &ProductBulkResult{}},
+		{
+			name:   "unknown child type",
+			result: `{"id":"gid://shopify/Product/1"}` + "\n" + `{"id":"gid://shopify/Unknown/1","__parentId":"gid://shopify/Product/1"}`,
+			out:    &[]ProductBulkResult{},
+		},
+		{
+			name:   "child field not defined",
+			result: `{"id":"gid://shopify/Product/1"}` + "\n" + `{"id":"gid://shopify/LineItem/1","__parentId":"gid://shopify/Product/1"}`,
+			out:    &[]ProductBulkResult{},
+		},
+		{name: "malformed line", result: `{"id":`, out: &[]ProductBulkResult{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := parseBulkQueryResult(strings.NewReader(tt.result), tt.out); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestBulkResultReader(t *testing.T) {
+	lines := strings.SplitAfter(productsBulkResult, "\n")
+	pr, pw := io.Pipe()
+	go func() {
+		// the first product is complete once the second one starts
+		for _, l := range lines[:6] {
+			_, _ = pw.Write([]byte(l))
+		}
+	}()
+
+	r := NewBulkResultReader[ProductBulkResult](pr)
+	first, err := r.Read()
+	if err != nil {
+		t.Fatalf("read first product: %v", err)
+	}
+	if first.Title != "Snowboard" || len(first.ProductVariants) != 2 {
+		t.Errorf("unexpected first product %v", first)
+	}
+
+	go func() {
+		_, _ = pw.Write([]byte(strings.Join(lines[6:], "")))
+		pw.Close()
+	}()
+	second, err := r.Read()
+	if err != nil {
+		t.Fatalf("read second product: %v", err)
+	}
+	if second.Title != "Ski" || len(second.ProductVariants) != 1 {
+		t.Errorf("unexpected second product %v", second)
+	}
+	if _, err = r.Read(); err != io.EOF {
+		t.Errorf("expected (%v), got (%v)", io.EOF, err)
+	}
+}
+
+func TestParseBulkQueryResultGrandchildren(t *testing.T) {
+	result := `{"id":"gid://shopify/Product/1","title":"Snowboard"}
+{"id":"gid://shopify/ProductVariant/11","sku":"SNOW-S","__parentId":"gid://shopify/Product/1"}
+{"id":"gid://shopify/Metafield/31","key":"weight","__parentId":"gid://shopify/ProductVariant/11"}
+{"id":"gid://shopify/ProductVariant/12","sku":"SNOW-M","__parentId":"gid://shopify/Product/1"}
+{"id":"gid://shopify/Metafield/21","key":"size","__parentId":"gid://shopify/Product/1"}
+{"id":"gid://shopify/Metafield/32","key":"weight","__parentId":"gid://shopify/ProductVariant/12"}
+{"id":"gid://shopify/Metafield/33","key":"color","__parentId":"gid://shopify/ProductVariant/12"}
+`
+	var products []ProductBulkResult
+	if err := parseBulkQueryResult(strings.NewReader(result), &products); err != nil {
+		t.Fatalf("parse result: %v", err)
+	}
+	if len(products) != 1 || len(products[0].ProductVariants) != 2 {
+		t.Fatalf("unexpected result %v", products)
+	}
+	if n := len(products[0].Metafields); n != 1 {
+		t.Errorf("expected 1 product metafield, got %d", n)
+	}
+	variants := products[0].ProductVariants
+	if len(variants[0].Metafields) != 1 || len(variants[1].Metafields) != 2 || variants[1].Metafields[1].Key != "color" {
+		t.Errorf("unexpected variant metafields (%v, %v)", variants[0].Metafields, variants[1].Metafields)
+	}
+
+	err := parseBulkQueryResult(strings.NewReader(`{"id":"gid://shopify/Product/1"}
+{"id":"gid://shopify/Metafield/31","__parentId":"gid://shopify/ProductVariant/11"}`), &products)
+	if err == nil {
+		t.Error("expected an error for a child of an unknown parent")
+	}
+}
+
+type bulkTestLocation struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type bulkTestInventoryLevel struct {
+	ID        string            `json:"id"`
+	Available int               `json:"available"`
+	Location  *bulkTestLocation `json:"location"`
+}
+
+type bulkTestItem struct {
+	ID     string                    `json:"id"`
+	Levels []*bulkTestInventoryLevel `json:"inventoryLevels"`
+	Media  []Media                   `json:"media" bulk:"MediaImage, Video"`
+}
+
+func TestRegisterBulkChildType(t *testing.T) {
+	result := `{"id":"gid://shopify/InventoryItem/1"}
+{"id":"gid://shopify/InventoryLevel/11","available":3,"location":{"id":"gid://shopify/Location/1"},"__parentId":"gid://shopify/InventoryItem/1"}
+{"id":"gid://shopify/Video/21","__parentId":"gid://shopify/InventoryItem/1"}
+`
+	var items []bulkTestItem
+	err := parseBulkQueryResult(strings.NewReader(result), &items)
+	if err == nil {
+		t.Fatal("expected an error for an unregistered child type")
+	}
+
+	RegisterBulkChildType("InventoryLevel", &bulkTestInventoryLevel{}, "")
+	items = nil
+	if err = parseBulkQueryResult(strings.NewReader(result), &items); err != nil {
+		t.Fatalf("parse result: %v", err)
+	}
+	if len(items) != 1 || len(items[0].Levels) != 1 || items[0].Levels[0].Available != 3 {
+		t.Fatalf("unexpected result %v", items)
+	}
+	if len(items[0].Media) != 1 || items[0].Media[0].ID != "gid://shopify/Video/21" {
+		t.Errorf("expected the video in the field tagged with its type, got %v", items[0].Media)
+	}
+
+	RegisterBulkChildType("InventoryLevel", bulkTestInventoryLevel{}, "Media")
+	defer RegisterBulkChildType("InventoryLevel", bulkTestInventoryLevel{}, "")
+	if err = parseBulkQueryResult(strings.NewReader(result), &items); err == nil {
+		t.Error("expected an error for a registered field of another type")
+	}
+}
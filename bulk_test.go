@@ -0,0 +1,71 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBulkQuery(t *testing.T) {
+	c := newCassetteClient(t, "bulk_product_list_all")
+
+	products, err := c.Product.ListAllWithContext(context.Background())
+	if err != nil {
+		t.Fatalf("list products: %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("expected 2 products, got %d", len(products))
+	}
+	if products[0].Title != "Snowboard" || products[1].Title != "Ski" {
+		t.Errorf("unexpected products (%v, %v)", products[0].Title, products[1].Title)
+	}
+	if len(products[0].ProductVariants) != 2 {
+		t.Fatalf("expected 2 variants, got %d", len(products[0].ProductVariants))
+	}
+	if products[0].ProductVariants[1].SKU != "SNOW-M" {
+		t.Errorf("expected SKU (%v), got (%v)", "SNOW-M", products[0].ProductVariants[1].SKU)
+	}
+	if len(products[1].ProductVariants) != 1 {
+		t.Errorf("expected 1 variant, got %d", len(products[1].ProductVariants))
+	}
+}
+
+func TestPostBulkQueryUserErrors(t *testing.T) {
+	c := newCassetteClient(t, "bulk_post_user_errors")
+
+	_, err := c.BulkOperation.PostBulkQueryWithContext(context.Background(), "{ shop { id } }")
+	if !errors.Is(err, ErrUserErrors) {
+		t.Fatalf("expected (%v), got (%v)", ErrUserErrors, err)
+	}
+}
+
+func TestPollBulkOperationInterval(t *testing.T) {
+	poll := func(s *BulkOperationServiceOp) time.Duration {
+		polls := 0
+		start := time.Now()
+		_, err := s.pollBulkOperation(context.Background(), nil, 20*time.Millisecond, func() (CurrentBulkOperation, error) {
+			polls++
+			if polls < 5 {
+				return CurrentBulkOperation{Status: "RUNNING"}, nil
+			}
+			return CurrentBulkOperation{Status: "COMPLETED"}, nil
+		})
+		if err != nil {
+			t.Fatalf("poll bulk operation: %v", err)
+		}
+		return time.Since(start)
+	}
+
+	// 20ms, 40ms, 80ms, 160ms
+	if elapsed := poll(&BulkOperationServiceOp{}); elapsed < 300*time.Millisecond {
+		t.Errorf("expected the interval to back off, polled for %v", elapsed)
+	}
+
+	// 4 polls 20ms apart
+	s := &BulkOperationServiceOp{}
+	s.DisablePollBackoff()
+	if elapsed := poll(s); elapsed > 250*time.Millisecond {
+		t.Errorf("expected a fixed interval, polled for %v", elapsed)
+	}
+}
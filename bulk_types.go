@@ -0,0 +1,161 @@
+package shopify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// bulkChildType is a registered child type of bulk operation results.
+type bulkChildType struct {
+	typ   reflect.Type
+	field string
+}
+
+type bulkChildFieldKey struct {
+	parent   reflect.Type
+	resource string
+}
+
+var (
+	bulkChildTypesMu sync.RWMutex
+	bulkChildTypes   = map[string]bulkChildType{}
+	bulkChildFields  = map[bulkChildFieldKey]reflect.StructField{}
+)
+
+func init() {
+	RegisterBulkChildType("LineItem", LineItem{}, "LineItems")
+	RegisterBulkChildType("FulfillmentOrderLineItem", FulfillmentOrderLineItem{}, "FulfillmentOrderLineItems")
+	RegisterBulkChildType("Metafield", Metafield{}, "Metafields")
+	RegisterBulkChildType("Order", Order{}, "Orders")
+	RegisterBulkChildType("Product", ProductBulkResult{}, "Products")
+	RegisterBulkChildType("ProductVariant", ProductVariant{}, "ProductVariants")
+	RegisterBulkChildType("Collection", Collection{}, "Collections")
+	RegisterBulkChildType("ProductImage", ProductImage{}, "ProductImages")
+	RegisterBulkChildType("MediaImage", Media{}, "Media")
+	RegisterBulkChildType("Video", Media{}, "Media")
+	RegisterBulkChildType("Model3d", Media{}, "Media")
+	RegisterBulkChildType("ExternalVideo", Media{}, "Media")
+}
+
+// RegisterBulkChildType registers how the lines of a bulk operation result whose GID has the
+// given resource type (e.g. "ProductVariant" for gid://shopify/ProductVariant/1) are attached to
+// their parent: they are decoded into values of the type of v and appended to the parent's slice
+// field named field. When field is empty, the field is the only slice field of the parent holding
+// values of the type of v.
+//
+// A parent struct field may also list the resource types it holds in a bulk tag, which takes
+// precedence over the registry, e.g.:
+//
+//	Media []Media `json:"media,omitempty" bulk:"MediaImage,Video,Model3d,ExternalVideo"`
+func RegisterBulkChildType(resource string, v interface{}, field string) {
+	typ := reflect.TypeOf(v)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	bulkChildTypesMu.Lock()
+	defer bulkChildTypesMu.Unlock()
+	bulkChildTypes[resource] = bulkChildType{typ: typ, field: field}
+	// fields resolved with the previous registration are stale
+	bulkChildFields = map[bulkChildFieldKey]reflect.StructField{}
+}
+
+// bulkResourceType returns the resource type of gid, e.g. "Product" for gid://shopify/Product/1.
+func bulkResourceType(gid string) (string, error) {
+	submatches := gidRegex.FindStringSubmatch(gid)
+	if len(submatches) != 2 {
+		return "", fmt.Errorf("malformed gid=`%s`", gid)
+	}
+	return submatches[1], nil
+}
+
+// bulkChildField returns the slice field of the parent struct type holding the children of the resource type.
+func bulkChildField(parent reflect.Type, resource string) (reflect.StructField, error) {
+	key := bulkChildFieldKey{parent: parent, resource: resource}
+
+	bulkChildTypesMu.RLock()
+	field, ok := bulkChildFields[key]
+	child, registered := bulkChildTypes[resource]
+	bulkChildTypesMu.RUnlock()
+	if ok {
+		return field, nil
+	}
+
+	field, err := resolveBulkChildField(parent, resource, child, registered)
+	if err != nil {
+		return field, err
+	}
+
+	bulkChildTypesMu.Lock()
+	bulkChildFields[key] = field
+	bulkChildTypesMu.Unlock()
+
+	return field, nil
+}
+
+func resolveBulkChildField(parent reflect.Type, resource string, child bulkChildType, registered bool) (reflect.StructField, error) {
+	if parent.Kind() != reflect.Struct {
+		return reflect.StructField{}, fmt.Errorf("parent type %s of `%s` is not a struct", parent.String(), resource)
+	}
+
+	fields := reflect.VisibleFields(parent)
+	for _, f := range fields {
+		tag, ok := f.Tag.Lookup("bulk")
+		if !ok {
+			continue
+		}
+		for _, r := range strings.Split(tag, ",") {
+			if strings.TrimSpace(r) != resource {
+				continue
+			}
+			if f.Type.Kind() != reflect.Slice {
+				return f, fmt.Errorf("Field '%s' of the parent type %s is not a slice", f.Name, parent.String())
+			}
+			return f, nil
+		}
+	}
+
+	if !registered {
+		return reflect.StructField{}, fmt.Errorf("`%s` not implemented type", resource)
+	}
+
+	if child.field != "" {
+		f, ok := parent.FieldByName(child.field)
+		if !ok {
+			return f, fmt.Errorf("Field '%s' not defined on the parent type %s", child.field, parent.String())
+		}
+		if !isBulkChildSlice(f.Type, child.typ) {
+			return f, fmt.Errorf("Field '%s' of the parent type %s is not a slice of %s", f.Name, parent.String(), child.typ.String())
+		}
+		return f, nil
+	}
+
+	var matches []reflect.StructField
+	for _, f := range fields {
+		if f.IsExported() && isBulkChildSlice(f.Type, child.typ) {
+			matches = append(matches, f)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return reflect.StructField{}, fmt.Errorf("no field of the parent type %s is a slice of %s", parent.String(), child.typ.String())
+	case 1:
+		return matches[0], nil
+	default:
+		return reflect.StructField{}, fmt.Errorf("several fields of the parent type %s are slices of %s, register `%s` with a field name", parent.String(), child.typ.String(), resource)
+	}
+}
+
+// isBulkChildSlice reports whether t is a slice of elem or of pointers to elem.
+func isBulkChildSlice(t, elem reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	t = t.Elem()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == elem
+}
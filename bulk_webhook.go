@@ -0,0 +1,148 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
+)
+
+const (
+	webhookHmacHeader    = "X-Shopify-Hmac-Sha256"
+	maxWebhookBodyLength = 1 << 20
+)
+
+// BulkOperationsFinishWebhook is the payload of the BULK_OPERATIONS_FINISH webhook.
+type BulkOperationsFinishWebhook struct {
+	AdminGraphqlAPIID string  `json:"admin_graphql_api_id"`
+	CompletedAt       string  `json:"completed_at"`
+	CreatedAt         string  `json:"created_at"`
+	ErrorCode         *string `json:"error_code"`
+	// Status is the lowercase status of the operation, e.g. completed.
+	Status string `json:"status"`
+	// Type is the lowercase type of the operation, query or mutation.
+	Type string `json:"type"`
+}
+
+// BulkOperationNotifier wakes the callers waiting for a bulk operation as soon as Shopify sends the
+// BULK_OPERATIONS_FINISH webhook for it, instead of their next poll. It is the http.Handler to mount
+// at the callback URL passed to BulkOperationService.UseWebhook.
+type BulkOperationNotifier struct {
+	secret string
+
+	mu      sync.Mutex
+	waiters map[string][]chan struct{}
+}
+
+// NewBulkOperationNotifier returns a notifier checking that webhooks are signed with the app's API
+// secret key. An empty secret disables the check, which should only be done in tests.
+func NewBulkOperationNotifier(secret string) *BulkOperationNotifier {
+	return &BulkOperationNotifier{secret: secret, waiters: map[string][]chan struct{}{}}
+}
+
+// ServeHTTP handles a BULK_OPERATIONS_FINISH webhook.
+func (n *BulkOperationNotifier) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodyLength))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if n.secret != "" && !utils.VerifyWebhookHmac(n.secret, body, r.Header.Get(webhookHmacHeader)) {
+		http.Error(w, "invalid HMAC", http.StatusUnauthorized)
+		return
+	}
+
+	var payload BulkOperationsFinishWebhook
+	if err = json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	n.Notify(payload.AdminGraphqlAPIID)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Notify wakes the callers waiting for the bulk operation id.
+func (n *BulkOperationNotifier) Notify(id string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, ch := range n.waiters[id] {
+		close(ch)
+	}
+	delete(n.waiters, id)
+}
+
+// subscribe returns a channel closed when the bulk operation id is notified, and a function to call
+// when the channel is no longer needed.
+func (n *BulkOperationNotifier) subscribe(id string) (<-chan struct{}, func()) {
+	ch := make(chan struct{})
+	n.mu.Lock()
+	n.waiters[id] = append(n.waiters[id], ch)
+	n.mu.Unlock()
+
+	return ch, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		waiters := n.waiters[id]
+		for i, c := range waiters {
+			if c == ch {
+				waiters = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(waiters) == 0 {
+			delete(n.waiters, id)
+		} else {
+			n.waiters[id] = waiters
+		}
+	}
+}
+
+// UseWebhook makes the waits for bulk operations end as soon as notifier receives their
+// BULK_OPERATIONS_FINISH webhook, polling remaining as a fallback. Unless callbackURL is empty,
+// e.g. when the subscription is managed elsewhere, it subscribes callbackURL to the topic if
+// no subscription already delivers it there. It should be called before waiting for operations.
+func (s *BulkOperationServiceOp) UseWebhook(callbackURL string, notifier *BulkOperationNotifier) error {
+	return s.UseWebhookWithContext(s.client.gql.Context(), callbackURL, notifier)
+}
+
+func (s *BulkOperationServiceOp) UseWebhookWithContext(ctx context.Context, callbackURL string, notifier *BulkOperationNotifier) error {
+	s.mu.Lock()
+	s.notifier = notifier
+	s.mu.Unlock()
+	if callbackURL == "" {
+		return nil
+	}
+
+	topic := WebhookSubscriptionTopicBulkOperationsFinish
+	subscriptions, err := s.client.Webhook.ListWebhookSubscriptionsWithContext(ctx, []WebhookSubscriptionTopic{topic})
+	if err != nil {
+		return err
+	}
+	for _, sub := range subscriptions {
+		if string(sub.CallbackURL) == callbackURL || string(sub.Endpoint.WebhookHTTPEndpoint.CallbackURL) == callbackURL {
+			return nil
+		}
+	}
+
+	out, err := s.client.Webhook.NewWebhookSubscriptionWithContext(ctx, WebhookTopic{WebhookSubscriptionTopic: topic}, WebhookTopicSubscription{
+		WebhookSubscriptionInput: WebhookSubscriptionInput{CallbackURL: graphql.String(callbackURL), Format: "JSON"},
+	})
+	if err != nil {
+		return err
+	}
+	if out.WebhookSubscription.ID == nil {
+		return fmt.Errorf("couldn't subscribe %s to %s", callbackURL, topic)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package shopify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBulkOperationNotifier(t *testing.T) {
+	n := NewBulkOperationNotifier("secret")
+	body := `{"admin_graphql_api_id":"gid://shopify/BulkOperation/1","completed_at":"2022-09-01T10:00:00-04:00","created_at":"2022-09-01T09:59:00-04:00","error_code":null,"status":"completed","type":"query"}`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	send := func(signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/bulk", strings.NewReader(body))
+		req.Header.Set(webhookHmacHeader, signature)
+		w := httptest.NewRecorder()
+		n.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	finished, release := n.subscribe("gid://shopify/BulkOperation/1")
+	defer release()
+	other, releaseOther := n.subscribe("gid://shopify/BulkOperation/2")
+	defer releaseOther()
+
+	if code := send("bm90IHRoZSBzaWduYXR1cmU="); code != http.StatusUnauthorized {
+		t.Errorf("expected status %d for a wrong HMAC, got %d", http.StatusUnauthorized, code)
+	}
+	select {
+	case <-finished:
+		t.Fatal("waiter woken by a webhook with a wrong HMAC")
+	default:
+	}
+
+	if code := send(signature); code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+	}
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("waiter not woken by the webhook")
+	}
+	select {
+	case <-other:
+		t.Error("waiter of another operation woken")
+	default:
+	}
+}
@@ -8,12 +8,19 @@ import (
 
 type CartService interface {
 	Get(id graphql.String) (*Cart, error)
+	GetWithContext(ctx context.Context, id graphql.String) (*Cart, error)
 	Create(cartInput *CartInput) (graphql.String, error)
+	CreateWithContext(ctx context.Context, cartInput *CartInput) (graphql.String, error)
 	CartLinesUpdate(id graphql.ID, cartLinesUpdateInput []CartLineUpdateInput) error
+	CartLinesUpdateWithContext(ctx context.Context, id graphql.ID, cartLinesUpdateInput []CartLineUpdateInput) error
 	CartLinesAdd(id graphql.ID, lines []CartLineInput) error
+	CartLinesAddWithContext(ctx context.Context, id graphql.ID, lines []CartLineInput) error
 	CartLinesRemove(id graphql.ID, lineIds []graphql.ID) error
+	CartLinesRemoveWithContext(ctx context.Context, id graphql.ID, lineIds []graphql.ID) error
 	CartNoteUpdate(id graphql.ID, note graphql.String) error
+	CartNoteUpdateWithContext(ctx context.Context, id graphql.ID, note graphql.String) error
 	CartDiscountCodesUpdate(id graphql.ID, discountCodes []graphql.String) error
+	CartDiscountCodesUpdateWithContext(ctx context.Context, id graphql.ID, discountCodes []graphql.String) error
 }
 
 type CartServiceOp struct {
@@ -121,6 +128,10 @@ const cartBaseQuery = `
 `
 
 func (c CartServiceOp) Get(id graphql.String) (*Cart, error) {
+	return c.GetWithContext(c.client.gql.Context(), id)
+}
+
+func (c CartServiceOp) GetWithContext(ctx context.Context, id graphql.String) (*Cart, error) {
 	q := fmt.Sprintf(`
 		query cart($id: ID!) {
 			cart(id: $id){
@@ -138,7 +149,7 @@ func (c CartServiceOp) Get(id graphql.String) (*Cart, error) {
 	out := struct {
 		Cart *Cart `json:"cart"`
 	}{}
-	err := c.client.gql.QueryString(context.Background(), q, vars, &out)
+	err := c.client.gql.QueryString(ctx, q, vars, &out)
 	if err != nil {
 		return nil, err
 	}
@@ -158,18 +169,22 @@ type MutationCartCreate struct {
 }
 
 func (c CartServiceOp) Create(cartInput *CartInput) (graphql.String, error) {
+	return c.CreateWithContext(c.client.gql.Context(), cartInput)
+}
+
+func (c CartServiceOp) CreateWithContext(ctx context.Context, cartInput *CartInput) (graphql.String, error) {
 	m := MutationCartCreate{}
 
 	vars := map[string]interface{}{
 		"cartInput": cartInput,
 	}
-	err := c.client.gql.Mutate(context.Background(), &m, vars)
+	err := c.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return "", err
 	}
 
 	if len(m.CartResult.UserErrors) > 0 {
-		return "", fmt.Errorf("%+v", m.CartResult.UserErrors)
+		return "", &UserErrorsError{UserErrors: m.CartResult.UserErrors}
 	}
 	id := m.CartResult.Cart.ID
 	return id, nil
@@ -188,19 +203,23 @@ type mutationCartLinesUpdate struct {
 }
 
 func (c CartServiceOp) CartLinesUpdate(id graphql.ID, cartLinesUpdateInput []CartLineUpdateInput) error {
+	return c.CartLinesUpdateWithContext(c.client.gql.Context(), id, cartLinesUpdateInput)
+}
+
+func (c CartServiceOp) CartLinesUpdateWithContext(ctx context.Context, id graphql.ID, cartLinesUpdateInput []CartLineUpdateInput) error {
 	m := mutationCartLinesUpdate{}
 
 	vars := map[string]interface{}{
 		"cartId": id,
 		"lines":  cartLinesUpdateInput,
 	}
-	err := c.client.gql.Mutate(context.Background(), &m, vars)
+	err := c.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.CartLinesUpdateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.CartLinesUpdateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.CartLinesUpdateResult.UserErrors}
 	}
 
 	return nil
@@ -211,19 +230,23 @@ type mutationCartLinesAdd struct {
 }
 
 func (c CartServiceOp) CartLinesAdd(id graphql.ID, lines []CartLineInput) error {
+	return c.CartLinesAddWithContext(c.client.gql.Context(), id, lines)
+}
+
+func (c CartServiceOp) CartLinesAddWithContext(ctx context.Context, id graphql.ID, lines []CartLineInput) error {
 	m := mutationCartLinesAdd{}
 
 	vars := map[string]interface{}{
 		"cartId": id,
 		"lines":  lines,
 	}
-	err := c.client.gql.Mutate(context.Background(), &m, vars)
+	err := c.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.CartLinesAddResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.CartLinesAddResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.CartLinesAddResult.UserErrors}
 	}
 
 	return nil
@@ -234,19 +257,23 @@ type mutationCartLinesRemove struct {
 }
 
 func (c CartServiceOp) CartLinesRemove(id graphql.ID, lineIds []graphql.ID) error {
+	return c.CartLinesRemoveWithContext(c.client.gql.Context(), id, lineIds)
+}
+
+func (c CartServiceOp) CartLinesRemoveWithContext(ctx context.Context, id graphql.ID, lineIds []graphql.ID) error {
 	m := mutationCartLinesRemove{}
 
 	vars := map[string]interface{}{
 		"cartId":  id,
 		"lineIds": lineIds,
 	}
-	err := c.client.gql.Mutate(context.Background(), &m, vars)
+	err := c.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.CartLinesRemoveResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.CartLinesRemoveResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.CartLinesRemoveResult.UserErrors}
 	}
 	return nil
 }
@@ -256,19 +283,23 @@ type mutationCartNoteUpdate struct {
 }
 
 func (c CartServiceOp) CartNoteUpdate(id graphql.ID, note graphql.String) error {
+	return c.CartNoteUpdateWithContext(c.client.gql.Context(), id, note)
+}
+
+func (c CartServiceOp) CartNoteUpdateWithContext(ctx context.Context, id graphql.ID, note graphql.String) error {
 	m := mutationCartNoteUpdate{}
 
 	vars := map[string]interface{}{
 		"cartId": id,
 		"note":   note,
 	}
-	err := c.client.gql.Mutate(context.Background(), &m, vars)
+	err := c.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.CartNoteUpdateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.CartNoteUpdateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.CartNoteUpdateResult.UserErrors}
 	}
 	return nil
 }
@@ -278,19 +309,23 @@ type mutationCartDiscountCodesUpdate struct {
 }
 
 func (c CartServiceOp) CartDiscountCodesUpdate(id graphql.ID, discountCodes []graphql.String) error {
+	return c.CartDiscountCodesUpdateWithContext(c.client.gql.Context(), id, discountCodes)
+}
+
+func (c CartServiceOp) CartDiscountCodesUpdateWithContext(ctx context.Context, id graphql.ID, discountCodes []graphql.String) error {
 	m := mutationCartDiscountCodesUpdate{}
 
 	vars := map[string]interface{}{
 		"cartId":        id,
 		"discountCodes": discountCodes,
 	}
-	err := c.client.gql.Mutate(context.Background(), &m, vars)
+	err := c.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.CartDiscountCodesUpdateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.CartDiscountCodesUpdateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.CartDiscountCodesUpdateResult.UserErrors}
 	}
 	return nil
 }
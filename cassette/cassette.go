@@ -0,0 +1,283 @@
+// Package cassette records the HTTP interactions of a Shopify client to fixture files
+// and replays them, so services can be tested without network access.
+//
+// Record a cassette against a live shop:
+//
+//	rec := cassette.NewRecorder("testdata/cassettes/product_get.json", nil)
+//	client := shopify.NewClientWithOpts(storeName, graphqlclient.WithToken(token), graphqlclient.WithMiddleware(rec.Middleware))
+//	// ... call services ...
+//	err := rec.Save()
+//
+// Replay it in tests:
+//
+//	rep, err := cassette.NewReplayer("testdata/cassettes/product_get.json")
+//	client := shopify.NewClientWithOpts(storeName, graphqlclient.WithTransport(rep))
+//
+// GraphQL requests are matched by their normalized query and variables, other requests by method and URL.
+// Only the query, variables, status code, content type and body are stored; credentials never are.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the recorded part of an HTTP request.
+type Request struct {
+	Method    string                 `json:"method"`
+	URL       string                 `json:"url,omitempty"`
+	Query     string                 `json:"query,omitempty"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the recorded part of an HTTP response.
+// JSON bodies are stored as is to keep fixtures readable, other bodies as a string.
+type Response struct {
+	StatusCode  int             `json:"statusCode"`
+	ContentType string          `json:"contentType,omitempty"`
+	JSON        json.RawMessage `json:"json,omitempty"`
+	Body        string          `json:"body,omitempty"`
+}
+
+// Cassette is the content of a fixture file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Load reads a cassette from path.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{}
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("decode cassette %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to path, creating parent directories as needed.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Recorder is an http.RoundTripper recording every interaction passing through it.
+type Recorder struct {
+	path string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder sending requests with next, or http.DefaultTransport if nil.
+// The interactions are written to path by Save.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{path: path, next: next}
+}
+
+// Middleware makes the Recorder usable with graphqlclient.WithMiddleware, recording before authentication.
+func (r *Recorder) Middleware(next http.RoundTripper) http.RoundTripper {
+	r.next = next
+	return r
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recReq, body, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	recResp := Response{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if isJSON(recResp.ContentType) && json.Valid(respBody) {
+		recResp.JSON = respBody
+	} else {
+		recResp.Body = string(respBody)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{Request: recReq, Response: recResp})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the Recorder's path.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Replayer is an http.RoundTripper serving recorded interactions.
+//
+// Identical requests are answered with their recorded responses in order, e.g. a bulk operation
+// polled until completion. Once exhausted, the last response is repeated.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions map[string][]*Interaction
+	served       map[string]int
+}
+
+// NewReplayer returns a Replayer serving the cassette at path.
+func NewReplayer(path string) (*Replayer, error) {
+	c, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayerFromCassette(c), nil
+}
+
+// NewReplayerFromCassette returns a Replayer serving the interactions of c.
+func NewReplayerFromCassette(c *Cassette) *Replayer {
+	r := &Replayer{
+		interactions: make(map[string][]*Interaction),
+		served:       make(map[string]int),
+	}
+	for _, i := range c.Interactions {
+		key := i.Request.key()
+		r.interactions[key] = append(r.interactions[key], i)
+	}
+	return r
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	recReq, _, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	key := recReq.key()
+
+	r.mu.Lock()
+	interactions := r.interactions[key]
+	if len(interactions) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("cassette: no interaction recorded for %s", key)
+	}
+	n := r.served[key]
+	if n >= len(interactions) {
+		n = len(interactions) - 1
+	}
+	r.served[key]++
+	i := interactions[n]
+	r.mu.Unlock()
+
+	body := []byte(i.Response.Body)
+	if len(i.Response.JSON) > 0 {
+		body = i.Response.JSON
+	}
+	header := make(http.Header)
+	if i.Response.ContentType != "" {
+		header.Set("Content-Type", i.Response.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+		StatusCode:    i.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// isJSON reports whether contentType is JSON, but not JSON Lines which must keep its line breaks.
+func isJSON(contentType string) bool {
+	return strings.Contains(contentType, "json") && !strings.Contains(contentType, "jsonl")
+}
+
+// readRequest extracts the recorded part of req, returning the consumed body so it can be restored.
+func readRequest(req *http.Request) (Request, []byte, error) {
+	r := Request{Method: req.Method}
+	if req.Body == nil || req.Body == http.NoBody {
+		r.URL = req.URL.String()
+		return r, nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return r, nil, err
+	}
+	var in struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err = json.Unmarshal(body, &in); err != nil || in.Query == "" {
+		r.URL = req.URL.String()
+		return r, body, nil
+	}
+	r.Query = in.Query
+	r.Variables = in.Variables
+	return r, body, nil
+}
+
+// key identifies requests that should be answered with the same interactions.
+func (r Request) key() string {
+	if r.Query == "" {
+		return r.Method + " " + r.URL
+	}
+	// encoding/json sorts map keys, so equal variables encode equally
+	vars, _ := json.Marshal(r.Variables)
+	if len(r.Variables) == 0 {
+		vars = []byte("{}")
+	}
+	return NormalizeQuery(r.Query) + " " + string(vars)
+}
+
+var (
+	spacesRe      = regexp.MustCompile(`\s+`)
+	punctuationRe = regexp.MustCompile(`\s*([{}():,!=$\[\]])\s*`)
+)
+
+// NormalizeQuery removes insignificant whitespace from a GraphQL query so equivalent queries compare equal.
+func NormalizeQuery(query string) string {
+	query = spacesRe.ReplaceAllString(strings.TrimSpace(query), " ")
+	return punctuationRe.ReplaceAllString(query, "$1")
+}
@@ -0,0 +1,80 @@
+package cassette
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	a := "query product($id: ID!) {\n\tproduct(id: $id) {\n\t\tid\n\t\ttitle\n\t}\n}"
+	b := "query product( $id:ID! ){ product(id:$id){ id title } }"
+	if NormalizeQuery(a) != NormalizeQuery(b) {
+		t.Errorf("expected (%v) to equal (%v)", NormalizeQuery(a), NormalizeQuery(b))
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.Write([]byte(`{"data": {"currentBulkOperation": {"status": "RUNNING"}}}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"currentBulkOperation": {"status": "COMPLETED"}}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec := NewRecorder(path, nil)
+	for i := 0; i < 2; i++ {
+		if _, err := send(rec, server.URL, `{"query": "{ currentBulkOperation { status } }"}`); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	rep, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	expected := []string{"RUNNING", "COMPLETED", "COMPLETED"}
+	for _, status := range expected {
+		body, err := send(rep, "http://replay.invalid/graphql.json", `{"query": "{\n\tcurrentBulkOperation {\n\t\tstatus\n\t}\n}"}`)
+		if err != nil {
+			t.Fatalf("replay: %v", err)
+		}
+		if !strings.Contains(body, status) {
+			t.Errorf("expected status (%v), got (%v)", status, body)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected replay not to hit the server, got %d calls", calls)
+	}
+
+	if _, err = send(rep, server.URL, `{"query": "{ shop { id } }"}`); err == nil {
+		t.Error("expected an error for an unrecorded request")
+	}
+}
+
+func send(rt http.RoundTripper, url string, body string) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(body))
+	if err != nil {
+		return "", err
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	return string(b), err
+}
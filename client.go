@@ -19,18 +19,23 @@ type Client struct {
 
 	retries int
 
-	Product       ProductService
-	Variant       VariantService
-	Inventory     InventoryService
-	Collection    CollectionService
-	Cart          CartService
-	Billing       BillingService
-	Order         OrderService
-	Fulfillment   FulfillmentService
-	Location      LocationService
-	Metafield     MetafieldService
-	BulkOperation BulkOperationService
-	Webhook       WebhookService
+	Product          ProductService
+	Variant          VariantService
+	Inventory        InventoryService
+	Collection       CollectionService
+	Cart             CartService
+	Billing          BillingService
+	Order            OrderService
+	DraftOrder       DraftOrderService
+	Customer         CustomerService
+	Discount         DiscountService
+	Fulfillment      FulfillmentService
+	FulfillmentOrder FulfillmentOrderService
+	Return           ReturnService
+	Location         LocationService
+	Metafield        MetafieldService
+	BulkOperation    BulkOperationService
+	Webhook          WebhookService
 }
 
 type ListOptions struct {
@@ -67,11 +72,16 @@ func NewClient(apiKey string, password string, storeName string) *Client {
 	c.Billing = &BillingServiceOp{client: c}
 	c.Collection = &CollectionServiceOp{client: c}
 	c.Order = &OrderServiceOp{client: c}
+	c.DraftOrder = &DraftOrderServiceOp{client: c}
 	c.Fulfillment = &FulfillmentServiceOp{client: c}
+	c.FulfillmentOrder = &FulfillmentOrderServiceOp{client: c}
+	c.Return = &ReturnServiceOp{client: c}
 	c.Location = &LocationServiceOp{client: c}
 	c.Metafield = &MetafieldServiceOp{client: c}
 	c.BulkOperation = &BulkOperationServiceOp{client: c}
 	c.Webhook = &WebhookServiceOp{client: c}
+	c.Customer = &CustomerServiceOp{client: c}
+	c.Discount = &DiscountServiceOp{client: c}
 
 	return c
 }
@@ -103,11 +113,16 @@ func NewClientWithOpts(storeName string, opts ...graphqlclient.Option) *Client {
 	c.Billing = &BillingServiceOp{client: c}
 	c.Collection = &CollectionServiceOp{client: c}
 	c.Order = &OrderServiceOp{client: c}
+	c.DraftOrder = &DraftOrderServiceOp{client: c}
 	c.Fulfillment = &FulfillmentServiceOp{client: c}
+	c.FulfillmentOrder = &FulfillmentOrderServiceOp{client: c}
+	c.Return = &ReturnServiceOp{client: c}
 	c.Location = &LocationServiceOp{client: c}
 	c.Metafield = &MetafieldServiceOp{client: c}
 	c.BulkOperation = &BulkOperationServiceOp{client: c}
 	c.Webhook = &WebhookServiceOp{client: c}
+	c.Customer = &CustomerServiceOp{client: c}
+	c.Discount = &DiscountServiceOp{client: c}
 
 	return c
 }
@@ -125,11 +140,16 @@ func NewClientWithToken(apiKey string, storeName string) *Client {
 	c.Billing = &BillingServiceOp{client: c}
 	c.Collection = &CollectionServiceOp{client: c}
 	// c.Order = &OrderServiceOp{client: c}
+	// c.DraftOrder = &DraftOrderServiceOp{client: c}
 	// c.Fulfillment = &FulfillmentServiceOp{client: c}
+	// c.FulfillmentOrder = &FulfillmentOrderServiceOp{client: c}
+	// c.Return = &ReturnServiceOp{client: c}
 	// c.Location = &LocationServiceOp{client: c}
 	c.Metafield = &MetafieldServiceOp{client: c}
 	c.BulkOperation = &BulkOperationServiceOp{client: c}
 	c.Webhook = &WebhookServiceOp{client: c}
+	c.Customer = &CustomerServiceOp{client: c}
+	c.Discount = &DiscountServiceOp{client: c}
 
 	return c
 }
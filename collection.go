@@ -15,17 +15,29 @@ import (
 
 type CollectionService interface {
 	List(query string) ([]*CollectionBulkResult, error)
+	ListWithContext(ctx context.Context, query string) ([]*CollectionBulkResult, error)
 	ListAll() ([]*CollectionBulkResult, error)
+	ListAllWithContext(ctx context.Context) ([]*CollectionBulkResult, error)
 	ListByCursor(first int, cursor string) (*CollectionsQueryResult, error)
+	ListByCursorWithContext(ctx context.Context, first int, cursor string) (*CollectionsQueryResult, error)
 	ListWithFields(first int, cursor string, query string, fields string) (*CollectionsQueryResult, error)
+	ListWithFieldsWithContext(ctx context.Context, first int, cursor string, query string, fields string) (*CollectionsQueryResult, error)
+	// Iter returns an iterator over the collections matching opts.Query, fetching pages on demand.
+	Iter(opts ListOptions) *Iterator[*CollectionQueryResult]
+	IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*CollectionQueryResult]
 
 	Get(id graphql.ID) (*CollectionQueryResult, error)
+	GetWithContext(ctx context.Context, id graphql.ID) (*CollectionQueryResult, error)
 	GetSingleCollection(id graphql.ID, cursor string) (*CollectionQueryResult, error)
+	GetSingleCollectionWithContext(ctx context.Context, id graphql.ID, cursor string) (*CollectionQueryResult, error)
 
 	Create(collection *CollectionCreate) (graphql.ID, error)
+	CreateWithContext(ctx context.Context, collection *CollectionCreate) (graphql.ID, error)
 	CreateBulk(collections []*CollectionCreate) error
+	CreateBulkWithContext(ctx context.Context, collections []*CollectionCreate) error
 
 	Update(collection *CollectionCreate) error
+	UpdateWithContext(ctx context.Context, collection *CollectionCreate) error
 }
 
 type CollectionServiceOp struct {
@@ -316,6 +328,10 @@ var collectionWithProductsBulkQuery = `
 `
 
 func (s *CollectionServiceOp) List(query string) ([]*CollectionBulkResult, error) {
+	return s.ListWithContext(s.client.gql.Context(), query)
+}
+
+func (s *CollectionServiceOp) ListWithContext(ctx context.Context, query string) ([]*CollectionBulkResult, error) {
 	q := fmt.Sprintf(`
 		{
 			collections(query: "$query"){
@@ -331,7 +347,7 @@ func (s *CollectionServiceOp) List(query string) ([]*CollectionBulkResult, error
 	q = strings.ReplaceAll(q, "$query", query)
 
 	res := []*CollectionBulkResult{}
-	err := s.client.BulkOperation.BulkQuery(q, &res)
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
 	if err != nil {
 		return []*CollectionBulkResult{}, err
 	}
@@ -340,6 +356,10 @@ func (s *CollectionServiceOp) List(query string) ([]*CollectionBulkResult, error
 }
 
 func (s *CollectionServiceOp) ListAll() ([]*CollectionBulkResult, error) {
+	return s.ListAllWithContext(s.client.gql.Context())
+}
+
+func (s *CollectionServiceOp) ListAllWithContext(ctx context.Context) ([]*CollectionBulkResult, error) {
 	q := fmt.Sprintf(`
 		{
 			collections{
@@ -353,7 +373,7 @@ func (s *CollectionServiceOp) ListAll() ([]*CollectionBulkResult, error) {
 	`, collectionBulkQuery)
 
 	res := []*CollectionBulkResult{}
-	err := s.client.BulkOperation.BulkQuery(q, &res)
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
 	if err != nil {
 		return []*CollectionBulkResult{}, err
 	}
@@ -362,6 +382,10 @@ func (s *CollectionServiceOp) ListAll() ([]*CollectionBulkResult, error) {
 }
 
 func (s *CollectionServiceOp) ListByCursor(first int, cursor string) (*CollectionsQueryResult, error) {
+	return s.ListByCursorWithContext(s.client.gql.Context(), first, cursor)
+}
+
+func (s *CollectionServiceOp) ListByCursorWithContext(ctx context.Context, first int, cursor string) (*CollectionsQueryResult, error) {
 	q := fmt.Sprintf(`
 		query collections($first: Int!, $cursor: String) {
 			collections(first: $first, after: $cursor){
@@ -387,7 +411,7 @@ func (s *CollectionServiceOp) ListByCursor(first int, cursor string) (*Collectio
 
 	out := CollectionsQueryResult{}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -397,6 +421,10 @@ func (s *CollectionServiceOp) ListByCursor(first int, cursor string) (*Collectio
 }
 
 func (s *CollectionServiceOp) ListWithFields(first int, cursor, query, fields string) (*CollectionsQueryResult, error) {
+	return s.ListWithFieldsWithContext(s.client.gql.Context(), first, cursor, query, fields)
+}
+
+func (s *CollectionServiceOp) ListWithFieldsWithContext(ctx context.Context, first int, cursor, query, fields string) (*CollectionsQueryResult, error) {
 	if fields == "" {
 		fields = `id`
 	}
@@ -426,7 +454,7 @@ func (s *CollectionServiceOp) ListWithFields(first int, cursor, query, fields st
 	out := CollectionsQueryResult{}
 
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -435,12 +463,54 @@ func (s *CollectionServiceOp) ListWithFields(first int, cursor, query, fields st
 	return &out, nil
 }
 
+func (s *CollectionServiceOp) Iter(opts ListOptions) *Iterator[*CollectionQueryResult] {
+	return s.IterWithContext(s.client.gql.Context(), opts)
+}
+
+func (s *CollectionServiceOp) IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*CollectionQueryResult] {
+	return Paginate(ctx, opts, s.listPage)
+}
+
+func (s *CollectionServiceOp) listPage(ctx context.Context, opts ListOptions) (*Page[*CollectionQueryResult], error) {
+	q := fmt.Sprintf(`
+		query collections($query: String, $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+			collections(query: $query, first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
+				edges{
+					node{
+						%s
+					}
+				}
+				%s
+			}
+		}
+	`, collectionBulkQuery, pageInfoQuery)
+
+	out := CollectionsQueryResult{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, pageVars(opts), &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[*CollectionQueryResult]{PageInfo: out.Collections.PageInfo}
+	for i := range out.Collections.Edges {
+		page.Nodes = append(page.Nodes, &out.Collections.Edges[i].Collection)
+	}
+
+	return page, nil
+}
+
 func (s *CollectionServiceOp) Get(id graphql.ID) (*CollectionQueryResult, error) {
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
+
+func (s *CollectionServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (*CollectionQueryResult, error) {
 	var (
 		out *CollectionQueryResult
 		err error
 	)
-	out, err = s.getPage(id, "")
+	out, err = s.getPage(ctx, id, "")
 	if err != nil {
 		return nil, err
 	}
@@ -451,7 +521,7 @@ func (s *CollectionServiceOp) Get(id graphql.ID) (*CollectionQueryResult, error)
 		cursor := nextPageData.Products.Edges[len(nextPageData.Products.Edges)-1].Cursor
 		// Shopify rate limit: 2 requests per sec
 		time.Sleep(500 * time.Millisecond)
-		nextPageData, err = s.getPage(id, cursor)
+		nextPageData, err = s.getPage(ctx, id, cursor)
 		if err != nil {
 			return nil, err
 		}
@@ -462,7 +532,7 @@ func (s *CollectionServiceOp) Get(id graphql.ID) (*CollectionQueryResult, error)
 	return out, nil
 }
 
-func (s *CollectionServiceOp) getPage(id graphql.ID, cursor string) (*CollectionQueryResult, error) {
+func (s *CollectionServiceOp) getPage(ctx context.Context, id graphql.ID, cursor string) (*CollectionQueryResult, error) {
 	q := fmt.Sprintf(`
 		query collection($id: ID!, $cursor: String) {
 			collection(id: $id){
@@ -482,7 +552,7 @@ func (s *CollectionServiceOp) getPage(id graphql.ID, cursor string) (*Collection
 		Collection *CollectionQueryResult `json:"collection"`
 	}{}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -492,6 +562,10 @@ func (s *CollectionServiceOp) getPage(id graphql.ID, cursor string) (*Collection
 }
 
 func (s *CollectionServiceOp) GetSingleCollection(id graphql.ID, cursor string) (*CollectionQueryResult, error) {
+	return s.GetSingleCollectionWithContext(s.client.gql.Context(), id, cursor)
+}
+
+func (s *CollectionServiceOp) GetSingleCollectionWithContext(ctx context.Context, id graphql.ID, cursor string) (*CollectionQueryResult, error) {
 	q := ""
 	if cursor != "" {
 		q = fmt.Sprintf(`
@@ -522,7 +596,7 @@ func (s *CollectionServiceOp) GetSingleCollection(id graphql.ID, cursor string)
 		Collection *CollectionQueryResult `json:"collection"`
 	}{}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -532,8 +606,12 @@ func (s *CollectionServiceOp) GetSingleCollection(id graphql.ID, cursor string)
 }
 
 func (s *CollectionServiceOp) CreateBulk(collections []*CollectionCreate) error {
+	return s.CreateBulkWithContext(s.client.gql.Context(), collections)
+}
+
+func (s *CollectionServiceOp) CreateBulkWithContext(ctx context.Context, collections []*CollectionCreate) error {
 	for _, c := range collections {
-		_, err := s.client.Collection.Create(c)
+		_, err := s.client.Collection.CreateWithContext(ctx, c)
 		if err != nil {
 			log.Warnf("Couldn't create collection (%v): %s", c, err)
 		}
@@ -543,6 +621,10 @@ func (s *CollectionServiceOp) CreateBulk(collections []*CollectionCreate) error
 }
 
 func (s *CollectionServiceOp) Create(collection *CollectionCreate) (graphql.ID, error) {
+	return s.CreateWithContext(s.client.gql.Context(), collection)
+}
+
+func (s *CollectionServiceOp) CreateWithContext(ctx context.Context, collection *CollectionCreate) (graphql.ID, error) {
 	var id graphql.ID
 	m := mutationCollectionCreate{}
 
@@ -550,14 +632,14 @@ func (s *CollectionServiceOp) Create(collection *CollectionCreate) (graphql.ID,
 		"input": collection.CollectionInput,
 	}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.Mutate(context.Background(), &m, vars)
+		return s.client.gql.Mutate(ctx, &m, vars)
 	})
 	if err != nil {
 		return id, err
 	}
 
 	if len(m.CollectionCreateResult.UserErrors) > 0 {
-		return id, fmt.Errorf("%+v", m.CollectionCreateResult.UserErrors)
+		return id, &UserErrorsError{UserErrors: m.CollectionCreateResult.UserErrors}
 	}
 
 	id = m.CollectionCreateResult.Collection.ID
@@ -565,20 +647,24 @@ func (s *CollectionServiceOp) Create(collection *CollectionCreate) (graphql.ID,
 }
 
 func (s *CollectionServiceOp) Update(collection *CollectionCreate) error {
+	return s.UpdateWithContext(s.client.gql.Context(), collection)
+}
+
+func (s *CollectionServiceOp) UpdateWithContext(ctx context.Context, collection *CollectionCreate) error {
 	m := mutationCollectionUpdate{}
 
 	vars := map[string]interface{}{
 		"input": collection.CollectionInput,
 	}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.Mutate(context.Background(), &m, vars)
+		return s.client.gql.Mutate(ctx, &m, vars)
 	})
 	if err != nil {
 		return err
 	}
 
 	if len(m.CollectionCreateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.CollectionCreateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.CollectionCreateResult.UserErrors}
 	}
 
 	return nil
@@ -1,12 +1,109 @@
 package shopify
 
-import "github.com/gempages/go-shopify-graphql/graphql"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
+)
+
+// ErrUserErrors is matched by errors.Is when a mutation responded with user errors.
+var ErrUserErrors = errors.New("user errors")
 
 type UserErrors struct {
 	Field   []graphql.String
 	Message graphql.String
 }
 
+// UserErrorsError is returned when a mutation responds with user errors.
+type UserErrorsError struct {
+	UserErrors []UserErrors
+}
+
+// Error implements error interface.
+func (e *UserErrorsError) Error() string {
+	return fmt.Sprintf("%+v", e.UserErrors)
+}
+
+// Is reports whether target is ErrUserErrors.
+func (e *UserErrorsError) Is(target error) bool {
+	return target == ErrUserErrors
+}
+
+// retryPolicy tells which failed requests are sent again.
+type retryPolicy int
+
+const (
+	// retryTransient sends requests again after a timeout, a lost connection or throttling. It
+	// suits queries and the mutations that can be applied twice.
+	retryTransient retryPolicy = iota
+	// retryThrottled only sends requests again after throttling, which Shopify rejects before
+	// running them. It suits the mutations that a retry after a lost response would apply twice.
+	retryThrottled
+)
+
+// exec runs the query or mutation q, retrying it as allowed by retry.
+func (c *Client) exec(ctx context.Context, retry retryPolicy, q string, vars map[string]interface{}, out interface{}) error {
+	f := func() error {
+		return c.gql.QueryString(ctx, q, vars, out)
+	}
+	if retry == retryThrottled {
+		return utils.ExecWithThrottleRetries(c.retries, f)
+	}
+	return utils.ExecWithRetries(c.retries, f)
+}
+
+// query runs the query q, retrying it after transient errors.
+func (c *Client) query(ctx context.Context, q string, vars map[string]interface{}, out interface{}) error {
+	return c.exec(ctx, retryTransient, q, vars, out)
+}
+
+// mutationQuery returns the mutation declared by signature, running call and selecting fields
+// along with the user errors of its payload.
+func mutationQuery(signature string, call string, fields string) string {
+	return fmt.Sprintf(`
+mutation %s {
+	%s {
+		%s
+		userErrors {
+			field
+			message
+		}
+	}
+}`, signature, call, fields)
+}
+
+// mutate runs mutation, whose only field returns a payload with user errors, retrying it as
+// allowed by retry, and decodes that payload into payload. It returns a *UserErrorsError when the
+// payload has user errors.
+func (c *Client) mutate(ctx context.Context, retry retryPolicy, mutation string, vars map[string]interface{}, payload interface{}) error {
+	out := map[string]json.RawMessage{}
+	err := c.exec(ctx, retry, mutation, vars, &out)
+	if err != nil {
+		return err
+	}
+
+	for _, raw := range out {
+		if len(raw) == 0 || string(raw) == "null" {
+			break
+		}
+		var errs struct {
+			UserErrors []UserErrors `json:"userErrors"`
+		}
+		if err := json.Unmarshal(raw, &errs); err != nil {
+			return err
+		}
+		if len(errs.UserErrors) > 0 {
+			return &UserErrorsError{UserErrors: errs.UserErrors}
+		}
+		return json.Unmarshal(raw, payload)
+	}
+	return fmt.Errorf("empty response to mutation")
+}
+
 type Money string   // Serialized and truncated to 2 decimals decimal.Decimal
 type Decimal string // Serialized decimal.Decimal
 
@@ -104,6 +201,10 @@ type PageInfo struct {
 	HasNextPage graphql.Boolean `json:"hasNextPage"`
 	// Indicates if there are any pages prior to the current page.
 	HasPreviousPage graphql.Boolean `json:"hasPreviousPage"`
+	// The cursor corresponding to the first node in edges.
+	StartCursor graphql.String `json:"startCursor,omitempty"`
+	// The cursor corresponding to the last node in edges.
+	EndCursor graphql.String `json:"endCursor,omitempty"`
 }
 
 // URL An RFC 3986 and RFC 3987 compliant URI string.
@@ -1,11 +1,626 @@
 package shopify
 
-import "github.com/gempages/go-shopify-graphql/graphql"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
+)
+
+type CustomerService interface {
+	Get(id graphql.ID) (*Customer, error)
+	GetWithContext(ctx context.Context, id graphql.ID) (*Customer, error)
+	// List returns the page of customers selected by opts. opts.Query uses the customer search syntax,
+	// e.g. "tag:vip state:ENABLED".
+	List(opts ListOptions) (*Page[*Customer], error)
+	ListWithContext(ctx context.Context, opts ListOptions) (*Page[*Customer], error)
+	// Iter returns an iterator over the customers matching opts.Query, fetching pages on demand.
+	Iter(opts ListOptions) *Iterator[*Customer]
+	IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*Customer]
+	// ListAll exports the customers matching query, or all of them when it's empty, with a bulk query.
+	ListAll(query string) ([]*Customer, error)
+	ListAllWithContext(ctx context.Context, query string) ([]*Customer, error)
+
+	Create(input CustomerInput) (*Customer, error)
+	CreateWithContext(ctx context.Context, input CustomerInput) (*Customer, error)
+	Update(input CustomerInput) (*Customer, error)
+	UpdateWithContext(ctx context.Context, input CustomerInput) (*Customer, error)
+	Delete(id graphql.ID) error
+	DeleteWithContext(ctx context.Context, id graphql.ID) error
+
+	// AddAddress, UpdateAddress and DeleteAddress read the addresses of the customer and write the
+	// changed list back, as customerUpdate replaces all of them. They aren't safe to run concurrently
+	// on the same customer, with each other or with Update: one of the changes would be lost.
+	AddAddress(customerID graphql.ID, address MailingAddressInput) (*Customer, error)
+	AddAddressWithContext(ctx context.Context, customerID graphql.ID, address MailingAddressInput) (*Customer, error)
+	UpdateAddress(customerID graphql.ID, addressID graphql.ID, address MailingAddressInput) (*Customer, error)
+	UpdateAddressWithContext(ctx context.Context, customerID graphql.ID, addressID graphql.ID, address MailingAddressInput) (*Customer, error)
+	DeleteAddress(customerID graphql.ID, addressID graphql.ID) (*Customer, error)
+	DeleteAddressWithContext(ctx context.Context, customerID graphql.ID, addressID graphql.ID) (*Customer, error)
+	SetDefaultAddress(customerID graphql.ID, addressID graphql.ID) (*Customer, error)
+	SetDefaultAddressWithContext(ctx context.Context, customerID graphql.ID, addressID graphql.ID) (*Customer, error)
+
+	AddTags(id graphql.ID, tags ...string) error
+	AddTagsWithContext(ctx context.Context, id graphql.ID, tags ...string) error
+	RemoveTags(id graphql.ID, tags ...string) error
+	RemoveTagsWithContext(ctx context.Context, id graphql.ID, tags ...string) error
+
+	UpdateEmailMarketingConsent(id graphql.ID, consent CustomerEmailMarketingConsentInput) (*Customer, error)
+	UpdateEmailMarketingConsentWithContext(ctx context.Context, id graphql.ID, consent CustomerEmailMarketingConsentInput) (*Customer, error)
+	UpdateSmsMarketingConsent(id graphql.ID, consent CustomerSmsMarketingConsentInput) (*Customer, error)
+	UpdateSmsMarketingConsentWithContext(ctx context.Context, id graphql.ID, consent CustomerSmsMarketingConsentInput) (*Customer, error)
+}
+
+type CustomerServiceOp struct {
+	client *Client
+}
 
 type Customer struct {
-	ID               graphql.ID     `json:"id,omitempty"`
-	LegacyResourceID graphql.String `json:"legacyResourceId,omitempty"`
-	FirstName        graphql.String `json:"firstName,omitempty"`
-	DisplayName      graphql.String `json:"displayName,omitempty"`
-	Email            graphql.String `json:"email,omitempty"`
+	ID               graphql.ID       `json:"id,omitempty"`
+	LegacyResourceID graphql.String   `json:"legacyResourceId,omitempty"`
+	FirstName        graphql.String   `json:"firstName,omitempty"`
+	LastName         graphql.String   `json:"lastName,omitempty"`
+	DisplayName      graphql.String   `json:"displayName,omitempty"`
+	Email            graphql.String   `json:"email,omitempty"`
+	Phone            graphql.String   `json:"phone,omitempty"`
+	Note             graphql.String   `json:"note,omitempty"`
+	Tags             []graphql.String `json:"tags,omitempty"`
+	State            CustomerState    `json:"state,omitempty"`
+	VerifiedEmail    graphql.Boolean  `json:"verifiedEmail,omitempty"`
+	TaxExempt        *graphql.Boolean `json:"taxExempt,omitempty"`
+	Locale           graphql.String   `json:"locale,omitempty"`
+	// NumberOfOrders is an UnsignedInt64 serialized as a string.
+	NumberOfOrders        graphql.String                      `json:"numberOfOrders,omitempty"`
+	AmountSpent           MoneyV2                             `json:"amountSpent,omitempty"`
+	DefaultAddress        *MailingAddress                     `json:"defaultAddress,omitempty"`
+	Addresses             []MailingAddress                    `json:"addresses,omitempty"`
+	EmailMarketingConsent *CustomerEmailMarketingConsentState `json:"emailMarketingConsent,omitempty"`
+	SmsMarketingConsent   *CustomerSmsMarketingConsentState   `json:"smsMarketingConsent,omitempty"`
+	CreatedAt             DateTime                            `json:"createdAt,omitempty"`
+	UpdatedAt             DateTime                            `json:"updatedAt,omitempty"`
+}
+
+// CustomerState enum
+// DECLINED The customer declined the email invite to create an account.
+// DISABLED The customer doesn't have an active account.
+// ENABLED The customer created an account.
+// INVITED The customer received an email invite to create an account.
+type CustomerState string
+
+// CustomerEmailMarketingState enum
+// NOT_SUBSCRIBED, PENDING, SUBSCRIBED, UNSUBSCRIBED, REDACTED, INVALID
+type CustomerEmailMarketingState string
+
+// CustomerSmsMarketingState enum
+// NOT_SUBSCRIBED, PENDING, SUBSCRIBED, UNSUBSCRIBED, REDACTED
+type CustomerSmsMarketingState string
+
+// CustomerMarketingOptInLevel enum
+// SINGLE_OPT_IN, CONFIRMED_OPT_IN, UNKNOWN
+type CustomerMarketingOptInLevel string
+
+type CustomerEmailMarketingConsentState struct {
+	MarketingState      CustomerEmailMarketingState `json:"marketingState,omitempty"`
+	MarketingOptInLevel CustomerMarketingOptInLevel `json:"marketingOptInLevel,omitempty"`
+	ConsentUpdatedAt    DateTime                    `json:"consentUpdatedAt,omitempty"`
+}
+
+type CustomerSmsMarketingConsentState struct {
+	MarketingState      CustomerSmsMarketingState   `json:"marketingState,omitempty"`
+	MarketingOptInLevel CustomerMarketingOptInLevel `json:"marketingOptInLevel,omitempty"`
+	ConsentUpdatedAt    DateTime                    `json:"consentUpdatedAt,omitempty"`
+	// ConsentCollectedFrom is the source of the consent, e.g. SHOPIFY or OTHER.
+	ConsentCollectedFrom graphql.String `json:"consentCollectedFrom,omitempty"`
+}
+
+type CustomerInput struct {
+	// ID is required to update a customer.
+	ID        graphql.ID       `json:"id,omitempty"`
+	FirstName graphql.String   `json:"firstName,omitempty"`
+	LastName  graphql.String   `json:"lastName,omitempty"`
+	Email     graphql.String   `json:"email,omitempty"`
+	Phone     graphql.String   `json:"phone,omitempty"`
+	Note      graphql.String   `json:"note,omitempty"`
+	Tags      []graphql.String `json:"tags,omitempty"`
+	Locale    graphql.String   `json:"locale,omitempty"`
+	// TaxExempt is set with graphql.NewBoolean, so that it can be set to false on update.
+	TaxExempt *graphql.Boolean `json:"taxExempt,omitempty"`
+	// Addresses replaces the addresses of the customer on update: addresses with an ID are updated,
+	// the others are created and the missing ones are deleted. See AddAddress to add a single one.
+	Addresses  []MailingAddressInput `json:"addresses,omitempty"`
+	Metafields []MetafieldInput      `json:"metafields,omitempty"`
+	// EmailMarketingConsent and SmsMarketingConsent can only be set on create, see
+	// UpdateEmailMarketingConsent and UpdateSmsMarketingConsent.
+	EmailMarketingConsent *CustomerEmailMarketingConsentInput `json:"emailMarketingConsent,omitempty"`
+	SmsMarketingConsent   *CustomerSmsMarketingConsentInput   `json:"smsMarketingConsent,omitempty"`
+}
+
+type MailingAddressInput struct {
+	ID           graphql.ID     `json:"id,omitempty"`
+	Address1     graphql.String `json:"address1,omitempty"`
+	Address2     graphql.String `json:"address2,omitempty"`
+	City         graphql.String `json:"city,omitempty"`
+	Company      graphql.String `json:"company,omitempty"`
+	CountryCode  CountryCode    `json:"countryCode,omitempty"`
+	FirstName    graphql.String `json:"firstName,omitempty"`
+	LastName     graphql.String `json:"lastName,omitempty"`
+	Phone        graphql.String `json:"phone,omitempty"`
+	ProvinceCode graphql.String `json:"provinceCode,omitempty"`
+	Zip          graphql.String `json:"zip,omitempty"`
+}
+
+type CustomerEmailMarketingConsentInput struct {
+	MarketingState      CustomerEmailMarketingState `json:"marketingState"`
+	MarketingOptInLevel CustomerMarketingOptInLevel `json:"marketingOptInLevel,omitempty"`
+	ConsentUpdatedAt    DateTime                    `json:"consentUpdatedAt,omitempty"`
+}
+
+type CustomerSmsMarketingConsentInput struct {
+	MarketingState      CustomerSmsMarketingState   `json:"marketingState"`
+	MarketingOptInLevel CustomerMarketingOptInLevel `json:"marketingOptInLevel,omitempty"`
+	ConsentUpdatedAt    DateTime                    `json:"consentUpdatedAt,omitempty"`
+}
+
+// customerAddressesInput replaces the addresses of a customer, including with none.
+type customerAddressesInput struct {
+	ID        graphql.ID            `json:"id"`
+	Addresses []MailingAddressInput `json:"addresses"`
+}
+
+const mailingAddressQuery = `
+	id
+	address1
+	address2
+	city
+	company
+	country
+	countryCodeV2
+	firstName
+	lastName
+	name
+	phone
+	province
+	provinceCode
+	zip
+`
+
+var customerQuery = fmt.Sprintf(`
+	id
+	legacyResourceId
+	firstName
+	lastName
+	displayName
+	email
+	phone
+	note
+	tags
+	state
+	verifiedEmail
+	taxExempt
+	locale
+	numberOfOrders
+	amountSpent {
+		amount
+		currencyCode
+	}
+	defaultAddress {
+		%[1]s
+	}
+	addresses {
+		%[1]s
+	}
+	emailMarketingConsent {
+		marketingState
+		marketingOptInLevel
+		consentUpdatedAt
+	}
+	smsMarketingConsent {
+		marketingState
+		marketingOptInLevel
+		consentUpdatedAt
+		consentCollectedFrom
+	}
+	createdAt
+	updatedAt
+`, mailingAddressQuery)
+
+var (
+	customerCreateMutation = fmt.Sprintf(`
+mutation customerCreate($input: CustomerInput!) {
+	customerCreate(input: $input) {
+		customer {
+			%s
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`, customerQuery)
+
+	customerUpdateMutation = fmt.Sprintf(`
+mutation customerUpdate($input: CustomerInput!) {
+	customerUpdate(input: $input) {
+		customer {
+			%s
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`, customerQuery)
+
+	customerUpdateDefaultAddressMutation = fmt.Sprintf(`
+mutation customerUpdateDefaultAddress($customerId: ID!, $addressId: ID!) {
+	customerUpdateDefaultAddress(customerId: $customerId, addressId: $addressId) {
+		customer {
+			%s
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`, customerQuery)
+
+	customerEmailMarketingConsentUpdateMutation = fmt.Sprintf(`
+mutation customerEmailMarketingConsentUpdate($input: CustomerEmailMarketingConsentUpdateInput!) {
+	customerEmailMarketingConsentUpdate(input: $input) {
+		customer {
+			%s
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`, customerQuery)
+
+	customerSmsMarketingConsentUpdateMutation = fmt.Sprintf(`
+mutation customerSmsMarketingConsentUpdate($input: CustomerSmsMarketingConsentUpdateInput!) {
+	customerSmsMarketingConsentUpdate(input: $input) {
+		customer {
+			%s
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`, customerQuery)
+)
+
+const customerDeleteMutation = `
+mutation customerDelete($input: CustomerDeleteInput!) {
+	customerDelete(input: $input) {
+		deletedCustomerId
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+const tagsAddMutation = `
+mutation tagsAdd($id: ID!, $tags: [String!]!) {
+	tagsAdd(id: $id, tags: $tags) {
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+const tagsRemoveMutation = `
+mutation tagsRemove($id: ID!, $tags: [String!]!) {
+	tagsRemove(id: $id, tags: $tags) {
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+func (s *CustomerServiceOp) Get(id graphql.ID) (*Customer, error) {
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
+
+func (s *CustomerServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (*Customer, error) {
+	q := fmt.Sprintf(`
+		query customer($id: ID!) {
+			customer(id: $id){
+				%s
+			}
+		}
+	`, customerQuery)
+
+	vars := map[string]interface{}{
+		"id": id,
+	}
+	out := struct {
+		Customer *Customer `json:"customer"`
+	}{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, vars, &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.Customer == nil {
+		return nil, fmt.Errorf("customer %v not found", id)
+	}
+
+	return out.Customer, nil
+}
+
+func (s *CustomerServiceOp) List(opts ListOptions) (*Page[*Customer], error) {
+	return s.ListWithContext(s.client.gql.Context(), opts)
+}
+
+func (s *CustomerServiceOp) ListWithContext(ctx context.Context, opts ListOptions) (*Page[*Customer], error) {
+	q := fmt.Sprintf(`
+		query customers($query: String, $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+			customers(query: $query, first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
+				edges{
+					node{
+						%s
+					}
+				}
+				%s
+			}
+		}
+	`, customerQuery, pageInfoQuery)
+
+	if opts.First == 0 && opts.Last == 0 {
+		opts.First = defaultPageSize
+	}
+	out := struct {
+		Customers struct {
+			Edges []struct {
+				Node *Customer `json:"node"`
+			} `json:"edges"`
+			PageInfo PageInfo `json:"pageInfo"`
+		} `json:"customers"`
+	}{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, pageVars(opts), &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[*Customer]{PageInfo: out.Customers.PageInfo}
+	for _, e := range out.Customers.Edges {
+		page.Nodes = append(page.Nodes, e.Node)
+	}
+
+	return page, nil
+}
+
+func (s *CustomerServiceOp) Iter(opts ListOptions) *Iterator[*Customer] {
+	return s.IterWithContext(s.client.gql.Context(), opts)
+}
+
+func (s *CustomerServiceOp) IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*Customer] {
+	return Paginate(ctx, opts, s.ListWithContext)
+}
+
+func (s *CustomerServiceOp) ListAll(query string) ([]*Customer, error) {
+	return s.ListAllWithContext(s.client.gql.Context(), query)
+}
+
+func (s *CustomerServiceOp) ListAllWithContext(ctx context.Context, query string) ([]*Customer, error) {
+	args := ""
+	if query != "" {
+		quoted, err := json.Marshal(query)
+		if err != nil {
+			return nil, err
+		}
+		args = fmt.Sprintf("(query: %s)", quoted)
+	}
+	q := fmt.Sprintf(`
+		{
+			customers%s{
+				edges{
+					node{
+						%s
+					}
+				}
+			}
+		}
+	`, args, customerQuery)
+
+	res := []*Customer{}
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
+	if err != nil {
+		return []*Customer{}, err
+	}
+
+	return res, nil
+}
+
+func (s *CustomerServiceOp) Create(input CustomerInput) (*Customer, error) {
+	return s.CreateWithContext(s.client.gql.Context(), input)
+}
+
+func (s *CustomerServiceOp) CreateWithContext(ctx context.Context, input CustomerInput) (*Customer, error) {
+	return s.mutateCustomer(ctx, customerCreateMutation, map[string]interface{}{"input": input})
+}
+
+func (s *CustomerServiceOp) Update(input CustomerInput) (*Customer, error) {
+	return s.UpdateWithContext(s.client.gql.Context(), input)
+}
+
+func (s *CustomerServiceOp) UpdateWithContext(ctx context.Context, input CustomerInput) (*Customer, error) {
+	return s.mutateCustomer(ctx, customerUpdateMutation, map[string]interface{}{"input": input})
+}
+
+func (s *CustomerServiceOp) Delete(id graphql.ID) error {
+	return s.DeleteWithContext(s.client.gql.Context(), id)
+}
+
+func (s *CustomerServiceOp) DeleteWithContext(ctx context.Context, id graphql.ID) error {
+	vars := map[string]interface{}{
+		"input": map[string]interface{}{"id": id},
+	}
+	return s.client.mutate(ctx, retryThrottled, customerDeleteMutation, vars, &struct{}{})
+}
+
+// AddAddress adds address to the addresses of the customer.
+func (s *CustomerServiceOp) AddAddress(customerID graphql.ID, address MailingAddressInput) (*Customer, error) {
+	return s.AddAddressWithContext(s.client.gql.Context(), customerID, address)
+}
+
+func (s *CustomerServiceOp) AddAddressWithContext(ctx context.Context, customerID graphql.ID, address MailingAddressInput) (*Customer, error) {
+	return s.updateAddresses(ctx, customerID, func(addresses []MailingAddressInput) ([]MailingAddressInput, error) {
+		address.ID = nil
+		return append(addresses, address), nil
+	})
+}
+
+// UpdateAddress replaces the address addressID of the customer with address.
+func (s *CustomerServiceOp) UpdateAddress(customerID graphql.ID, addressID graphql.ID, address MailingAddressInput) (*Customer, error) {
+	return s.UpdateAddressWithContext(s.client.gql.Context(), customerID, addressID, address)
+}
+
+func (s *CustomerServiceOp) UpdateAddressWithContext(ctx context.Context, customerID graphql.ID, addressID graphql.ID, address MailingAddressInput) (*Customer, error) {
+	return s.updateAddresses(ctx, customerID, func(addresses []MailingAddressInput) ([]MailingAddressInput, error) {
+		for i := range addresses {
+			if addresses[i].ID == addressID {
+				address.ID = addressID
+				addresses[i] = address
+				return addresses, nil
+			}
+		}
+		return nil, fmt.Errorf("address %v of customer %v not found", addressID, customerID)
+	})
+}
+
+// DeleteAddress removes the address addressID from the addresses of the customer.
+func (s *CustomerServiceOp) DeleteAddress(customerID graphql.ID, addressID graphql.ID) (*Customer, error) {
+	return s.DeleteAddressWithContext(s.client.gql.Context(), customerID, addressID)
+}
+
+func (s *CustomerServiceOp) DeleteAddressWithContext(ctx context.Context, customerID graphql.ID, addressID graphql.ID) (*Customer, error) {
+	return s.updateAddresses(ctx, customerID, func(addresses []MailingAddressInput) ([]MailingAddressInput, error) {
+		for i := range addresses {
+			if addresses[i].ID == addressID {
+				return append(addresses[:i], addresses[i+1:]...), nil
+			}
+		}
+		return nil, fmt.Errorf("address %v of customer %v not found", addressID, customerID)
+	})
+}
+
+func (s *CustomerServiceOp) SetDefaultAddress(customerID graphql.ID, addressID graphql.ID) (*Customer, error) {
+	return s.SetDefaultAddressWithContext(s.client.gql.Context(), customerID, addressID)
+}
+
+func (s *CustomerServiceOp) SetDefaultAddressWithContext(ctx context.Context, customerID graphql.ID, addressID graphql.ID) (*Customer, error) {
+	return s.mutateCustomer(ctx, customerUpdateDefaultAddressMutation, map[string]interface{}{
+		"customerId": customerID,
+		"addressId":  addressID,
+	})
+}
+
+// AddTags adds tags to the customer id. It also works with the ID of any other taggable resource, e.g. an order.
+func (s *CustomerServiceOp) AddTags(id graphql.ID, tags ...string) error {
+	return s.AddTagsWithContext(s.client.gql.Context(), id, tags...)
+}
+
+func (s *CustomerServiceOp) AddTagsWithContext(ctx context.Context, id graphql.ID, tags ...string) error {
+	return mutateTags(ctx, s.client, tagsAddMutation, id, tags)
+}
+
+// RemoveTags removes tags from the customer id. It also works with the ID of any other taggable resource.
+func (s *CustomerServiceOp) RemoveTags(id graphql.ID, tags ...string) error {
+	return s.RemoveTagsWithContext(s.client.gql.Context(), id, tags...)
+}
+
+func (s *CustomerServiceOp) RemoveTagsWithContext(ctx context.Context, id graphql.ID, tags ...string) error {
+	return mutateTags(ctx, s.client, tagsRemoveMutation, id, tags)
+}
+
+func (s *CustomerServiceOp) UpdateEmailMarketingConsent(id graphql.ID, consent CustomerEmailMarketingConsentInput) (*Customer, error) {
+	return s.UpdateEmailMarketingConsentWithContext(s.client.gql.Context(), id, consent)
+}
+
+func (s *CustomerServiceOp) UpdateEmailMarketingConsentWithContext(ctx context.Context, id graphql.ID, consent CustomerEmailMarketingConsentInput) (*Customer, error) {
+	return s.mutateCustomer(ctx, customerEmailMarketingConsentUpdateMutation, map[string]interface{}{
+		"input": map[string]interface{}{
+			"customerId":            id,
+			"emailMarketingConsent": consent,
+		},
+	})
+}
+
+func (s *CustomerServiceOp) UpdateSmsMarketingConsent(id graphql.ID, consent CustomerSmsMarketingConsentInput) (*Customer, error) {
+	return s.UpdateSmsMarketingConsentWithContext(s.client.gql.Context(), id, consent)
+}
+
+func (s *CustomerServiceOp) UpdateSmsMarketingConsentWithContext(ctx context.Context, id graphql.ID, consent CustomerSmsMarketingConsentInput) (*Customer, error) {
+	return s.mutateCustomer(ctx, customerSmsMarketingConsentUpdateMutation, map[string]interface{}{
+		"input": map[string]interface{}{
+			"customerId":          id,
+			"smsMarketingConsent": consent,
+		},
+	})
+}
+
+// updateAddresses replaces the addresses of the customer with the ones returned by update, which is
+// passed the current ones.
+func (s *CustomerServiceOp) updateAddresses(ctx context.Context, customerID graphql.ID, update func([]MailingAddressInput) ([]MailingAddressInput, error)) (*Customer, error) {
+	c, err := s.GetWithContext(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]MailingAddressInput, 0, len(c.Addresses))
+	for _, a := range c.Addresses {
+		addresses = append(addresses, MailingAddressInput{
+			ID:           a.ID,
+			Address1:     a.Address1,
+			Address2:     a.Address2,
+			City:         a.City,
+			Company:      a.Company,
+			CountryCode:  a.CountryCodeV2,
+			FirstName:    a.FirstName,
+			LastName:     a.LastName,
+			Phone:        a.Phone,
+			ProvinceCode: a.ProvinceCode,
+			Zip:          a.Zip,
+		})
+	}
+	addresses, err = update(addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutateCustomer(ctx, customerUpdateMutation, map[string]interface{}{
+		"input": customerAddressesInput{ID: customerID, Addresses: addresses},
+	})
+}
+
+// mutateCustomer runs mutation, whose only field returns a customer.
+func (s *CustomerServiceOp) mutateCustomer(ctx context.Context, mutation string, vars map[string]interface{}) (*Customer, error) {
+	payload := struct {
+		Customer *Customer `json:"customer"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, mutation, vars, &payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload.Customer, nil
+}
+
+// mutateTags runs the tagsAdd or tagsRemove mutation.
+func mutateTags(ctx context.Context, client *Client, mutation string, id graphql.ID, tags []string) error {
+	vars := map[string]interface{}{
+		"id":   id,
+		"tags": tags,
+	}
+	return client.mutate(ctx, retryThrottled, mutation, vars, &struct{}{})
 }
@@ -0,0 +1,153 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestCustomers(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	srv.AddCustomer(&shopifytest.Customer{FirstName: "Bob", Email: "bob@example.com", Tags: []string{"wholesale"}})
+
+	c, err := client.Customer.CreateWithContext(ctx, shopify.CustomerInput{
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Email:     "jane@example.com",
+		Phone:     "+16135551111",
+		Tags:      []graphql.String{"vip"},
+		TaxExempt: graphql.NewBoolean(true),
+		Addresses: []shopify.MailingAddressInput{{Address1: "1 Main St", City: "Ottawa", CountryCode: "CA"}},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if c.DisplayName != "Jane Doe" || c.TaxExempt == nil || !*c.TaxExempt || c.DefaultAddress == nil || c.DefaultAddress.City != "Ottawa" ||
+		c.EmailMarketingConsent == nil || c.EmailMarketingConsent.MarketingState != "NOT_SUBSCRIBED" {
+		t.Errorf("unexpected customer: %+v", c)
+	}
+
+	_, err = client.Customer.CreateWithContext(ctx, shopify.CustomerInput{Email: "jane@example.com"})
+	if !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors for a duplicate email, got %v", err)
+	}
+
+	page, err := client.Customer.ListWithContext(ctx, shopify.ListOptions{Query: "tag:vip"})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Nodes) != 1 || page.Nodes[0].ID != c.ID {
+		t.Errorf("unexpected customers: %+v", page.Nodes)
+	}
+
+	c, err = client.Customer.AddAddressWithContext(ctx, c.ID, shopify.MailingAddressInput{Address1: "2 Side St", City: "Toronto", CountryCode: "CA"})
+	if err != nil {
+		t.Fatalf("add address: %v", err)
+	}
+	if len(c.Addresses) != 2 {
+		t.Fatalf("expected 2 addresses, got %+v", c.Addresses)
+	}
+	first, second := c.Addresses[0].ID, c.Addresses[1].ID
+	c, err = client.Customer.SetDefaultAddressWithContext(ctx, c.ID, second)
+	if err != nil {
+		t.Fatalf("set default address: %v", err)
+	}
+	if c.DefaultAddress.ID != second {
+		t.Errorf("expected the default address to be %v, got %v", second, c.DefaultAddress.ID)
+	}
+	c, err = client.Customer.UpdateAddressWithContext(ctx, c.ID, second, shopify.MailingAddressInput{Address1: "3 Side St", City: "Toronto", CountryCode: "CA"})
+	if err != nil {
+		t.Fatalf("update address: %v", err)
+	}
+	if c.DefaultAddress.ID != second || c.DefaultAddress.Address1 != "3 Side St" {
+		t.Errorf("unexpected default address: %+v", c.DefaultAddress)
+	}
+	c, err = client.Customer.DeleteAddressWithContext(ctx, c.ID, first)
+	if err != nil {
+		t.Fatalf("delete address: %v", err)
+	}
+	if len(c.Addresses) != 1 || c.Addresses[0].ID != second {
+		t.Errorf("unexpected addresses: %+v", c.Addresses)
+	}
+
+	err = client.Customer.AddTagsWithContext(ctx, c.ID, "newsletter", "vip")
+	if err != nil {
+		t.Fatalf("add tags: %v", err)
+	}
+	err = client.Customer.RemoveTagsWithContext(ctx, c.ID, "vip")
+	if err != nil {
+		t.Fatalf("remove tags: %v", err)
+	}
+
+	c, err = client.Customer.UpdateEmailMarketingConsentWithContext(ctx, c.ID, shopify.CustomerEmailMarketingConsentInput{
+		MarketingState:      "SUBSCRIBED",
+		MarketingOptInLevel: "SINGLE_OPT_IN",
+	})
+	if err != nil {
+		t.Fatalf("update email marketing consent: %v", err)
+	}
+	if c.EmailMarketingConsent.MarketingState != "SUBSCRIBED" {
+		t.Errorf("unexpected email marketing consent: %+v", c.EmailMarketingConsent)
+	}
+	c, err = client.Customer.UpdateSmsMarketingConsentWithContext(ctx, c.ID, shopify.CustomerSmsMarketingConsentInput{MarketingState: "SUBSCRIBED"})
+	if err != nil {
+		t.Fatalf("update sms marketing consent: %v", err)
+	}
+	if c.SmsMarketingConsent == nil || c.SmsMarketingConsent.MarketingState != "SUBSCRIBED" {
+		t.Errorf("unexpected sms marketing consent: %+v", c.SmsMarketingConsent)
+	}
+
+	c, err = client.Customer.UpdateWithContext(ctx, shopify.CustomerInput{ID: c.ID, Note: "called twice", TaxExempt: graphql.NewBoolean(false)})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if c.Note != "called twice" || c.TaxExempt == nil || *c.TaxExempt || len(c.Tags) != 1 || c.Tags[0] != "newsletter" {
+		t.Errorf("unexpected customer: %+v", c)
+	}
+
+	customers, err := client.Customer.ListAllWithContext(ctx, "")
+	if err != nil {
+		t.Fatalf("list all: %v", err)
+	}
+	if len(customers) != 2 {
+		t.Errorf("expected 2 customers, got %d", len(customers))
+	}
+
+	err = client.Customer.DeleteWithContext(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err = client.Customer.GetWithContext(ctx, c.ID); err == nil {
+		t.Error("expected the deleted customer to be gone")
+	}
+	if len(srv.Customers()) != 1 {
+		t.Errorf("expected 1 customer left, got %d", len(srv.Customers()))
+	}
+}
+
+func TestCustomerAddAddressLostResponse(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	client.SetRetries(2)
+	ctx := context.Background()
+
+	c := srv.AddCustomer(&shopifytest.Customer{FirstName: "Bob", Email: "bob@example.com"})
+
+	srv.DropResponses(1)
+	_, err := client.Customer.AddAddressWithContext(ctx, c.ID, shopify.MailingAddressInput{Address1: "2 Side St", City: "Toronto", CountryCode: "CA"})
+	if err == nil {
+		t.Fatalf("expected the lost response to fail adding the address")
+	}
+	if n := len(srv.Customers()[0].Addresses); n != 1 {
+		t.Errorf("expected the address to be added once, got %d addresses", n)
+	}
+}
@@ -0,0 +1,1030 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+)
+
+// DiscountService manages code discounts, redeemed with a code at checkout (see
+// CartService.CartDiscountCodesUpdate), and automatic discounts, applied to every eligible cart.
+// The automatic free shipping discounts need the Admin API version 2023-07, see graphqlclient.WithVersion.
+type DiscountService interface {
+	GetCode(id graphql.ID) (*DiscountCodeNode, error)
+	GetCodeWithContext(ctx context.Context, id graphql.ID) (*DiscountCodeNode, error)
+	// GetCodeByCode returns the code discount redeemed with code, or an error if there is none.
+	GetCodeByCode(code string) (*DiscountCodeNode, error)
+	GetCodeByCodeWithContext(ctx context.Context, code string) (*DiscountCodeNode, error)
+	GetAutomatic(id graphql.ID) (*DiscountAutomaticNode, error)
+	GetAutomaticWithContext(ctx context.Context, id graphql.ID) (*DiscountAutomaticNode, error)
+
+	CreateBasicCode(input DiscountCodeBasicInput) (*DiscountCodeNode, error)
+	CreateBasicCodeWithContext(ctx context.Context, input DiscountCodeBasicInput) (*DiscountCodeNode, error)
+	UpdateBasicCode(id graphql.ID, input DiscountCodeBasicInput) (*DiscountCodeNode, error)
+	UpdateBasicCodeWithContext(ctx context.Context, id graphql.ID, input DiscountCodeBasicInput) (*DiscountCodeNode, error)
+	CreateBxgyCode(input DiscountCodeBxgyInput) (*DiscountCodeNode, error)
+	CreateBxgyCodeWithContext(ctx context.Context, input DiscountCodeBxgyInput) (*DiscountCodeNode, error)
+	UpdateBxgyCode(id graphql.ID, input DiscountCodeBxgyInput) (*DiscountCodeNode, error)
+	UpdateBxgyCodeWithContext(ctx context.Context, id graphql.ID, input DiscountCodeBxgyInput) (*DiscountCodeNode, error)
+	CreateFreeShippingCode(input DiscountCodeFreeShippingInput) (*DiscountCodeNode, error)
+	CreateFreeShippingCodeWithContext(ctx context.Context, input DiscountCodeFreeShippingInput) (*DiscountCodeNode, error)
+	UpdateFreeShippingCode(id graphql.ID, input DiscountCodeFreeShippingInput) (*DiscountCodeNode, error)
+	UpdateFreeShippingCodeWithContext(ctx context.Context, id graphql.ID, input DiscountCodeFreeShippingInput) (*DiscountCodeNode, error)
+	ActivateCode(id graphql.ID) (*DiscountCodeNode, error)
+	ActivateCodeWithContext(ctx context.Context, id graphql.ID) (*DiscountCodeNode, error)
+	DeactivateCode(id graphql.ID) (*DiscountCodeNode, error)
+	DeactivateCodeWithContext(ctx context.Context, id graphql.ID) (*DiscountCodeNode, error)
+	DeleteCode(id graphql.ID) error
+	DeleteCodeWithContext(ctx context.Context, id graphql.ID) error
+
+	// AddRedeemCodes adds codes to the code discount id. Shopify creates them asynchronously: poll
+	// GetRedeemCodeBulkCreation with the ID of the returned creation until it's Done.
+	AddRedeemCodes(id graphql.ID, codes ...string) (*DiscountRedeemCodeBulkCreation, error)
+	AddRedeemCodesWithContext(ctx context.Context, id graphql.ID, codes ...string) (*DiscountRedeemCodeBulkCreation, error)
+	GetRedeemCodeBulkCreation(id graphql.ID) (*DiscountRedeemCodeBulkCreation, error)
+	GetRedeemCodeBulkCreationWithContext(ctx context.Context, id graphql.ID) (*DiscountRedeemCodeBulkCreation, error)
+
+	CreateBasicAutomatic(input DiscountAutomaticBasicInput) (*DiscountAutomaticNode, error)
+	CreateBasicAutomaticWithContext(ctx context.Context, input DiscountAutomaticBasicInput) (*DiscountAutomaticNode, error)
+	UpdateBasicAutomatic(id graphql.ID, input DiscountAutomaticBasicInput) (*DiscountAutomaticNode, error)
+	UpdateBasicAutomaticWithContext(ctx context.Context, id graphql.ID, input DiscountAutomaticBasicInput) (*DiscountAutomaticNode, error)
+	CreateBxgyAutomatic(input DiscountAutomaticBxgyInput) (*DiscountAutomaticNode, error)
+	CreateBxgyAutomaticWithContext(ctx context.Context, input DiscountAutomaticBxgyInput) (*DiscountAutomaticNode, error)
+	UpdateBxgyAutomatic(id graphql.ID, input DiscountAutomaticBxgyInput) (*DiscountAutomaticNode, error)
+	UpdateBxgyAutomaticWithContext(ctx context.Context, id graphql.ID, input DiscountAutomaticBxgyInput) (*DiscountAutomaticNode, error)
+	CreateFreeShippingAutomatic(input DiscountAutomaticFreeShippingInput) (*DiscountAutomaticNode, error)
+	CreateFreeShippingAutomaticWithContext(ctx context.Context, input DiscountAutomaticFreeShippingInput) (*DiscountAutomaticNode, error)
+	UpdateFreeShippingAutomatic(id graphql.ID, input DiscountAutomaticFreeShippingInput) (*DiscountAutomaticNode, error)
+	UpdateFreeShippingAutomaticWithContext(ctx context.Context, id graphql.ID, input DiscountAutomaticFreeShippingInput) (*DiscountAutomaticNode, error)
+	ActivateAutomatic(id graphql.ID) (*DiscountAutomaticNode, error)
+	ActivateAutomaticWithContext(ctx context.Context, id graphql.ID) (*DiscountAutomaticNode, error)
+	DeactivateAutomatic(id graphql.ID) (*DiscountAutomaticNode, error)
+	DeactivateAutomaticWithContext(ctx context.Context, id graphql.ID) (*DiscountAutomaticNode, error)
+	DeleteAutomatic(id graphql.ID) error
+	DeleteAutomaticWithContext(ctx context.Context, id graphql.ID) error
+}
+
+type DiscountServiceOp struct {
+	client *Client
+}
+
+// DiscountStatus enum
+// ACTIVE The discount is active.
+// EXPIRED The discount is expired.
+// SCHEDULED The discount is scheduled.
+type DiscountStatus string
+
+const (
+	DiscountStatusActive    DiscountStatus = "ACTIVE"
+	DiscountStatusExpired   DiscountStatus = "EXPIRED"
+	DiscountStatusScheduled DiscountStatus = "SCHEDULED"
+)
+
+type DiscountCodeNode struct {
+	ID           graphql.ID   `json:"id,omitempty"`
+	CodeDiscount DiscountCode `json:"codeDiscount,omitempty"`
+}
+
+type DiscountAutomaticNode struct {
+	ID                graphql.ID        `json:"id,omitempty"`
+	AutomaticDiscount DiscountAutomatic `json:"automaticDiscount,omitempty"`
+}
+
+// DiscountBase holds the fields of the basic, buy X get Y and free shipping discounts. Typename tells
+// which one it is, e.g. DiscountCodeBxgy, and the fields that don't apply to it are left empty.
+type DiscountBase struct {
+	Typename     graphql.String       `json:"__typename,omitempty"`
+	Title        graphql.String       `json:"title,omitempty"`
+	Status       DiscountStatus       `json:"status,omitempty"`
+	Summary      graphql.String       `json:"summary,omitempty"`
+	StartsAt     DateTime             `json:"startsAt,omitempty"`
+	EndsAt       *DateTime            `json:"endsAt,omitempty"`
+	CreatedAt    DateTime             `json:"createdAt,omitempty"`
+	CombinesWith DiscountCombinesWith `json:"combinesWith,omitempty"`
+	// AsyncUsageCount is the number of times the discount was used, updated asynchronously.
+	AsyncUsageCount graphql.Int `json:"asyncUsageCount,omitempty"`
+
+	// basic and buy X get Y
+	CustomerGets *DiscountCustomerGets `json:"customerGets,omitempty"`
+	// buy X get Y
+	CustomerBuys      *DiscountCustomerBuys `json:"customerBuys,omitempty"`
+	UsesPerOrderLimit *graphql.Int          `json:"usesPerOrderLimit,omitempty"`
+	// basic and free shipping
+	MinimumRequirement *DiscountMinimumRequirement `json:"minimumRequirement,omitempty"`
+	// free shipping
+	DestinationSelection *DiscountShippingDestinationSelection `json:"destinationSelection,omitempty"`
+	MaximumShippingPrice *MoneyV2                              `json:"maximumShippingPrice,omitempty"`
+}
+
+type DiscountCode struct {
+	DiscountBase
+
+	UsageLimit             *graphql.Int    `json:"usageLimit,omitempty"`
+	AppliesOncePerCustomer graphql.Boolean `json:"appliesOncePerCustomer,omitempty"`
+	// Codes holds the first redeem codes of the discount.
+	Codes struct {
+		Edges []struct {
+			Node DiscountRedeemCode `json:"node,omitempty"`
+		} `json:"edges,omitempty"`
+	} `json:"codes,omitempty"`
+}
+
+type DiscountAutomatic struct {
+	DiscountBase
+}
+
+type DiscountRedeemCode struct {
+	ID              graphql.ID     `json:"id,omitempty"`
+	Code            graphql.String `json:"code,omitempty"`
+	AsyncUsageCount graphql.Int    `json:"asyncUsageCount,omitempty"`
+}
+
+type DiscountCombinesWith struct {
+	OrderDiscounts    graphql.Boolean `json:"orderDiscounts"`
+	ProductDiscounts  graphql.Boolean `json:"productDiscounts"`
+	ShippingDiscounts graphql.Boolean `json:"shippingDiscounts"`
+}
+
+type DiscountCustomerGets struct {
+	Value                    DiscountCustomerGetsValue `json:"value,omitempty"`
+	Items                    DiscountItems             `json:"items,omitempty"`
+	AppliesOnOneTimePurchase graphql.Boolean           `json:"appliesOnOneTimePurchase,omitempty"`
+	AppliesOnSubscription    graphql.Boolean           `json:"appliesOnSubscription,omitempty"`
+}
+
+// DiscountCustomerGetsValue is a DiscountPercentage, DiscountAmount or DiscountOnQuantity, told apart by Typename.
+type DiscountCustomerGetsValue struct {
+	Typename graphql.String `json:"__typename,omitempty"`
+	// Percentage is between 0.0 and 1.0.
+	Percentage        graphql.Float   `json:"percentage,omitempty"`
+	Amount            *MoneyV2        `json:"amount,omitempty"`
+	AppliesOnEachItem graphql.Boolean `json:"appliesOnEachItem,omitempty"`
+	Quantity          *struct {
+		// Quantity is an UnsignedInt64 serialized as a string.
+		Quantity graphql.String `json:"quantity,omitempty"`
+	} `json:"quantity,omitempty"`
+	Effect *struct {
+		Percentage graphql.Float `json:"percentage,omitempty"`
+	} `json:"effect,omitempty"`
+}
+
+type DiscountCustomerBuys struct {
+	Value DiscountCustomerBuysValue `json:"value,omitempty"`
+	Items DiscountItems             `json:"items,omitempty"`
+}
+
+// DiscountCustomerBuysValue is a DiscountQuantity or DiscountPurchaseAmount, told apart by Typename.
+type DiscountCustomerBuysValue struct {
+	Typename graphql.String `json:"__typename,omitempty"`
+	Quantity graphql.String `json:"quantity,omitempty"`
+	Amount   graphql.String `json:"amount,omitempty"`
+}
+
+// DiscountItems is an AllDiscountItems, DiscountProducts or DiscountCollections, told apart by Typename.
+type DiscountItems struct {
+	Typename        graphql.String          `json:"__typename,omitempty"`
+	AllItems        graphql.Boolean         `json:"allItems,omitempty"`
+	Products        *DiscountItemConnection `json:"products,omitempty"`
+	ProductVariants *DiscountItemConnection `json:"productVariants,omitempty"`
+	Collections     *DiscountItemConnection `json:"collections,omitempty"`
+}
+
+// DiscountItemConnection holds the first products, variants or collections a discount applies to.
+type DiscountItemConnection struct {
+	Edges []struct {
+		Node struct {
+			ID graphql.ID `json:"id,omitempty"`
+		} `json:"node,omitempty"`
+	} `json:"edges,omitempty"`
+}
+
+// IDs returns the IDs of the items.
+func (c *DiscountItemConnection) IDs() []graphql.ID {
+	if c == nil {
+		return nil
+	}
+	ids := make([]graphql.ID, 0, len(c.Edges))
+	for _, e := range c.Edges {
+		ids = append(ids, e.Node.ID)
+	}
+	return ids
+}
+
+// DiscountMinimumRequirement is a DiscountMinimumQuantity or DiscountMinimumSubtotal, told apart by Typename.
+type DiscountMinimumRequirement struct {
+	Typename                     graphql.String `json:"__typename,omitempty"`
+	GreaterThanOrEqualToQuantity graphql.String `json:"greaterThanOrEqualToQuantity,omitempty"`
+	GreaterThanOrEqualToSubtotal *MoneyV2       `json:"greaterThanOrEqualToSubtotal,omitempty"`
+}
+
+// DiscountShippingDestinationSelection is a DiscountCountryAll or DiscountCountries, told apart by Typename.
+type DiscountShippingDestinationSelection struct {
+	Typename           graphql.String  `json:"__typename,omitempty"`
+	AllCountries       graphql.Boolean `json:"allCountries,omitempty"`
+	Countries          []CountryCode   `json:"countries,omitempty"`
+	IncludeRestOfWorld graphql.Boolean `json:"includeRestOfWorld,omitempty"`
+}
+
+type DiscountRedeemCodeBulkCreation struct {
+	ID            graphql.ID      `json:"id,omitempty"`
+	Done          graphql.Boolean `json:"done,omitempty"`
+	CodesCount    graphql.Int     `json:"codesCount,omitempty"`
+	ImportedCount graphql.Int     `json:"importedCount,omitempty"`
+	FailedCount   graphql.Int     `json:"failedCount,omitempty"`
+	// Codes holds the requested codes, along with the reasons the failed ones weren't created.
+	Codes struct {
+		Edges []struct {
+			Node struct {
+				Code   graphql.String `json:"code,omitempty"`
+				Errors []UserErrors   `json:"errors,omitempty"`
+			} `json:"node,omitempty"`
+		} `json:"edges,omitempty"`
+	} `json:"codes,omitempty"`
+}
+
+type DiscountCombinesWithInput struct {
+	OrderDiscounts    graphql.Boolean `json:"orderDiscounts"`
+	ProductDiscounts  graphql.Boolean `json:"productDiscounts"`
+	ShippingDiscounts graphql.Boolean `json:"shippingDiscounts"`
+}
+
+// DiscountCustomerSelectionInput selects the customers eligible to a code discount: all of them,
+// or the ones added.
+type DiscountCustomerSelectionInput struct {
+	All       graphql.Boolean         `json:"all,omitempty"`
+	Customers *DiscountCustomersInput `json:"customers,omitempty"`
+}
+
+type DiscountCustomersInput struct {
+	Add    []graphql.ID `json:"add,omitempty"`
+	Remove []graphql.ID `json:"remove,omitempty"`
+}
+
+type DiscountCustomerGetsInput struct {
+	Value                    *DiscountCustomerGetsValueInput `json:"value,omitempty"`
+	Items                    *DiscountItemsInput             `json:"items,omitempty"`
+	AppliesOnOneTimePurchase graphql.Boolean                 `json:"appliesOnOneTimePurchase,omitempty"`
+	AppliesOnSubscription    graphql.Boolean                 `json:"appliesOnSubscription,omitempty"`
+}
+
+// DiscountCustomerGetsValueInput sets exactly one of Percentage, DiscountAmount and DiscountOnQuantity,
+// the latter being for buy X get Y discounts.
+type DiscountCustomerGetsValueInput struct {
+	// Percentage is between 0.0 and 1.0.
+	Percentage         graphql.Float            `json:"percentage,omitempty"`
+	DiscountAmount     *DiscountAmountInput     `json:"discountAmount,omitempty"`
+	DiscountOnQuantity *DiscountOnQuantityInput `json:"discountOnQuantity,omitempty"`
+}
+
+type DiscountAmountInput struct {
+	Amount            graphql.String  `json:"amount"`
+	AppliesOnEachItem graphql.Boolean `json:"appliesOnEachItem,omitempty"`
+}
+
+type DiscountOnQuantityInput struct {
+	Quantity graphql.String      `json:"quantity"`
+	Effect   DiscountEffectInput `json:"effect"`
+}
+
+type DiscountEffectInput struct {
+	Percentage graphql.Float `json:"percentage"`
+}
+
+// DiscountItemsInput sets All, or the Products and Collections a discount applies to.
+type DiscountItemsInput struct {
+	All         graphql.Boolean           `json:"all,omitempty"`
+	Products    *DiscountProductsInput    `json:"products,omitempty"`
+	Collections *DiscountCollectionsInput `json:"collections,omitempty"`
+}
+
+type DiscountProductsInput struct {
+	ProductsToAdd           []graphql.ID `json:"productsToAdd,omitempty"`
+	ProductsToRemove        []graphql.ID `json:"productsToRemove,omitempty"`
+	ProductVariantsToAdd    []graphql.ID `json:"productVariantsToAdd,omitempty"`
+	ProductVariantsToRemove []graphql.ID `json:"productVariantsToRemove,omitempty"`
+}
+
+type DiscountCollectionsInput struct {
+	Add    []graphql.ID `json:"add,omitempty"`
+	Remove []graphql.ID `json:"remove,omitempty"`
+}
+
+// DiscountCustomerBuysInput is what the customer must buy to get a buy X get Y discount.
+type DiscountCustomerBuysInput struct {
+	Value *DiscountCustomerBuysValueInput `json:"value,omitempty"`
+	Items *DiscountItemsInput             `json:"items,omitempty"`
+}
+
+// DiscountCustomerBuysValueInput sets either the Quantity of items to buy, or the Amount to spend on them.
+type DiscountCustomerBuysValueInput struct {
+	Quantity graphql.String `json:"quantity,omitempty"`
+	Amount   graphql.String `json:"amount,omitempty"`
+}
+
+// DiscountMinimumRequirementInput sets either a minimum Quantity of items or a minimum Subtotal.
+type DiscountMinimumRequirementInput struct {
+	Quantity *DiscountMinimumQuantityInput `json:"quantity,omitempty"`
+	Subtotal *DiscountMinimumSubtotalInput `json:"subtotal,omitempty"`
+}
+
+type DiscountMinimumQuantityInput struct {
+	GreaterThanOrEqualToQuantity graphql.String `json:"greaterThanOrEqualToQuantity"`
+}
+
+type DiscountMinimumSubtotalInput struct {
+	GreaterThanOrEqualToSubtotal graphql.String `json:"greaterThanOrEqualToSubtotal"`
+}
+
+// DiscountShippingDestinationSelectionInput sets All, or the Countries shipping is free to.
+type DiscountShippingDestinationSelectionInput struct {
+	All       graphql.Boolean         `json:"all,omitempty"`
+	Countries *DiscountCountriesInput `json:"countries,omitempty"`
+}
+
+type DiscountCountriesInput struct {
+	Add                []CountryCode   `json:"add,omitempty"`
+	Remove             []CountryCode   `json:"remove,omitempty"`
+	IncludeRestOfWorld graphql.Boolean `json:"includeRestOfWorld,omitempty"`
+}
+
+type DiscountCodeBasicInput struct {
+	Title                  graphql.String                   `json:"title,omitempty"`
+	Code                   graphql.String                   `json:"code,omitempty"`
+	StartsAt               DateTime                         `json:"startsAt,omitempty"`
+	EndsAt                 DateTime                         `json:"endsAt,omitempty"`
+	UsageLimit             graphql.Int                      `json:"usageLimit,omitempty"`
+	AppliesOncePerCustomer graphql.Boolean                  `json:"appliesOncePerCustomer,omitempty"`
+	CustomerSelection      *DiscountCustomerSelectionInput  `json:"customerSelection,omitempty"`
+	CustomerGets           *DiscountCustomerGetsInput       `json:"customerGets,omitempty"`
+	MinimumRequirement     *DiscountMinimumRequirementInput `json:"minimumRequirement,omitempty"`
+	CombinesWith           *DiscountCombinesWithInput       `json:"combinesWith,omitempty"`
+}
+
+type DiscountCodeBxgyInput struct {
+	Title                  graphql.String                  `json:"title,omitempty"`
+	Code                   graphql.String                  `json:"code,omitempty"`
+	StartsAt               DateTime                        `json:"startsAt,omitempty"`
+	EndsAt                 DateTime                        `json:"endsAt,omitempty"`
+	UsageLimit             graphql.Int                     `json:"usageLimit,omitempty"`
+	UsesPerOrderLimit      graphql.Int                     `json:"usesPerOrderLimit,omitempty"`
+	AppliesOncePerCustomer graphql.Boolean                 `json:"appliesOncePerCustomer,omitempty"`
+	CustomerSelection      *DiscountCustomerSelectionInput `json:"customerSelection,omitempty"`
+	CustomerBuys           *DiscountCustomerBuysInput      `json:"customerBuys,omitempty"`
+	CustomerGets           *DiscountCustomerGetsInput      `json:"customerGets,omitempty"`
+	CombinesWith           *DiscountCombinesWithInput      `json:"combinesWith,omitempty"`
+}
+
+type DiscountCodeFreeShippingInput struct {
+	Title                  graphql.String                             `json:"title,omitempty"`
+	Code                   graphql.String                             `json:"code,omitempty"`
+	StartsAt               DateTime                                   `json:"startsAt,omitempty"`
+	EndsAt                 DateTime                                   `json:"endsAt,omitempty"`
+	UsageLimit             graphql.Int                                `json:"usageLimit,omitempty"`
+	AppliesOncePerCustomer graphql.Boolean                            `json:"appliesOncePerCustomer,omitempty"`
+	CustomerSelection      *DiscountCustomerSelectionInput            `json:"customerSelection,omitempty"`
+	Destination            *DiscountShippingDestinationSelectionInput `json:"destination,omitempty"`
+	// MaximumShippingPrice is a Decimal: shipping rates above it aren't discounted.
+	MaximumShippingPrice     graphql.String                   `json:"maximumShippingPrice,omitempty"`
+	MinimumRequirement       *DiscountMinimumRequirementInput `json:"minimumRequirement,omitempty"`
+	AppliesOnOneTimePurchase graphql.Boolean                  `json:"appliesOnOneTimePurchase,omitempty"`
+	AppliesOnSubscription    graphql.Boolean                  `json:"appliesOnSubscription,omitempty"`
+	CombinesWith             *DiscountCombinesWithInput       `json:"combinesWith,omitempty"`
+}
+
+type DiscountAutomaticBasicInput struct {
+	Title              graphql.String                   `json:"title,omitempty"`
+	StartsAt           DateTime                         `json:"startsAt,omitempty"`
+	EndsAt             DateTime                         `json:"endsAt,omitempty"`
+	CustomerGets       *DiscountCustomerGetsInput       `json:"customerGets,omitempty"`
+	MinimumRequirement *DiscountMinimumRequirementInput `json:"minimumRequirement,omitempty"`
+	CombinesWith       *DiscountCombinesWithInput       `json:"combinesWith,omitempty"`
+}
+
+type DiscountAutomaticBxgyInput struct {
+	Title             graphql.String             `json:"title,omitempty"`
+	StartsAt          DateTime                   `json:"startsAt,omitempty"`
+	EndsAt            DateTime                   `json:"endsAt,omitempty"`
+	UsesPerOrderLimit graphql.Int                `json:"usesPerOrderLimit,omitempty"`
+	CustomerBuys      *DiscountCustomerBuysInput `json:"customerBuys,omitempty"`
+	CustomerGets      *DiscountCustomerGetsInput `json:"customerGets,omitempty"`
+	CombinesWith      *DiscountCombinesWithInput `json:"combinesWith,omitempty"`
+}
+
+type DiscountAutomaticFreeShippingInput struct {
+	Title                    graphql.String                             `json:"title,omitempty"`
+	StartsAt                 DateTime                                   `json:"startsAt,omitempty"`
+	EndsAt                   DateTime                                   `json:"endsAt,omitempty"`
+	Destination              *DiscountShippingDestinationSelectionInput `json:"destination,omitempty"`
+	MaximumShippingPrice     graphql.String                             `json:"maximumShippingPrice,omitempty"`
+	MinimumRequirement       *DiscountMinimumRequirementInput           `json:"minimumRequirement,omitempty"`
+	AppliesOnOneTimePurchase graphql.Boolean                            `json:"appliesOnOneTimePurchase,omitempty"`
+	AppliesOnSubscription    graphql.Boolean                            `json:"appliesOnSubscription,omitempty"`
+	CombinesWith             *DiscountCombinesWithInput                 `json:"combinesWith,omitempty"`
+}
+
+const discountItemsQuery = `
+	__typename
+	... on AllDiscountItems {
+		allItems
+	}
+	... on DiscountProducts {
+		products(first: 50) {
+			edges {
+				node {
+					id
+				}
+			}
+		}
+		productVariants(first: 50) {
+			edges {
+				node {
+					id
+				}
+			}
+		}
+	}
+	... on DiscountCollections {
+		collections(first: 50) {
+			edges {
+				node {
+					id
+				}
+			}
+		}
+	}
+`
+
+var discountCustomerGetsQuery = fmt.Sprintf(`
+	customerGets {
+		value {
+			__typename
+			... on DiscountPercentage {
+				percentage
+			}
+			... on DiscountAmount {
+				amount {
+					amount
+					currencyCode
+				}
+				appliesOnEachItem
+			}
+			... on DiscountOnQuantity {
+				quantity {
+					quantity
+				}
+				effect {
+					... on DiscountPercentage {
+						percentage
+					}
+				}
+			}
+		}
+		items {
+			%s
+		}
+		appliesOnOneTimePurchase
+		appliesOnSubscription
+	}
+`, discountItemsQuery)
+
+var discountCustomerBuysQuery = fmt.Sprintf(`
+	customerBuys {
+		value {
+			__typename
+			... on DiscountQuantity {
+				quantity
+			}
+			... on DiscountPurchaseAmount {
+				amount
+			}
+		}
+		items {
+			%s
+		}
+	}
+	usesPerOrderLimit
+`, discountItemsQuery)
+
+const discountMinimumRequirementQuery = `
+	minimumRequirement {
+		__typename
+		... on DiscountMinimumQuantity {
+			greaterThanOrEqualToQuantity
+		}
+		... on DiscountMinimumSubtotal {
+			greaterThanOrEqualToSubtotal {
+				amount
+				currencyCode
+			}
+		}
+	}
+`
+
+const discountFreeShippingQuery = `
+	destinationSelection {
+		__typename
+		... on DiscountCountryAll {
+			allCountries
+		}
+		... on DiscountCountries {
+			countries
+			includeRestOfWorld
+		}
+	}
+	maximumShippingPrice {
+		amount
+		currencyCode
+	}
+`
+
+const discountBaseQuery = `
+	title
+	status
+	summary
+	startsAt
+	endsAt
+	createdAt
+	asyncUsageCount
+	combinesWith {
+		orderDiscounts
+		productDiscounts
+		shippingDiscounts
+	}
+`
+
+const discountCodeFieldsQuery = `
+	usageLimit
+	appliesOncePerCustomer
+	codes(first: 10) {
+		edges {
+			node {
+				id
+				code
+				asyncUsageCount
+			}
+		}
+	}
+`
+
+var discountCodeNodeQuery = fmt.Sprintf(`
+	id
+	codeDiscount {
+		__typename
+		... on DiscountCodeBasic {
+			%[1]s
+			%[2]s
+			%[3]s
+			%[4]s
+		}
+		... on DiscountCodeBxgy {
+			%[1]s
+			%[2]s
+			%[5]s
+			%[3]s
+		}
+		... on DiscountCodeFreeShipping {
+			%[1]s
+			%[2]s
+			%[4]s
+			%[6]s
+		}
+	}
+`, discountBaseQuery, discountCodeFieldsQuery, discountCustomerGetsQuery, discountMinimumRequirementQuery,
+	discountCustomerBuysQuery, discountFreeShippingQuery)
+
+var discountAutomaticNodeQuery = fmt.Sprintf(`
+	id
+	automaticDiscount {
+		__typename
+		... on DiscountAutomaticBasic {
+			%[1]s
+			%[2]s
+			%[3]s
+		}
+		... on DiscountAutomaticBxgy {
+			%[1]s
+			%[4]s
+			%[2]s
+		}
+		... on DiscountAutomaticFreeShipping {
+			%[1]s
+			%[3]s
+			%[5]s
+		}
+	}
+`, discountBaseQuery, discountCustomerGetsQuery, discountMinimumRequirementQuery, discountCustomerBuysQuery,
+	discountFreeShippingQuery)
+
+const discountRedeemCodeBulkCreationQuery = `
+	id
+	done
+	codesCount
+	importedCount
+	failedCount
+	codes(first: 250) {
+		edges {
+			node {
+				code
+				errors {
+					field
+					message
+				}
+			}
+		}
+	}
+`
+
+var (
+	discountCodeNodeFields      = fmt.Sprintf("codeDiscountNode {%s}", discountCodeNodeQuery)
+	discountAutomaticNodeFields = fmt.Sprintf("automaticDiscountNode {%s}", discountAutomaticNodeQuery)
+
+	discountCodeBasicCreateMutation = mutationQuery(
+		"discountCodeBasicCreate($input: DiscountCodeBasicInput!)",
+		"discountCodeBasicCreate(basicCodeDiscount: $input)",
+		discountCodeNodeFields)
+	discountCodeBasicUpdateMutation = mutationQuery(
+		"discountCodeBasicUpdate($id: ID!, $input: DiscountCodeBasicInput!)",
+		"discountCodeBasicUpdate(id: $id, basicCodeDiscount: $input)",
+		discountCodeNodeFields)
+	discountCodeBxgyCreateMutation = mutationQuery(
+		"discountCodeBxgyCreate($input: DiscountCodeBxgyInput!)",
+		"discountCodeBxgyCreate(bxgyCodeDiscount: $input)",
+		discountCodeNodeFields)
+	discountCodeBxgyUpdateMutation = mutationQuery(
+		"discountCodeBxgyUpdate($id: ID!, $input: DiscountCodeBxgyInput!)",
+		"discountCodeBxgyUpdate(id: $id, bxgyCodeDiscount: $input)",
+		discountCodeNodeFields)
+	discountCodeFreeShippingCreateMutation = mutationQuery(
+		"discountCodeFreeShippingCreate($input: DiscountCodeFreeShippingInput!)",
+		"discountCodeFreeShippingCreate(freeShippingCodeDiscount: $input)",
+		discountCodeNodeFields)
+	discountCodeFreeShippingUpdateMutation = mutationQuery(
+		"discountCodeFreeShippingUpdate($id: ID!, $input: DiscountCodeFreeShippingInput!)",
+		"discountCodeFreeShippingUpdate(id: $id, freeShippingCodeDiscount: $input)",
+		discountCodeNodeFields)
+	discountCodeActivateMutation = mutationQuery(
+		"discountCodeActivate($id: ID!)",
+		"discountCodeActivate(id: $id)",
+		discountCodeNodeFields)
+	discountCodeDeactivateMutation = mutationQuery(
+		"discountCodeDeactivate($id: ID!)",
+		"discountCodeDeactivate(id: $id)",
+		discountCodeNodeFields)
+	discountCodeDeleteMutation = mutationQuery(
+		"discountCodeDelete($id: ID!)",
+		"discountCodeDelete(id: $id)",
+		"deletedCodeDiscountId")
+	discountRedeemCodeBulkAddMutation = mutationQuery(
+		"discountRedeemCodeBulkAdd($discountId: ID!, $codes: [DiscountRedeemCodeInput!]!)",
+		"discountRedeemCodeBulkAdd(discountId: $discountId, codes: $codes)",
+		fmt.Sprintf("bulkCreation {%s}", discountRedeemCodeBulkCreationQuery))
+
+	discountAutomaticBasicCreateMutation = mutationQuery(
+		"discountAutomaticBasicCreate($input: DiscountAutomaticBasicInput!)",
+		"discountAutomaticBasicCreate(automaticBasicDiscount: $input)",
+		discountAutomaticNodeFields)
+	discountAutomaticBasicUpdateMutation = mutationQuery(
+		"discountAutomaticBasicUpdate($id: ID!, $input: DiscountAutomaticBasicInput!)",
+		"discountAutomaticBasicUpdate(id: $id, automaticBasicDiscount: $input)",
+		discountAutomaticNodeFields)
+	discountAutomaticBxgyCreateMutation = mutationQuery(
+		"discountAutomaticBxgyCreate($input: DiscountAutomaticBxgyInput!)",
+		"discountAutomaticBxgyCreate(automaticBxgyDiscount: $input)",
+		discountAutomaticNodeFields)
+	discountAutomaticBxgyUpdateMutation = mutationQuery(
+		"discountAutomaticBxgyUpdate($id: ID!, $input: DiscountAutomaticBxgyInput!)",
+		"discountAutomaticBxgyUpdate(id: $id, automaticBxgyDiscount: $input)",
+		discountAutomaticNodeFields)
+	discountAutomaticFreeShippingCreateMutation = mutationQuery(
+		"discountAutomaticFreeShippingCreate($input: DiscountAutomaticFreeShippingInput!)",
+		"discountAutomaticFreeShippingCreate(freeShippingAutomaticDiscount: $input)",
+		discountAutomaticNodeFields)
+	discountAutomaticFreeShippingUpdateMutation = mutationQuery(
+		"discountAutomaticFreeShippingUpdate($id: ID!, $input: DiscountAutomaticFreeShippingInput!)",
+		"discountAutomaticFreeShippingUpdate(id: $id, freeShippingAutomaticDiscount: $input)",
+		discountAutomaticNodeFields)
+	discountAutomaticActivateMutation = mutationQuery(
+		"discountAutomaticActivate($id: ID!)",
+		"discountAutomaticActivate(id: $id)",
+		discountAutomaticNodeFields)
+	discountAutomaticDeactivateMutation = mutationQuery(
+		"discountAutomaticDeactivate($id: ID!)",
+		"discountAutomaticDeactivate(id: $id)",
+		discountAutomaticNodeFields)
+	discountAutomaticDeleteMutation = mutationQuery(
+		"discountAutomaticDelete($id: ID!)",
+		"discountAutomaticDelete(id: $id)",
+		"deletedAutomaticDiscountId")
+)
+
+// discountPayload is the payload of the discount mutations.
+type discountPayload struct {
+	CodeDiscountNode      *DiscountCodeNode               `json:"codeDiscountNode"`
+	AutomaticDiscountNode *DiscountAutomaticNode          `json:"automaticDiscountNode"`
+	BulkCreation          *DiscountRedeemCodeBulkCreation `json:"bulkCreation"`
+}
+
+func (s *DiscountServiceOp) GetCode(id graphql.ID) (*DiscountCodeNode, error) {
+	return s.GetCodeWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DiscountServiceOp) GetCodeWithContext(ctx context.Context, id graphql.ID) (*DiscountCodeNode, error) {
+	q := fmt.Sprintf(`
+		query codeDiscountNode($id: ID!) {
+			codeDiscountNode(id: $id){
+				%s
+			}
+		}
+	`, discountCodeNodeQuery)
+
+	out := struct {
+		CodeDiscountNode *DiscountCodeNode `json:"codeDiscountNode"`
+	}{}
+	err := s.client.query(ctx, q, map[string]interface{}{"id": id}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if out.CodeDiscountNode == nil {
+		return nil, fmt.Errorf("code discount %v not found", id)
+	}
+
+	return out.CodeDiscountNode, nil
+}
+
+func (s *DiscountServiceOp) GetCodeByCode(code string) (*DiscountCodeNode, error) {
+	return s.GetCodeByCodeWithContext(s.client.gql.Context(), code)
+}
+
+func (s *DiscountServiceOp) GetCodeByCodeWithContext(ctx context.Context, code string) (*DiscountCodeNode, error) {
+	q := fmt.Sprintf(`
+		query codeDiscountNodeByCode($code: String!) {
+			codeDiscountNodeByCode(code: $code){
+				%s
+			}
+		}
+	`, discountCodeNodeQuery)
+
+	out := struct {
+		CodeDiscountNode *DiscountCodeNode `json:"codeDiscountNodeByCode"`
+	}{}
+	err := s.client.query(ctx, q, map[string]interface{}{"code": code}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if out.CodeDiscountNode == nil {
+		return nil, fmt.Errorf("code discount %q not found", code)
+	}
+
+	return out.CodeDiscountNode, nil
+}
+
+func (s *DiscountServiceOp) GetAutomatic(id graphql.ID) (*DiscountAutomaticNode, error) {
+	return s.GetAutomaticWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DiscountServiceOp) GetAutomaticWithContext(ctx context.Context, id graphql.ID) (*DiscountAutomaticNode, error) {
+	q := fmt.Sprintf(`
+		query automaticDiscountNode($id: ID!) {
+			automaticDiscountNode(id: $id){
+				%s
+			}
+		}
+	`, discountAutomaticNodeQuery)
+
+	out := struct {
+		AutomaticDiscountNode *DiscountAutomaticNode `json:"automaticDiscountNode"`
+	}{}
+	err := s.client.query(ctx, q, map[string]interface{}{"id": id}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if out.AutomaticDiscountNode == nil {
+		return nil, fmt.Errorf("automatic discount %v not found", id)
+	}
+
+	return out.AutomaticDiscountNode, nil
+}
+
+func (s *DiscountServiceOp) CreateBasicCode(input DiscountCodeBasicInput) (*DiscountCodeNode, error) {
+	return s.CreateBasicCodeWithContext(s.client.gql.Context(), input)
+}
+
+func (s *DiscountServiceOp) CreateBasicCodeWithContext(ctx context.Context, input DiscountCodeBasicInput) (*DiscountCodeNode, error) {
+	return s.mutateCode(ctx, discountCodeBasicCreateMutation, map[string]interface{}{"input": input})
+}
+
+func (s *DiscountServiceOp) UpdateBasicCode(id graphql.ID, input DiscountCodeBasicInput) (*DiscountCodeNode, error) {
+	return s.UpdateBasicCodeWithContext(s.client.gql.Context(), id, input)
+}
+
+func (s *DiscountServiceOp) UpdateBasicCodeWithContext(ctx context.Context, id graphql.ID, input DiscountCodeBasicInput) (*DiscountCodeNode, error) {
+	return s.mutateCode(ctx, discountCodeBasicUpdateMutation, map[string]interface{}{"id": id, "input": input})
+}
+
+func (s *DiscountServiceOp) CreateBxgyCode(input DiscountCodeBxgyInput) (*DiscountCodeNode, error) {
+	return s.CreateBxgyCodeWithContext(s.client.gql.Context(), input)
+}
+
+func (s *DiscountServiceOp) CreateBxgyCodeWithContext(ctx context.Context, input DiscountCodeBxgyInput) (*DiscountCodeNode, error) {
+	return s.mutateCode(ctx, discountCodeBxgyCreateMutation, map[string]interface{}{"input": input})
+}
+
+func (s *DiscountServiceOp) UpdateBxgyCode(id graphql.ID, input DiscountCodeBxgyInput) (*DiscountCodeNode, error) {
+	return s.UpdateBxgyCodeWithContext(s.client.gql.Context(), id, input)
+}
+
+func (s *DiscountServiceOp) UpdateBxgyCodeWithContext(ctx context.Context, id graphql.ID, input DiscountCodeBxgyInput) (*DiscountCodeNode, error) {
+	return s.mutateCode(ctx, discountCodeBxgyUpdateMutation, map[string]interface{}{"id": id, "input": input})
+}
+
+func (s *DiscountServiceOp) CreateFreeShippingCode(input DiscountCodeFreeShippingInput) (*DiscountCodeNode, error) {
+	return s.CreateFreeShippingCodeWithContext(s.client.gql.Context(), input)
+}
+
+func (s *DiscountServiceOp) CreateFreeShippingCodeWithContext(ctx context.Context, input DiscountCodeFreeShippingInput) (*DiscountCodeNode, error) {
+	return s.mutateCode(ctx, discountCodeFreeShippingCreateMutation, map[string]interface{}{"input": input})
+}
+
+func (s *DiscountServiceOp) UpdateFreeShippingCode(id graphql.ID, input DiscountCodeFreeShippingInput) (*DiscountCodeNode, error) {
+	return s.UpdateFreeShippingCodeWithContext(s.client.gql.Context(), id, input)
+}
+
+func (s *DiscountServiceOp) UpdateFreeShippingCodeWithContext(ctx context.Context, id graphql.ID, input DiscountCodeFreeShippingInput) (*DiscountCodeNode, error) {
+	return s.mutateCode(ctx, discountCodeFreeShippingUpdateMutation, map[string]interface{}{"id": id, "input": input})
+}
+
+// ActivateCode makes the code discount id active from now on.
+func (s *DiscountServiceOp) ActivateCode(id graphql.ID) (*DiscountCodeNode, error) {
+	return s.ActivateCodeWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DiscountServiceOp) ActivateCodeWithContext(ctx context.Context, id graphql.ID) (*DiscountCodeNode, error) {
+	return s.mutateCode(ctx, discountCodeActivateMutation, map[string]interface{}{"id": id})
+}
+
+// DeactivateCode ends the code discount id now.
+func (s *DiscountServiceOp) DeactivateCode(id graphql.ID) (*DiscountCodeNode, error) {
+	return s.DeactivateCodeWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DiscountServiceOp) DeactivateCodeWithContext(ctx context.Context, id graphql.ID) (*DiscountCodeNode, error) {
+	return s.mutateCode(ctx, discountCodeDeactivateMutation, map[string]interface{}{"id": id})
+}
+
+func (s *DiscountServiceOp) DeleteCode(id graphql.ID) error {
+	return s.DeleteCodeWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DiscountServiceOp) DeleteCodeWithContext(ctx context.Context, id graphql.ID) error {
+	_, err := s.mutate(ctx, discountCodeDeleteMutation, map[string]interface{}{"id": id})
+	return err
+}
+
+func (s *DiscountServiceOp) AddRedeemCodes(id graphql.ID, codes ...string) (*DiscountRedeemCodeBulkCreation, error) {
+	return s.AddRedeemCodesWithContext(s.client.gql.Context(), id, codes...)
+}
+
+func (s *DiscountServiceOp) AddRedeemCodesWithContext(ctx context.Context, id graphql.ID, codes ...string) (*DiscountRedeemCodeBulkCreation, error) {
+	inputs := make([]map[string]string, 0, len(codes))
+	for _, code := range codes {
+		inputs = append(inputs, map[string]string{"code": code})
+	}
+	payload, err := s.mutate(ctx, discountRedeemCodeBulkAddMutation, map[string]interface{}{
+		"discountId": id,
+		"codes":      inputs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.BulkCreation, nil
+}
+
+func (s *DiscountServiceOp) GetRedeemCodeBulkCreation(id graphql.ID) (*DiscountRedeemCodeBulkCreation, error) {
+	return s.GetRedeemCodeBulkCreationWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DiscountServiceOp) GetRedeemCodeBulkCreationWithContext(ctx context.Context, id graphql.ID) (*DiscountRedeemCodeBulkCreation, error) {
+	q := fmt.Sprintf(`
+		query discountRedeemCodeBulkCreation($id: ID!) {
+			discountRedeemCodeBulkCreation(id: $id){
+				%s
+			}
+		}
+	`, discountRedeemCodeBulkCreationQuery)
+
+	out := struct {
+		BulkCreation *DiscountRedeemCodeBulkCreation `json:"discountRedeemCodeBulkCreation"`
+	}{}
+	err := s.client.query(ctx, q, map[string]interface{}{"id": id}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if out.BulkCreation == nil {
+		return nil, fmt.Errorf("redeem code bulk creation %v not found", id)
+	}
+
+	return out.BulkCreation, nil
+}
+
+func (s *DiscountServiceOp) CreateBasicAutomatic(input DiscountAutomaticBasicInput) (*DiscountAutomaticNode, error) {
+	return s.CreateBasicAutomaticWithContext(s.client.gql.Context(), input)
+}
+
+func (s *DiscountServiceOp) CreateBasicAutomaticWithContext(ctx context.Context, input DiscountAutomaticBasicInput) (*DiscountAutomaticNode, error) {
+	return s.mutateAutomatic(ctx, discountAutomaticBasicCreateMutation, map[string]interface{}{"input": input})
+}
+
+func (s *DiscountServiceOp) UpdateBasicAutomatic(id graphql.ID, input DiscountAutomaticBasicInput) (*DiscountAutomaticNode, error) {
+	return s.UpdateBasicAutomaticWithContext(s.client.gql.Context(), id, input)
+}
+
+func (s *DiscountServiceOp) UpdateBasicAutomaticWithContext(ctx context.Context, id graphql.ID, input DiscountAutomaticBasicInput) (*DiscountAutomaticNode, error) {
+	return s.mutateAutomatic(ctx, discountAutomaticBasicUpdateMutation, map[string]interface{}{"id": id, "input": input})
+}
+
+func (s *DiscountServiceOp) CreateBxgyAutomatic(input DiscountAutomaticBxgyInput) (*DiscountAutomaticNode, error) {
+	return s.CreateBxgyAutomaticWithContext(s.client.gql.Context(), input)
+}
+
+func (s *DiscountServiceOp) CreateBxgyAutomaticWithContext(ctx context.Context, input DiscountAutomaticBxgyInput) (*DiscountAutomaticNode, error) {
+	return s.mutateAutomatic(ctx, discountAutomaticBxgyCreateMutation, map[string]interface{}{"input": input})
+}
+
+func (s *DiscountServiceOp) UpdateBxgyAutomatic(id graphql.ID, input DiscountAutomaticBxgyInput) (*DiscountAutomaticNode, error) {
+	return s.UpdateBxgyAutomaticWithContext(s.client.gql.Context(), id, input)
+}
+
+func (s *DiscountServiceOp) UpdateBxgyAutomaticWithContext(ctx context.Context, id graphql.ID, input DiscountAutomaticBxgyInput) (*DiscountAutomaticNode, error) {
+	return s.mutateAutomatic(ctx, discountAutomaticBxgyUpdateMutation, map[string]interface{}{"id": id, "input": input})
+}
+
+func (s *DiscountServiceOp) CreateFreeShippingAutomatic(input DiscountAutomaticFreeShippingInput) (*DiscountAutomaticNode, error) {
+	return s.CreateFreeShippingAutomaticWithContext(s.client.gql.Context(), input)
+}
+
+func (s *DiscountServiceOp) CreateFreeShippingAutomaticWithContext(ctx context.Context, input DiscountAutomaticFreeShippingInput) (*DiscountAutomaticNode, error) {
+	return s.mutateAutomatic(ctx, discountAutomaticFreeShippingCreateMutation, map[string]interface{}{"input": input})
+}
+
+func (s *DiscountServiceOp) UpdateFreeShippingAutomatic(id graphql.ID, input DiscountAutomaticFreeShippingInput) (*DiscountAutomaticNode, error) {
+	return s.UpdateFreeShippingAutomaticWithContext(s.client.gql.Context(), id, input)
+}
+
+func (s *DiscountServiceOp) UpdateFreeShippingAutomaticWithContext(ctx context.Context, id graphql.ID, input DiscountAutomaticFreeShippingInput) (*DiscountAutomaticNode, error) {
+	return s.mutateAutomatic(ctx, discountAutomaticFreeShippingUpdateMutation, map[string]interface{}{"id": id, "input": input})
+}
+
+// ActivateAutomatic makes the automatic discount id active from now on.
+func (s *DiscountServiceOp) ActivateAutomatic(id graphql.ID) (*DiscountAutomaticNode, error) {
+	return s.ActivateAutomaticWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DiscountServiceOp) ActivateAutomaticWithContext(ctx context.Context, id graphql.ID) (*DiscountAutomaticNode, error) {
+	return s.mutateAutomatic(ctx, discountAutomaticActivateMutation, map[string]interface{}{"id": id})
+}
+
+// DeactivateAutomatic ends the automatic discount id now.
+func (s *DiscountServiceOp) DeactivateAutomatic(id graphql.ID) (*DiscountAutomaticNode, error) {
+	return s.DeactivateAutomaticWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DiscountServiceOp) DeactivateAutomaticWithContext(ctx context.Context, id graphql.ID) (*DiscountAutomaticNode, error) {
+	return s.mutateAutomatic(ctx, discountAutomaticDeactivateMutation, map[string]interface{}{"id": id})
+}
+
+func (s *DiscountServiceOp) DeleteAutomatic(id graphql.ID) error {
+	return s.DeleteAutomaticWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DiscountServiceOp) DeleteAutomaticWithContext(ctx context.Context, id graphql.ID) error {
+	_, err := s.mutate(ctx, discountAutomaticDeleteMutation, map[string]interface{}{"id": id})
+	return err
+}
+
+func (s *DiscountServiceOp) mutateCode(ctx context.Context, mutation string, vars map[string]interface{}) (*DiscountCodeNode, error) {
+	payload, err := s.mutate(ctx, mutation, vars)
+	if err != nil {
+		return nil, err
+	}
+	return payload.CodeDiscountNode, nil
+}
+
+func (s *DiscountServiceOp) mutateAutomatic(ctx context.Context, mutation string, vars map[string]interface{}) (*DiscountAutomaticNode, error) {
+	payload, err := s.mutate(ctx, mutation, vars)
+	if err != nil {
+		return nil, err
+	}
+	return payload.AutomaticDiscountNode, nil
+}
+
+// mutate runs one of the discount mutations and returns its payload.
+func (s *DiscountServiceOp) mutate(ctx context.Context, mutation string, vars map[string]interface{}) (*discountPayload, error) {
+	payload := &discountPayload{}
+	err := s.client.mutate(ctx, retryThrottled, mutation, vars, payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
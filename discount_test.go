@@ -0,0 +1,156 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestDiscounts(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	p := srv.AddProduct(&shopifytest.Product{Title: "Snowboard"})
+
+	basic, err := client.Discount.CreateBasicCodeWithContext(ctx, shopify.DiscountCodeBasicInput{
+		Title:             "Summer sale",
+		Code:              "SUMMER10",
+		CustomerSelection: &shopify.DiscountCustomerSelectionInput{All: true},
+		CustomerGets: &shopify.DiscountCustomerGetsInput{
+			Value: &shopify.DiscountCustomerGetsValueInput{Percentage: 0.1},
+			Items: &shopify.DiscountItemsInput{Products: &shopify.DiscountProductsInput{ProductsToAdd: []graphql.ID{p.ID}}},
+		},
+		MinimumRequirement: &shopify.DiscountMinimumRequirementInput{
+			Subtotal: &shopify.DiscountMinimumSubtotalInput{GreaterThanOrEqualToSubtotal: "50.00"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create basic code: %v", err)
+	}
+	d := basic.CodeDiscount
+	if d.Typename != "DiscountCodeBasic" || d.Status != shopify.DiscountStatusActive || len(d.Codes.Edges) != 1 ||
+		d.CustomerGets == nil || d.CustomerGets.Value.Percentage != 0.1 ||
+		len(d.CustomerGets.Items.Products.IDs()) != 1 || d.CustomerGets.Items.Products.IDs()[0] != p.ID ||
+		d.MinimumRequirement == nil || d.MinimumRequirement.GreaterThanOrEqualToSubtotal.Amount != "50.00" {
+		t.Errorf("unexpected basic code discount: %+v", d)
+	}
+
+	_, err = client.Discount.CreateFreeShippingCodeWithContext(ctx, shopify.DiscountCodeFreeShippingInput{Title: "Duplicate", Code: "summer10"})
+	if !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors for a duplicate code, got %v", err)
+	}
+
+	shipping, err := client.Discount.CreateFreeShippingCodeWithContext(ctx, shopify.DiscountCodeFreeShippingInput{
+		Title: "Free shipping",
+		Code:  "SHIPFREE",
+		Destination: &shopify.DiscountShippingDestinationSelectionInput{
+			Countries: &shopify.DiscountCountriesInput{Add: []shopify.CountryCode{"CA", "US"}},
+		},
+		MaximumShippingPrice: "20.00",
+	})
+	if err != nil {
+		t.Fatalf("create free shipping code: %v", err)
+	}
+	if sel := shipping.CodeDiscount.DestinationSelection; sel == nil || len(sel.Countries) != 2 ||
+		shipping.CodeDiscount.MaximumShippingPrice == nil {
+		t.Errorf("unexpected free shipping code discount: %+v", shipping.CodeDiscount)
+	}
+
+	updated, err := client.Discount.UpdateBasicCodeWithContext(ctx, basic.ID, shopify.DiscountCodeBasicInput{
+		CustomerGets: &shopify.DiscountCustomerGetsInput{
+			Value: &shopify.DiscountCustomerGetsValueInput{DiscountAmount: &shopify.DiscountAmountInput{Amount: "5.00"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("update basic code: %v", err)
+	}
+	if v := updated.CodeDiscount.CustomerGets.Value; v.Typename != "DiscountAmount" || v.Amount == nil || v.Amount.Amount != "5.00" {
+		t.Errorf("unexpected customer gets value: %+v", v)
+	}
+
+	creation, err := client.Discount.AddRedeemCodesWithContext(ctx, basic.ID, "SUMMER-A", "SUMMER-B", "SHIPFREE")
+	if err != nil {
+		t.Fatalf("add redeem codes: %v", err)
+	}
+	creation, err = client.Discount.GetRedeemCodeBulkCreationWithContext(ctx, creation.ID)
+	if err != nil {
+		t.Fatalf("get redeem code bulk creation: %v", err)
+	}
+	if !creation.Done || creation.ImportedCount != 2 || creation.FailedCount != 1 {
+		t.Errorf("unexpected redeem code bulk creation: %+v", creation)
+	}
+	got, err := client.Discount.GetCodeByCodeWithContext(ctx, "summer-b")
+	if err != nil {
+		t.Fatalf("get by code: %v", err)
+	}
+	if got.ID != basic.ID || len(got.CodeDiscount.Codes.Edges) != 3 {
+		t.Errorf("unexpected code discount: %+v", got)
+	}
+
+	deactivated, err := client.Discount.DeactivateCodeWithContext(ctx, basic.ID)
+	if err != nil {
+		t.Fatalf("deactivate code: %v", err)
+	}
+	if deactivated.CodeDiscount.Status != shopify.DiscountStatusExpired || deactivated.CodeDiscount.EndsAt == nil {
+		t.Errorf("expected the discount to be expired, got %+v", deactivated.CodeDiscount)
+	}
+	activated, err := client.Discount.ActivateCodeWithContext(ctx, basic.ID)
+	if err != nil {
+		t.Fatalf("activate code: %v", err)
+	}
+	if activated.CodeDiscount.Status != shopify.DiscountStatusActive {
+		t.Errorf("expected the discount to be active, got %s", activated.CodeDiscount.Status)
+	}
+
+	bxgy, err := client.Discount.CreateBxgyAutomaticWithContext(ctx, shopify.DiscountAutomaticBxgyInput{
+		Title:        "Buy 2 get 1 free",
+		StartsAt:     shopify.DateTime(time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)),
+		CustomerBuys: &shopify.DiscountCustomerBuysInput{Value: &shopify.DiscountCustomerBuysValueInput{Quantity: "2"}, Items: &shopify.DiscountItemsInput{All: true}},
+		CustomerGets: &shopify.DiscountCustomerGetsInput{
+			Value: &shopify.DiscountCustomerGetsValueInput{DiscountOnQuantity: &shopify.DiscountOnQuantityInput{
+				Quantity: "1",
+				Effect:   shopify.DiscountEffectInput{Percentage: 1},
+			}},
+			Items: &shopify.DiscountItemsInput{All: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create bxgy automatic: %v", err)
+	}
+	a := bxgy.AutomaticDiscount
+	if a.Typename != "DiscountAutomaticBxgy" || a.Status != shopify.DiscountStatusScheduled ||
+		a.CustomerBuys == nil || a.CustomerBuys.Value.Quantity != "2" ||
+		a.CustomerGets.Value.Quantity == nil || a.CustomerGets.Value.Effect.Percentage != 1 || !a.CustomerGets.Items.AllItems {
+		t.Errorf("unexpected bxgy automatic discount: %+v", a)
+	}
+	if _, err = client.Discount.ActivateAutomaticWithContext(ctx, bxgy.ID); err != nil {
+		t.Fatalf("activate automatic: %v", err)
+	}
+	got2, err := client.Discount.GetAutomaticWithContext(ctx, bxgy.ID)
+	if err != nil {
+		t.Fatalf("get automatic: %v", err)
+	}
+	if got2.AutomaticDiscount.Status != shopify.DiscountStatusActive {
+		t.Errorf("expected the automatic discount to be active, got %s", got2.AutomaticDiscount.Status)
+	}
+
+	if err = client.Discount.DeleteAutomaticWithContext(ctx, bxgy.ID); err != nil {
+		t.Fatalf("delete automatic: %v", err)
+	}
+	if err = client.Discount.DeleteCodeWithContext(ctx, bxgy.ID); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors deleting a missing discount, got %v", err)
+	}
+	if err = client.Discount.DeleteCodeWithContext(ctx, shipping.ID); err != nil {
+		t.Fatalf("delete code: %v", err)
+	}
+	if len(srv.Discounts()) != 1 {
+		t.Errorf("expected 1 discount left, got %d", len(srv.Discounts()))
+	}
+}
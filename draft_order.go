@@ -0,0 +1,596 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+)
+
+// DraftOrderService manages draft orders, the quotes and invoices that become orders once completed.
+type DraftOrderService interface {
+	Get(id graphql.ID) (*DraftOrder, error)
+	GetWithContext(ctx context.Context, id graphql.ID) (*DraftOrder, error)
+	// List returns the page of draft orders selected by opts. opts.Query uses the draft order search
+	// syntax, e.g. "status:open tag:quote".
+	List(opts ListOptions) (*Page[*DraftOrder], error)
+	ListWithContext(ctx context.Context, opts ListOptions) (*Page[*DraftOrder], error)
+	// Iter returns an iterator over the draft orders matching opts.Query, fetching pages on demand.
+	Iter(opts ListOptions) *Iterator[*DraftOrder]
+	IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*DraftOrder]
+
+	Create(input DraftOrderInput) (*DraftOrder, error)
+	CreateWithContext(ctx context.Context, input DraftOrderInput) (*DraftOrder, error)
+	// Update updates the draft order id with the fields set in input. Line items, when set, replace
+	// the ones of the draft order.
+	Update(id graphql.ID, input DraftOrderInput) (*DraftOrder, error)
+	UpdateWithContext(ctx context.Context, id graphql.ID, input DraftOrderInput) (*DraftOrder, error)
+	// Calculate returns the prices and shipping rates of the draft order input would create, without
+	// creating it.
+	Calculate(input DraftOrderInput) (*CalculatedDraftOrder, error)
+	CalculateWithContext(ctx context.Context, input DraftOrderInput) (*CalculatedDraftOrder, error)
+	// Complete turns the draft order into an order, marked as paid unless paymentPending, and returns
+	// the completed draft order with its Order set.
+	Complete(id graphql.ID, paymentPending bool) (*DraftOrder, error)
+	CompleteWithContext(ctx context.Context, id graphql.ID, paymentPending bool) (*DraftOrder, error)
+	// SendInvoice emails the invoice of the draft order, to its email unless email.To is set.
+	SendInvoice(id graphql.ID, email DraftOrderInvoiceEmail) (*DraftOrder, error)
+	SendInvoiceWithContext(ctx context.Context, id graphql.ID, email DraftOrderInvoiceEmail) (*DraftOrder, error)
+	Delete(id graphql.ID) error
+	DeleteWithContext(ctx context.Context, id graphql.ID) error
+
+	// BulkAddTags adds tags to the draft orders ids in the background, returning the job doing it.
+	BulkAddTags(ids []graphql.ID, tags ...string) (*Job, error)
+	BulkAddTagsWithContext(ctx context.Context, ids []graphql.ID, tags ...string) (*Job, error)
+	// BulkRemoveTags removes tags from the draft orders ids in the background, returning the job
+	// doing it.
+	BulkRemoveTags(ids []graphql.ID, tags ...string) (*Job, error)
+	BulkRemoveTagsWithContext(ctx context.Context, ids []graphql.ID, tags ...string) (*Job, error)
+}
+
+type DraftOrderServiceOp struct {
+	client *Client
+}
+
+type DraftOrderStatus string
+
+const (
+	DraftOrderStatusOpen        DraftOrderStatus = "OPEN"
+	DraftOrderStatusInvoiceSent DraftOrderStatus = "INVOICE_SENT"
+	DraftOrderStatusCompleted   DraftOrderStatus = "COMPLETED"
+)
+
+type DraftOrderAppliedDiscountType string
+
+const (
+	DraftOrderAppliedDiscountTypeFixedAmount DraftOrderAppliedDiscountType = "FIXED_AMOUNT"
+	DraftOrderAppliedDiscountTypePercentage  DraftOrderAppliedDiscountType = "PERCENTAGE"
+)
+
+type DraftOrderInput struct {
+	CustomerID      graphql.ID           `json:"customerId,omitempty"`
+	Email           graphql.String       `json:"email,omitempty"`
+	Phone           graphql.String       `json:"phone,omitempty"`
+	Note            graphql.String       `json:"note,omitempty"`
+	Tags            []graphql.String     `json:"tags,omitempty"`
+	PoNumber        graphql.String       `json:"poNumber,omitempty"`
+	TaxExempt       *graphql.Boolean     `json:"taxExempt,omitempty"`
+	ShippingAddress *MailingAddressInput `json:"shippingAddress,omitempty"`
+	BillingAddress  *MailingAddressInput `json:"billingAddress,omitempty"`
+	// UseCustomerDefaultAddress sets the addresses of the draft order to the default address of
+	// the customer.
+	UseCustomerDefaultAddress graphql.Boolean                 `json:"useCustomerDefaultAddress,omitempty"`
+	LineItems                 []DraftOrderLineItemInput       `json:"lineItems,omitempty"`
+	AppliedDiscount           *DraftOrderAppliedDiscountInput `json:"appliedDiscount,omitempty"`
+	ShippingLine              *ShippingLineInput              `json:"shippingLine,omitempty"`
+}
+
+// DraftOrderLineItemInput is a line item of a variant, or a custom line item with a title and an
+// original unit price when VariantID is nil.
+type DraftOrderLineItemInput struct {
+	VariantID         graphql.ID                      `json:"variantId,omitempty"`
+	Quantity          graphql.Int                     `json:"quantity"`
+	Title             graphql.String                  `json:"title,omitempty"`
+	SKU               graphql.String                  `json:"sku,omitempty"`
+	OriginalUnitPrice Money                           `json:"originalUnitPrice,omitempty"`
+	AppliedDiscount   *DraftOrderAppliedDiscountInput `json:"appliedDiscount,omitempty"`
+}
+
+type DraftOrderAppliedDiscountInput struct {
+	Title       graphql.String `json:"title,omitempty"`
+	Description graphql.String `json:"description,omitempty"`
+	// Value is an amount for FIXED_AMOUNT discounts, and a percentage between 0 and 100 for
+	// PERCENTAGE ones.
+	Value     graphql.Float                 `json:"value"`
+	ValueType DraftOrderAppliedDiscountType `json:"valueType"`
+}
+
+type ShippingLineInput struct {
+	Title graphql.String `json:"title,omitempty"`
+	Price Money          `json:"price,omitempty"`
+	// ShippingRateHandle selects one of the available shipping rates of a calculated draft order.
+	ShippingRateHandle graphql.String `json:"shippingRateHandle,omitempty"`
+}
+
+type DraftOrderInvoiceEmail struct {
+	To            graphql.String   `json:"to,omitempty"`
+	From          graphql.String   `json:"from,omitempty"`
+	Bcc           []graphql.String `json:"bcc,omitempty"`
+	Subject       graphql.String   `json:"subject,omitempty"`
+	CustomMessage graphql.String   `json:"customMessage,omitempty"`
+}
+
+type DraftOrder struct {
+	ID     graphql.ID       `json:"id,omitempty"`
+	Name   graphql.String   `json:"name,omitempty"`
+	Status DraftOrderStatus `json:"status,omitempty"`
+	Email  graphql.String   `json:"email,omitempty"`
+	Phone  graphql.String   `json:"phone,omitempty"`
+	// Note is queried as note2, the note field of draft orders being deprecated.
+	Note            graphql.String             `json:"note2,omitempty"`
+	Tags            []graphql.String           `json:"tags,omitempty"`
+	PoNumber        graphql.String             `json:"poNumber,omitempty"`
+	TaxExempt       graphql.Boolean            `json:"taxExempt,omitempty"`
+	InvoiceURL      graphql.String             `json:"invoiceUrl,omitempty"`
+	InvoiceSentAt   DateTime                   `json:"invoiceSentAt,omitempty"`
+	Customer        *Customer                  `json:"customer,omitempty"`
+	ShippingAddress *MailingAddress            `json:"shippingAddress,omitempty"`
+	BillingAddress  *MailingAddress            `json:"billingAddress,omitempty"`
+	LineItems       []DraftOrderLineItem       `json:"lineItems,omitempty"`
+	AppliedDiscount *DraftOrderAppliedDiscount `json:"appliedDiscount,omitempty"`
+	ShippingLine    *ShippingLine              `json:"shippingLine,omitempty"`
+
+	SubtotalPriceSet      MoneyBag `json:"subtotalPriceSet,omitempty"`
+	TotalShippingPriceSet MoneyBag `json:"totalShippingPriceSet,omitempty"`
+	TotalTaxSet           MoneyBag `json:"totalTaxSet,omitempty"`
+	TotalPriceSet         MoneyBag `json:"totalPriceSet,omitempty"`
+
+	// Order is the order created by completing the draft order.
+	Order       *OrderBase `json:"order,omitempty"`
+	CompletedAt DateTime   `json:"completedAt,omitempty"`
+	CreatedAt   DateTime   `json:"createdAt,omitempty"`
+	UpdatedAt   DateTime   `json:"updatedAt,omitempty"`
+}
+
+type DraftOrderLineItem struct {
+	// ID is empty for the line items of a calculated draft order.
+	ID       graphql.ID      `json:"id,omitempty"`
+	Title    graphql.String  `json:"title,omitempty"`
+	SKU      graphql.String  `json:"sku,omitempty"`
+	Quantity graphql.Int     `json:"quantity,omitempty"`
+	Custom   graphql.Boolean `json:"custom,omitempty"`
+	Variant  LineItemVariant `json:"variant,omitempty"`
+
+	OriginalUnitPriceSet MoneyBag                   `json:"originalUnitPriceSet,omitempty"`
+	OriginalTotalSet     MoneyBag                   `json:"originalTotalSet,omitempty"`
+	DiscountedTotalSet   MoneyBag                   `json:"discountedTotalSet,omitempty"`
+	AppliedDiscount      *DraftOrderAppliedDiscount `json:"appliedDiscount,omitempty"`
+}
+
+type DraftOrderAppliedDiscount struct {
+	Title       graphql.String                `json:"title,omitempty"`
+	Description graphql.String                `json:"description,omitempty"`
+	Value       graphql.Float                 `json:"value,omitempty"`
+	ValueType   DraftOrderAppliedDiscountType `json:"valueType,omitempty"`
+	AmountSet   MoneyBag                      `json:"amountSet,omitempty"`
+}
+
+// CalculatedDraftOrder is a draft order priced by DraftOrderService.Calculate, with the shipping rates
+// available to its shipping address.
+type CalculatedDraftOrder struct {
+	LineItems              []DraftOrderLineItem       `json:"lineItems,omitempty"`
+	AppliedDiscount        *DraftOrderAppliedDiscount `json:"appliedDiscount,omitempty"`
+	AvailableShippingRates []ShippingRate             `json:"availableShippingRates,omitempty"`
+	SubtotalPriceSet       MoneyBag                   `json:"subtotalPriceSet,omitempty"`
+	TotalShippingPriceSet  MoneyBag                   `json:"totalShippingPriceSet,omitempty"`
+	TotalTaxSet            MoneyBag                   `json:"totalTaxSet,omitempty"`
+	TotalPriceSet          MoneyBag                   `json:"totalPriceSet,omitempty"`
+}
+
+// Job is a job running in the background, such as a bulk tagging of draft orders.
+type Job struct {
+	ID   graphql.ID      `json:"id,omitempty"`
+	Done graphql.Boolean `json:"done,omitempty"`
+}
+
+const moneyBagQuery = `
+	presentmentMoney{
+		amount
+		currencyCode
+	}
+	shopMoney{
+		amount
+		currencyCode
+	}
+`
+
+var draftOrderAppliedDiscountQuery = fmt.Sprintf(`
+	title
+	description
+	value
+	valueType
+	amountSet{
+		%s
+	}
+`, moneyBagQuery)
+
+var draftOrderLineItemQuery = fmt.Sprintf(`
+	title
+	sku
+	quantity
+	custom
+	variant{
+		id
+	}
+	originalUnitPriceSet{
+		%[1]s
+	}
+	originalTotalSet{
+		%[1]s
+	}
+	discountedTotalSet{
+		%[1]s
+	}
+	appliedDiscount{
+		%[2]s
+	}
+`, moneyBagQuery, draftOrderAppliedDiscountQuery)
+
+var draftOrderQuery = fmt.Sprintf(`
+	id
+	name
+	status
+	email
+	phone
+	note2
+	tags
+	poNumber
+	taxExempt
+	invoiceUrl
+	invoiceSentAt
+	customer{
+		id
+		displayName
+		email
+	}
+	shippingAddress{
+		%[1]s
+	}
+	billingAddress{
+		%[1]s
+	}
+	lineItems(first: 250){
+		edges{
+			node{
+				id
+				%[2]s
+			}
+		}
+	}
+	appliedDiscount{
+		%[3]s
+	}
+	shippingLine{
+		title
+		originalPriceSet{
+			%[4]s
+		}
+	}
+	subtotalPriceSet{
+		%[4]s
+	}
+	totalShippingPriceSet{
+		%[4]s
+	}
+	totalTaxSet{
+		%[4]s
+	}
+	totalPriceSet{
+		%[4]s
+	}
+	order{
+		id
+		name
+	}
+	completedAt
+	createdAt
+	updatedAt
+`, mailingAddressQuery, draftOrderLineItemQuery, draftOrderAppliedDiscountQuery, moneyBagQuery)
+
+// draftOrderNode decodes a draft order with its line items connection.
+type draftOrderNode struct {
+	DraftOrder
+	LineItems struct {
+		Edges []struct {
+			Node DraftOrderLineItem `json:"node"`
+		} `json:"edges"`
+	} `json:"lineItems"`
+}
+
+func (n *draftOrderNode) draftOrder() *DraftOrder {
+	d := n.DraftOrder
+	for _, e := range n.LineItems.Edges {
+		d.LineItems = append(d.LineItems, e.Node)
+	}
+	return &d
+}
+
+var (
+	draftOrderFields = fmt.Sprintf("draftOrder{%s}", draftOrderQuery)
+
+	draftOrderCreateMutation = mutationQuery(
+		"draftOrderCreate($input: DraftOrderInput!)",
+		"draftOrderCreate(input: $input)",
+		draftOrderFields)
+	draftOrderUpdateMutation = mutationQuery(
+		"draftOrderUpdate($id: ID!, $input: DraftOrderInput!)",
+		"draftOrderUpdate(id: $id, input: $input)",
+		draftOrderFields)
+	draftOrderCompleteMutation = mutationQuery(
+		"draftOrderComplete($id: ID!, $paymentPending: Boolean)",
+		"draftOrderComplete(id: $id, paymentPending: $paymentPending)",
+		draftOrderFields)
+	draftOrderInvoiceSendMutation = mutationQuery(
+		"draftOrderInvoiceSend($id: ID!, $email: EmailInput)",
+		"draftOrderInvoiceSend(id: $id, email: $email)",
+		draftOrderFields)
+
+	draftOrderCalculateMutation = fmt.Sprintf(`
+mutation draftOrderCalculate($input: DraftOrderInput!) {
+	draftOrderCalculate(input: $input) {
+		calculatedDraftOrder{
+			lineItems{
+				%[1]s
+			}
+			appliedDiscount{
+				%[2]s
+			}
+			availableShippingRates{
+				handle
+				title
+				priceV2: price{
+					amount
+					currencyCode
+				}
+			}
+			subtotalPriceSet{
+				%[3]s
+			}
+			totalShippingPriceSet{
+				%[3]s
+			}
+			totalTaxSet{
+				%[3]s
+			}
+			totalPriceSet{
+				%[3]s
+			}
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`, draftOrderLineItemQuery, draftOrderAppliedDiscountQuery, moneyBagQuery)
+)
+
+const draftOrderDeleteMutation = `
+mutation draftOrderDelete($input: DraftOrderDeleteInput!) {
+	draftOrderDelete(input: $input) {
+		deletedId
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+const draftOrderBulkAddTagsMutation = `
+mutation draftOrderBulkAddTags($ids: [ID!], $tags: [String!]!) {
+	draftOrderBulkAddTags(ids: $ids, tags: $tags) {
+		job {
+			id
+			done
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+const draftOrderBulkRemoveTagsMutation = `
+mutation draftOrderBulkRemoveTags($ids: [ID!], $tags: [String!]!) {
+	draftOrderBulkRemoveTags(ids: $ids, tags: $tags) {
+		job {
+			id
+			done
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+func (s *DraftOrderServiceOp) Get(id graphql.ID) (*DraftOrder, error) {
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DraftOrderServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (*DraftOrder, error) {
+	q := fmt.Sprintf(`
+		query draftOrder($id: ID!) {
+			draftOrder(id: $id){
+				%s
+			}
+		}
+	`, draftOrderQuery)
+
+	out := struct {
+		DraftOrder *draftOrderNode `json:"draftOrder"`
+	}{}
+	err := s.client.query(ctx, q, map[string]interface{}{"id": id}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if out.DraftOrder == nil {
+		return nil, fmt.Errorf("draft order %v not found", id)
+	}
+
+	return out.DraftOrder.draftOrder(), nil
+}
+
+func (s *DraftOrderServiceOp) List(opts ListOptions) (*Page[*DraftOrder], error) {
+	return s.ListWithContext(s.client.gql.Context(), opts)
+}
+
+func (s *DraftOrderServiceOp) ListWithContext(ctx context.Context, opts ListOptions) (*Page[*DraftOrder], error) {
+	q := fmt.Sprintf(`
+		query draftOrders($query: String, $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+			draftOrders(query: $query, first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
+				edges{
+					node{
+						%s
+					}
+				}
+				%s
+			}
+		}
+	`, draftOrderQuery, pageInfoQuery)
+
+	if opts.First == 0 && opts.Last == 0 {
+		opts.First = defaultPageSize
+	}
+	out := struct {
+		DraftOrders struct {
+			Edges []struct {
+				Node *draftOrderNode `json:"node"`
+			} `json:"edges"`
+			PageInfo PageInfo `json:"pageInfo"`
+		} `json:"draftOrders"`
+	}{}
+	err := s.client.query(ctx, q, pageVars(opts), &out)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[*DraftOrder]{PageInfo: out.DraftOrders.PageInfo}
+	for _, e := range out.DraftOrders.Edges {
+		page.Nodes = append(page.Nodes, e.Node.draftOrder())
+	}
+
+	return page, nil
+}
+
+func (s *DraftOrderServiceOp) Iter(opts ListOptions) *Iterator[*DraftOrder] {
+	return s.IterWithContext(s.client.gql.Context(), opts)
+}
+
+func (s *DraftOrderServiceOp) IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*DraftOrder] {
+	return Paginate(ctx, opts, s.ListWithContext)
+}
+
+func (s *DraftOrderServiceOp) Create(input DraftOrderInput) (*DraftOrder, error) {
+	return s.CreateWithContext(s.client.gql.Context(), input)
+}
+
+func (s *DraftOrderServiceOp) CreateWithContext(ctx context.Context, input DraftOrderInput) (*DraftOrder, error) {
+	return s.mutate(ctx, draftOrderCreateMutation, map[string]interface{}{"input": input})
+}
+
+func (s *DraftOrderServiceOp) Update(id graphql.ID, input DraftOrderInput) (*DraftOrder, error) {
+	return s.UpdateWithContext(s.client.gql.Context(), id, input)
+}
+
+func (s *DraftOrderServiceOp) UpdateWithContext(ctx context.Context, id graphql.ID, input DraftOrderInput) (*DraftOrder, error) {
+	return s.mutate(ctx, draftOrderUpdateMutation, map[string]interface{}{"id": id, "input": input})
+}
+
+func (s *DraftOrderServiceOp) Calculate(input DraftOrderInput) (*CalculatedDraftOrder, error) {
+	return s.CalculateWithContext(s.client.gql.Context(), input)
+}
+
+func (s *DraftOrderServiceOp) CalculateWithContext(ctx context.Context, input DraftOrderInput) (*CalculatedDraftOrder, error) {
+	payload := struct {
+		CalculatedDraftOrder *CalculatedDraftOrder `json:"calculatedDraftOrder"`
+	}{}
+	err := s.client.mutate(ctx, retryTransient, draftOrderCalculateMutation, map[string]interface{}{"input": input}, &payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload.CalculatedDraftOrder, nil
+}
+
+func (s *DraftOrderServiceOp) Complete(id graphql.ID, paymentPending bool) (*DraftOrder, error) {
+	return s.CompleteWithContext(s.client.gql.Context(), id, paymentPending)
+}
+
+func (s *DraftOrderServiceOp) CompleteWithContext(ctx context.Context, id graphql.ID, paymentPending bool) (*DraftOrder, error) {
+	return s.mutate(ctx, draftOrderCompleteMutation, map[string]interface{}{"id": id, "paymentPending": paymentPending})
+}
+
+func (s *DraftOrderServiceOp) SendInvoice(id graphql.ID, email DraftOrderInvoiceEmail) (*DraftOrder, error) {
+	return s.SendInvoiceWithContext(s.client.gql.Context(), id, email)
+}
+
+func (s *DraftOrderServiceOp) SendInvoiceWithContext(ctx context.Context, id graphql.ID, email DraftOrderInvoiceEmail) (*DraftOrder, error) {
+	return s.mutate(ctx, draftOrderInvoiceSendMutation, map[string]interface{}{"id": id, "email": email})
+}
+
+func (s *DraftOrderServiceOp) Delete(id graphql.ID) error {
+	return s.DeleteWithContext(s.client.gql.Context(), id)
+}
+
+func (s *DraftOrderServiceOp) DeleteWithContext(ctx context.Context, id graphql.ID) error {
+	vars := map[string]interface{}{
+		"input": map[string]interface{}{"id": id},
+	}
+	return s.client.mutate(ctx, retryThrottled, draftOrderDeleteMutation, vars, &struct{}{})
+}
+
+func (s *DraftOrderServiceOp) BulkAddTags(ids []graphql.ID, tags ...string) (*Job, error) {
+	return s.BulkAddTagsWithContext(s.client.gql.Context(), ids, tags...)
+}
+
+func (s *DraftOrderServiceOp) BulkAddTagsWithContext(ctx context.Context, ids []graphql.ID, tags ...string) (*Job, error) {
+	return s.bulkTags(ctx, draftOrderBulkAddTagsMutation, ids, tags)
+}
+
+func (s *DraftOrderServiceOp) BulkRemoveTags(ids []graphql.ID, tags ...string) (*Job, error) {
+	return s.BulkRemoveTagsWithContext(s.client.gql.Context(), ids, tags...)
+}
+
+func (s *DraftOrderServiceOp) BulkRemoveTagsWithContext(ctx context.Context, ids []graphql.ID, tags ...string) (*Job, error) {
+	return s.bulkTags(ctx, draftOrderBulkRemoveTagsMutation, ids, tags)
+}
+
+// bulkTags runs the draftOrderBulkAddTags or draftOrderBulkRemoveTags mutation and returns its job.
+func (s *DraftOrderServiceOp) bulkTags(ctx context.Context, mutation string, ids []graphql.ID, tags []string) (*Job, error) {
+	vars := map[string]interface{}{
+		"ids":  ids,
+		"tags": tags,
+	}
+	payload := struct {
+		Job *Job `json:"job"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, mutation, vars, &payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload.Job, nil
+}
+
+// mutate runs one of the draft order mutations and returns the draft order of its payload.
+func (s *DraftOrderServiceOp) mutate(ctx context.Context, mutation string, vars map[string]interface{}) (*DraftOrder, error) {
+	payload := struct {
+		DraftOrder *draftOrderNode `json:"draftOrder"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, mutation, vars, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.DraftOrder == nil {
+		return nil, fmt.Errorf("empty response to mutation")
+	}
+	return payload.DraftOrder.draftOrder(), nil
+}
@@ -0,0 +1,138 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestDraftOrders(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	srv.AddLocation(&shopifytest.Location{Name: "Warehouse"})
+	p := srv.AddProduct(&shopifytest.Product{
+		Title:    "Snowboard",
+		Variants: []*shopifytest.Variant{{Title: "Default Title", SKU: "SB", Price: "10.00"}},
+	})
+	c := srv.AddCustomer(&shopifytest.Customer{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"})
+
+	input := shopify.DraftOrderInput{
+		CustomerID:      c.ID,
+		TaxExempt:       graphql.NewBoolean(true),
+		ShippingAddress: &shopify.MailingAddressInput{Address1: "1 Main St", City: "Ottawa", CountryCode: "CA"},
+		LineItems: []shopify.DraftOrderLineItemInput{
+			{
+				VariantID:       p.Variants[0].ID,
+				Quantity:        2,
+				AppliedDiscount: &shopify.DraftOrderAppliedDiscountInput{Value: 10, ValueType: shopify.DraftOrderAppliedDiscountTypePercentage},
+			},
+			{Title: "Gift wrap", Quantity: 1, OriginalUnitPrice: "3.00"},
+		},
+		AppliedDiscount: &shopify.DraftOrderAppliedDiscountInput{Title: "Loyalty", Value: 1, ValueType: shopify.DraftOrderAppliedDiscountTypeFixedAmount},
+	}
+	calculated, err := client.DraftOrder.CalculateWithContext(ctx, input)
+	if err != nil {
+		t.Fatalf("calculate: %v", err)
+	}
+	if calculated.SubtotalPriceSet.ShopMoney.Amount != "20.00" || len(calculated.LineItems) != 2 ||
+		calculated.LineItems[0].DiscountedTotalSet.ShopMoney.Amount != "18.00" || !calculated.LineItems[1].Custom ||
+		len(calculated.AvailableShippingRates) == 0 || calculated.AvailableShippingRates[0].PriceV2.Amount != "5.00" {
+		t.Errorf("unexpected calculated draft order: %+v", calculated)
+	}
+
+	input.ShippingLine = &shopify.ShippingLineInput{ShippingRateHandle: calculated.AvailableShippingRates[0].Handle}
+	draft, err := client.DraftOrder.CreateWithContext(ctx, input)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if draft.Name != "#D1" || draft.Status != shopify.DraftOrderStatusOpen || !draft.TaxExempt || draft.Email != "jane@example.com" ||
+		draft.TotalPriceSet.ShopMoney.Amount != "25.00" || draft.ShippingLine == nil || len(draft.LineItems) != 2 {
+		t.Errorf("unexpected draft order: %+v", draft)
+	}
+	if _, err = client.DraftOrder.CreateWithContext(ctx, shopify.DraftOrderInput{Email: "jane@example.com"}); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors creating a draft order without line items, got %v", err)
+	}
+
+	draft, err = client.DraftOrder.UpdateWithContext(ctx, draft.ID, shopify.DraftOrderInput{Note: "Quote for Jane", Tags: []graphql.String{"quote"}, TaxExempt: graphql.NewBoolean(false)})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if draft.Note != "Quote for Jane" || draft.TaxExempt || len(draft.Tags) != 1 || len(draft.LineItems) != 2 {
+		t.Errorf("unexpected updated draft order: %+v", draft)
+	}
+
+	draft, err = client.DraftOrder.SendInvoiceWithContext(ctx, draft.ID, shopify.DraftOrderInvoiceEmail{CustomMessage: "Thanks!"})
+	if err != nil {
+		t.Fatalf("send invoice: %v", err)
+	}
+	if draft.Status != shopify.DraftOrderStatusInvoiceSent || draft.InvoiceSentAt == "" || draft.InvoiceURL == "" {
+		t.Errorf("unexpected draft order after sending the invoice: %+v", draft)
+	}
+
+	job, err := client.DraftOrder.BulkAddTagsWithContext(ctx, []graphql.ID{draft.ID}, "wholesale")
+	if err != nil {
+		t.Fatalf("bulk add tags: %v", err)
+	}
+	if !job.Done {
+		t.Errorf("expected the job to be done: %+v", job)
+	}
+	if _, err = client.DraftOrder.BulkRemoveTagsWithContext(ctx, []graphql.ID{draft.ID}, "quote"); err != nil {
+		t.Fatalf("bulk remove tags: %v", err)
+	}
+	page, err := client.DraftOrder.ListWithContext(ctx, shopify.ListOptions{Query: "status:invoice_sent tag:wholesale"})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Nodes) != 1 || len(page.Nodes[0].Tags) != 1 || page.Nodes[0].Tags[0] != "wholesale" {
+		t.Errorf("unexpected draft orders: %+v", page.Nodes)
+	}
+
+	draft, err = client.DraftOrder.CompleteWithContext(ctx, draft.ID, false)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if draft.Status != shopify.DraftOrderStatusCompleted || draft.Order == nil || draft.CompletedAt == "" {
+		t.Fatalf("unexpected completed draft order: %+v", draft)
+	}
+	orders := srv.Orders()
+	if len(orders) != 1 || orders[0].ID != draft.Order.ID || orders[0].DisplayFinancialStatus != "PAID" ||
+		orders[0].Customer != c || len(orders[0].LineItems) != 2 || len(orders[0].FulfillmentOrders) != 1 {
+		t.Errorf("unexpected order: %+v", orders)
+	}
+	if _, err = client.DraftOrder.CompleteWithContext(ctx, draft.ID, false); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors completing a completed draft order, got %v", err)
+	}
+
+	if err = client.DraftOrder.DeleteWithContext(ctx, draft.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err = client.DraftOrder.GetWithContext(ctx, draft.ID); err == nil {
+		t.Error("expected the deleted draft order not to be found")
+	}
+}
+
+func TestDraftOrderCreateLostResponse(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	client.SetRetries(2)
+	ctx := context.Background()
+
+	srv.DropResponses(1)
+	_, err := client.DraftOrder.CreateWithContext(ctx, shopify.DraftOrderInput{
+		LineItems: []shopify.DraftOrderLineItemInput{{Title: "Gift wrap", Quantity: 1, OriginalUnitPrice: "3.00"}},
+	})
+	if err == nil {
+		t.Fatalf("expected the lost response to fail the creation")
+	}
+	if n := len(srv.DraftOrders()); n != 1 {
+		t.Errorf("expected the draft order to be created once, got %d", n)
+	}
+}
@@ -3,18 +3,65 @@ package shopify
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
 )
 
 type FulfillmentService interface {
 	Create(input FulfillmentV2Input) error
+	CreateWithContext(ctx context.Context, input FulfillmentV2Input) error
+	// CreateV2 creates a fulfillment of fulfillment order line items and returns it.
+	CreateV2(input FulfillmentV2Input) (*Fulfillment, error)
+	CreateV2WithContext(ctx context.Context, input FulfillmentV2Input) (*Fulfillment, error)
+	Get(id graphql.ID) (*Fulfillment, error)
+	GetWithContext(ctx context.Context, id graphql.ID) (*Fulfillment, error)
+	// UpdateTracking replaces the tracking information of the fulfillment.
+	UpdateTracking(id graphql.ID, tracking FulfillmentTrackingInput, notifyCustomer bool) (*Fulfillment, error)
+	UpdateTrackingWithContext(ctx context.Context, id graphql.ID, tracking FulfillmentTrackingInput, notifyCustomer bool) (*Fulfillment, error)
+	// Cancel cancels the fulfillment, making its line items fulfillable again.
+	Cancel(id graphql.ID) (*Fulfillment, error)
+	CancelWithContext(ctx context.Context, id graphql.ID) (*Fulfillment, error)
 }
 
 type FulfillmentServiceOp struct {
 	client *Client
 }
 
+type Fulfillment struct {
+	ID                   graphql.ID                `json:"id,omitempty"`
+	Name                 graphql.String            `json:"name,omitempty"`
+	Status               FulfillmentStatus         `json:"status,omitempty"`
+	TrackingInfo         []FulfillmentTrackingInfo `json:"trackingInfo,omitempty"`
+	FulfillmentLineItems []FulfillmentLineItem     `json:"fulfillmentLineItems,omitempty"`
+	CreatedAt            time.Time                 `json:"createdAt,omitempty"`
+	UpdatedAt            time.Time                 `json:"updatedAt,omitempty"`
+}
+
+// FulfillmentLineItem is a quantity of an order line item shipped by a fulfillment. Returns are
+// made of fulfillment line items.
+type FulfillmentLineItem struct {
+	ID       graphql.ID  `json:"id,omitempty"`
+	Quantity graphql.Int `json:"quantity,omitempty"`
+	LineItem LineItem    `json:"lineItem,omitempty"`
+}
+
+type FulfillmentStatus string
+
+const (
+	FulfillmentStatusSuccess   FulfillmentStatus = "SUCCESS"
+	FulfillmentStatusCancelled FulfillmentStatus = "CANCELLED"
+	FulfillmentStatusError     FulfillmentStatus = "ERROR"
+	FulfillmentStatusFailure   FulfillmentStatus = "FAILURE"
+)
+
+type FulfillmentTrackingInfo struct {
+	Company graphql.String `json:"company,omitempty"`
+	Number  graphql.String `json:"number,omitempty"`
+	URL     URL            `json:"url,omitempty"`
+}
+
 type FulfillmentV2Input struct {
 	LineItemsByFulfillmentOrder []FulfillmentOrderLineItemsInput `json:"lineItemsByFulfillmentOrder,omitempty"`
 	NotifyCustomer              graphql.Boolean                  `json:"notifyCustomer,omitempty"`
@@ -45,20 +92,184 @@ type FulfillmentCreateV2Result struct {
 	UserErrors []UserErrors `json:"userErrors,omitempty"`
 }
 
+const fulfillmentQuery = `
+	id
+	name
+	status
+	trackingInfo{
+		company
+		number
+		url
+	}
+	fulfillmentLineItems(first: 250){
+		edges{
+			node{
+				id
+				quantity
+				lineItem{
+					id
+					sku
+					title
+				}
+			}
+		}
+	}
+	createdAt
+	updatedAt
+`
+
+// fulfillmentNode decodes a fulfillment with its line items connection.
+type fulfillmentNode struct {
+	Fulfillment
+	FulfillmentLineItems struct {
+		Edges []struct {
+			Node FulfillmentLineItem `json:"node"`
+		} `json:"edges"`
+	} `json:"fulfillmentLineItems"`
+}
+
+func (n *fulfillmentNode) fulfillment() *Fulfillment {
+	f := n.Fulfillment
+	for _, e := range n.FulfillmentLineItems.Edges {
+		f.FulfillmentLineItems = append(f.FulfillmentLineItems, e.Node)
+	}
+	return &f
+}
+
+var (
+	fulfillmentCreateV2Mutation = fmt.Sprintf(`
+		mutation fulfillmentCreateV2($fulfillment: FulfillmentV2Input!) {
+			fulfillmentCreateV2(fulfillment: $fulfillment){
+				fulfillment{
+					%s
+				}
+				userErrors{
+					field
+					message
+				}
+			}
+		}
+	`, fulfillmentQuery)
+
+	fulfillmentTrackingInfoUpdateV2Mutation = fmt.Sprintf(`
+		mutation fulfillmentTrackingInfoUpdateV2($fulfillmentId: ID!, $trackingInfoInput: FulfillmentTrackingInput!, $notifyCustomer: Boolean) {
+			fulfillmentTrackingInfoUpdateV2(fulfillmentId: $fulfillmentId, trackingInfoInput: $trackingInfoInput, notifyCustomer: $notifyCustomer){
+				fulfillment{
+					%s
+				}
+				userErrors{
+					field
+					message
+				}
+			}
+		}
+	`, fulfillmentQuery)
+
+	fulfillmentCancelMutation = fmt.Sprintf(`
+		mutation fulfillmentCancel($id: ID!) {
+			fulfillmentCancel(id: $id){
+				fulfillment{
+					%s
+				}
+				userErrors{
+					field
+					message
+				}
+			}
+		}
+	`, fulfillmentQuery)
+)
+
 func (s *FulfillmentServiceOp) Create(fulfillment FulfillmentV2Input) error {
+	return s.CreateWithContext(s.client.gql.Context(), fulfillment)
+}
+
+func (s *FulfillmentServiceOp) CreateWithContext(ctx context.Context, fulfillment FulfillmentV2Input) error {
 	m := mutationFulfillmentCreateV2{}
 
 	vars := map[string]interface{}{
 		"fulfillment": fulfillment,
 	}
-	err := s.client.gql.Mutate(context.Background(), &m, vars)
+	err := s.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
-		return fmt.Errorf("Mutation error: %s", err)
+		return fmt.Errorf("Mutation error: %w", err)
 	}
 
 	if len(m.FulfillmentCreateV2Result.UserErrors) > 0 {
-		return fmt.Errorf("UserErrors: %+v", m.FulfillmentCreateV2Result.UserErrors)
+		return &UserErrorsError{UserErrors: m.FulfillmentCreateV2Result.UserErrors}
 	}
 
 	return nil
 }
+
+func (s *FulfillmentServiceOp) CreateV2(input FulfillmentV2Input) (*Fulfillment, error) {
+	return s.CreateV2WithContext(s.client.gql.Context(), input)
+}
+
+func (s *FulfillmentServiceOp) CreateV2WithContext(ctx context.Context, input FulfillmentV2Input) (*Fulfillment, error) {
+	return s.mutateFulfillment(ctx, fulfillmentCreateV2Mutation, map[string]interface{}{"fulfillment": input})
+}
+
+func (s *FulfillmentServiceOp) Get(id graphql.ID) (*Fulfillment, error) {
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
+
+func (s *FulfillmentServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (*Fulfillment, error) {
+	q := fmt.Sprintf(`
+		query fulfillment($id: ID!) {
+			fulfillment(id: $id){
+				%s
+			}
+		}
+	`, fulfillmentQuery)
+
+	out := struct {
+		Fulfillment *fulfillmentNode `json:"fulfillment"`
+	}{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, map[string]interface{}{"id": id}, &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.Fulfillment == nil {
+		return nil, fmt.Errorf("fulfillment %v not found", id)
+	}
+
+	return out.Fulfillment.fulfillment(), nil
+}
+
+func (s *FulfillmentServiceOp) UpdateTracking(id graphql.ID, tracking FulfillmentTrackingInput, notifyCustomer bool) (*Fulfillment, error) {
+	return s.UpdateTrackingWithContext(s.client.gql.Context(), id, tracking, notifyCustomer)
+}
+
+func (s *FulfillmentServiceOp) UpdateTrackingWithContext(ctx context.Context, id graphql.ID, tracking FulfillmentTrackingInput, notifyCustomer bool) (*Fulfillment, error) {
+	vars := map[string]interface{}{
+		"fulfillmentId":     id,
+		"trackingInfoInput": tracking,
+		"notifyCustomer":    notifyCustomer,
+	}
+	return s.mutateFulfillment(ctx, fulfillmentTrackingInfoUpdateV2Mutation, vars)
+}
+
+func (s *FulfillmentServiceOp) Cancel(id graphql.ID) (*Fulfillment, error) {
+	return s.CancelWithContext(s.client.gql.Context(), id)
+}
+
+func (s *FulfillmentServiceOp) CancelWithContext(ctx context.Context, id graphql.ID) (*Fulfillment, error) {
+	return s.mutateFulfillment(ctx, fulfillmentCancelMutation, map[string]interface{}{"id": id})
+}
+
+func (s *FulfillmentServiceOp) mutateFulfillment(ctx context.Context, mutation string, vars map[string]interface{}) (*Fulfillment, error) {
+	payload := struct {
+		Fulfillment *fulfillmentNode `json:"fulfillment"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, mutation, vars, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Fulfillment == nil {
+		return nil, fmt.Errorf("empty response to mutation")
+	}
+	return payload.Fulfillment.fulfillment(), nil
+}
@@ -0,0 +1,415 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+)
+
+// FulfillmentOrderService moves fulfillment orders through their statuses, for merchant managed
+// locations and for fulfillment services answering fulfillment requests.
+type FulfillmentOrderService interface {
+	Get(id graphql.ID) (*FulfillmentOrder, error)
+	GetWithContext(ctx context.Context, id graphql.ID) (*FulfillmentOrder, error)
+	// ListAssigned returns a page of the fulfillment orders assigned to the locations of the app's
+	// fulfillment services, selected by filter.
+	ListAssigned(filter AssignedFulfillmentOrderFilter, opts ListOptions) (*Page[*FulfillmentOrder], error)
+	ListAssignedWithContext(ctx context.Context, filter AssignedFulfillmentOrderFilter, opts ListOptions) (*Page[*FulfillmentOrder], error)
+	// IterAssigned returns an iterator over the fulfillment orders assigned to the locations of the
+	// app's fulfillment services, selected by filter, fetching pages on demand.
+	IterAssigned(filter AssignedFulfillmentOrderFilter, opts ListOptions) *Iterator[*FulfillmentOrder]
+	IterAssignedWithContext(ctx context.Context, filter AssignedFulfillmentOrderFilter, opts ListOptions) *Iterator[*FulfillmentOrder]
+
+	// Hold puts the fulfillment order on hold, so that it can't be fulfilled until released.
+	Hold(id graphql.ID, hold FulfillmentOrderHoldInput) (*FulfillmentOrder, error)
+	HoldWithContext(ctx context.Context, id graphql.ID, hold FulfillmentOrderHoldInput) (*FulfillmentOrder, error)
+	ReleaseHold(id graphql.ID) (*FulfillmentOrder, error)
+	ReleaseHoldWithContext(ctx context.Context, id graphql.ID) (*FulfillmentOrder, error)
+	// Move assigns the fulfillment order, or its unfulfilled line items when partially fulfilled, to
+	// the location locationID.
+	Move(id graphql.ID, locationID graphql.ID) (*FulfillmentOrderMoveResult, error)
+	MoveWithContext(ctx context.Context, id graphql.ID, locationID graphql.ID) (*FulfillmentOrderMoveResult, error)
+	// Reschedule changes when a scheduled fulfillment order becomes open.
+	Reschedule(id graphql.ID, fulfillAt time.Time) (*FulfillmentOrder, error)
+	RescheduleWithContext(ctx context.Context, id graphql.ID, fulfillAt time.Time) (*FulfillmentOrder, error)
+	// Cancel cancels the fulfillment order, replacing it with an open fulfillment order of its
+	// unfulfilled line items.
+	Cancel(id graphql.ID) (*FulfillmentOrderCancelResult, error)
+	CancelWithContext(ctx context.Context, id graphql.ID) (*FulfillmentOrderCancelResult, error)
+	// Close marks a fulfillment order whose fulfillment request was accepted as incomplete, when the
+	// fulfillment service can't fulfill it.
+	Close(id graphql.ID, message string) (*FulfillmentOrder, error)
+	CloseWithContext(ctx context.Context, id graphql.ID, message string) (*FulfillmentOrder, error)
+	AcceptFulfillmentRequest(id graphql.ID, message string) (*FulfillmentOrder, error)
+	AcceptFulfillmentRequestWithContext(ctx context.Context, id graphql.ID, message string) (*FulfillmentOrder, error)
+	RejectFulfillmentRequest(id graphql.ID, rejection FulfillmentRequestRejection) (*FulfillmentOrder, error)
+	RejectFulfillmentRequestWithContext(ctx context.Context, id graphql.ID, rejection FulfillmentRequestRejection) (*FulfillmentOrder, error)
+}
+
+type FulfillmentOrderServiceOp struct {
+	client *Client
+}
+
+// AssignedFulfillmentOrderFilter selects assigned fulfillment orders. Its zero value selects all of them.
+type AssignedFulfillmentOrderFilter struct {
+	AssignmentStatus FulfillmentOrderAssignmentStatus
+	LocationIDs      []graphql.ID
+}
+
+type FulfillmentOrderAssignmentStatus string
+
+const (
+	FulfillmentOrderAssignmentStatusFulfillmentRequested  FulfillmentOrderAssignmentStatus = "FULFILLMENT_REQUESTED"
+	FulfillmentOrderAssignmentStatusFulfillmentAccepted   FulfillmentOrderAssignmentStatus = "FULFILLMENT_ACCEPTED"
+	FulfillmentOrderAssignmentStatusCancellationRequested FulfillmentOrderAssignmentStatus = "CANCELLATION_REQUESTED"
+)
+
+type FulfillmentOrderHoldInput struct {
+	Reason         FulfillmentHoldReason `json:"reason"`
+	ReasonNotes    graphql.String        `json:"reasonNotes,omitempty"`
+	NotifyMerchant graphql.Boolean       `json:"notifyMerchant,omitempty"`
+}
+
+type FulfillmentRequestRejection struct {
+	Reason  FulfillmentOrderRejectionReason
+	Message string
+}
+
+type FulfillmentOrderRejectionReason string
+
+const (
+	FulfillmentOrderRejectionReasonIncorrectAddress         FulfillmentOrderRejectionReason = "INCORRECT_ADDRESS"
+	FulfillmentOrderRejectionReasonIneligibleProduct        FulfillmentOrderRejectionReason = "INELIGIBLE_PRODUCT"
+	FulfillmentOrderRejectionReasonInventoryOutOfStock      FulfillmentOrderRejectionReason = "INVENTORY_OUT_OF_STOCK"
+	FulfillmentOrderRejectionReasonUndeliverableDestination FulfillmentOrderRejectionReason = "UNDELIVERABLE_DESTINATION"
+	FulfillmentOrderRejectionReasonOther                    FulfillmentOrderRejectionReason = "OTHER"
+)
+
+type FulfillmentOrderMoveResult struct {
+	// MovedFulfillmentOrder is the fulfillment order assigned to the new location: the original one,
+	// or a new one of its unfulfilled line items when it was partially fulfilled.
+	MovedFulfillmentOrder *FulfillmentOrder
+	// OriginalFulfillmentOrder is the moved fulfillment order, in its final state.
+	OriginalFulfillmentOrder *FulfillmentOrder
+	// RemainingFulfillmentOrder is the fulfillment order of the line items left at the original
+	// location, if any.
+	RemainingFulfillmentOrder *FulfillmentOrder
+}
+
+type FulfillmentOrderCancelResult struct {
+	FulfillmentOrder *FulfillmentOrder
+	// ReplacementFulfillmentOrder is nil when no line item was left to fulfill.
+	ReplacementFulfillmentOrder *FulfillmentOrder
+}
+
+const fulfillmentOrderQuery = `
+	id
+	orderId
+	status
+	requestStatus
+	fulfillAt
+	assignedLocation{
+		name
+		location{
+			id
+			name
+		}
+	}
+	fulfillmentHolds{
+		reason
+		reasonNotes
+	}
+	supportedActions{
+		action
+	}
+	lineItems(first: 250){
+		edges{
+			node{
+				id
+				totalQuantity
+				remainingQuantity
+				lineItem{
+					id
+					sku
+					title
+					quantity
+				}
+			}
+		}
+	}
+	createdAt
+	updatedAt
+`
+
+// fulfillmentOrderNode decodes a fulfillment order with its line items connection.
+type fulfillmentOrderNode struct {
+	FulfillmentOrder
+	LineItems struct {
+		Edges []struct {
+			Node FulfillmentOrderLineItem `json:"node"`
+		} `json:"edges"`
+	} `json:"lineItems"`
+}
+
+func (n *fulfillmentOrderNode) fulfillmentOrder() *FulfillmentOrder {
+	if n == nil {
+		return nil
+	}
+	fo := n.FulfillmentOrder
+	for _, e := range n.LineItems.Edges {
+		fo.FulfillmentOrderLineItems = append(fo.FulfillmentOrderLineItems, e.Node)
+	}
+	return &fo
+}
+
+// fulfillmentOrderPayload is the payload of the fulfillment order mutations.
+type fulfillmentOrderPayload struct {
+	FulfillmentOrder            *fulfillmentOrderNode `json:"fulfillmentOrder"`
+	ReplacementFulfillmentOrder *fulfillmentOrderNode `json:"replacementFulfillmentOrder"`
+	MovedFulfillmentOrder       *fulfillmentOrderNode `json:"movedFulfillmentOrder"`
+	OriginalFulfillmentOrder    *fulfillmentOrderNode `json:"originalFulfillmentOrder"`
+	RemainingFulfillmentOrder   *fulfillmentOrderNode `json:"remainingFulfillmentOrder"`
+}
+
+var (
+	fulfillmentOrderFields       = fmt.Sprintf("fulfillmentOrder{%s}", fulfillmentOrderQuery)
+	fulfillmentOrderMoveFields   = fmt.Sprintf("movedFulfillmentOrder{%[1]s}\noriginalFulfillmentOrder{%[1]s}\nremainingFulfillmentOrder{%[1]s}", fulfillmentOrderQuery)
+	fulfillmentOrderCancelFields = fmt.Sprintf("fulfillmentOrder{%[1]s}\nreplacementFulfillmentOrder{%[1]s}", fulfillmentOrderQuery)
+
+	fulfillmentOrderHoldMutation = mutationQuery(
+		"fulfillmentOrderHold($id: ID!, $fulfillmentHold: FulfillmentOrderHoldInput!)",
+		"fulfillmentOrderHold(id: $id, fulfillmentHold: $fulfillmentHold)",
+		fulfillmentOrderFields)
+	fulfillmentOrderReleaseHoldMutation = mutationQuery(
+		"fulfillmentOrderReleaseHold($id: ID!)",
+		"fulfillmentOrderReleaseHold(id: $id)",
+		fulfillmentOrderFields)
+	fulfillmentOrderMoveMutation = mutationQuery(
+		"fulfillmentOrderMove($id: ID!, $newLocationId: ID!)",
+		"fulfillmentOrderMove(id: $id, newLocationId: $newLocationId)",
+		fulfillmentOrderMoveFields)
+	fulfillmentOrderRescheduleMutation = mutationQuery(
+		"fulfillmentOrderReschedule($id: ID!, $fulfillAt: DateTime!)",
+		"fulfillmentOrderReschedule(id: $id, fulfillAt: $fulfillAt)",
+		fulfillmentOrderFields)
+	fulfillmentOrderCancelMutation = mutationQuery(
+		"fulfillmentOrderCancel($id: ID!)",
+		"fulfillmentOrderCancel(id: $id)",
+		fulfillmentOrderCancelFields)
+	fulfillmentOrderCloseMutation = mutationQuery(
+		"fulfillmentOrderClose($id: ID!, $message: String)",
+		"fulfillmentOrderClose(id: $id, message: $message)",
+		fulfillmentOrderFields)
+	fulfillmentOrderAcceptFulfillmentRequestMutation = mutationQuery(
+		"fulfillmentOrderAcceptFulfillmentRequest($id: ID!, $message: String)",
+		"fulfillmentOrderAcceptFulfillmentRequest(id: $id, message: $message)",
+		fulfillmentOrderFields)
+	fulfillmentOrderRejectFulfillmentRequestMutation = mutationQuery(
+		"fulfillmentOrderRejectFulfillmentRequest($id: ID!, $reason: FulfillmentOrderRejectionReason, $message: String)",
+		"fulfillmentOrderRejectFulfillmentRequest(id: $id, reason: $reason, message: $message)",
+		fulfillmentOrderFields)
+)
+
+func (s *FulfillmentOrderServiceOp) Get(id graphql.ID) (*FulfillmentOrder, error) {
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
+
+func (s *FulfillmentOrderServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (*FulfillmentOrder, error) {
+	q := fmt.Sprintf(`
+		query fulfillmentOrder($id: ID!) {
+			fulfillmentOrder(id: $id){
+				%s
+			}
+		}
+	`, fulfillmentOrderQuery)
+
+	out := struct {
+		FulfillmentOrder *fulfillmentOrderNode `json:"fulfillmentOrder"`
+	}{}
+	err := s.client.query(ctx, q, map[string]interface{}{"id": id}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if out.FulfillmentOrder == nil {
+		return nil, fmt.Errorf("fulfillment order %v not found", id)
+	}
+
+	return out.FulfillmentOrder.fulfillmentOrder(), nil
+}
+
+func (s *FulfillmentOrderServiceOp) ListAssigned(filter AssignedFulfillmentOrderFilter, opts ListOptions) (*Page[*FulfillmentOrder], error) {
+	return s.ListAssignedWithContext(s.client.gql.Context(), filter, opts)
+}
+
+func (s *FulfillmentOrderServiceOp) ListAssignedWithContext(ctx context.Context, filter AssignedFulfillmentOrderFilter, opts ListOptions) (*Page[*FulfillmentOrder], error) {
+	q := fmt.Sprintf(`
+		query assignedFulfillmentOrders($assignmentStatus: FulfillmentOrderAssignmentStatus, $locationIds: [ID!], $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+			assignedFulfillmentOrders(assignmentStatus: $assignmentStatus, locationIds: $locationIds, first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
+				edges{
+					node{
+						%s
+					}
+				}
+				%s
+			}
+		}
+	`, fulfillmentOrderQuery, pageInfoQuery)
+
+	if opts.First == 0 && opts.Last == 0 {
+		opts.First = defaultPageSize
+	}
+	opts.Query = ""
+	vars := pageVars(opts)
+	if filter.AssignmentStatus != "" {
+		vars["assignmentStatus"] = filter.AssignmentStatus
+	}
+	if len(filter.LocationIDs) > 0 {
+		vars["locationIds"] = filter.LocationIDs
+	}
+
+	out := struct {
+		AssignedFulfillmentOrders struct {
+			Edges []struct {
+				Node *fulfillmentOrderNode `json:"node"`
+			} `json:"edges"`
+			PageInfo PageInfo `json:"pageInfo"`
+		} `json:"assignedFulfillmentOrders"`
+	}{}
+	err := s.client.query(ctx, q, vars, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[*FulfillmentOrder]{PageInfo: out.AssignedFulfillmentOrders.PageInfo}
+	for _, e := range out.AssignedFulfillmentOrders.Edges {
+		page.Nodes = append(page.Nodes, e.Node.fulfillmentOrder())
+	}
+
+	return page, nil
+}
+
+func (s *FulfillmentOrderServiceOp) IterAssigned(filter AssignedFulfillmentOrderFilter, opts ListOptions) *Iterator[*FulfillmentOrder] {
+	return s.IterAssignedWithContext(s.client.gql.Context(), filter, opts)
+}
+
+func (s *FulfillmentOrderServiceOp) IterAssignedWithContext(ctx context.Context, filter AssignedFulfillmentOrderFilter, opts ListOptions) *Iterator[*FulfillmentOrder] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts ListOptions) (*Page[*FulfillmentOrder], error) {
+		return s.ListAssignedWithContext(ctx, filter, opts)
+	})
+}
+
+func (s *FulfillmentOrderServiceOp) Hold(id graphql.ID, hold FulfillmentOrderHoldInput) (*FulfillmentOrder, error) {
+	return s.HoldWithContext(s.client.gql.Context(), id, hold)
+}
+
+func (s *FulfillmentOrderServiceOp) HoldWithContext(ctx context.Context, id graphql.ID, hold FulfillmentOrderHoldInput) (*FulfillmentOrder, error) {
+	return s.mutateFulfillmentOrder(ctx, fulfillmentOrderHoldMutation, map[string]interface{}{"id": id, "fulfillmentHold": hold})
+}
+
+func (s *FulfillmentOrderServiceOp) ReleaseHold(id graphql.ID) (*FulfillmentOrder, error) {
+	return s.ReleaseHoldWithContext(s.client.gql.Context(), id)
+}
+
+func (s *FulfillmentOrderServiceOp) ReleaseHoldWithContext(ctx context.Context, id graphql.ID) (*FulfillmentOrder, error) {
+	return s.mutateFulfillmentOrder(ctx, fulfillmentOrderReleaseHoldMutation, map[string]interface{}{"id": id})
+}
+
+func (s *FulfillmentOrderServiceOp) Move(id graphql.ID, locationID graphql.ID) (*FulfillmentOrderMoveResult, error) {
+	return s.MoveWithContext(s.client.gql.Context(), id, locationID)
+}
+
+func (s *FulfillmentOrderServiceOp) MoveWithContext(ctx context.Context, id graphql.ID, locationID graphql.ID) (*FulfillmentOrderMoveResult, error) {
+	payload, err := s.mutate(ctx, fulfillmentOrderMoveMutation, map[string]interface{}{"id": id, "newLocationId": locationID})
+	if err != nil {
+		return nil, err
+	}
+	return &FulfillmentOrderMoveResult{
+		MovedFulfillmentOrder:     payload.MovedFulfillmentOrder.fulfillmentOrder(),
+		OriginalFulfillmentOrder:  payload.OriginalFulfillmentOrder.fulfillmentOrder(),
+		RemainingFulfillmentOrder: payload.RemainingFulfillmentOrder.fulfillmentOrder(),
+	}, nil
+}
+
+func (s *FulfillmentOrderServiceOp) Reschedule(id graphql.ID, fulfillAt time.Time) (*FulfillmentOrder, error) {
+	return s.RescheduleWithContext(s.client.gql.Context(), id, fulfillAt)
+}
+
+func (s *FulfillmentOrderServiceOp) RescheduleWithContext(ctx context.Context, id graphql.ID, fulfillAt time.Time) (*FulfillmentOrder, error) {
+	vars := map[string]interface{}{
+		"id":        id,
+		"fulfillAt": fulfillAt.UTC().Format(time.RFC3339),
+	}
+	return s.mutateFulfillmentOrder(ctx, fulfillmentOrderRescheduleMutation, vars)
+}
+
+func (s *FulfillmentOrderServiceOp) Cancel(id graphql.ID) (*FulfillmentOrderCancelResult, error) {
+	return s.CancelWithContext(s.client.gql.Context(), id)
+}
+
+func (s *FulfillmentOrderServiceOp) CancelWithContext(ctx context.Context, id graphql.ID) (*FulfillmentOrderCancelResult, error) {
+	payload, err := s.mutate(ctx, fulfillmentOrderCancelMutation, map[string]interface{}{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	return &FulfillmentOrderCancelResult{
+		FulfillmentOrder:            payload.FulfillmentOrder.fulfillmentOrder(),
+		ReplacementFulfillmentOrder: payload.ReplacementFulfillmentOrder.fulfillmentOrder(),
+	}, nil
+}
+
+func (s *FulfillmentOrderServiceOp) Close(id graphql.ID, message string) (*FulfillmentOrder, error) {
+	return s.CloseWithContext(s.client.gql.Context(), id, message)
+}
+
+func (s *FulfillmentOrderServiceOp) CloseWithContext(ctx context.Context, id graphql.ID, message string) (*FulfillmentOrder, error) {
+	return s.mutateFulfillmentOrder(ctx, fulfillmentOrderCloseMutation, messageVars(id, message))
+}
+
+func (s *FulfillmentOrderServiceOp) AcceptFulfillmentRequest(id graphql.ID, message string) (*FulfillmentOrder, error) {
+	return s.AcceptFulfillmentRequestWithContext(s.client.gql.Context(), id, message)
+}
+
+func (s *FulfillmentOrderServiceOp) AcceptFulfillmentRequestWithContext(ctx context.Context, id graphql.ID, message string) (*FulfillmentOrder, error) {
+	return s.mutateFulfillmentOrder(ctx, fulfillmentOrderAcceptFulfillmentRequestMutation, messageVars(id, message))
+}
+
+func (s *FulfillmentOrderServiceOp) RejectFulfillmentRequest(id graphql.ID, rejection FulfillmentRequestRejection) (*FulfillmentOrder, error) {
+	return s.RejectFulfillmentRequestWithContext(s.client.gql.Context(), id, rejection)
+}
+
+func (s *FulfillmentOrderServiceOp) RejectFulfillmentRequestWithContext(ctx context.Context, id graphql.ID, rejection FulfillmentRequestRejection) (*FulfillmentOrder, error) {
+	vars := messageVars(id, rejection.Message)
+	if rejection.Reason != "" {
+		vars["reason"] = rejection.Reason
+	}
+	return s.mutateFulfillmentOrder(ctx, fulfillmentOrderRejectFulfillmentRequestMutation, vars)
+}
+
+// messageVars returns the variables of a mutation of the fulfillment order id taking an optional message.
+func messageVars(id graphql.ID, message string) map[string]interface{} {
+	vars := map[string]interface{}{"id": id}
+	if message != "" {
+		vars["message"] = message
+	}
+	return vars
+}
+
+func (s *FulfillmentOrderServiceOp) mutateFulfillmentOrder(ctx context.Context, mutation string, vars map[string]interface{}) (*FulfillmentOrder, error) {
+	payload, err := s.mutate(ctx, mutation, vars)
+	if err != nil {
+		return nil, err
+	}
+	return payload.FulfillmentOrder.fulfillmentOrder(), nil
+}
+
+// mutate runs one of the fulfillment order mutations and returns its payload.
+func (s *FulfillmentOrderServiceOp) mutate(ctx context.Context, mutation string, vars map[string]interface{}) (*fulfillmentOrderPayload, error) {
+	payload := &fulfillmentOrderPayload{}
+	err := s.client.mutate(ctx, retryThrottled, mutation, vars, payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
@@ -0,0 +1,76 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestFulfillmentRequests(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	app := srv.AddLocation(&shopifytest.Location{Name: "3PL", Legacy: true})
+	o := srv.AddOrder(&shopifytest.Order{
+		LineItems: []*shopifytest.LineItem{{ID: "gid://shopify/LineItem/1", Title: "Snowboard", Quantity: 1}},
+		FulfillmentOrders: []*shopifytest.FulfillmentOrder{
+			{
+				AssignedLocationID: app.ID,
+				RequestStatus:      "SUBMITTED",
+				LineItems:          []*shopifytest.FulfillmentOrderLineItem{{LineItemID: "gid://shopify/LineItem/1", TotalQuantity: 1, RemainingQuantity: 1}},
+			},
+			{AssignedLocationID: app.ID, RequestStatus: "SUBMITTED"},
+		},
+	})
+	accepted, rejected := o.FulfillmentOrders[0].ID, o.FulfillmentOrders[1].ID
+
+	requested, err := client.FulfillmentOrder.IterAssignedWithContext(ctx, shopify.AssignedFulfillmentOrderFilter{
+		AssignmentStatus: shopify.FulfillmentOrderAssignmentStatusFulfillmentRequested,
+		LocationIDs:      []graphql.ID{app.ID},
+	}, shopify.ListOptions{First: 1}).All()
+	if err != nil {
+		t.Fatalf("list assigned: %v", err)
+	}
+	if len(requested) != 2 || requested[0].OrderID != o.ID || requested[0].RequestStatus != shopify.FulfillmentOrderRequestStatusSubmitted {
+		t.Errorf("unexpected requested fulfillment orders: %+v", requested)
+	}
+
+	fo, err := client.FulfillmentOrder.AcceptFulfillmentRequestWithContext(ctx, accepted, "On it")
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if fo.Status != shopify.FulfillmentOrderStatusInProgress || fo.RequestStatus != shopify.FulfillmentOrderRequestStatusAccepted {
+		t.Errorf("unexpected accepted fulfillment order: %+v", fo)
+	}
+	fo, err = client.FulfillmentOrder.RejectFulfillmentRequestWithContext(ctx, rejected, shopify.FulfillmentRequestRejection{Reason: shopify.FulfillmentOrderRejectionReasonInventoryOutOfStock})
+	if err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if fo.RequestStatus != shopify.FulfillmentOrderRequestStatusRejected {
+		t.Errorf("unexpected rejected fulfillment order: %+v", fo)
+	}
+	if _, err = client.FulfillmentOrder.CloseWithContext(ctx, rejected, ""); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors closing a rejected fulfillment order, got %v", err)
+	}
+
+	page, err := client.FulfillmentOrder.ListAssignedWithContext(ctx, shopify.AssignedFulfillmentOrderFilter{AssignmentStatus: shopify.FulfillmentOrderAssignmentStatusFulfillmentAccepted}, shopify.ListOptions{})
+	if err != nil {
+		t.Fatalf("list assigned: %v", err)
+	}
+	if len(page.Nodes) != 1 || page.Nodes[0].ID != accepted {
+		t.Errorf("expected the accepted fulfillment order, got %+v", page.Nodes)
+	}
+	fo, err = client.FulfillmentOrder.CloseWithContext(ctx, accepted, "Out of stock")
+	if err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if fo.Status != shopify.FulfillmentOrderStatusIncomplete || fo.RequestStatus != shopify.FulfillmentOrderRequestStatusClosed {
+		t.Errorf("unexpected closed fulfillment order: %+v", fo)
+	}
+}
@@ -0,0 +1,143 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestFulfillments(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	warehouse := srv.AddLocation(&shopifytest.Location{Name: "Warehouse"})
+	store := srv.AddLocation(&shopifytest.Location{Name: "Store"})
+	o := srv.AddOrder(&shopifytest.Order{
+		LineItems: []*shopifytest.LineItem{{ID: "gid://shopify/LineItem/1", Title: "Snowboard", Quantity: 3}},
+		FulfillmentOrders: []*shopifytest.FulfillmentOrder{{
+			AssignedLocationID: warehouse.ID,
+			LineItems:          []*shopifytest.FulfillmentOrderLineItem{{LineItemID: "gid://shopify/LineItem/1", TotalQuantity: 3, RemainingQuantity: 3}},
+		}},
+	})
+	foID := o.FulfillmentOrders[0].ID
+	foLineItemID := o.FulfillmentOrders[0].LineItems[0].ID
+
+	fo, err := client.FulfillmentOrder.HoldWithContext(ctx, foID, shopify.FulfillmentOrderHoldInput{Reason: shopify.FulfillmentHoldReasonAwaitingPayment})
+	if err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if fo.Status != shopify.FulfillmentOrderStatusOnHold || len(fo.FulfillmentHolds) != 1 || !fo.Supports(shopify.FulfillmentOrderActionReleaseHold) {
+		t.Errorf("unexpected fulfillment order on hold: %+v", fo)
+	}
+	input := shopify.FulfillmentV2Input{
+		LineItemsByFulfillmentOrder: []shopify.FulfillmentOrderLineItemsInput{{
+			FulfillmentOrderID:        foID,
+			FulfillmentOrderLineItems: []shopify.FulfillmentOrderLineItemInput{{ID: foLineItemID, Quantity: 1}},
+		}},
+		TrackingInfo: shopify.FulfillmentTrackingInput{Company: "UPS", Number: "1Z999"},
+	}
+	if _, err = client.Fulfillment.CreateV2WithContext(ctx, input); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors fulfilling a fulfillment order on hold, got %v", err)
+	}
+	if _, err = client.FulfillmentOrder.ReleaseHoldWithContext(ctx, foID); err != nil {
+		t.Fatalf("release hold: %v", err)
+	}
+
+	f, err := client.Fulfillment.CreateV2WithContext(ctx, input)
+	if err != nil {
+		t.Fatalf("create fulfillment: %v", err)
+	}
+	if f.Name != "#1001.1" || f.Status != shopify.FulfillmentStatusSuccess || len(f.TrackingInfo) != 1 || f.TrackingInfo[0].Company != "UPS" {
+		t.Errorf("unexpected fulfillment: %+v", f)
+	}
+	if o.DisplayFulfillmentStatus != "PARTIALLY_FULFILLED" || o.LineItems[0].FulfillmentStatus != "partial" {
+		t.Errorf("expected the order to be partially fulfilled, got %s", o.DisplayFulfillmentStatus)
+	}
+	f, err = client.Fulfillment.UpdateTrackingWithContext(ctx, f.ID, shopify.FulfillmentTrackingInput{Company: "DHL", Number: "42", URL: "https://dhl.example/42"}, false)
+	if err != nil {
+		t.Fatalf("update tracking: %v", err)
+	}
+	if len(f.TrackingInfo) != 1 || f.TrackingInfo[0].Company != "DHL" || f.TrackingInfo[0].URL != "https://dhl.example/42" {
+		t.Errorf("unexpected tracking info: %+v", f.TrackingInfo)
+	}
+
+	moved, err := client.FulfillmentOrder.MoveWithContext(ctx, foID, store.ID)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if moved.OriginalFulfillmentOrder.Status != shopify.FulfillmentOrderStatusClosed || moved.MovedFulfillmentOrder.ID == foID ||
+		moved.MovedFulfillmentOrder.AssignedLocation.Location.ID != store.ID || moved.MovedFulfillmentOrder.FulfillmentOrderLineItems[0].RemainingQuantity != 2 {
+		t.Errorf("expected the unfulfilled line items to move to a new fulfillment order, got %+v", moved)
+	}
+
+	cancelled, err := client.Fulfillment.CancelWithContext(ctx, f.ID)
+	if err != nil {
+		t.Fatalf("cancel fulfillment: %v", err)
+	}
+	if cancelled.Status != shopify.FulfillmentStatusCancelled || o.DisplayFulfillmentStatus != "UNFULFILLED" {
+		t.Errorf("unexpected cancelled fulfillment: %+v", cancelled)
+	}
+	fo, err = client.FulfillmentOrder.GetWithContext(ctx, foID)
+	if err != nil {
+		t.Fatalf("get fulfillment order: %v", err)
+	}
+	if fo.Status != shopify.FulfillmentOrderStatusOpen || fo.FulfillmentOrderLineItems[0].RemainingQuantity != 1 || fo.AssignedLocation.Name != "Warehouse" {
+		t.Errorf("expected the cancelled quantity back on the original fulfillment order, got %+v", fo)
+	}
+
+	result, err := client.FulfillmentOrder.CancelWithContext(ctx, moved.MovedFulfillmentOrder.ID)
+	if err != nil {
+		t.Fatalf("cancel fulfillment order: %v", err)
+	}
+	if result.FulfillmentOrder.Status != shopify.FulfillmentOrderStatusCancelled || result.ReplacementFulfillmentOrder == nil ||
+		result.ReplacementFulfillmentOrder.Status != shopify.FulfillmentOrderStatusOpen {
+		t.Errorf("unexpected cancel result: %+v", result)
+	}
+	_, err = client.FulfillmentOrder.RescheduleWithContext(ctx, foID, time.Now().Add(24*time.Hour))
+	if !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors rescheduling an open fulfillment order, got %v", err)
+	}
+}
+
+func TestFulfillmentCreateLostResponse(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	client.SetRetries(2)
+	ctx := context.Background()
+
+	warehouse := srv.AddLocation(&shopifytest.Location{Name: "Warehouse"})
+	o := srv.AddOrder(&shopifytest.Order{
+		LineItems: []*shopifytest.LineItem{{ID: "gid://shopify/LineItem/1", Title: "Snowboard", Quantity: 3}},
+		FulfillmentOrders: []*shopifytest.FulfillmentOrder{{
+			AssignedLocationID: warehouse.ID,
+			LineItems:          []*shopifytest.FulfillmentOrderLineItem{{LineItemID: "gid://shopify/LineItem/1", TotalQuantity: 3, RemainingQuantity: 3}},
+		}},
+	})
+	input := shopify.FulfillmentV2Input{
+		LineItemsByFulfillmentOrder: []shopify.FulfillmentOrderLineItemsInput{{
+			FulfillmentOrderID:        o.FulfillmentOrders[0].ID,
+			FulfillmentOrderLineItems: []shopify.FulfillmentOrderLineItemInput{{ID: o.FulfillmentOrders[0].LineItems[0].ID, Quantity: 1}},
+		}},
+	}
+
+	srv.DropResponses(1)
+	if _, err := client.Fulfillment.CreateV2WithContext(ctx, input); err == nil {
+		t.Fatalf("expected the lost response to fail the fulfillment")
+	}
+	srv.DropResponses(1)
+	if err := client.Fulfillment.CreateWithContext(ctx, input); err == nil || !strings.HasPrefix(err.Error(), "Mutation error: ") {
+		t.Fatalf("expected a mutation error, got %v", err)
+	}
+	if len(o.Fulfillments) != 2 || o.FulfillmentOrders[0].LineItems[0].RemainingQuantity != 1 {
+		t.Errorf("expected each fulfillment to be created once, got %d fulfillments and %d remaining",
+			len(o.Fulfillments), o.FulfillmentOrders[0].LineItems[0].RemainingQuantity)
+	}
+}
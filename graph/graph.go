@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"strings"
 
 	"github.com/gempages/go-shopify-graphql/graphql"
 )
@@ -14,12 +16,31 @@ const (
 	shopifyStoreFrontAccessTokenHeader = "X-Shopify-Storefront-Access-Token"
 )
 
-var (
-	apiProtocol   = "https"
-	apiPathPrefix = "admin/api"
-	apiEndpoint   = "graphql.json"
+const (
+	apiProtocol = "https"
+	apiEndpoint = "graphql.json"
 )
 
+// apiKind tells which Shopify GraphQL API a client talks to.
+type apiKind int
+
+const (
+	apiKindAdmin apiKind = iota
+	apiKindStoreFront
+)
+
+// pathPrefix returns the API path for the given version, e.g. "admin/api/2022-07".
+func (k apiKind) pathPrefix(apiVersion string) string {
+	prefix := "admin/api"
+	if k == apiKindStoreFront {
+		prefix = "api"
+	}
+	if apiVersion != "" {
+		prefix = fmt.Sprintf("%s/%s", prefix, apiVersion)
+	}
+	return prefix
+}
+
 // Option is used to configure options
 type Option func(t *transport)
 
@@ -33,21 +54,32 @@ func WithContext(ctx context.Context) Option {
 // WithVersion optionally sets the API version if the passed string is valid
 func WithVersion(apiVersion string) Option {
 	return func(t *transport) {
-		if apiVersion != "" {
-			apiPathPrefix = fmt.Sprintf("admin/api/%s", apiVersion)
-		} else {
-			apiPathPrefix = "admin/api"
-		}
+		t.apiKind = apiKindAdmin
+		t.apiVersion = apiVersion
 	}
 }
 
+// WithStoreFrontVersion optionally sets the Storefront API version and makes the client talk to the Storefront API
 func WithStoreFrontVersion(apiVersion string) Option {
 	return func(t *transport) {
-		if apiVersion != "" {
-			apiPathPrefix = fmt.Sprintf("api/%s", apiVersion)
-		} else {
-			apiPathPrefix = "api"
-		}
+		t.apiKind = apiKindStoreFront
+		t.apiVersion = apiVersion
+	}
+}
+
+// WithEndpoint optionally sets the full URL of the GraphQL endpoint, ignoring the shop name and API version.
+// Useful to point a client at a local mock server in tests.
+func WithEndpoint(endpoint string) Option {
+	return func(t *transport) {
+		t.endpoint = endpoint
+	}
+}
+
+// WithBaseURL optionally replaces the protocol and host of the GraphQL endpoint, e.g. "http://127.0.0.1:8080".
+// The API path derived from the version is kept.
+func WithBaseURL(baseURL string) Option {
+	return func(t *transport) {
+		t.baseURL = strings.TrimSuffix(baseURL, "/")
 	}
 }
 
@@ -74,13 +106,27 @@ func WithPrivateAppAuth(apiKey string, password string) Option {
 
 type transport struct {
 	ctx                   context.Context
+	apiKind               apiKind
+	apiVersion            string
+	endpoint              string
+	baseURL               string
 	accessToken           string
 	storeFrontAccessToken string
 	apiKey                string
 	password              string
+	base                  http.RoundTripper
+	httpClient            *http.Client
+	middlewares           []Middleware
+	host                  string
 }
 
 func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	// credentials are only sent to the API host, not to e.g. the storage serving bulk operation results
+	if req.URL.Host != t.host {
+		return t.roundTrip(req)
+	}
 	if t.accessToken != "" {
 		req.Header.Set(shopifyAccessTokenHeader, t.accessToken)
 	} else if t.apiKey != "" && t.password != "" {
@@ -89,7 +135,15 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		req.Header.Set(shopifyStoreFrontAccessTokenHeader, t.storeFrontAccessToken)
 	}
 
-	return http.DefaultTransport.RoundTrip(req)
+	return t.roundTrip(req)
+}
+
+func (t *transport) roundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
 }
 
 // NewClient creates a new client (in fact, just a simple wrapper for a graphql.Client)
@@ -100,9 +154,19 @@ func NewClient(shopName string, opts ...Option) *graphql.Client {
 		opt(trans)
 	}
 
-	httpClient := &http.Client{Transport: trans}
+	httpClient := &http.Client{}
+	if trans.httpClient != nil {
+		*httpClient = *trans.httpClient
+		if trans.base == nil {
+			trans.base = trans.httpClient.Transport
+		}
+	}
+	httpClient.Transport = chain(trans, trans.middlewares)
 
-	url := buildAPIEndpoint(shopName)
+	url := trans.buildAPIEndpoint(shopName)
+	if u, err := neturl.Parse(url); err == nil {
+		trans.host = u.Host
+	}
 
 	graphClient := graphql.NewClient(url, httpClient)
 	if trans.ctx != nil {
@@ -112,7 +176,14 @@ func NewClient(shopName string, opts ...Option) *graphql.Client {
 	return graphClient
 }
 
-func buildAPIEndpoint(shopName string) string {
-	return fmt.Sprintf("%s://%s/%s/%s", apiProtocol, shopName, apiPathPrefix, apiEndpoint)
-	// return fmt.Sprintf("%s://%s.%s/%s/%s", apiProtocol, shopName, shopifyBaseDomain, apiPathPrefix, apiEndpoint)
+func (t *transport) buildAPIEndpoint(shopName string) string {
+	if t.endpoint != "" {
+		return t.endpoint
+	}
+	pathPrefix := t.apiKind.pathPrefix(t.apiVersion)
+	if t.baseURL != "" {
+		return fmt.Sprintf("%s/%s/%s", t.baseURL, pathPrefix, apiEndpoint)
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", apiProtocol, shopName, pathPrefix, apiEndpoint)
+	// return fmt.Sprintf("%s://%s.%s/%s/%s", apiProtocol, shopName, shopifyBaseDomain, pathPrefix, apiEndpoint)
 }
@@ -0,0 +1,108 @@
+package graphqlclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBuildAPIEndpoint(t *testing.T) {
+	testTable := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "admin_default",
+			expected: "https://shop.myshopify.com/admin/api/graphql.json",
+		},
+		{
+			name:     "admin_version",
+			opts:     []Option{WithVersion("2022-07")},
+			expected: "https://shop.myshopify.com/admin/api/2022-07/graphql.json",
+		},
+		{
+			name:     "storefront_version",
+			opts:     []Option{WithStoreFrontVersion("2022-10")},
+			expected: "https://shop.myshopify.com/api/2022-10/graphql.json",
+		},
+		{
+			name:     "base_url",
+			opts:     []Option{WithVersion("2022-07"), WithBaseURL("http://127.0.0.1:8080/")},
+			expected: "http://127.0.0.1:8080/admin/api/2022-07/graphql.json",
+		},
+		{
+			name:     "endpoint",
+			opts:     []Option{WithVersion("2022-07"), WithEndpoint("http://127.0.0.1:8080/graphql")},
+			expected: "http://127.0.0.1:8080/graphql",
+		},
+	}
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			trans := &transport{}
+			for _, opt := range tc.opts {
+				opt(trans)
+			}
+			if got := trans.buildAPIEndpoint("shop.myshopify.com"); got != tc.expected {
+				t.Errorf("expected (%v), got (%v)", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewClientDoesNotShareVersion(t *testing.T) {
+	paths := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	admin := NewClient("shop", WithBaseURL(server.URL), WithVersion("2022-07"), WithToken("token"))
+	storeFront := NewClient("shop", WithBaseURL(server.URL), WithStoreFrontVersion("2022-10"), WithStoreFrontToken("token"))
+
+	var v map[string]interface{}
+	if err := admin.QueryString(context.Background(), "query { shop { id } }", nil, &v); err != nil {
+		t.Fatalf("admin query: %v", err)
+	}
+	if got := <-paths; got != "/admin/api/2022-07/graphql.json" {
+		t.Errorf("expected admin path, got (%v)", got)
+	}
+	if err := storeFront.QueryString(context.Background(), "query { shop { name } }", nil, &v); err != nil {
+		t.Fatalf("storefront query: %v", err)
+	}
+	if got := <-paths; got != "/api/2022-10/graphql.json" {
+		t.Errorf("expected storefront path, got (%v)", got)
+	}
+}
+
+func TestCredentialsOnlySentToAPIHost(t *testing.T) {
+	tokens := make(map[string]string)
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		tokens[req.URL.Host] = req.Header.Get(shopifyAccessTokenHeader)
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusOK)
+		rec.WriteString(`{"data": {}}`)
+		return rec.Result(), nil
+	})
+
+	c := NewClient("shop.myshopify.com", WithTransport(base), WithToken("token"))
+	var v map[string]interface{}
+	if err := c.QueryString(context.Background(), "query { shop { id } }", nil, &v); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	resp, err := c.HTTPClient().Get("https://storage.googleapis.com/bulk.jsonl")
+	if err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	resp.Body.Close()
+
+	if tokens["shop.myshopify.com"] != "token" {
+		t.Errorf("expected the API host to receive the access token")
+	}
+	if tokens["storage.googleapis.com"] != "" {
+		t.Errorf("expected other hosts not to receive the access token")
+	}
+}
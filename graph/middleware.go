@@ -0,0 +1,49 @@
+package graphqlclient
+
+import "net/http"
+
+// Middleware wraps a RoundTripper to add behaviour around every request sent by the client,
+// e.g. logging, metrics, header injection or recording.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithTransport optionally sets the RoundTripper used to send requests once authenticated.
+// Defaults to http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(t *transport) {
+		t.base = rt
+	}
+}
+
+// WithHTTPClient optionally sets the http.Client whose settings (timeout, cookie jar, redirect policy)
+// are used by the client. The given client is copied, not modified. Its Transport is used to send requests
+// unless WithTransport is also set.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(t *transport) {
+		t.httpClient = httpClient
+	}
+}
+
+// WithMiddleware optionally appends middlewares wrapping the authenticated transport.
+// Middlewares run in the order they are added: the first one sees the request first
+// and the response last.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(t *transport) {
+		t.middlewares = append(t.middlewares, middlewares...)
+	}
+}
+
+// chain wraps rt with middlewares so that middlewares[0] is the outermost one.
+func chain(rt http.RoundTripper, middlewares []Middleware) http.RoundTripper {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
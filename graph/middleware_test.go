@@ -0,0 +1,66 @@
+package graphqlclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+":"+req.Header.Get(shopifyAccessTokenHeader))
+				resp, err := next.RoundTrip(req)
+				calls = append(calls, name+":done")
+				return resp, err
+			})
+		}
+	}
+
+	c := NewClient("shop", WithEndpoint(server.URL), WithToken("token"), WithMiddleware(record("first"), record("second")))
+	var v map[string]interface{}
+	if err := c.QueryString(context.Background(), "query { shop { id } }", nil, &v); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+
+	// middlewares wrap the auth transport, so the token isn't set yet when they run
+	expected := []string{"first:", "second:", "second:done", "first:done"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected (%v), got (%v)", expected, calls)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	var token string
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		token = req.Header.Get(shopifyAccessTokenHeader)
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusOK)
+		rec.WriteString(`{"data": {}}`)
+		return rec.Result(), nil
+	})
+
+	httpClient := &http.Client{Timeout: time.Second}
+	c := NewClient("shop", WithHTTPClient(httpClient), WithTransport(base), WithToken("token"))
+	var v map[string]interface{}
+	if err := c.QueryString(context.Background(), "query { shop { id } }", nil, &v); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if token != "token" {
+		t.Errorf("expected base transport to receive the access token, got (%v)", token)
+	}
+	if httpClient.Transport != nil {
+		t.Error("expected the given http.Client not to be modified")
+	}
+}
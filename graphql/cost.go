@@ -0,0 +1,134 @@
+package graphql
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// minQueryCost is the cost assumed for a query that has not been sent yet.
+// Shopify never charges less than one point for a query.
+const minQueryCost = 1
+
+// maxTrackedQueries bounds the number of distinct queries whose last
+// requested cost is remembered.
+const maxTrackedQueries = 1000
+
+// QueryCost represents the "cost" object of the "extensions" in a response
+// from the Shopify GraphQL Admin API.
+//
+// Specification: https://shopify.dev/api/usage/rate-limits#graphql-admin-api-rate-limits.
+type QueryCost struct {
+	RequestedQueryCost float64        `json:"requestedQueryCost"`
+	ActualQueryCost    *float64       `json:"actualQueryCost"`
+	ThrottleStatus     ThrottleStatus `json:"throttleStatus"`
+}
+
+// ThrottleStatus represents the state of the shop's leaky bucket.
+type ThrottleStatus struct {
+	MaximumAvailable   float64 `json:"maximumAvailable"`
+	CurrentlyAvailable float64 `json:"currentlyAvailable"`
+	RestoreRate        float64 `json:"restoreRate"`
+}
+
+// costLimiter models the shop's leaky bucket from the throttle status reported
+// by the server, and delays queries that would exceed the available points.
+type costLimiter struct {
+	mu        sync.Mutex
+	status    ThrottleStatus
+	updatedAt time.Time
+	costs     map[string]float64 // last requested cost by query
+	now       func() time.Time
+}
+
+func newCostLimiter() *costLimiter {
+	return &costLimiter{
+		costs: make(map[string]float64),
+		now:   time.Now,
+	}
+}
+
+// update records the cost extension returned for query.
+func (l *costLimiter) update(query string, cost *QueryCost) {
+	if cost == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.costs) >= maxTrackedQueries {
+		l.costs = make(map[string]float64)
+	}
+	l.costs[query] = cost.RequestedQueryCost
+	if cost.ThrottleStatus.MaximumAvailable > 0 {
+		l.status = cost.ThrottleStatus
+		l.updatedAt = l.now()
+	}
+}
+
+// available returns the throttle status restored up to now.
+// It must be called with l.mu held.
+func (l *costLimiter) available() ThrottleStatus {
+	s := l.status
+	elapsed := l.now().Sub(l.updatedAt).Seconds()
+	s.CurrentlyAvailable = math.Min(s.MaximumAvailable, s.CurrentlyAvailable+s.RestoreRate*elapsed)
+	return s
+}
+
+// reserve takes the expected cost of query out of the bucket and returns zero,
+// or returns how long to wait until the bucket holds enough points.
+func (l *costLimiter) reserve(query string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.status.MaximumAvailable == 0 {
+		// No throttle status has been reported yet, e.g. the Storefront API.
+		return 0
+	}
+	cost, ok := l.costs[query]
+	if !ok {
+		cost = minQueryCost
+	}
+	s := l.available()
+	if cost > s.MaximumAvailable || s.RestoreRate <= 0 {
+		// The query can never fit in the bucket, let the server reject it.
+		return 0
+	}
+	if cost > s.CurrentlyAvailable {
+		return time.Duration((cost - s.CurrentlyAvailable) / s.RestoreRate * float64(time.Second))
+	}
+	s.CurrentlyAvailable -= cost
+	l.status = s
+	l.updatedAt = l.now()
+	return 0
+}
+
+// wait blocks until query can be sent without exceeding the available points,
+// or until ctx is done.
+func (l *costLimiter) wait(ctx context.Context, query string) error {
+	for {
+		delay := l.reserve(query)
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// throttleStatus returns the current throttle status and whether the server has reported one.
+func (l *costLimiter) throttleStatus() (ThrottleStatus, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.status.MaximumAvailable == 0 {
+		return ThrottleStatus{}, false
+	}
+	return l.available(), true
+}
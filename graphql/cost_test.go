@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCostLimiterReserve(t *testing.T) {
+	now := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	l := newCostLimiter()
+	l.now = func() time.Time { return now }
+
+	if d := l.reserve("{shop{id}}"); d != 0 {
+		t.Errorf("expected no wait before any status is reported, got %v", d)
+	}
+
+	actual := float64(100)
+	l.update("{shop{id}}", &QueryCost{
+		RequestedQueryCost: 100,
+		ActualQueryCost:    &actual,
+		ThrottleStatus: ThrottleStatus{
+			MaximumAvailable:   1000,
+			CurrentlyAvailable: 150,
+			RestoreRate:        50,
+		},
+	})
+
+	if d := l.reserve("{shop{id}}"); d != 0 {
+		t.Errorf("expected no wait with 150 points available, got %v", d)
+	}
+	// 50 points left, the query needs 100: wait for 50 points at 50 points per second.
+	if got, want := l.reserve("{shop{id}}"), time.Second; got != want {
+		t.Errorf("got wait %v, want %v", got, want)
+	}
+
+	now = now.Add(time.Second)
+	if d := l.reserve("{shop{id}}"); d != 0 {
+		t.Errorf("expected no wait after the bucket restored, got %v", d)
+	}
+	status, _ := l.throttleStatus()
+	if status.CurrentlyAvailable != 0 {
+		t.Errorf("got %v points available, want 0", status.CurrentlyAvailable)
+	}
+
+	now = now.Add(time.Minute)
+	status, _ = l.throttleStatus()
+	if status.CurrentlyAvailable != 1000 {
+		t.Errorf("got %v points available, want the bucket to be capped at 1000", status.CurrentlyAvailable)
+	}
+}
+
+func TestCostLimiterMaxCostExceeded(t *testing.T) {
+	l := newCostLimiter()
+	l.update("query", &QueryCost{
+		RequestedQueryCost: 2000,
+		ThrottleStatus: ThrottleStatus{
+			MaximumAvailable:   1000,
+			CurrentlyAvailable: 0,
+			RestoreRate:        50,
+		},
+	})
+	if d := l.reserve("query"); d != 0 {
+		t.Errorf("expected a query over the max cost not to wait, got %v", d)
+	}
+}
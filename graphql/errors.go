@@ -0,0 +1,134 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error codes reported by Shopify in the "extensions" of a GraphQL error.
+const (
+	ErrorCodeThrottled           = "THROTTLED"
+	ErrorCodeMaxCostExceeded     = "MAX_COST_EXCEEDED"
+	ErrorCodeAccessDenied        = "ACCESS_DENIED"
+	ErrorCodeInternalServerError = "INTERNAL_SERVER_ERROR"
+)
+
+// Sentinel errors to use with errors.Is on errors returned by the client.
+var (
+	// ErrThrottled is matched when the shop ran out of query cost points or requests.
+	ErrThrottled = errors.New("throttled")
+	// ErrMaxCostExceeded is matched when a single query costs more than the shop's bucket can hold.
+	ErrMaxCostExceeded = errors.New("max cost exceeded")
+	// ErrAccessDenied is matched when the app lacks the access scope required by the request.
+	ErrAccessDenied = errors.New("access denied")
+	// ErrUnauthorized is matched when the API key or access token is invalid.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound is matched when the requested endpoint doesn't exist, e.g. the shop was closed.
+	ErrNotFound = errors.New("not found")
+	// ErrInternalServerError is matched when the server failed to process the request.
+	ErrInternalServerError = errors.New("internal server error")
+)
+
+// Location is a position of a GraphQL error in the query.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// ErrorExtensions represents the "extensions" of a GraphQL error.
+type ErrorExtensions struct {
+	Code          string `json:"code"`
+	Documentation string `json:"documentation"`
+	RequestID     string `json:"requestId"`
+}
+
+// GraphQLError represents an entry of the "errors" array in a response from a GraphQL server.
+//
+// Specification: https://facebook.github.io/graphql/#sec-Errors.
+type GraphQLError struct {
+	Message    string          `json:"message"`
+	Locations  []Location      `json:"locations"`
+	Path       []interface{}   `json:"path"`
+	Extensions ErrorExtensions `json:"extensions"`
+}
+
+// Error implements error interface.
+func (e *GraphQLError) Error() string {
+	return e.Message
+}
+
+// Is reports whether the error code of e corresponds to target.
+func (e *GraphQLError) Is(target error) bool {
+	switch target {
+	case ErrThrottled:
+		// older API versions only report the message
+		return e.Extensions.Code == ErrorCodeThrottled || e.Message == "Throttled"
+	case ErrMaxCostExceeded:
+		return e.Extensions.Code == ErrorCodeMaxCostExceeded
+	case ErrAccessDenied:
+		return e.Extensions.Code == ErrorCodeAccessDenied
+	case ErrInternalServerError:
+		return e.Extensions.Code == ErrorCodeInternalServerError
+	}
+	return false
+}
+
+// Errors represents the "errors" array in a response from a GraphQL server.
+// If returned via error interface, the slice is expected to contain at least 1 element.
+type Errors []*GraphQLError
+
+// Error implements error interface.
+func (e Errors) Error() string {
+	return e[0].Message
+}
+
+// Is reports whether any of the errors matches target.
+func (e Errors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors that matches target, and if so, sets target to it.
+func (e Errors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPError is returned when the server responds with a non-200 status code.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+}
+
+// Error implements error interface.
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("non-200 OK status code: %v body: %q", e.Status, e.Body)
+}
+
+// Is reports whether the status code of e corresponds to target.
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrThrottled:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrAccessDenied:
+		return e.StatusCode == http.StatusForbidden
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrInternalServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDoErrors(t *testing.T) {
+	testTable := []struct {
+		name       string
+		statusCode int
+		body       string
+		is         []error
+		isNot      []error
+	}{
+		{
+			name:       "throttled",
+			statusCode: http.StatusOK,
+			body:       `{"errors": [{"message": "Throttled", "extensions": {"code": "THROTTLED", "documentation": "https://shopify.dev/api/usage/rate-limits"}}]}`,
+			is:         []error{ErrThrottled},
+			isNot:      []error{ErrMaxCostExceeded, ErrAccessDenied},
+		},
+		{
+			name:       "max_cost_exceeded",
+			statusCode: http.StatusOK,
+			body:       `{"errors": [{"message": "Query cost is 2002, which exceeds the single query max cost limit (1000).", "extensions": {"code": "MAX_COST_EXCEEDED", "cost": 2002, "maxCost": 1000}}]}`,
+			is:         []error{ErrMaxCostExceeded},
+			isNot:      []error{ErrThrottled},
+		},
+		{
+			name:       "access_denied",
+			statusCode: http.StatusOK,
+			body:       `{"errors": [{"message": "Access denied for orders field.", "locations": [{"line": 1, "column": 2}], "path": ["orders"], "extensions": {"code": "ACCESS_DENIED"}}]}`,
+			is:         []error{ErrAccessDenied},
+			isNot:      []error{ErrUnauthorized},
+		},
+		{
+			name:       "invalid_token",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"errors": "[API] Invalid API key or access token (unrecognized login or wrong password)"}`,
+			is:         []error{ErrUnauthorized},
+			isNot:      []error{ErrAccessDenied, ErrThrottled},
+		},
+		{
+			name:       "forbidden",
+			statusCode: http.StatusForbidden,
+			body:       ``,
+			is:         []error{ErrAccessDenied},
+			isNot:      []error{ErrUnauthorized},
+		},
+	}
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			c := NewClient(server.URL, server.Client())
+			var v map[string]interface{}
+			err := c.do(context.Background(), "{orders{edges{node{id}}}}", nil, &v)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, target := range tc.is {
+				if !errors.Is(err, target) {
+					t.Errorf("expected errors.Is(%v, %v)", err, target)
+				}
+			}
+			for _, target := range tc.isNot {
+				if errors.Is(err, target) {
+					t.Errorf("expected !errors.Is(%v, %v)", err, target)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorsAs(t *testing.T) {
+	var err error = Errors{
+		{Message: "Field 'foo' doesn't exist on type 'Product'", Path: []interface{}{"query", "product", "foo"}},
+		{Message: "Throttled", Extensions: ErrorExtensions{Code: ErrorCodeThrottled}},
+	}
+
+	var gqlErr *GraphQLError
+	if !errors.As(err, &gqlErr) {
+		t.Fatal("expected errors.As to find a *GraphQLError")
+	}
+	if len(gqlErr.Path) != 3 {
+		t.Errorf("got path %v, want the path of the first error", gqlErr.Path)
+	}
+	if !errors.Is(err, ErrThrottled) {
+		t.Error("expected the second error to match ErrThrottled")
+	}
+
+	err = &HTTPError{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: []byte("Not Found")}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected errors.As to find the *HTTPError, got %v", httpErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected a 404 to match ErrNotFound")
+	}
+}
@@ -4,13 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 
-	"github.com/gempages/go-helper/errors"
 	"github.com/gempages/go-helper/tracing"
-	"github.com/gempages/go-shopify-graphql/utils"
 	"github.com/getsentry/sentry-go"
 	"golang.org/x/net/context/ctxhttp"
 )
@@ -20,6 +17,7 @@ type Client struct {
 	url        string // GraphQL server URL.
 	httpClient *http.Client
 	ctx        context.Context
+	limiter    *costLimiter
 }
 
 // NewClient creates a GraphQL client targeting the specified GraphQL server URL.
@@ -31,6 +29,7 @@ func NewClient(url string, httpClient *http.Client) *Client {
 	return &Client{
 		url:        url,
 		httpClient: httpClient,
+		limiter:    newCostLimiter(),
 	}
 }
 
@@ -49,6 +48,17 @@ func (c *Client) Context() context.Context {
 	return context.Background()
 }
 
+// HTTPClient returns the http.Client used to send requests.
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// ThrottleStatus returns the shop's query cost bucket as last reported by the server,
+// restored up to now. The second return value is false if no status has been reported yet.
+func (c *Client) ThrottleStatus() (ThrottleStatus, bool) {
+	return c.limiter.throttleStatus()
+}
+
 // QueryString executes a single GraphQL query request,
 // using the given raw query `q` and populating the response into the `v`.
 // `q` should be a correct GraphQL request string that corresponds to the GraphQL schema.
@@ -75,8 +85,8 @@ func (c *Client) Mutate(ctx context.Context, m interface{}, variables map[string
 
 // do executes a single GraphQL operation.
 func (c *Client) do(ctx context.Context, query string, variables map[string]interface{}, v interface{}) error {
-	if c.ctx != nil {
-		ctx = c.ctx
+	if ctx == nil {
+		ctx = c.Context()
 	}
 	var err error
 	in := struct {
@@ -89,7 +99,7 @@ func (c *Client) do(ctx context.Context, query string, variables map[string]inte
 
 	// sentry tracing
 	span := sentry.StartSpan(ctx, "shopify_graphql.send")
-	span.Description = utils.GetDescriptionFromQuery(query)
+	span.Description = QueryDescription(query)
 	span.Data = map[string]interface{}{
 		"GraphQL Query":     query,
 		"GraphQL Variables": variables,
@@ -100,6 +110,12 @@ func (c *Client) do(ctx context.Context, query string, variables map[string]inte
 	}()
 	// end sentry tracing
 
+	// wait until the shop's bucket holds enough points for the query
+	err = c.limiter.wait(ctx, query)
+	if err != nil {
+		return err
+	}
+
 	var buf bytes.Buffer
 	err = json.NewEncoder(&buf).Encode(in)
 	if err != nil {
@@ -112,18 +128,27 @@ func (c *Client) do(ctx context.Context, query string, variables map[string]inte
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return errors.NewErrorWithContext(ctx, fmt.Errorf("non-200 OK status code: %v", resp.Status), map[string]any{"body": string(body)})
+		err = &HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header,
+			Body:       body,
+		}
+		return err
 	}
 	var out struct {
-		Data   *json.RawMessage
-		Errors graphErrors
-		//Extensions interface{} // Unused.
+		Data       *json.RawMessage
+		Errors     Errors
+		Extensions struct {
+			Cost *QueryCost
+		}
 	}
 	err = json.NewDecoder(resp.Body).Decode(&out)
 	if err != nil {
 		// TODO: Consider including response body in returned error, if deemed helpful.
 		return err
 	}
+	c.limiter.update(query, out.Extensions.Cost)
 	// xx := make(map[string]interface{})
 	if out.Data != nil {
 		err := json.Unmarshal(*out.Data, v)
@@ -133,28 +158,12 @@ func (c *Client) do(ctx context.Context, query string, variables map[string]inte
 		}
 	}
 	if len(out.Errors) > 0 {
-		return out.Errors
+		err = out.Errors
+		return err
 	}
 	return nil
 }
 
-// errors represents the "errors" array in a response from a GraphQL server.
-// If returned via error interface, the slice is expected to contain at least 1 element.
-//
-// Specification: https://facebook.github.io/graphql/#sec-Errors.
-type graphErrors []struct {
-	Message   string
-	Locations []struct {
-		Line   int
-		Column int
-	}
-}
-
-// Error implements error interface.
-func (e graphErrors) Error() string {
-	return e[0].Message
-}
-
 type operationType uint8
 
 const (
@@ -3,14 +3,46 @@ package shopify
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
 )
 
+// InventoryService manages inventory items and their levels at locations. The named quantities read and
+// changed by GetLevel, IterLevels, IterLocationLevels, SetOnHandQuantities, AdjustQuantities and
+// MoveQuantities need the Admin API version 2023-07, see graphqlclient.WithVersion.
 type InventoryService interface {
 	Update(id graphql.ID, input InventoryItemUpdateInput) error
+	UpdateWithContext(ctx context.Context, id graphql.ID, input InventoryItemUpdateInput) error
 	Adjust(locationID graphql.ID, input []InventoryAdjustItemInput) error
+	AdjustWithContext(ctx context.Context, locationID graphql.ID, input []InventoryAdjustItemInput) error
 	ActivateInventory(locationID graphql.ID, id graphql.ID) error
+	ActivateInventoryWithContext(ctx context.Context, locationID graphql.ID, id graphql.ID) error
+
+	// GetLevel returns the inventory level of the item at the location, with its quantities of names,
+	// or of all the quantity names when names is empty.
+	GetLevel(itemID graphql.ID, locationID graphql.ID, names ...InventoryQuantityName) (*InventoryLevel, error)
+	GetLevelWithContext(ctx context.Context, itemID graphql.ID, locationID graphql.ID, names ...InventoryQuantityName) (*InventoryLevel, error)
+	// IterLevels returns an iterator over the inventory levels of the item at each location where it's
+	// stocked, with their quantities of names, or of all the quantity names when names is empty.
+	IterLevels(itemID graphql.ID, names []InventoryQuantityName, opts ListOptions) *Iterator[*InventoryLevel]
+	IterLevelsWithContext(ctx context.Context, itemID graphql.ID, names []InventoryQuantityName, opts ListOptions) *Iterator[*InventoryLevel]
+	// IterLocationLevels returns an iterator over the inventory levels of the items stocked at the
+	// location, with their quantities of names, or of all the quantity names when names is empty.
+	IterLocationLevels(locationID graphql.ID, names []InventoryQuantityName, opts ListOptions) *Iterator[*InventoryLevel]
+	IterLocationLevelsWithContext(ctx context.Context, locationID graphql.ID, names []InventoryQuantityName, opts ListOptions) *Iterator[*InventoryLevel]
+	// SetOnHandQuantities sets the on hand quantities of items at locations, adjusting their available
+	// quantities by the difference.
+	SetOnHandQuantities(input InventorySetOnHandQuantitiesInput) (*InventoryAdjustmentGroup, error)
+	SetOnHandQuantitiesWithContext(ctx context.Context, input InventorySetOnHandQuantitiesInput) (*InventoryAdjustmentGroup, error)
+	// AdjustQuantities adds deltas to the quantities of a name of items at locations.
+	AdjustQuantities(input InventoryAdjustQuantitiesInput) (*InventoryAdjustmentGroup, error)
+	AdjustQuantitiesWithContext(ctx context.Context, input InventoryAdjustQuantitiesInput) (*InventoryAdjustmentGroup, error)
+	// MoveQuantities moves quantities of items between quantity names at their locations, like
+	// available to reserved.
+	MoveQuantities(input InventoryMoveQuantitiesInput) (*InventoryAdjustmentGroup, error)
+	MoveQuantitiesWithContext(ctx context.Context, input InventoryMoveQuantitiesInput) (*InventoryAdjustmentGroup, error)
 }
 
 type InventoryServiceOp struct {
@@ -26,9 +58,125 @@ type InventoryItem struct {
 }
 
 type InventoryLevel struct {
+	ID        graphql.ID     `json:"id,omitempty"`
 	UpdatedAt graphql.String `json:"updatedAt,omitempty"`
-	Available graphql.Int    `json:"available,omitempty"`
-	Item      InventoryItem  `json:"item,omitempty"`
+	// Available is only set by queries selecting the deprecated available field, use Quantity instead.
+	Available  graphql.Int         `json:"available,omitempty"`
+	Quantities []InventoryQuantity `json:"quantities,omitempty"`
+	Item       InventoryItem       `json:"item,omitempty"`
+	Location   Location            `json:"location,omitempty"`
+}
+
+// Quantity returns the quantity of name at the level, and whether it was fetched.
+func (l *InventoryLevel) Quantity(name InventoryQuantityName) (int, bool) {
+	for _, q := range l.Quantities {
+		if q.Name == name {
+			return q.Quantity, true
+		}
+	}
+	return 0, false
+}
+
+// InventoryQuantityName is the name of a state of inventory quantities.
+type InventoryQuantityName string
+
+const (
+	InventoryQuantityAvailable      InventoryQuantityName = "available"
+	InventoryQuantityOnHand         InventoryQuantityName = "on_hand"
+	InventoryQuantityCommitted      InventoryQuantityName = "committed"
+	InventoryQuantityReserved       InventoryQuantityName = "reserved"
+	InventoryQuantityIncoming       InventoryQuantityName = "incoming"
+	InventoryQuantityDamaged        InventoryQuantityName = "damaged"
+	InventoryQuantitySafetyStock    InventoryQuantityName = "safety_stock"
+	InventoryQuantityQualityControl InventoryQuantityName = "quality_control"
+)
+
+// inventoryQuantityNames are the names fetched when none are given.
+var inventoryQuantityNames = []InventoryQuantityName{
+	InventoryQuantityAvailable,
+	InventoryQuantityOnHand,
+	InventoryQuantityCommitted,
+	InventoryQuantityReserved,
+	InventoryQuantityIncoming,
+	InventoryQuantityDamaged,
+	InventoryQuantitySafetyStock,
+	InventoryQuantityQualityControl,
+}
+
+type InventoryQuantity struct {
+	Name     InventoryQuantityName `json:"name,omitempty"`
+	Quantity int                   `json:"quantity"`
+}
+
+// InventoryAdjustmentGroup is the changes to inventory quantities made by a mutation.
+type InventoryAdjustmentGroup struct {
+	ID                   graphql.ID        `json:"id,omitempty"`
+	CreatedAt            time.Time         `json:"createdAt,omitempty"`
+	Reason               string            `json:"reason,omitempty"`
+	ReferenceDocumentURI string            `json:"referenceDocumentUri,omitempty"`
+	Changes              []InventoryChange `json:"changes,omitempty"`
+}
+
+type InventoryChange struct {
+	Name  InventoryQuantityName `json:"name,omitempty"`
+	Delta int                   `json:"delta"`
+	// QuantityAfterChange is nil when Shopify doesn't know the quantity.
+	QuantityAfterChange *int          `json:"quantityAfterChange,omitempty"`
+	Item                InventoryItem `json:"item,omitempty"`
+	Location            Location      `json:"location,omitempty"`
+	LedgerDocumentURI   string        `json:"ledgerDocumentUri,omitempty"`
+}
+
+// InventorySetOnHandQuantitiesInput sets absolute on hand quantities. Reason is one of Shopify's
+// inventory reasons, like correction, cycle_count_available or received, and ReferenceDocumentURI
+// optionally identifies the document behind the change, like gid://my-app/StockCount/42.
+type InventorySetOnHandQuantitiesInput struct {
+	Reason               string                      `json:"reason"`
+	ReferenceDocumentURI string                      `json:"referenceDocumentUri,omitempty"`
+	SetQuantities        []InventorySetQuantityInput `json:"setQuantities"`
+}
+
+type InventorySetQuantityInput struct {
+	InventoryItemID graphql.ID `json:"inventoryItemId"`
+	LocationID      graphql.ID `json:"locationId"`
+	Quantity        int        `json:"quantity"`
+}
+
+// InventoryAdjustQuantitiesInput adds deltas to the quantities of Name. Changes to quantities other
+// than available need a LedgerDocumentURI.
+type InventoryAdjustQuantitiesInput struct {
+	Reason               string                 `json:"reason"`
+	Name                 InventoryQuantityName  `json:"name"`
+	ReferenceDocumentURI string                 `json:"referenceDocumentUri,omitempty"`
+	Changes              []InventoryChangeInput `json:"changes"`
+}
+
+type InventoryChangeInput struct {
+	InventoryItemID   graphql.ID `json:"inventoryItemId"`
+	LocationID        graphql.ID `json:"locationId"`
+	Delta             int        `json:"delta"`
+	LedgerDocumentURI string     `json:"ledgerDocumentUri,omitempty"`
+}
+
+type InventoryMoveQuantitiesInput struct {
+	Reason               string                        `json:"reason"`
+	ReferenceDocumentURI string                        `json:"referenceDocumentUri,omitempty"`
+	Changes              []InventoryMoveQuantityChange `json:"changes"`
+}
+
+type InventoryMoveQuantityChange struct {
+	InventoryItemID graphql.ID                         `json:"inventoryItemId"`
+	Quantity        int                                `json:"quantity"`
+	From            InventoryMoveQuantityTerminalInput `json:"from"`
+	To              InventoryMoveQuantityTerminalInput `json:"to"`
+}
+
+// InventoryMoveQuantityTerminalInput is a side of a move. Its LedgerDocumentURI is needed unless
+// Name is available.
+type InventoryMoveQuantityTerminalInput struct {
+	LocationID        graphql.ID            `json:"locationId"`
+	Name              InventoryQuantityName `json:"name"`
+	LedgerDocumentURI string                `json:"ledgerDocumentUri,omitempty"`
 }
 
 type InventoryItemUpdateInput struct {
@@ -65,55 +213,300 @@ type InventoryActivateResult struct {
 }
 
 func (s *InventoryServiceOp) Update(id graphql.ID, input InventoryItemUpdateInput) error {
+	return s.UpdateWithContext(s.client.gql.Context(), id, input)
+}
+
+func (s *InventoryServiceOp) UpdateWithContext(ctx context.Context, id graphql.ID, input InventoryItemUpdateInput) error {
 	m := mutationInventoryItemUpdate{}
 	vars := map[string]interface{}{
 		"id":    id,
 		"input": input,
 	}
-	err := s.client.gql.Mutate(context.Background(), &m, vars)
+	err := s.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.InventoryItemUpdateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.InventoryItemUpdateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.InventoryItemUpdateResult.UserErrors}
 	}
 
 	return nil
 }
 
 func (s *InventoryServiceOp) Adjust(locationID graphql.ID, input []InventoryAdjustItemInput) error {
+	return s.AdjustWithContext(s.client.gql.Context(), locationID, input)
+}
+
+func (s *InventoryServiceOp) AdjustWithContext(ctx context.Context, locationID graphql.ID, input []InventoryAdjustItemInput) error {
 	m := mutationInventoryBulkAdjustQuantityAtLocation{}
 	vars := map[string]interface{}{
 		"locationId":               locationID,
 		"inventoryItemAdjustments": input,
 	}
-	err := s.client.gql.Mutate(context.Background(), &m, vars)
+	err := s.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.InventoryBulkAdjustQuantityAtLocationResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.InventoryBulkAdjustQuantityAtLocationResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.InventoryBulkAdjustQuantityAtLocationResult.UserErrors}
 	}
 
 	return nil
 }
 
 func (s *InventoryServiceOp) ActivateInventory(locationID graphql.ID, id graphql.ID) error {
+	return s.ActivateInventoryWithContext(s.client.gql.Context(), locationID, id)
+}
+
+func (s *InventoryServiceOp) ActivateInventoryWithContext(ctx context.Context, locationID graphql.ID, id graphql.ID) error {
 	m := mutationInventoryActivate{}
 	vars := map[string]interface{}{
 		"itemID":     id,
 		"locationId": locationID,
 	}
-	err := s.client.gql.Mutate(context.Background(), &m, vars)
+	err := s.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.InventoryActivateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.InventoryActivateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.InventoryActivateResult.UserErrors}
 	}
 
 	return nil
 }
+
+const inventoryLevelQuery = `
+	id
+	updatedAt
+	quantities(names: $names){
+		name
+		quantity
+	}
+	item{
+		id
+		legacyResourceId
+		sku
+	}
+	location{
+		id
+		name
+	}
+`
+
+const inventoryAdjustmentGroupQuery = `
+	inventoryAdjustmentGroup{
+		id
+		createdAt
+		reason
+		referenceDocumentUri
+		changes{
+			name
+			delta
+			quantityAfterChange
+			ledgerDocumentUri
+			item{
+				id
+				sku
+			}
+			location{
+				id
+				name
+			}
+		}
+	}
+	userErrors{
+		field
+		message
+	}
+`
+
+var (
+	inventorySetOnHandQuantitiesMutation = fmt.Sprintf(`
+		mutation inventorySetOnHandQuantities($input: InventorySetOnHandQuantitiesInput!) {
+			inventorySetOnHandQuantities(input: $input){
+				%s
+			}
+		}
+	`, inventoryAdjustmentGroupQuery)
+
+	inventoryAdjustQuantitiesMutation = fmt.Sprintf(`
+		mutation inventoryAdjustQuantities($input: InventoryAdjustQuantitiesInput!) {
+			inventoryAdjustQuantities(input: $input){
+				%s
+			}
+		}
+	`, inventoryAdjustmentGroupQuery)
+
+	inventoryMoveQuantitiesMutation = fmt.Sprintf(`
+		mutation inventoryMoveQuantities($input: InventoryMoveQuantitiesInput!) {
+			inventoryMoveQuantities(input: $input){
+				%s
+			}
+		}
+	`, inventoryAdjustmentGroupQuery)
+)
+
+func (s *InventoryServiceOp) GetLevel(itemID graphql.ID, locationID graphql.ID, names ...InventoryQuantityName) (*InventoryLevel, error) {
+	return s.GetLevelWithContext(s.client.gql.Context(), itemID, locationID, names...)
+}
+
+func (s *InventoryServiceOp) GetLevelWithContext(ctx context.Context, itemID graphql.ID, locationID graphql.ID, names ...InventoryQuantityName) (*InventoryLevel, error) {
+	q := fmt.Sprintf(`
+		query inventoryLevel($id: ID!, $locationId: ID!, $names: [String!]!) {
+			inventoryItem(id: $id){
+				inventoryLevel(locationId: $locationId){
+					%s
+				}
+			}
+		}
+	`, inventoryLevelQuery)
+
+	vars := map[string]interface{}{
+		"id":         itemID,
+		"locationId": locationID,
+		"names":      quantityNames(names),
+	}
+
+	out := struct {
+		InventoryItem *struct {
+			InventoryLevel *InventoryLevel `json:"inventoryLevel"`
+		} `json:"inventoryItem"`
+	}{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, vars, &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.InventoryItem == nil {
+		return nil, fmt.Errorf("inventory item %v not found", itemID)
+	}
+	if out.InventoryItem.InventoryLevel == nil {
+		return nil, fmt.Errorf("inventory item %v is not stocked at location %v", itemID, locationID)
+	}
+
+	return out.InventoryItem.InventoryLevel, nil
+}
+
+func (s *InventoryServiceOp) IterLevels(itemID graphql.ID, names []InventoryQuantityName, opts ListOptions) *Iterator[*InventoryLevel] {
+	return s.IterLevelsWithContext(s.client.gql.Context(), itemID, names, opts)
+}
+
+func (s *InventoryServiceOp) IterLevelsWithContext(ctx context.Context, itemID graphql.ID, names []InventoryQuantityName, opts ListOptions) *Iterator[*InventoryLevel] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts ListOptions) (*Page[*InventoryLevel], error) {
+		return s.listLevelsPage(ctx, "inventoryItem", itemID, names, opts)
+	})
+}
+
+func (s *InventoryServiceOp) IterLocationLevels(locationID graphql.ID, names []InventoryQuantityName, opts ListOptions) *Iterator[*InventoryLevel] {
+	return s.IterLocationLevelsWithContext(s.client.gql.Context(), locationID, names, opts)
+}
+
+func (s *InventoryServiceOp) IterLocationLevelsWithContext(ctx context.Context, locationID graphql.ID, names []InventoryQuantityName, opts ListOptions) *Iterator[*InventoryLevel] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts ListOptions) (*Page[*InventoryLevel], error) {
+		return s.listLevelsPage(ctx, "location", locationID, names, opts)
+	})
+}
+
+// listLevelsPage fetches a page of the inventoryLevels connection of the owner, an inventoryItem or
+// a location.
+func (s *InventoryServiceOp) listLevelsPage(ctx context.Context, owner string, id graphql.ID, names []InventoryQuantityName, opts ListOptions) (*Page[*InventoryLevel], error) {
+	q := fmt.Sprintf(`
+		query inventoryLevels($id: ID!, $names: [String!]!, $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+			owner: %s(id: $id){
+				inventoryLevels(first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
+					edges{
+						node{
+							%s
+						}
+					}
+					%s
+				}
+			}
+		}
+	`, owner, inventoryLevelQuery, pageInfoQuery)
+
+	if opts.First == 0 && opts.Last == 0 {
+		opts.First = defaultPageSize
+	}
+	opts.Query = ""
+	vars := pageVars(opts)
+	vars["id"] = id
+	vars["names"] = quantityNames(names)
+
+	out := struct {
+		Owner *struct {
+			InventoryLevels struct {
+				Edges []struct {
+					Node *InventoryLevel `json:"node"`
+				} `json:"edges"`
+				PageInfo PageInfo `json:"pageInfo"`
+			} `json:"inventoryLevels"`
+		} `json:"owner"`
+	}{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, vars, &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.Owner == nil {
+		return nil, fmt.Errorf("%s %v not found", owner, id)
+	}
+
+	page := &Page[*InventoryLevel]{PageInfo: out.Owner.InventoryLevels.PageInfo}
+	for _, e := range out.Owner.InventoryLevels.Edges {
+		page.Nodes = append(page.Nodes, e.Node)
+	}
+
+	return page, nil
+}
+
+func (s *InventoryServiceOp) SetOnHandQuantities(input InventorySetOnHandQuantitiesInput) (*InventoryAdjustmentGroup, error) {
+	return s.SetOnHandQuantitiesWithContext(s.client.gql.Context(), input)
+}
+
+func (s *InventoryServiceOp) SetOnHandQuantitiesWithContext(ctx context.Context, input InventorySetOnHandQuantitiesInput) (*InventoryAdjustmentGroup, error) {
+	return s.mutateQuantities(ctx, retryTransient, inventorySetOnHandQuantitiesMutation, input)
+}
+
+func (s *InventoryServiceOp) AdjustQuantities(input InventoryAdjustQuantitiesInput) (*InventoryAdjustmentGroup, error) {
+	return s.AdjustQuantitiesWithContext(s.client.gql.Context(), input)
+}
+
+func (s *InventoryServiceOp) AdjustQuantitiesWithContext(ctx context.Context, input InventoryAdjustQuantitiesInput) (*InventoryAdjustmentGroup, error) {
+	return s.mutateQuantities(ctx, retryThrottled, inventoryAdjustQuantitiesMutation, input)
+}
+
+func (s *InventoryServiceOp) MoveQuantities(input InventoryMoveQuantitiesInput) (*InventoryAdjustmentGroup, error) {
+	return s.MoveQuantitiesWithContext(s.client.gql.Context(), input)
+}
+
+func (s *InventoryServiceOp) MoveQuantitiesWithContext(ctx context.Context, input InventoryMoveQuantitiesInput) (*InventoryAdjustmentGroup, error) {
+	return s.mutateQuantities(ctx, retryThrottled, inventoryMoveQuantitiesMutation, input)
+}
+
+// mutateQuantities runs one of the inventory quantities mutations, retrying it as allowed by retry.
+// Setting on hand quantities can be repeated, but adjusting or moving them would apply the deltas
+// again.
+func (s *InventoryServiceOp) mutateQuantities(ctx context.Context, retry retryPolicy, mutation string, input interface{}) (*InventoryAdjustmentGroup, error) {
+	payload := struct {
+		InventoryAdjustmentGroup *InventoryAdjustmentGroup `json:"inventoryAdjustmentGroup"`
+	}{}
+	err := s.client.mutate(ctx, retry, mutation, map[string]interface{}{"input": input}, &payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload.InventoryAdjustmentGroup, nil
+}
+
+// quantityNames returns names, or all the quantity names when it's empty.
+func quantityNames(names []InventoryQuantityName) []InventoryQuantityName {
+	if len(names) == 0 {
+		return inventoryQuantityNames
+	}
+	return names
+}
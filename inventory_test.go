@@ -0,0 +1,194 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestInventory(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	warehouse := srv.AddLocation(&shopifytest.Location{Name: "Warehouse"})
+	store := srv.AddLocation(&shopifytest.Location{Name: "Store"})
+	p := srv.AddProduct(&shopifytest.Product{
+		Title: "Snowboard",
+		Variants: []*shopifytest.Variant{{
+			SKU: "SB-1",
+			InventoryLevels: []*shopifytest.InventoryLevel{{
+				LocationID: warehouse.ID,
+				Quantities: map[string]int{"available": 8, "committed": 2, "incoming": 5},
+			}},
+		}},
+	})
+	itemID := graphql.ID(p.Variants[0].InventoryItemID)
+	warehouseID, storeID := graphql.ID(warehouse.ID), graphql.ID(store.ID)
+
+	level, err := client.Inventory.GetLevelWithContext(ctx, itemID, warehouseID)
+	if err != nil {
+		t.Fatalf("get level: %v", err)
+	}
+	for name, want := range map[shopify.InventoryQuantityName]int{
+		shopify.InventoryQuantityAvailable: 8,
+		shopify.InventoryQuantityCommitted: 2,
+		shopify.InventoryQuantityIncoming:  5,
+		shopify.InventoryQuantityOnHand:    10,
+		shopify.InventoryQuantityReserved:  0,
+	} {
+		if got, ok := level.Quantity(name); !ok || got != want {
+			t.Errorf("expected %s to be %d, got %d (fetched: %v)", name, want, got, ok)
+		}
+	}
+	if level.Location.Name != "Warehouse" || level.Item.SKU != "SB-1" {
+		t.Errorf("unexpected level: %+v", level)
+	}
+
+	if _, err = client.Inventory.GetLevelWithContext(ctx, itemID, storeID); err == nil {
+		t.Errorf("expected an error for an item not stocked at the location")
+	}
+	if err = client.Inventory.ActivateInventoryWithContext(ctx, storeID, itemID); err != nil {
+		t.Fatalf("activate inventory: %v", err)
+	}
+
+	group, err := client.Inventory.SetOnHandQuantitiesWithContext(ctx, shopify.InventorySetOnHandQuantitiesInput{
+		Reason:               "cycle_count_available",
+		ReferenceDocumentURI: "gid://warehouse-sync/StockCount/1",
+		SetQuantities: []shopify.InventorySetQuantityInput{
+			{InventoryItemID: itemID, LocationID: warehouseID, Quantity: 15},
+			{InventoryItemID: itemID, LocationID: storeID, Quantity: 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("set on hand quantities: %v", err)
+	}
+	if group.Reason != "cycle_count_available" || group.ReferenceDocumentURI != "gid://warehouse-sync/StockCount/1" ||
+		len(group.Changes) != 4 || group.Changes[0].Delta != 5 || *group.Changes[0].QuantityAfterChange != 13 {
+		t.Errorf("unexpected adjustment group: %+v", group)
+	}
+	if v := srv.Products()[0].Variants[0]; v.InventoryQuantity != 16 {
+		t.Errorf("expected the variant inventory quantity to be 16, got %d", v.InventoryQuantity)
+	}
+
+	_, err = client.Inventory.AdjustQuantitiesWithContext(ctx, shopify.InventoryAdjustQuantitiesInput{
+		Reason:  "damaged",
+		Name:    shopify.InventoryQuantityDamaged,
+		Changes: []shopify.InventoryChangeInput{{InventoryItemID: itemID, LocationID: warehouseID, Delta: 1}},
+	})
+	if !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors adjusting damaged without a ledger document, got %v", err)
+	}
+	if _, err = client.Inventory.AdjustQuantitiesWithContext(ctx, shopify.InventoryAdjustQuantitiesInput{
+		Reason: "correction",
+		Name:   shopify.InventoryQuantityAvailable,
+		Changes: []shopify.InventoryChangeInput{
+			{InventoryItemID: itemID, LocationID: storeID, Delta: -1},
+		},
+	}); err != nil {
+		t.Fatalf("adjust quantities: %v", err)
+	}
+
+	group, err = client.Inventory.MoveQuantitiesWithContext(ctx, shopify.InventoryMoveQuantitiesInput{
+		Reason: "reservation_created",
+		Changes: []shopify.InventoryMoveQuantityChange{{
+			InventoryItemID: itemID,
+			Quantity:        4,
+			From:            shopify.InventoryMoveQuantityTerminalInput{LocationID: warehouseID, Name: shopify.InventoryQuantityAvailable},
+			To: shopify.InventoryMoveQuantityTerminalInput{
+				LocationID:        warehouseID,
+				Name:              shopify.InventoryQuantityReserved,
+				LedgerDocumentURI: "gid://warehouse-sync/Reservation/1",
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("move quantities: %v", err)
+	}
+	if len(group.Changes) != 2 {
+		t.Errorf("expected a move within on hand to make 2 changes, got %+v", group.Changes)
+	}
+
+	levels, err := client.Inventory.IterLevelsWithContext(ctx, itemID, []shopify.InventoryQuantityName{
+		shopify.InventoryQuantityAvailable, shopify.InventoryQuantityReserved, shopify.InventoryQuantityOnHand,
+	}, shopify.ListOptions{First: 1}).All()
+	if err != nil {
+		t.Fatalf("list levels: %v", err)
+	}
+	if len(levels) != 2 {
+		t.Fatalf("expected 2 levels, got %d", len(levels))
+	}
+	want := map[string][3]int{"Warehouse": {9, 4, 15}, "Store": {2, 0, 2}}
+	for _, l := range levels {
+		available, _ := l.Quantity(shopify.InventoryQuantityAvailable)
+		reserved, _ := l.Quantity(shopify.InventoryQuantityReserved)
+		onHand, _ := l.Quantity(shopify.InventoryQuantityOnHand)
+		if got := [3]int{available, reserved, onHand}; got != want[string(l.Location.Name)] {
+			t.Errorf("expected %s quantities %v, got %v", l.Location.Name, want[string(l.Location.Name)], got)
+		}
+	}
+
+	levels, err = client.Inventory.IterLocationLevelsWithContext(ctx, storeID, nil, shopify.ListOptions{}).All()
+	if err != nil {
+		t.Fatalf("list location levels: %v", err)
+	}
+	if len(levels) != 1 || levels[0].Item.ID != itemID {
+		t.Errorf("unexpected location levels: %+v", levels)
+	}
+}
+
+func TestInventoryAdjustQuantitiesLostResponse(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	client.SetRetries(2)
+	ctx := context.Background()
+
+	warehouse := srv.AddLocation(&shopifytest.Location{Name: "Warehouse"})
+	p := srv.AddProduct(&shopifytest.Product{
+		Title: "Snowboard",
+		Variants: []*shopifytest.Variant{{
+			InventoryLevels: []*shopifytest.InventoryLevel{{
+				LocationID: warehouse.ID,
+				Quantities: map[string]int{"available": 8},
+			}},
+		}},
+	})
+	itemID, warehouseID := graphql.ID(p.Variants[0].InventoryItemID), graphql.ID(warehouse.ID)
+
+	srv.DropResponses(1)
+	_, err := client.Inventory.AdjustQuantitiesWithContext(ctx, shopify.InventoryAdjustQuantitiesInput{
+		Reason:  "correction",
+		Name:    shopify.InventoryQuantityAvailable,
+		Changes: []shopify.InventoryChangeInput{{InventoryItemID: itemID, LocationID: warehouseID, Delta: 3}},
+	})
+	if err == nil {
+		t.Fatalf("expected the lost response to fail the adjustment")
+	}
+
+	level, err := client.Inventory.GetLevelWithContext(ctx, itemID, warehouseID, shopify.InventoryQuantityAvailable)
+	if err != nil {
+		t.Fatalf("get level: %v", err)
+	}
+	if got, _ := level.Quantity(shopify.InventoryQuantityAvailable); got != 11 {
+		t.Errorf("expected the delta to be applied once, to 11 available, got %d", got)
+	}
+
+	// setting quantities can be repeated, so it's retried after the lost response
+	srv.DropResponses(1)
+	_, err = client.Inventory.SetOnHandQuantitiesWithContext(ctx, shopify.InventorySetOnHandQuantitiesInput{
+		Reason:        "correction",
+		SetQuantities: []shopify.InventorySetQuantityInput{{InventoryItemID: itemID, LocationID: warehouseID, Quantity: 20}},
+	})
+	if err != nil {
+		t.Fatalf("set on hand quantities: %v", err)
+	}
+	if v := srv.Products()[0].Variants[0]; v.InventoryQuantity != 20 {
+		t.Errorf("expected the variant inventory quantity to be 20, got %d", v.InventoryQuantity)
+	}
+}
@@ -2,12 +2,34 @@ package shopify
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
 )
 
 type LocationService interface {
 	Get(id graphql.ID) (*Location, error)
+	GetWithContext(ctx context.Context, id graphql.ID) (*Location, error)
+	// List returns the page of locations selected by filter and opts, whose Query can search
+	// locations like "name:Warehouse*" or "country:US".
+	List(filter LocationFilter, opts ListOptions) (*Page[*Location], error)
+	ListWithContext(ctx context.Context, filter LocationFilter, opts ListOptions) (*Page[*Location], error)
+	// Iter returns an iterator over the locations selected by filter and opts, fetching pages on demand.
+	Iter(filter LocationFilter, opts ListOptions) *Iterator[*Location]
+	IterWithContext(ctx context.Context, filter LocationFilter, opts ListOptions) *Iterator[*Location]
+	Add(input LocationAddInput) (*Location, error)
+	AddWithContext(ctx context.Context, input LocationAddInput) (*Location, error)
+	Edit(id graphql.ID, input LocationEditInput) (*Location, error)
+	EditWithContext(ctx context.Context, id graphql.ID, input LocationEditInput) (*Location, error)
+	Activate(id graphql.ID) (*Location, error)
+	ActivateWithContext(ctx context.Context, id graphql.ID) (*Location, error)
+	// Deactivate deactivates the location, moving its inventory and open fulfillment orders to the
+	// location destinationID. Locations with inventory or open fulfillment orders can't be deactivated
+	// without a destination, which is then empty.
+	Deactivate(id graphql.ID, destinationID graphql.ID) (*Location, error)
+	DeactivateWithContext(ctx context.Context, id graphql.ID, destinationID graphql.ID) (*Location, error)
 }
 
 type LocationServiceOp struct {
@@ -15,17 +37,168 @@ type LocationServiceOp struct {
 }
 
 type Location struct {
-	ID   graphql.ID     `json:"id,omitempty"`
-	Name graphql.String `json:"name,omitempty"`
+	ID                   graphql.ID      `json:"id,omitempty"`
+	LegacyResourceID     graphql.String  `json:"legacyResourceId,omitempty"`
+	Name                 graphql.String  `json:"name,omitempty"`
+	Address              LocationAddress `json:"address,omitempty"`
+	FulfillsOnlineOrders bool            `json:"fulfillsOnlineOrders,omitempty"`
+	IsActive             bool            `json:"isActive,omitempty"`
+	// ShipsInventory is whether the location is used to calculate shipping rates.
+	ShipsInventory bool `json:"shipsInventory,omitempty"`
+	// IsFulfillmentService is whether the location is managed by a fulfillment service, which makes it
+	// a legacy location.
+	IsFulfillmentService bool `json:"isFulfillmentService,omitempty"`
+	HasActiveInventory   bool `json:"hasActiveInventory,omitempty"`
+	Activatable          bool `json:"activatable,omitempty"`
+	Deactivatable        bool `json:"deactivatable,omitempty"`
+	// DeactivatedAt is empty for active locations.
+	DeactivatedAt graphql.String `json:"deactivatedAt,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt     time.Time      `json:"updatedAt,omitempty"`
 }
 
+type LocationAddress struct {
+	Address1     graphql.String   `json:"address1,omitempty"`
+	Address2     graphql.String   `json:"address2,omitempty"`
+	City         graphql.String   `json:"city,omitempty"`
+	Country      graphql.String   `json:"country,omitempty"`
+	CountryCode  CountryCode      `json:"countryCode,omitempty"`
+	Province     graphql.String   `json:"province,omitempty"`
+	ProvinceCode graphql.String   `json:"provinceCode,omitempty"`
+	Zip          graphql.String   `json:"zip,omitempty"`
+	Phone        graphql.String   `json:"phone,omitempty"`
+	Formatted    []graphql.String `json:"formatted,omitempty"`
+}
+
+// LocationFilter selects the locations listed in addition to the active ones that aren't legacy.
+type LocationFilter struct {
+	IncludeInactive bool
+	// IncludeLegacy includes the locations of fulfillment services.
+	IncludeLegacy bool
+}
+
+type LocationAddInput struct {
+	Name    graphql.String       `json:"name"`
+	Address LocationAddressInput `json:"address"`
+	// FulfillsOnlineOrders defaults to true, set it with graphql.NewBoolean.
+	FulfillsOnlineOrders *graphql.Boolean `json:"fulfillsOnlineOrders,omitempty"`
+}
+
+// LocationEditInput changes the fields it sets, Address changing the address fields it sets.
+type LocationEditInput struct {
+	Name    graphql.String        `json:"name,omitempty"`
+	Address *LocationAddressInput `json:"address,omitempty"`
+	// FulfillsOnlineOrders is set with graphql.NewBoolean.
+	FulfillsOnlineOrders *graphql.Boolean `json:"fulfillsOnlineOrders,omitempty"`
+}
+
+// LocationAddressInput is the address of a location. CountryCode is required to add a location.
+type LocationAddressInput struct {
+	Address1     graphql.String `json:"address1,omitempty"`
+	Address2     graphql.String `json:"address2,omitempty"`
+	City         graphql.String `json:"city,omitempty"`
+	CountryCode  CountryCode    `json:"countryCode,omitempty"`
+	ProvinceCode graphql.String `json:"provinceCode,omitempty"`
+	Zip          graphql.String `json:"zip,omitempty"`
+	Phone        graphql.String `json:"phone,omitempty"`
+}
+
+const locationQuery = `
+	id
+	legacyResourceId
+	name
+	address{
+		address1
+		address2
+		city
+		country
+		countryCode
+		province
+		provinceCode
+		zip
+		phone
+		formatted
+	}
+	fulfillsOnlineOrders
+	isActive
+	shipsInventory
+	isFulfillmentService
+	hasActiveInventory
+	activatable
+	deactivatable
+	deactivatedAt
+	createdAt
+	updatedAt
+`
+
+var (
+	locationAddMutation = fmt.Sprintf(`
+		mutation locationAdd($input: LocationAddInput!) {
+			locationAdd(input: $input){
+				location{
+					%s
+				}
+				userErrors{
+					field
+					message
+				}
+			}
+		}
+	`, locationQuery)
+
+	locationEditMutation = fmt.Sprintf(`
+		mutation locationEdit($id: ID!, $input: LocationEditInput!) {
+			locationEdit(id: $id, input: $input){
+				location{
+					%s
+				}
+				userErrors{
+					field
+					message
+				}
+			}
+		}
+	`, locationQuery)
+
+	locationActivateMutation = fmt.Sprintf(`
+		mutation locationActivate($locationId: ID!) {
+			locationActivate(locationId: $locationId){
+				location{
+					%s
+				}
+				userErrors: locationActivateUserErrors{
+					field
+					message
+				}
+			}
+		}
+	`, locationQuery)
+
+	locationDeactivateMutation = fmt.Sprintf(`
+		mutation locationDeactivate($locationId: ID!, $destinationLocationId: ID) {
+			locationDeactivate(locationId: $locationId, destinationLocationId: $destinationLocationId){
+				location{
+					%s
+				}
+				userErrors: locationDeactivateUserErrors{
+					field
+					message
+				}
+			}
+		}
+	`, locationQuery)
+)
+
 func (s *LocationServiceOp) Get(id graphql.ID) (*Location, error) {
-	q := `query location($id: ID!) {
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
+
+func (s *LocationServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (*Location, error) {
+	q := fmt.Sprintf(`query location($id: ID!) {
 		location(id: $id){
-			id
-			name
+			%s
 		}
-	}`
+	}`, locationQuery)
 
 	vars := map[string]interface{}{
 		"id": id,
@@ -34,10 +207,118 @@ func (s *LocationServiceOp) Get(id graphql.ID) (*Location, error) {
 	out := struct {
 		Location *Location `json:"location"`
 	}{}
-	err := s.client.gql.QueryString(context.Background(), q, vars, &out)
+	err := s.client.query(ctx, q, vars, &out)
 	if err != nil {
 		return nil, err
 	}
+	if out.Location == nil {
+		return nil, fmt.Errorf("location %v not found", id)
+	}
 
 	return out.Location, nil
 }
+
+func (s *LocationServiceOp) List(filter LocationFilter, opts ListOptions) (*Page[*Location], error) {
+	return s.ListWithContext(s.client.gql.Context(), filter, opts)
+}
+
+func (s *LocationServiceOp) ListWithContext(ctx context.Context, filter LocationFilter, opts ListOptions) (*Page[*Location], error) {
+	q := fmt.Sprintf(`
+		query locations($query: String, $includeInactive: Boolean, $includeLegacy: Boolean, $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+			locations(query: $query, includeInactive: $includeInactive, includeLegacy: $includeLegacy, first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
+				edges{
+					node{
+						%s
+					}
+				}
+				%s
+			}
+		}
+	`, locationQuery, pageInfoQuery)
+
+	if opts.First == 0 && opts.Last == 0 {
+		opts.First = defaultPageSize
+	}
+	vars := pageVars(opts)
+	vars["includeInactive"] = filter.IncludeInactive
+	vars["includeLegacy"] = filter.IncludeLegacy
+
+	out := struct {
+		Locations struct {
+			Edges []struct {
+				Node *Location `json:"node"`
+			} `json:"edges"`
+			PageInfo PageInfo `json:"pageInfo"`
+		} `json:"locations"`
+	}{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, vars, &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[*Location]{PageInfo: out.Locations.PageInfo}
+	for _, e := range out.Locations.Edges {
+		page.Nodes = append(page.Nodes, e.Node)
+	}
+
+	return page, nil
+}
+
+func (s *LocationServiceOp) Iter(filter LocationFilter, opts ListOptions) *Iterator[*Location] {
+	return s.IterWithContext(s.client.gql.Context(), filter, opts)
+}
+
+func (s *LocationServiceOp) IterWithContext(ctx context.Context, filter LocationFilter, opts ListOptions) *Iterator[*Location] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts ListOptions) (*Page[*Location], error) {
+		return s.ListWithContext(ctx, filter, opts)
+	})
+}
+
+func (s *LocationServiceOp) Add(input LocationAddInput) (*Location, error) {
+	return s.AddWithContext(s.client.gql.Context(), input)
+}
+
+func (s *LocationServiceOp) AddWithContext(ctx context.Context, input LocationAddInput) (*Location, error) {
+	return s.mutateLocation(ctx, locationAddMutation, map[string]interface{}{"input": input})
+}
+
+func (s *LocationServiceOp) Edit(id graphql.ID, input LocationEditInput) (*Location, error) {
+	return s.EditWithContext(s.client.gql.Context(), id, input)
+}
+
+func (s *LocationServiceOp) EditWithContext(ctx context.Context, id graphql.ID, input LocationEditInput) (*Location, error) {
+	return s.mutateLocation(ctx, locationEditMutation, map[string]interface{}{"id": id, "input": input})
+}
+
+func (s *LocationServiceOp) Activate(id graphql.ID) (*Location, error) {
+	return s.ActivateWithContext(s.client.gql.Context(), id)
+}
+
+func (s *LocationServiceOp) ActivateWithContext(ctx context.Context, id graphql.ID) (*Location, error) {
+	return s.mutateLocation(ctx, locationActivateMutation, map[string]interface{}{"locationId": id})
+}
+
+func (s *LocationServiceOp) Deactivate(id graphql.ID, destinationID graphql.ID) (*Location, error) {
+	return s.DeactivateWithContext(s.client.gql.Context(), id, destinationID)
+}
+
+func (s *LocationServiceOp) DeactivateWithContext(ctx context.Context, id graphql.ID, destinationID graphql.ID) (*Location, error) {
+	vars := map[string]interface{}{"locationId": id}
+	if destinationID != nil && destinationID != "" {
+		vars["destinationLocationId"] = destinationID
+	}
+	return s.mutateLocation(ctx, locationDeactivateMutation, vars)
+}
+
+func (s *LocationServiceOp) mutateLocation(ctx context.Context, mutation string, vars map[string]interface{}) (*Location, error) {
+	payload := struct {
+		Location *Location `json:"location"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, mutation, vars, &payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload.Location, nil
+}
@@ -0,0 +1,136 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestLocations(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	srv.AddLocation(&shopifytest.Location{Name: "Fulfillment app", Legacy: true})
+	warehouse, err := client.Location.AddWithContext(ctx, shopify.LocationAddInput{
+		Name: "Warehouse",
+		Address: shopify.LocationAddressInput{
+			Address1:     "1 Main St",
+			City:         "Ottawa",
+			ProvinceCode: "ON",
+			CountryCode:  "CA",
+			Zip:          "K1A 0B1",
+		},
+	})
+	if err != nil {
+		t.Fatalf("add location: %v", err)
+	}
+	if !warehouse.IsActive || !warehouse.FulfillsOnlineOrders || warehouse.Address.Country != "Canada" ||
+		len(warehouse.Address.Formatted) != 3 || warehouse.HasActiveInventory {
+		t.Errorf("unexpected location: %+v", warehouse)
+	}
+	store, err := client.Location.AddWithContext(ctx, shopify.LocationAddInput{
+		Name:                 "Store",
+		Address:              shopify.LocationAddressInput{CountryCode: "US"},
+		FulfillsOnlineOrders: graphql.NewBoolean(false),
+	})
+	if err != nil {
+		t.Fatalf("add location: %v", err)
+	}
+	if store.FulfillsOnlineOrders {
+		t.Errorf("expected the store not to fulfill online orders")
+	}
+	_, err = client.Location.AddWithContext(ctx, shopify.LocationAddInput{Name: "store", Address: shopify.LocationAddressInput{CountryCode: "US"}})
+	if !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors for a duplicate name, got %v", err)
+	}
+
+	locations, err := client.Location.IterWithContext(ctx, shopify.LocationFilter{}, shopify.ListOptions{First: 1}).All()
+	if err != nil {
+		t.Fatalf("list locations: %v", err)
+	}
+	if len(locations) != 2 {
+		t.Errorf("expected 2 locations, got %d", len(locations))
+	}
+	page, err := client.Location.ListWithContext(ctx, shopify.LocationFilter{IncludeLegacy: true}, shopify.ListOptions{})
+	if err != nil {
+		t.Fatalf("list locations: %v", err)
+	}
+	if len(page.Nodes) != 3 || !page.Nodes[0].IsFulfillmentService {
+		t.Errorf("expected 3 locations including the legacy one, got %+v", page.Nodes)
+	}
+	page, err = client.Location.ListWithContext(ctx, shopify.LocationFilter{}, shopify.ListOptions{Query: "country:US"})
+	if err != nil {
+		t.Fatalf("search locations: %v", err)
+	}
+	if len(page.Nodes) != 1 || page.Nodes[0].ID != store.ID {
+		t.Errorf("expected to find the store, got %+v", page.Nodes)
+	}
+
+	if _, err = client.Location.DeactivateWithContext(ctx, warehouse.ID, nil); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors deactivating the only location fulfilling online orders, got %v", err)
+	}
+	store, err = client.Location.EditWithContext(ctx, store.ID, shopify.LocationEditInput{
+		Address:              &shopify.LocationAddressInput{City: "Portland"},
+		FulfillsOnlineOrders: graphql.NewBoolean(true),
+	})
+	if err != nil {
+		t.Fatalf("edit location: %v", err)
+	}
+	if store.Address.City != "Portland" || store.Address.CountryCode != "US" || !store.FulfillsOnlineOrders {
+		t.Errorf("unexpected edited location: %+v", store)
+	}
+
+	p := srv.AddProduct(&shopifytest.Product{
+		Title: "Snowboard",
+		Variants: []*shopifytest.Variant{{
+			InventoryLevels: []*shopifytest.InventoryLevel{{
+				LocationID: warehouse.ID.(string),
+				Quantities: map[string]int{"available": 7},
+			}},
+		}},
+	})
+	if _, err = client.Location.DeactivateWithContext(ctx, warehouse.ID, nil); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors deactivating a location with inventory, got %v", err)
+	}
+	deactivated, err := client.Location.DeactivateWithContext(ctx, warehouse.ID, store.ID)
+	if err != nil {
+		t.Fatalf("deactivate location: %v", err)
+	}
+	if deactivated.IsActive || !deactivated.Activatable || deactivated.DeactivatedAt == "" {
+		t.Errorf("expected the location to be inactive, got %+v", deactivated)
+	}
+	level, err := client.Inventory.GetLevelWithContext(ctx, graphql.ID(p.Variants[0].InventoryItemID), store.ID, shopify.InventoryQuantityAvailable)
+	if err != nil {
+		t.Fatalf("get relocated level: %v", err)
+	}
+	if available, _ := level.Quantity(shopify.InventoryQuantityAvailable); available != 7 {
+		t.Errorf("expected 7 available at the store, got %d", available)
+	}
+
+	locations, err = client.Location.IterWithContext(ctx, shopify.LocationFilter{}, shopify.ListOptions{}).All()
+	if err != nil {
+		t.Fatalf("list locations: %v", err)
+	}
+	if len(locations) != 1 {
+		t.Errorf("expected 1 active location, got %d", len(locations))
+	}
+	if _, err = client.Location.ActivateWithContext(ctx, warehouse.ID); err != nil {
+		t.Fatalf("activate location: %v", err)
+	}
+	got, err := client.Location.GetWithContext(ctx, warehouse.ID)
+	if err != nil {
+		t.Fatalf("get location: %v", err)
+	}
+	if !got.IsActive || got.HasActiveInventory {
+		t.Errorf("expected the location to be active and empty, got %+v", got)
+	}
+	if _, err = client.Location.GetWithContext(ctx, "gid://shopify/Location/404"); err == nil {
+		t.Error("expected an error getting a missing location")
+	}
+}
@@ -6,18 +6,25 @@ import (
 	"strings"
 
 	"github.com/gempages/go-shopify-graphql/graphql"
-
-	log "github.com/sirupsen/logrus"
 )
 
 type MetafieldService interface {
 	ListAllShopMetafields() ([]*Metafield, error)
+	ListAllShopMetafieldsWithContext(ctx context.Context) ([]*Metafield, error)
 	ListShopMetafieldsByNamespace(namespace string) ([]*Metafield, error)
+	ListShopMetafieldsByNamespaceWithContext(ctx context.Context, namespace string) ([]*Metafield, error)
+	// Iter returns an iterator over the shop metafields in namespace, or in all namespaces when it's empty,
+	// fetching pages on demand.
+	Iter(namespace string, opts ListOptions) *Iterator[*Metafield]
+	IterWithContext(ctx context.Context, namespace string, opts ListOptions) *Iterator[*Metafield]
 
 	GetShopMetafieldByKey(namespace, key string) (Metafield, error)
+	GetShopMetafieldByKeyWithContext(ctx context.Context, namespace, key string) (Metafield, error)
 
 	Delete(metafield MetafieldDeleteInput) error
+	DeleteWithContext(ctx context.Context, metafield MetafieldDeleteInput) error
 	DeleteBulk(metafield []MetafieldDeleteInput) error
+	DeleteBulkWithContext(ctx context.Context, metafield []MetafieldDeleteInput) error
 }
 
 type MetafieldServiceOp struct {
@@ -61,7 +68,22 @@ type metafieldDeleteResult struct {
 	UserErrors []UserErrors `json:"userErrors"`
 }
 
+const metafieldDeleteBulkMutation = `
+mutation metafieldDelete($input: MetafieldDeleteInput!) {
+	metafieldDelete(input: $input) {
+		deletedId
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
 func (s *MetafieldServiceOp) ListAllShopMetafields() ([]*Metafield, error) {
+	return s.ListAllShopMetafieldsWithContext(s.client.gql.Context())
+}
+
+func (s *MetafieldServiceOp) ListAllShopMetafieldsWithContext(ctx context.Context) ([]*Metafield, error) {
 	q := `
 		{
 			shop{
@@ -86,7 +108,7 @@ func (s *MetafieldServiceOp) ListAllShopMetafields() ([]*Metafield, error) {
 `
 
 	res := []*Metafield{}
-	err := s.client.BulkOperation.BulkQuery(q, &res)
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
 	if err != nil {
 		return []*Metafield{}, err
 	}
@@ -95,6 +117,10 @@ func (s *MetafieldServiceOp) ListAllShopMetafields() ([]*Metafield, error) {
 }
 
 func (s *MetafieldServiceOp) ListShopMetafieldsByNamespace(namespace string) ([]*Metafield, error) {
+	return s.ListShopMetafieldsByNamespaceWithContext(s.client.gql.Context(), namespace)
+}
+
+func (s *MetafieldServiceOp) ListShopMetafieldsByNamespaceWithContext(ctx context.Context, namespace string) ([]*Metafield, error) {
 	q := `
 		{
 			shop{
@@ -120,7 +146,7 @@ func (s *MetafieldServiceOp) ListShopMetafieldsByNamespace(namespace string) ([]
 	q = strings.ReplaceAll(q, "$namespace", namespace)
 
 	res := []*Metafield{}
-	err := s.client.BulkOperation.BulkQuery(q, &res)
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
 	if err != nil {
 		return []*Metafield{}, err
 	}
@@ -128,7 +154,74 @@ func (s *MetafieldServiceOp) ListShopMetafieldsByNamespace(namespace string) ([]
 	return res, nil
 }
 
+func (s *MetafieldServiceOp) Iter(namespace string, opts ListOptions) *Iterator[*Metafield] {
+	return s.IterWithContext(s.client.gql.Context(), namespace, opts)
+}
+
+func (s *MetafieldServiceOp) IterWithContext(ctx context.Context, namespace string, opts ListOptions) *Iterator[*Metafield] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts ListOptions) (*Page[*Metafield], error) {
+		return s.listShopPage(ctx, namespace, opts)
+	})
+}
+
+func (s *MetafieldServiceOp) listShopPage(ctx context.Context, namespace string, opts ListOptions) (*Page[*Metafield], error) {
+	q := fmt.Sprintf(`
+		query metafields($namespace: String, $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+			shop{
+				metafields(namespace: $namespace, first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
+					edges{
+						node{
+							createdAt
+							description
+							id
+							key
+							legacyResourceId
+							namespace
+							ownerType
+							updatedAt
+							value
+							type
+						}
+					}
+					%s
+				}
+			}
+		}
+	`, pageInfoQuery)
+
+	vars := pageVars(opts)
+	if namespace != "" {
+		vars["namespace"] = namespace
+	}
+
+	out := struct {
+		Shop struct {
+			Metafields struct {
+				Edges []struct {
+					Metafield *Metafield `json:"node,omitempty"`
+				} `json:"edges,omitempty"`
+				PageInfo PageInfo `json:"pageInfo,omitempty"`
+			} `json:"metafields,omitempty"`
+		} `json:"shop,omitempty"`
+	}{}
+	err := s.client.query(ctx, q, vars, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[*Metafield]{PageInfo: out.Shop.Metafields.PageInfo}
+	for _, e := range out.Shop.Metafields.Edges {
+		page.Nodes = append(page.Nodes, e.Metafield)
+	}
+
+	return page, nil
+}
+
 func (s *MetafieldServiceOp) GetShopMetafieldByKey(namespace, key string) (Metafield, error) {
+	return s.GetShopMetafieldByKeyWithContext(s.client.gql.Context(), namespace, key)
+}
+
+func (s *MetafieldServiceOp) GetShopMetafieldByKeyWithContext(ctx context.Context, namespace, key string) (Metafield, error) {
 	var q struct {
 		Shop struct {
 			Metafield Metafield `graphql:"metafield(namespace: $namespace, key: $key)"`
@@ -139,7 +232,7 @@ func (s *MetafieldServiceOp) GetShopMetafieldByKey(namespace, key string) (Metaf
 		"key":       graphql.String(key),
 	}
 
-	err := s.client.gql.Query(context.Background(), &q, vars)
+	err := s.client.gql.Query(ctx, &q, vars)
 	if err != nil {
 		return Metafield{}, err
 	}
@@ -148,29 +241,46 @@ func (s *MetafieldServiceOp) GetShopMetafieldByKey(namespace, key string) (Metaf
 }
 
 func (s *MetafieldServiceOp) DeleteBulk(metafields []MetafieldDeleteInput) error {
-	for _, m := range metafields {
-		err := s.Delete(m)
-		if err != nil {
-			log.Warnf("Couldn't delete metafield (%v): %s", m, err)
+	return s.DeleteBulkWithContext(s.client.gql.Context(), metafields)
+}
+
+// DeleteBulkWithContext deletes metafields with a bulk mutation. When some metafields couldn't be deleted,
+// it returns a *BulkMutationError whose failures index metafields.
+func (s *MetafieldServiceOp) DeleteBulkWithContext(ctx context.Context, metafields []MetafieldDeleteInput) error {
+	vars := make([]map[string]interface{}, len(metafields))
+	for i, m := range metafields {
+		vars[i] = map[string]interface{}{
+			"input": m,
 		}
 	}
 
-	return nil
+	results, err := BulkMutate[mutationMetafieldDelete](ctx, s.client.BulkOperation, metafieldDeleteBulkMutation, vars)
+	if err != nil {
+		return err
+	}
+
+	return bulkMutationError(results, func(m *mutationMetafieldDelete) []UserErrors {
+		return m.MetafieldDeleteResult.UserErrors
+	})
 }
 
 func (s *MetafieldServiceOp) Delete(metafield MetafieldDeleteInput) error {
+	return s.DeleteWithContext(s.client.gql.Context(), metafield)
+}
+
+func (s *MetafieldServiceOp) DeleteWithContext(ctx context.Context, metafield MetafieldDeleteInput) error {
 	m := mutationMetafieldDelete{}
 
 	vars := map[string]interface{}{
 		"input": metafield,
 	}
-	err := s.client.gql.Mutate(context.Background(), &m, vars)
+	err := s.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.MetafieldDeleteResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.MetafieldDeleteResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.MetafieldDeleteResult.UserErrors}
 	}
 
 	return nil
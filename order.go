@@ -4,21 +4,52 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gempages/go-shopify-graphql/graphql"
 )
 
 type OrderService interface {
 	Get(id graphql.ID) (*OrderQueryResult, error)
+	GetWithContext(ctx context.Context, id graphql.ID) (*OrderQueryResult, error)
 
 	List(opts ListOptions) ([]*Order, error)
+	ListWithContext(ctx context.Context, opts ListOptions) ([]*Order, error)
 	ListAll() ([]*Order, error)
+	ListAllWithContext(ctx context.Context) ([]*Order, error)
 
 	ListAfterCursor(opts ListOptions) ([]*OrderQueryResult, string, string, error)
+	ListAfterCursorWithContext(ctx context.Context, opts ListOptions) ([]*OrderQueryResult, string, string, error)
+	// Iter returns an iterator over the orders matching opts.Query, fetching pages on demand.
+	Iter(opts ListOptions) *Iterator[*OrderQueryResult]
+	IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*OrderQueryResult]
 
 	Update(input OrderInput) error
+	UpdateWithContext(ctx context.Context, input OrderInput) error
 
 	GetFulfillmentOrdersAtLocation(orderID graphql.ID, locationID graphql.ID) ([]FulfillmentOrder, error)
+	GetFulfillmentOrdersAtLocationWithContext(ctx context.Context, orderID graphql.ID, locationID graphql.ID) ([]FulfillmentOrder, error)
+
+	// BeginEdit begins an editing session of the order id, staging changes until committed.
+	BeginEdit(id graphql.ID) (*OrderEdit, error)
+	BeginEditWithContext(ctx context.Context, id graphql.ID) (*OrderEdit, error)
+
+	// Transactions returns the transactions of the order id, with their IDs and parents.
+	Transactions(id graphql.ID) ([]OrderTransaction, error)
+	TransactionsWithContext(ctx context.Context, id graphql.ID) ([]OrderTransaction, error)
+	// Capture captures an amount of an authorized transaction and returns the capture transaction.
+	Capture(input OrderCaptureInput) (*OrderTransaction, error)
+	CaptureWithContext(ctx context.Context, input OrderCaptureInput) (*OrderTransaction, error)
+	// MarkAsPaid records the outstanding amount of the order id as paid by a manual transaction.
+	MarkAsPaid(id graphql.ID) (*OrderBase, error)
+	MarkAsPaidWithContext(ctx context.Context, id graphql.ID) (*OrderBase, error)
+
+	// SuggestRefund calculates the refund of the line items and shipping selected by input,
+	// and the transactions to refund it with, without creating it.
+	SuggestRefund(orderID graphql.ID, input SuggestedRefundInput) (*SuggestedRefund, error)
+	SuggestRefundWithContext(ctx context.Context, orderID graphql.ID, input SuggestedRefundInput) (*SuggestedRefund, error)
+	CreateRefund(input RefundInput) (*Refund, error)
+	CreateRefundWithContext(ctx context.Context, input RefundInput) (*Refund, error)
 }
 
 type OrderServiceOp struct {
@@ -120,13 +151,100 @@ type LineItemVariant struct {
 }
 
 type FulfillmentOrder struct {
-	ID                        graphql.ID                 `json:"id,omitempty"`
-	Status                    FulfillmentOrderStatus     `json:"status,omitempty"`
-	FulfillmentOrderLineItems []FulfillmentOrderLineItem `json:"lineItems,omitempty"`
+	ID            graphql.ID                    `json:"id,omitempty"`
+	OrderID       graphql.ID                    `json:"orderId,omitempty"`
+	Status        FulfillmentOrderStatus        `json:"status,omitempty"`
+	RequestStatus FulfillmentOrderRequestStatus `json:"requestStatus,omitempty"`
+	// FulfillAt is when a scheduled fulfillment order becomes open.
+	FulfillAt                 *time.Time                        `json:"fulfillAt,omitempty"`
+	AssignedLocation          FulfillmentOrderAssignedLocation  `json:"assignedLocation,omitempty"`
+	FulfillmentHolds          []FulfillmentHold                 `json:"fulfillmentHolds,omitempty"`
+	SupportedActions          []FulfillmentOrderSupportedAction `json:"supportedActions,omitempty"`
+	FulfillmentOrderLineItems []FulfillmentOrderLineItem        `json:"lineItems,omitempty"`
+	CreatedAt                 time.Time                         `json:"createdAt,omitempty"`
+	UpdatedAt                 time.Time                         `json:"updatedAt,omitempty"`
+}
+
+// Supports reports whether action can be taken on the fulfillment order, according to its
+// SupportedActions.
+func (fo *FulfillmentOrder) Supports(action FulfillmentOrderAction) bool {
+	for _, a := range fo.SupportedActions {
+		if a.Action == action {
+			return true
+		}
+	}
+	return false
 }
 
 type FulfillmentOrderStatus string
 
+const (
+	FulfillmentOrderStatusOpen       FulfillmentOrderStatus = "OPEN"
+	FulfillmentOrderStatusInProgress FulfillmentOrderStatus = "IN_PROGRESS"
+	FulfillmentOrderStatusScheduled  FulfillmentOrderStatus = "SCHEDULED"
+	FulfillmentOrderStatusOnHold     FulfillmentOrderStatus = "ON_HOLD"
+	FulfillmentOrderStatusIncomplete FulfillmentOrderStatus = "INCOMPLETE"
+	FulfillmentOrderStatusClosed     FulfillmentOrderStatus = "CLOSED"
+	FulfillmentOrderStatusCancelled  FulfillmentOrderStatus = "CANCELLED"
+)
+
+// FulfillmentOrderRequestStatus is the status of the requests made to the fulfillment service
+// assigned a fulfillment order.
+type FulfillmentOrderRequestStatus string
+
+const (
+	FulfillmentOrderRequestStatusUnsubmitted           FulfillmentOrderRequestStatus = "UNSUBMITTED"
+	FulfillmentOrderRequestStatusSubmitted             FulfillmentOrderRequestStatus = "SUBMITTED"
+	FulfillmentOrderRequestStatusAccepted              FulfillmentOrderRequestStatus = "ACCEPTED"
+	FulfillmentOrderRequestStatusRejected              FulfillmentOrderRequestStatus = "REJECTED"
+	FulfillmentOrderRequestStatusCancellationRequested FulfillmentOrderRequestStatus = "CANCELLATION_REQUESTED"
+	FulfillmentOrderRequestStatusCancellationAccepted  FulfillmentOrderRequestStatus = "CANCELLATION_ACCEPTED"
+	FulfillmentOrderRequestStatusCancellationRejected  FulfillmentOrderRequestStatus = "CANCELLATION_REJECTED"
+	FulfillmentOrderRequestStatusClosed                FulfillmentOrderRequestStatus = "CLOSED"
+)
+
+type FulfillmentOrderAction string
+
+const (
+	FulfillmentOrderActionCreateFulfillment      FulfillmentOrderAction = "CREATE_FULFILLMENT"
+	FulfillmentOrderActionRequestFulfillment     FulfillmentOrderAction = "REQUEST_FULFILLMENT"
+	FulfillmentOrderActionCancelFulfillmentOrder FulfillmentOrderAction = "CANCEL_FULFILLMENT_ORDER"
+	FulfillmentOrderActionRequestCancellation    FulfillmentOrderAction = "REQUEST_CANCELLATION"
+	FulfillmentOrderActionMove                   FulfillmentOrderAction = "MOVE"
+	FulfillmentOrderActionHold                   FulfillmentOrderAction = "HOLD"
+	FulfillmentOrderActionReleaseHold            FulfillmentOrderAction = "RELEASE_HOLD"
+	FulfillmentOrderActionMarkAsOpen             FulfillmentOrderAction = "MARK_AS_OPEN"
+	FulfillmentOrderActionExternal               FulfillmentOrderAction = "EXTERNAL"
+)
+
+type FulfillmentOrderSupportedAction struct {
+	Action FulfillmentOrderAction `json:"action,omitempty"`
+}
+
+// FulfillmentOrderAssignedLocation is the location a fulfillment order is assigned to. Location is
+// nil when the location was deleted, Name being kept.
+type FulfillmentOrderAssignedLocation struct {
+	Name     graphql.String `json:"name,omitempty"`
+	Location *Location      `json:"location,omitempty"`
+}
+
+type FulfillmentHold struct {
+	Reason      FulfillmentHoldReason `json:"reason,omitempty"`
+	ReasonNotes graphql.String        `json:"reasonNotes,omitempty"`
+}
+
+type FulfillmentHoldReason string
+
+const (
+	FulfillmentHoldReasonAwaitingPayment     FulfillmentHoldReason = "AWAITING_PAYMENT"
+	FulfillmentHoldReasonHighRiskOfFraud     FulfillmentHoldReason = "HIGH_RISK_OF_FRAUD"
+	FulfillmentHoldReasonIncorrectAddress    FulfillmentHoldReason = "INCORRECT_ADDRESS"
+	FulfillmentHoldReasonInventoryOutOfStock FulfillmentHoldReason = "INVENTORY_OUT_OF_STOCK"
+	FulfillmentHoldReasonUnknownDeliveryDate FulfillmentHoldReason = "UNKNOWN_DELIVERY_DATE"
+	FulfillmentHoldReasonAwaitingReturnItems FulfillmentHoldReason = "AWAITING_RETURN_ITEMS"
+	FulfillmentHoldReasonOther               FulfillmentHoldReason = "OTHER"
+)
+
 type FulfillmentOrderLineItem struct {
 	ID                graphql.ID  `json:"id,omitempty"`
 	RemainingQuantity graphql.Int `json:"remainingQuantity"`
@@ -136,14 +254,39 @@ type FulfillmentOrderLineItem struct {
 
 type OrderTransactionStatus string
 
+const (
+	OrderTransactionStatusSuccess          OrderTransactionStatus = "SUCCESS"
+	OrderTransactionStatusPending          OrderTransactionStatus = "PENDING"
+	OrderTransactionStatusFailure          OrderTransactionStatus = "FAILURE"
+	OrderTransactionStatusError            OrderTransactionStatus = "ERROR"
+	OrderTransactionStatusAwaitingResponse OrderTransactionStatus = "AWAITING_RESPONSE"
+	OrderTransactionStatusUnknown          OrderTransactionStatus = "UNKNOWN"
+)
+
 type OrderTransactionKind string
 
+const (
+	OrderTransactionKindSale             OrderTransactionKind = "SALE"
+	OrderTransactionKindAuthorization    OrderTransactionKind = "AUTHORIZATION"
+	OrderTransactionKindCapture          OrderTransactionKind = "CAPTURE"
+	OrderTransactionKindRefund           OrderTransactionKind = "REFUND"
+	OrderTransactionKindVoid             OrderTransactionKind = "VOID"
+	OrderTransactionKindChange           OrderTransactionKind = "CHANGE"
+	OrderTransactionKindEMVAuthorization OrderTransactionKind = "EMV_AUTHORIZATION"
+	OrderTransactionKindSuggestedRefund  OrderTransactionKind = "SUGGESTED_REFUND"
+)
+
 type OrderTransaction struct {
+	ID          graphql.ID             `json:"id,omitempty"`
 	ProcessedAt DateTime               `json:"processedAt,omitempty"`
 	Status      OrderTransactionStatus `json:"status,omitempty"`
 	Kind        OrderTransactionKind   `json:"kind,omitempty"`
+	Gateway     graphql.String         `json:"gateway,omitempty"`
 	Test        graphql.Boolean        `json:"test,omitempty"`
 	AmountSet   *MoneyBag              `json:"amountSet,omitempty"`
+	// ParentTransaction is the authorization of a capture, or the transaction refunded by a
+	// refund. Only its ID is queried.
+	ParentTransaction *OrderTransaction `json:"parentTransaction,omitempty"`
 }
 
 type mutationOrderUpdate struct {
@@ -345,6 +488,10 @@ fragment lineItem on LineItem {
 `
 
 func (s *OrderServiceOp) Get(id graphql.ID) (*OrderQueryResult, error) {
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
+
+func (s *OrderServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (*OrderQueryResult, error) {
 	q := fmt.Sprintf(`
 		query order($id: ID!) {
 			node(id: $id){
@@ -391,7 +538,7 @@ func (s *OrderServiceOp) Get(id graphql.ID) (*OrderQueryResult, error) {
 	out := struct {
 		Order *OrderQueryResult `json:"node"`
 	}{}
-	err := s.client.gql.QueryString(context.Background(), q, vars, &out)
+	err := s.client.gql.QueryString(ctx, q, vars, &out)
 	if err != nil {
 		return nil, err
 	}
@@ -400,6 +547,10 @@ func (s *OrderServiceOp) Get(id graphql.ID) (*OrderQueryResult, error) {
 }
 
 func (s *OrderServiceOp) List(opts ListOptions) ([]*Order, error) {
+	return s.ListWithContext(s.client.gql.Context(), opts)
+}
+
+func (s *OrderServiceOp) ListWithContext(ctx context.Context, opts ListOptions) ([]*Order, error) {
 	q := fmt.Sprintf(`
 		{
 			orders(query: "$query"){
@@ -424,7 +575,7 @@ func (s *OrderServiceOp) List(opts ListOptions) ([]*Order, error) {
 	q = strings.ReplaceAll(q, "$query", opts.Query)
 
 	res := []*Order{}
-	err := s.client.BulkOperation.BulkQuery(q, &res)
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -433,9 +584,13 @@ func (s *OrderServiceOp) List(opts ListOptions) ([]*Order, error) {
 }
 
 func (s *OrderServiceOp) ListAll() ([]*Order, error) {
+	return s.ListAllWithContext(s.client.gql.Context())
+}
+
+func (s *OrderServiceOp) ListAllWithContext(ctx context.Context) ([]*Order, error) {
 	q := fmt.Sprintf(`
 		{
-			orders(query: "$query"){
+			orders{
 				edges{
 					node{
 						%s
@@ -455,7 +610,7 @@ func (s *OrderServiceOp) ListAll() ([]*Order, error) {
 	`, orderBaseQuery, lineItemFragment)
 
 	res := []*Order{}
-	err := s.client.BulkOperation.BulkQuery(q, &res)
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -464,6 +619,10 @@ func (s *OrderServiceOp) ListAll() ([]*Order, error) {
 }
 
 func (s *OrderServiceOp) ListAfterCursor(opts ListOptions) ([]*OrderQueryResult, string, string, error) {
+	return s.ListAfterCursorWithContext(s.client.gql.Context(), opts)
+}
+
+func (s *OrderServiceOp) ListAfterCursorWithContext(ctx context.Context, opts ListOptions) ([]*OrderQueryResult, string, string, error) {
 	q := fmt.Sprintf(`
 		query orders($query: String, $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
 			orders(query: $query, first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
@@ -518,7 +677,7 @@ func (s *OrderServiceOp) ListAfterCursor(opts ListOptions) ([]*OrderQueryResult,
 			} `json:"pageInfo,omitempty"`
 		} `json:"orders,omitempty"`
 	}{}
-	err := s.client.gql.QueryString(context.Background(), q, vars, &out)
+	err := s.client.gql.QueryString(ctx, q, vars, &out)
 	if err != nil {
 		return nil, "", "", err
 	}
@@ -537,25 +696,86 @@ func (s *OrderServiceOp) ListAfterCursor(opts ListOptions) ([]*OrderQueryResult,
 	return res, firstCursor, lastCursor, nil
 }
 
+func (s *OrderServiceOp) Iter(opts ListOptions) *Iterator[*OrderQueryResult] {
+	return s.IterWithContext(s.client.gql.Context(), opts)
+}
+
+func (s *OrderServiceOp) IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*OrderQueryResult] {
+	return Paginate(ctx, opts, s.listPage)
+}
+
+func (s *OrderServiceOp) listPage(ctx context.Context, opts ListOptions) (*Page[*OrderQueryResult], error) {
+	q := fmt.Sprintf(`
+		query orders($query: String, $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+			orders(query: $query, first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
+				edges{
+					node{
+						%s
+
+						lineItems(first:25){
+							edges{
+								node{
+									...lineItem
+								}
+							}
+						}
+					}
+				}
+				%s
+			}
+		}
+
+		%s
+	`, orderLightQuery, pageInfoQuery, lineItemFragmentLight)
+
+	out := struct {
+		Orders struct {
+			Edges []struct {
+				OrderQueryResult *OrderQueryResult `json:"node,omitempty"`
+			} `json:"edges,omitempty"`
+			PageInfo PageInfo `json:"pageInfo,omitempty"`
+		} `json:"orders,omitempty"`
+	}{}
+	err := s.client.query(ctx, q, pageVars(opts), &out)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[*OrderQueryResult]{PageInfo: out.Orders.PageInfo}
+	for _, o := range out.Orders.Edges {
+		page.Nodes = append(page.Nodes, o.OrderQueryResult)
+	}
+
+	return page, nil
+}
+
 func (s *OrderServiceOp) Update(input OrderInput) error {
+	return s.UpdateWithContext(s.client.gql.Context(), input)
+}
+
+func (s *OrderServiceOp) UpdateWithContext(ctx context.Context, input OrderInput) error {
 	m := mutationOrderUpdate{}
 
 	vars := map[string]interface{}{
 		"input": input,
 	}
-	err := s.client.gql.Mutate(context.Background(), &m, vars)
+	err := s.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.OrderUpdateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.OrderUpdateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.OrderUpdateResult.UserErrors}
 	}
 
 	return nil
 }
 
 func (s *OrderServiceOp) GetFulfillmentOrdersAtLocation(orderID graphql.ID, locationID graphql.ID) ([]FulfillmentOrder, error) {
+	return s.GetFulfillmentOrdersAtLocationWithContext(s.client.gql.Context(), orderID, locationID)
+}
+
+func (s *OrderServiceOp) GetFulfillmentOrdersAtLocationWithContext(ctx context.Context, orderID graphql.ID, locationID graphql.ID) ([]FulfillmentOrder, error) {
 	q := `
 	{
 		order(id:"$id"){
@@ -584,7 +804,7 @@ func (s *OrderServiceOp) GetFulfillmentOrdersAtLocation(orderID graphql.ID, loca
 	q = strings.ReplaceAll(q, "$id", orderID.(string))
 	q = strings.ReplaceAll(q, "$query", fmt.Sprintf(`assigned_location_id:%s`, locationID.(string)))
 	res := []FulfillmentOrder{}
-	err := s.client.BulkOperation.BulkQuery(q, &res)
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
 	if err != nil {
 		return []FulfillmentOrder{}, err
 	}
@@ -0,0 +1,452 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+)
+
+// OrderEdit is an order editing session, begun by OrderService.BeginEdit. Its changes are staged on
+// CalculatedOrder, which shows the order as it will be, and are applied to the order by Commit.
+// An OrderEdit is not safe for concurrent use.
+type OrderEdit struct {
+	client *Client
+
+	// CalculatedOrder is the order with the changes staged so far, updated by every change.
+	CalculatedOrder *CalculatedOrder
+	// StagedChanges are the changes staged in the session, in the order they were made.
+	StagedChanges []OrderEditChange
+	committed     bool
+}
+
+// CalculatedOrder is an order with the changes staged by an order editing session.
+type CalculatedOrder struct {
+	ID                        graphql.ID           `json:"id,omitempty"`
+	OriginalOrder             OrderBase            `json:"originalOrder,omitempty"`
+	LineItems                 []CalculatedLineItem `json:"lineItems,omitempty"`
+	SubtotalLineItemsQuantity graphql.Int          `json:"subtotalLineItemsQuantity,omitempty"`
+	SubtotalPriceSet          MoneyBag             `json:"subtotalPriceSet,omitempty"`
+	TotalPriceSet             MoneyBag             `json:"totalPriceSet,omitempty"`
+	// TotalOutstandingSet is the amount the customer owes once the changes are committed, negative
+	// when the customer is owed a refund.
+	TotalOutstandingSet MoneyBag        `json:"totalOutstandingSet,omitempty"`
+	Committed           graphql.Boolean `json:"committed,omitempty"`
+}
+
+// LineItem returns the calculated line item id, or nil.
+func (o *CalculatedOrder) LineItem(id graphql.ID) *CalculatedLineItem {
+	for i := range o.LineItems {
+		if o.LineItems[i].ID == id {
+			return &o.LineItems[i]
+		}
+	}
+	return nil
+}
+
+// CalculatedLineItem is a line item of a calculated order. Its ID differs from the ID of the line
+// item of the order it stands for.
+type CalculatedLineItem struct {
+	ID           graphql.ID      `json:"id,omitempty"`
+	Title        graphql.String  `json:"title,omitempty"`
+	SKU          graphql.String  `json:"sku,omitempty"`
+	VariantTitle graphql.String  `json:"variantTitle,omitempty"`
+	Variant      LineItemVariant `json:"variant,omitempty"`
+	Quantity     graphql.Int     `json:"quantity,omitempty"`
+	// EditableQuantity is the quantity that can still be changed, excluding fulfilled items.
+	EditableQuantity          graphql.Int     `json:"editableQuantity,omitempty"`
+	Restockable               graphql.Boolean `json:"restockable,omitempty"`
+	Restocking                graphql.Boolean `json:"restocking,omitempty"`
+	HasStagedLineItemDiscount graphql.Boolean `json:"hasStagedLineItemDiscount,omitempty"`
+	OriginalUnitPriceSet      MoneyBag        `json:"originalUnitPriceSet,omitempty"`
+	DiscountedUnitPriceSet    MoneyBag        `json:"discountedUnitPriceSet,omitempty"`
+	EditableSubtotalSet       MoneyBag        `json:"editableSubtotalSet,omitempty"`
+}
+
+// OrderEditChange is a change staged by an order editing session. Only the fields of its Kind are set.
+type OrderEditChange struct {
+	Kind OrderEditChangeKind
+	// LineItemID is the calculated line item the change added or applies to.
+	LineItemID graphql.ID
+	VariantID  graphql.ID
+	Title      string
+	Quantity   int
+	Restock    bool
+	Discount   *OrderEditAppliedDiscountInput
+}
+
+type OrderEditChangeKind string
+
+const (
+	OrderEditChangeAddVariant          OrderEditChangeKind = "ADD_VARIANT"
+	OrderEditChangeAddCustomItem       OrderEditChangeKind = "ADD_CUSTOM_ITEM"
+	OrderEditChangeSetQuantity         OrderEditChangeKind = "SET_QUANTITY"
+	OrderEditChangeAddLineItemDiscount OrderEditChangeKind = "ADD_LINE_ITEM_DISCOUNT"
+)
+
+type OrderEditAddVariantInput struct {
+	VariantID graphql.ID
+	Quantity  int
+	// LocationID is the location to fulfill the variant from, Shopify picking one when nil.
+	LocationID graphql.ID
+	// AllowDuplicates adds the variant even though the order already has it.
+	AllowDuplicates bool
+}
+
+type OrderEditAddCustomItemInput struct {
+	Title            string
+	Price            MoneyInput
+	Quantity         int
+	RequiresShipping bool
+	Taxable          bool
+	LocationID       graphql.ID
+}
+
+// OrderEditAppliedDiscountInput is a discount of each unit of a line item, of either a fixed amount
+// or a percentage.
+type OrderEditAppliedDiscountInput struct {
+	Description  graphql.String `json:"description,omitempty"`
+	FixedValue   *MoneyInput    `json:"fixedValue,omitempty"`
+	PercentValue graphql.Float  `json:"percentValue,omitempty"`
+}
+
+type OrderEditCommitInput struct {
+	NotifyCustomer bool
+	StaffNote      string
+}
+
+const calculatedLineItemQuery = `
+	id
+	title
+	sku
+	variantTitle
+	variant{
+		id
+		legacyResourceId
+	}
+	quantity
+	editableQuantity
+	restockable
+	restocking
+	hasStagedLineItemDiscount
+	originalUnitPriceSet{
+		presentmentMoney{
+			amount
+			currencyCode
+		}
+		shopMoney{
+			amount
+			currencyCode
+		}
+	}
+	discountedUnitPriceSet{
+		presentmentMoney{
+			amount
+			currencyCode
+		}
+		shopMoney{
+			amount
+			currencyCode
+		}
+	}
+	editableSubtotalSet{
+		presentmentMoney{
+			amount
+			currencyCode
+		}
+		shopMoney{
+			amount
+			currencyCode
+		}
+	}
+`
+
+var calculatedOrderQuery = fmt.Sprintf(`
+	id
+	originalOrder{
+		id
+		name
+	}
+	lineItems(first: 250){
+		edges{
+			node{
+				%s
+			}
+		}
+	}
+	subtotalLineItemsQuantity
+	subtotalPriceSet{
+		presentmentMoney{
+			amount
+			currencyCode
+		}
+		shopMoney{
+			amount
+			currencyCode
+		}
+	}
+	totalPriceSet{
+		presentmentMoney{
+			amount
+			currencyCode
+		}
+		shopMoney{
+			amount
+			currencyCode
+		}
+	}
+	totalOutstandingSet{
+		presentmentMoney{
+			amount
+			currencyCode
+		}
+		shopMoney{
+			amount
+			currencyCode
+		}
+	}
+	committed
+`, calculatedLineItemQuery)
+
+// calculatedOrderNode decodes a calculated order with its line items connection.
+type calculatedOrderNode struct {
+	CalculatedOrder
+	LineItems struct {
+		Edges []struct {
+			Node CalculatedLineItem `json:"node"`
+		} `json:"edges"`
+	} `json:"lineItems"`
+}
+
+func (n *calculatedOrderNode) calculatedOrder() *CalculatedOrder {
+	o := n.CalculatedOrder
+	for _, e := range n.LineItems.Edges {
+		o.LineItems = append(o.LineItems, e.Node)
+	}
+	return &o
+}
+
+var (
+	orderEditFields = fmt.Sprintf(`
+		calculatedLineItem{
+			%s
+		}
+		calculatedOrder{
+			%s
+		}`, calculatedLineItemQuery, calculatedOrderQuery)
+
+	orderEditBeginMutation = mutationQuery(
+		"orderEditBegin($id: ID!)",
+		"orderEditBegin(id: $id)",
+		fmt.Sprintf("calculatedOrder{%s}", calculatedOrderQuery))
+	orderEditAddVariantMutation = mutationQuery(
+		"orderEditAddVariant($id: ID!, $variantId: ID!, $quantity: Int!, $locationId: ID, $allowDuplicates: Boolean)",
+		"orderEditAddVariant(id: $id, variantId: $variantId, quantity: $quantity, locationId: $locationId, allowDuplicates: $allowDuplicates)",
+		orderEditFields)
+	orderEditAddCustomItemMutation = mutationQuery(
+		"orderEditAddCustomItem($id: ID!, $title: String!, $price: MoneyInput!, $quantity: Int!, $requiresShipping: Boolean, $taxable: Boolean, $locationId: ID)",
+		"orderEditAddCustomItem(id: $id, title: $title, price: $price, quantity: $quantity, requiresShipping: $requiresShipping, taxable: $taxable, locationId: $locationId)",
+		orderEditFields)
+	orderEditSetQuantityMutation = mutationQuery(
+		"orderEditSetQuantity($id: ID!, $lineItemId: ID!, $quantity: Int!, $restock: Boolean)",
+		"orderEditSetQuantity(id: $id, lineItemId: $lineItemId, quantity: $quantity, restock: $restock)",
+		orderEditFields)
+	orderEditAddLineItemDiscountMutation = mutationQuery(
+		"orderEditAddLineItemDiscount($id: ID!, $lineItemId: ID!, $discount: OrderEditAppliedDiscountInput!)",
+		"orderEditAddLineItemDiscount(id: $id, lineItemId: $lineItemId, discount: $discount)",
+		orderEditFields)
+
+	orderEditCommitMutation = mutationQuery(
+		"orderEditCommit($id: ID!, $notifyCustomer: Boolean, $staffNote: String)",
+		"orderEditCommit(id: $id, notifyCustomer: $notifyCustomer, staffNote: $staffNote)",
+		fmt.Sprintf(`order{
+			%s
+			lineItems(first:50){
+				edges{
+					node{
+						...lineItem
+					}
+				}
+			}
+		}`, orderBaseQuery)) + lineItemFragment
+)
+
+// BeginEdit begins an editing session of the order id.
+func (s *OrderServiceOp) BeginEdit(id graphql.ID) (*OrderEdit, error) {
+	return s.BeginEditWithContext(s.client.gql.Context(), id)
+}
+
+func (s *OrderServiceOp) BeginEditWithContext(ctx context.Context, id graphql.ID) (*OrderEdit, error) {
+	payload := struct {
+		CalculatedOrder *calculatedOrderNode `json:"calculatedOrder"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, orderEditBeginMutation, map[string]interface{}{"id": id}, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.CalculatedOrder == nil {
+		return nil, fmt.Errorf("empty response to mutation")
+	}
+
+	return &OrderEdit{client: s.client, CalculatedOrder: payload.CalculatedOrder.calculatedOrder()}, nil
+}
+
+// AddVariant stages the addition of a line item of a variant and returns it.
+func (e *OrderEdit) AddVariant(input OrderEditAddVariantInput) (*CalculatedLineItem, error) {
+	return e.AddVariantWithContext(e.client.gql.Context(), input)
+}
+
+func (e *OrderEdit) AddVariantWithContext(ctx context.Context, input OrderEditAddVariantInput) (*CalculatedLineItem, error) {
+	vars := map[string]interface{}{
+		"variantId":       input.VariantID,
+		"quantity":        input.Quantity,
+		"allowDuplicates": input.AllowDuplicates,
+	}
+	if input.LocationID != nil && input.LocationID != "" {
+		vars["locationId"] = input.LocationID
+	}
+	li, err := e.mutate(ctx, orderEditAddVariantMutation, vars)
+	if err != nil {
+		return nil, err
+	}
+	e.StagedChanges = append(e.StagedChanges, OrderEditChange{
+		Kind:       OrderEditChangeAddVariant,
+		LineItemID: li.ID,
+		VariantID:  input.VariantID,
+		Quantity:   input.Quantity,
+	})
+	return li, nil
+}
+
+// AddCustomItem stages the addition of a line item that isn't a product variant and returns it.
+func (e *OrderEdit) AddCustomItem(input OrderEditAddCustomItemInput) (*CalculatedLineItem, error) {
+	return e.AddCustomItemWithContext(e.client.gql.Context(), input)
+}
+
+func (e *OrderEdit) AddCustomItemWithContext(ctx context.Context, input OrderEditAddCustomItemInput) (*CalculatedLineItem, error) {
+	vars := map[string]interface{}{
+		"title":            input.Title,
+		"price":            input.Price,
+		"quantity":         input.Quantity,
+		"requiresShipping": input.RequiresShipping,
+		"taxable":          input.Taxable,
+	}
+	if input.LocationID != nil && input.LocationID != "" {
+		vars["locationId"] = input.LocationID
+	}
+	li, err := e.mutate(ctx, orderEditAddCustomItemMutation, vars)
+	if err != nil {
+		return nil, err
+	}
+	e.StagedChanges = append(e.StagedChanges, OrderEditChange{
+		Kind:       OrderEditChangeAddCustomItem,
+		LineItemID: li.ID,
+		Title:      input.Title,
+		Quantity:   input.Quantity,
+	})
+	return li, nil
+}
+
+// SetQuantity stages a change of the quantity of the calculated line item lineItemID, zero removing
+// it. Restock returns removed units to the inventory.
+func (e *OrderEdit) SetQuantity(lineItemID graphql.ID, quantity int, restock bool) (*CalculatedLineItem, error) {
+	return e.SetQuantityWithContext(e.client.gql.Context(), lineItemID, quantity, restock)
+}
+
+func (e *OrderEdit) SetQuantityWithContext(ctx context.Context, lineItemID graphql.ID, quantity int, restock bool) (*CalculatedLineItem, error) {
+	vars := map[string]interface{}{
+		"lineItemId": lineItemID,
+		"quantity":   quantity,
+		"restock":    restock,
+	}
+	li, err := e.mutate(ctx, orderEditSetQuantityMutation, vars)
+	if err != nil {
+		return nil, err
+	}
+	e.StagedChanges = append(e.StagedChanges, OrderEditChange{
+		Kind:       OrderEditChangeSetQuantity,
+		LineItemID: lineItemID,
+		Quantity:   quantity,
+		Restock:    restock,
+	})
+	return li, nil
+}
+
+// AddLineItemDiscount stages a discount of the calculated line item lineItemID, which must have
+// been added in the session.
+func (e *OrderEdit) AddLineItemDiscount(lineItemID graphql.ID, discount OrderEditAppliedDiscountInput) (*CalculatedLineItem, error) {
+	return e.AddLineItemDiscountWithContext(e.client.gql.Context(), lineItemID, discount)
+}
+
+func (e *OrderEdit) AddLineItemDiscountWithContext(ctx context.Context, lineItemID graphql.ID, discount OrderEditAppliedDiscountInput) (*CalculatedLineItem, error) {
+	vars := map[string]interface{}{
+		"lineItemId": lineItemID,
+		"discount":   discount,
+	}
+	li, err := e.mutate(ctx, orderEditAddLineItemDiscountMutation, vars)
+	if err != nil {
+		return nil, err
+	}
+	e.StagedChanges = append(e.StagedChanges, OrderEditChange{
+		Kind:       OrderEditChangeAddLineItemDiscount,
+		LineItemID: lineItemID,
+		Discount:   &discount,
+	})
+	return li, nil
+}
+
+// Commit applies the staged changes to the order and returns it. The session can't be used after
+// a successful commit.
+func (e *OrderEdit) Commit(input OrderEditCommitInput) (*OrderQueryResult, error) {
+	return e.CommitWithContext(e.client.gql.Context(), input)
+}
+
+func (e *OrderEdit) CommitWithContext(ctx context.Context, input OrderEditCommitInput) (*OrderQueryResult, error) {
+	if e.committed {
+		return nil, fmt.Errorf("order edit %v already committed", e.CalculatedOrder.ID)
+	}
+	vars := map[string]interface{}{
+		"id":             e.CalculatedOrder.ID,
+		"notifyCustomer": input.NotifyCustomer,
+	}
+	if input.StaffNote != "" {
+		vars["staffNote"] = input.StaffNote
+	}
+
+	payload := struct {
+		Order *OrderQueryResult `json:"order"`
+	}{}
+	err := e.client.mutate(ctx, retryThrottled, orderEditCommitMutation, vars, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	e.committed = true
+	e.CalculatedOrder.Committed = true
+	return payload.Order, nil
+}
+
+// mutate runs one of the order edit mutations on the calculated order, updating it, and returns
+// the calculated line item changed.
+func (e *OrderEdit) mutate(ctx context.Context, mutation string, vars map[string]interface{}) (*CalculatedLineItem, error) {
+	if e.committed {
+		return nil, fmt.Errorf("order edit %v already committed", e.CalculatedOrder.ID)
+	}
+	vars["id"] = e.CalculatedOrder.ID
+
+	payload := struct {
+		CalculatedLineItem *CalculatedLineItem  `json:"calculatedLineItem"`
+		CalculatedOrder    *calculatedOrderNode `json:"calculatedOrder"`
+	}{}
+	err := e.client.mutate(ctx, retryThrottled, mutation, vars, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.CalculatedOrder != nil {
+		e.CalculatedOrder = payload.CalculatedOrder.calculatedOrder()
+	}
+	if payload.CalculatedLineItem == nil {
+		return nil, fmt.Errorf("empty response to mutation")
+	}
+	return payload.CalculatedLineItem, nil
+}
@@ -0,0 +1,148 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	graphqlclient "github.com/gempages/go-shopify-graphql/graph"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestOrderEdit(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	p := srv.AddProduct(&shopifytest.Product{
+		Title:    "Snowboard",
+		Variants: []*shopifytest.Variant{{Title: "S", SKU: "SB-S", Price: "10.00"}, {Title: "M", SKU: "SB-M", Price: "12.00"}},
+	})
+	small, medium := p.Variants[0], p.Variants[1]
+	o := srv.AddOrder(&shopifytest.Order{
+		LineItems: []*shopifytest.LineItem{{ID: "gid://shopify/LineItem/1", Title: "Snowboard - S", VariantID: small.ID, Quantity: 2, Price: "10.00"}},
+		FulfillmentOrders: []*shopifytest.FulfillmentOrder{{
+			AssignedLocationID: "gid://shopify/Location/1",
+			LineItems:          []*shopifytest.FulfillmentOrderLineItem{{LineItemID: "gid://shopify/LineItem/1", TotalQuantity: 2, RemainingQuantity: 2}},
+		}},
+		Transactions: []*shopifytest.Transaction{{Kind: "SALE", Amount: "20.00"}},
+	})
+
+	edit, err := client.Order.BeginEditWithContext(ctx, o.ID)
+	if err != nil {
+		t.Fatalf("begin edit: %v", err)
+	}
+	if edit.CalculatedOrder.OriginalOrder.ID != o.ID || len(edit.CalculatedOrder.LineItems) != 1 {
+		t.Fatalf("unexpected calculated order: %+v", edit.CalculatedOrder)
+	}
+	existing := edit.CalculatedOrder.LineItems[0].ID
+
+	if _, err = edit.AddVariantWithContext(ctx, shopify.OrderEditAddVariantInput{VariantID: small.ID, Quantity: 1}); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors adding a variant already in the order, got %v", err)
+	}
+	added, err := edit.AddVariantWithContext(ctx, shopify.OrderEditAddVariantInput{VariantID: medium.ID, Quantity: 1})
+	if err != nil {
+		t.Fatalf("add variant: %v", err)
+	}
+	if added.SKU != "SB-M" || added.OriginalUnitPriceSet.ShopMoney.Amount != "12.00" {
+		t.Errorf("unexpected added line item: %+v", added)
+	}
+	custom, err := edit.AddCustomItemWithContext(ctx, shopify.OrderEditAddCustomItemInput{
+		Title:    "Gift wrapping",
+		Price:    shopify.MoneyInput{Amount: "5", CurrencyCode: "USD"},
+		Quantity: 1,
+	})
+	if err != nil {
+		t.Fatalf("add custom item: %v", err)
+	}
+	if _, err = edit.AddLineItemDiscountWithContext(ctx, existing, shopify.OrderEditAppliedDiscountInput{PercentValue: 10}); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors discounting a line item of the order, got %v", err)
+	}
+	added, err = edit.AddLineItemDiscountWithContext(ctx, added.ID, shopify.OrderEditAppliedDiscountInput{Description: "Loyalty", PercentValue: 25})
+	if err != nil {
+		t.Fatalf("add line item discount: %v", err)
+	}
+	if !added.HasStagedLineItemDiscount || added.DiscountedUnitPriceSet.ShopMoney.Amount != "9.00" {
+		t.Errorf("unexpected discounted line item: %+v", added)
+	}
+	if _, err = edit.SetQuantity(existing, 1, true); err != nil {
+		t.Fatalf("set quantity: %v", err)
+	}
+
+	calculated := edit.CalculatedOrder
+	if len(edit.StagedChanges) != 4 || len(calculated.LineItems) != 3 || calculated.SubtotalLineItemsQuantity != 3 ||
+		calculated.SubtotalPriceSet.ShopMoney.Amount != "24.00" || calculated.TotalOutstandingSet.ShopMoney.Amount != "4.00" {
+		t.Errorf("unexpected calculated order: %+v", calculated)
+	}
+	if li := calculated.LineItem(existing); li == nil || !li.Restocking || li.Quantity != 1 {
+		t.Errorf("expected the line item of the order to be restocking, got %+v", li)
+	}
+	if len(o.LineItems) != 1 || o.LineItems[0].Quantity != 2 {
+		t.Errorf("expected the order to be unchanged before committing, got %+v", o.LineItems)
+	}
+
+	order, err := edit.CommitWithContext(ctx, shopify.OrderEditCommitInput{NotifyCustomer: true, StaffNote: "Swapped a board"})
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if len(order.LineItems.Edges) != 3 || order.LineItems.Edges[0].LineItem.Quantity != 1 ||
+		order.LineItems.Edges[1].LineItem.DiscountedUnitPriceSet.ShopMoney.Amount != "9.00" || order.LineItems.Edges[2].LineItem.Title != "Gift wrapping" {
+		t.Errorf("unexpected committed order: %+v", order.LineItems)
+	}
+	fo := o.FulfillmentOrders[0]
+	if len(o.FulfillmentOrders) != 1 || len(fo.LineItems) != 3 || fo.LineItems[0].RemainingQuantity != 1 {
+		t.Errorf("expected the fulfillment order to follow the edit, got %+v", fo.LineItems)
+	}
+	if _, err = edit.SetQuantityWithContext(ctx, custom.ID, 2, false); err == nil {
+		t.Errorf("expected an error changing a committed edit")
+	}
+}
+
+func TestOrderEditEmptyLineItem(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(string(body), "orderEditBegin") {
+			_, _ = io.WriteString(w, `{"data":{"orderEditBegin":{"calculatedOrder":{"id":"gid://shopify/CalculatedOrder/1"},"userErrors":[]}}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"data":{"orderEditAddVariant":{"calculatedLineItem":null,"calculatedOrder":null,"userErrors":[]}}}`)
+	}))
+	defer ts.Close()
+	client := shopify.NewClientWithOpts("test-shop.myshopify.com", graphqlclient.WithEndpoint(ts.URL), graphqlclient.WithToken("token"))
+	ctx := context.Background()
+
+	edit, err := client.Order.BeginEditWithContext(ctx, "gid://shopify/Order/1")
+	if err != nil {
+		t.Fatalf("begin edit: %v", err)
+	}
+	li, err := edit.AddVariantWithContext(ctx, shopify.OrderEditAddVariantInput{VariantID: "gid://shopify/ProductVariant/1", Quantity: 1})
+	if err == nil || err.Error() != "empty response to mutation" {
+		t.Fatalf("expected an empty response error, got %v, %+v", err, li)
+	}
+	if len(edit.StagedChanges) != 0 {
+		t.Errorf("expected no staged change, got %+v", edit.StagedChanges)
+	}
+}
+
+func TestOrderEditBeginLostResponse(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	client.SetRetries(2)
+
+	o := srv.AddOrder(&shopifytest.Order{
+		LineItems: []*shopifytest.LineItem{{ID: "gid://shopify/LineItem/1", Title: "Snowboard", Quantity: 1, Price: "10.00"}},
+	})
+
+	srv.DropResponses(1)
+	if _, err := client.Order.BeginEdit(o.ID); err == nil {
+		t.Fatalf("expected the lost response to fail beginning the edit")
+	}
+}
@@ -0,0 +1,51 @@
+package shopify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+)
+
+func TestOrderGet(t *testing.T) {
+	c := newCassetteClient(t, "order_get")
+
+	order, err := c.Order.GetWithContext(context.Background(), graphql.ID("gid://shopify/Order/1001"))
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if order.Name != "#1001" {
+		t.Errorf("expected name (%v), got (%v)", "#1001", order.Name)
+	}
+	if len(order.LineItems.Edges) != 2 {
+		t.Fatalf("expected 2 line items, got %d", len(order.LineItems.Edges))
+	}
+	if order.LineItems.Edges[0].LineItem.SKU != "SNOW-S" {
+		t.Errorf("expected SKU (%v), got (%v)", "SNOW-S", order.LineItems.Edges[0].LineItem.SKU)
+	}
+	if len(order.FulfillmentOrders.Edges) != 1 {
+		t.Errorf("expected 1 fulfillment order, got %d", len(order.FulfillmentOrders.Edges))
+	}
+}
+
+func TestOrderListAfterCursor(t *testing.T) {
+	c := newCassetteClient(t, "order_list_after_cursor")
+
+	orders, first, last, err := c.Order.ListAfterCursorWithContext(context.Background(), ListOptions{
+		Query: "financial_status:paid",
+		First: 2,
+		After: "cursor-1000",
+	})
+	if err != nil {
+		t.Fatalf("list orders: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("expected 2 orders, got %d", len(orders))
+	}
+	if first != "cursor-1001" || last != "cursor-1002" {
+		t.Errorf("unexpected cursors (%v, %v)", first, last)
+	}
+	if orders[1].Name != "#1002" {
+		t.Errorf("expected name (%v), got (%v)", "#1002", orders[1].Name)
+	}
+}
@@ -0,0 +1,159 @@
+package shopify
+
+import (
+	"context"
+)
+
+// defaultPageSize is the number of nodes fetched per page when ListOptions sets neither First nor Last.
+const defaultPageSize = 50
+
+const pageInfoQuery = `
+	pageInfo{
+		hasNextPage
+		hasPreviousPage
+		startCursor
+		endCursor
+	}
+`
+
+// Page is a page of nodes of a connection.
+type Page[T any] struct {
+	Nodes    []T
+	PageInfo PageInfo
+}
+
+// PageQuery fetches the page of a connection selected by the First, Last, After and Before fields of opts.
+type PageQuery[T any] func(ctx context.Context, opts ListOptions) (*Page[T], error)
+
+// Iterator yields the nodes of a connection, fetching its pages on demand.
+//
+//	it := client.Product.IterWithContext(ctx, shopify.ListOptions{Query: "status:active"})
+//	for it.Next() {
+//		p := it.Node()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx      context.Context
+	query    PageQuery[T]
+	opts     ListOptions
+	backward bool
+
+	nodes []T
+	node  T
+	done  bool
+	err   error
+}
+
+// Paginate returns an Iterator over the nodes of the connection fetched by query, starting at the
+// page selected by opts. It pages backward when opts sets Last or Before, in which case nodes are
+// yielded from the end of the connection to its start.
+func Paginate[T any](ctx context.Context, opts ListOptions, query PageQuery[T]) *Iterator[T] {
+	it := &Iterator[T]{
+		ctx:      ctx,
+		query:    query,
+		opts:     opts,
+		backward: opts.Last > 0 || opts.Before != "",
+	}
+	if it.backward {
+		it.opts.First, it.opts.After = 0, ""
+		if it.opts.Last <= 0 {
+			it.opts.Last = defaultPageSize
+		}
+	} else {
+		it.opts.Last, it.opts.Before = 0, ""
+		if it.opts.First <= 0 {
+			it.opts.First = defaultPageSize
+		}
+	}
+	return it
+}
+
+// Next advances the iterator to the next node, which is then available through Node. It returns
+// false when there are no more nodes, or when fetching a page failed or the context was cancelled,
+// in which case Err returns the error.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	for len(it.nodes) == 0 {
+		if it.done || !it.fetch() {
+			return false
+		}
+	}
+
+	if it.backward {
+		it.node = it.nodes[len(it.nodes)-1]
+		it.nodes = it.nodes[:len(it.nodes)-1]
+	} else {
+		it.node = it.nodes[0]
+		it.nodes = it.nodes[1:]
+	}
+	return true
+}
+
+// Node returns the current node.
+func (it *Iterator[T]) Node() T {
+	return it.node
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All consumes the iterator and returns the remaining nodes.
+func (it *Iterator[T]) All() ([]T, error) {
+	var nodes []T
+	for it.Next() {
+		nodes = append(nodes, it.Node())
+	}
+	return nodes, it.Err()
+}
+
+func (it *Iterator[T]) fetch() bool {
+	page, err := it.query(it.ctx, it.opts)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.nodes = page.Nodes
+	if it.backward {
+		it.done = !bool(page.PageInfo.HasPreviousPage) || page.PageInfo.StartCursor == ""
+		it.opts.Before = string(page.PageInfo.StartCursor)
+	} else {
+		it.done = !bool(page.PageInfo.HasNextPage) || page.PageInfo.EndCursor == ""
+		it.opts.After = string(page.PageInfo.EndCursor)
+	}
+	return true
+}
+
+// pageVars returns the variables of a connection query for opts.
+func pageVars(opts ListOptions) map[string]interface{} {
+	vars := map[string]interface{}{
+		"reverse": opts.Reverse,
+	}
+	if opts.Query != "" {
+		vars["query"] = opts.Query
+	}
+	if opts.First > 0 {
+		vars["first"] = opts.First
+	}
+	if opts.Last > 0 {
+		vars["last"] = opts.Last
+	}
+	if opts.After != "" {
+		vars["after"] = opts.After
+	}
+	if opts.Before != "" {
+		vars["before"] = opts.Before
+	}
+	return vars
+}
@@ -0,0 +1,142 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+)
+
+// fakeConnection pages through nodes 1 to n, using the nodes as cursors.
+func fakeConnection(n int, calls *[]ListOptions) PageQuery[int] {
+	return func(ctx context.Context, opts ListOptions) (*Page[int], error) {
+		*calls = append(*calls, opts)
+		start, end := 1, n+1
+		if opts.After != "" {
+			start, _ = strconv.Atoi(opts.After)
+			start++
+		}
+		if opts.Before != "" {
+			end, _ = strconv.Atoi(opts.Before)
+		}
+		if opts.First > 0 && end-start > opts.First {
+			end = start + opts.First
+		}
+		if opts.Last > 0 && end-start > opts.Last {
+			start = end - opts.Last
+		}
+
+		page := &Page[int]{}
+		for i := start; i < end; i++ {
+			page.Nodes = append(page.Nodes, i)
+		}
+		page.PageInfo.HasNextPage = graphql.Boolean(end <= n)
+		page.PageInfo.HasPreviousPage = graphql.Boolean(start > 1)
+		if len(page.Nodes) > 0 {
+			page.PageInfo.StartCursor = graphql.String(strconv.Itoa(start))
+			page.PageInfo.EndCursor = graphql.String(strconv.Itoa(end - 1))
+		}
+		return page, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	testTable := []struct {
+		name     string
+		opts     ListOptions
+		expected []int
+		pages    int
+	}{
+		{
+			name:     "forward",
+			opts:     ListOptions{First: 2},
+			expected: []int{1, 2, 3, 4, 5},
+			pages:    3,
+		},
+		{
+			name:     "forward_after",
+			opts:     ListOptions{First: 2, After: "3"},
+			expected: []int{4, 5},
+			pages:    1,
+		},
+		{
+			name:     "backward",
+			opts:     ListOptions{Last: 2},
+			expected: []int{5, 4, 3, 2, 1},
+			pages:    3,
+		},
+		{
+			name:     "backward_before",
+			opts:     ListOptions{Before: "4"},
+			expected: []int{3, 2, 1},
+			pages:    1,
+		},
+	}
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []ListOptions
+			nodes, err := Paginate(context.Background(), tc.opts, fakeConnection(5, &calls)).All()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(nodes, tc.expected) {
+				t.Errorf("expected (%v), got (%v)", tc.expected, nodes)
+			}
+			if len(calls) != tc.pages {
+				t.Errorf("expected %d pages fetched, got %d", tc.pages, len(calls))
+			}
+		})
+	}
+}
+
+func TestPaginateFetchesOnDemand(t *testing.T) {
+	var calls []ListOptions
+	it := Paginate(context.Background(), ListOptions{First: 2}, fakeConnection(5, &calls))
+	if len(calls) != 0 {
+		t.Fatalf("expected no page fetched before Next")
+	}
+	it.Next()
+	it.Next()
+	if len(calls) != 1 {
+		t.Errorf("expected 1 page fetched, got %d", len(calls))
+	}
+	it.Next()
+	if len(calls) != 2 || calls[1].After != "2" {
+		t.Errorf("expected the second page to be fetched after cursor 2, got %+v", calls)
+	}
+}
+
+func TestPaginateStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls []ListOptions
+	it := Paginate(ctx, ListOptions{First: 2}, fakeConnection(5, &calls))
+	if !it.Next() {
+		t.Fatalf("expected a first node")
+	}
+	cancel()
+	if it.Next() {
+		t.Errorf("expected the iteration to stop")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", it.Err())
+	}
+}
+
+func TestPaginateError(t *testing.T) {
+	queryErr := errors.New("throttled")
+	it := Paginate(context.Background(), ListOptions{}, func(ctx context.Context, opts ListOptions) (*Page[int], error) {
+		if opts.First != defaultPageSize {
+			t.Errorf("expected the default page size, got %d", opts.First)
+		}
+		return nil, queryErr
+	})
+	if it.Next() {
+		t.Errorf("expected no node")
+	}
+	if !errors.Is(it.Err(), queryErr) {
+		t.Errorf("expected (%v), got (%v)", queryErr, it.Err())
+	}
+}
@@ -8,29 +8,45 @@ import (
 
 	"github.com/gempages/go-shopify-graphql/graphql"
 	"github.com/gempages/go-shopify-graphql/utils"
-
-	log "github.com/sirupsen/logrus"
 )
 
 type ProductService interface {
 	List(query string) ([]*ProductBulkResult, error)
+	ListWithContext(ctx context.Context, query string) ([]*ProductBulkResult, error)
 	ListAll() ([]*ProductBulkResult, error)
+	ListAllWithContext(ctx context.Context) ([]*ProductBulkResult, error)
 	ListWithFields(first int, cursor string, query string, fields string) (*ProductsQueryResult, error)
+	ListWithFieldsWithContext(ctx context.Context, first int, cursor string, query string, fields string) (*ProductsQueryResult, error)
+	// Iter returns an iterator over the products matching opts.Query, fetching pages on demand.
+	Iter(opts ListOptions) *Iterator[*ProductQueryResult]
+	IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*ProductQueryResult]
 
 	Get(gid graphql.ID) (*ProductQueryResult, error)
+	GetWithContext(ctx context.Context, gid graphql.ID) (*ProductQueryResult, error)
 	GetWithFields(id graphql.ID, fields string) (*ProductQueryResult, error)
+	GetWithFieldsWithContext(ctx context.Context, id graphql.ID, fields string) (*ProductQueryResult, error)
 	GetSingleProductCollection(id graphql.ID, cursor string) (*ProductQueryResult, error)
+	GetSingleProductCollectionWithContext(ctx context.Context, id graphql.ID, cursor string) (*ProductQueryResult, error)
 	GetSingleProductVariant(id graphql.ID, cursor string) (*ProductQueryResult, error)
+	GetSingleProductVariantWithContext(ctx context.Context, id graphql.ID, cursor string) (*ProductQueryResult, error)
 	GetSingleProduct(id graphql.ID) (*ProductQueryResult, error)
+	GetSingleProductWithContext(ctx context.Context, id graphql.ID) (*ProductQueryResult, error)
 	Create(product *ProductCreate) error
+	CreateWithContext(ctx context.Context, product *ProductCreate) error
 	CreateBulk(products []*ProductCreate) error
+	CreateBulkWithContext(ctx context.Context, products []*ProductCreate) error
 
 	Update(product *ProductUpdate) error
+	UpdateWithContext(ctx context.Context, product *ProductUpdate) error
 	UpdateBulk(products []*ProductUpdate) error
+	UpdateBulkWithContext(ctx context.Context, products []*ProductUpdate) error
 
 	Delete(product *ProductDelete) error
+	DeleteWithContext(ctx context.Context, product *ProductDelete) error
 	DeleteBulk(products []*ProductDelete) error
+	DeleteBulkWithContext(ctx context.Context, products []*ProductDelete) error
 	TriggerListAll() (id graphql.ID, err error)
+	TriggerListAllWithContext(ctx context.Context) (id graphql.ID, err error)
 }
 
 type ProductServiceOp struct {
@@ -309,10 +325,47 @@ type productUpdateResult struct {
 }
 
 type productDeleteResult struct {
-	ID         string       `json:"deletedProductId,omitempty"`
+	ID         string       `graphql:"deletedProductId" json:"deletedProductId,omitempty"`
 	UserErrors []UserErrors `json:"userErrors"`
 }
 
+const productCreateBulkMutation = `
+mutation productCreate($input: ProductInput!, $media: [CreateMediaInput!]) {
+	productCreate(input: $input, media: $media) {
+		product {
+			id
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+const productUpdateBulkMutation = `
+mutation productUpdate($input: ProductInput!) {
+	productUpdate(input: $input) {
+		product {
+			id
+		}
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
+const productDeleteBulkMutation = `
+mutation productDelete($input: ProductDeleteInput!) {
+	productDelete(input: $input) {
+		deletedProductId
+		userErrors {
+			field
+			message
+		}
+	}
+}`
+
 const productBaseQuery = `
   id
   legacyResourceId
@@ -636,6 +689,10 @@ var productBulkQuery = fmt.Sprintf(`
 `, productBaseQuery)
 
 func (s *ProductServiceOp) ListAll() ([]*ProductBulkResult, error) {
+	return s.ListAllWithContext(s.client.gql.Context())
+}
+
+func (s *ProductServiceOp) ListAllWithContext(ctx context.Context) ([]*ProductBulkResult, error) {
 	q := fmt.Sprintf(`
 		{
 			products{
@@ -649,7 +706,7 @@ func (s *ProductServiceOp) ListAll() ([]*ProductBulkResult, error) {
 	`, productBulkQuery)
 
 	res := []*ProductBulkResult{}
-	err := s.client.BulkOperation.BulkQuery(q, &res)
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
 	if err != nil {
 		return []*ProductBulkResult{}, err
 	}
@@ -658,6 +715,10 @@ func (s *ProductServiceOp) ListAll() ([]*ProductBulkResult, error) {
 }
 
 func (s *ProductServiceOp) TriggerListAll() (id graphql.ID, err error) {
+	return s.TriggerListAllWithContext(s.client.gql.Context())
+}
+
+func (s *ProductServiceOp) TriggerListAllWithContext(ctx context.Context) (id graphql.ID, err error) {
 	q := fmt.Sprintf(`
 		{
 			products{
@@ -671,11 +732,15 @@ func (s *ProductServiceOp) TriggerListAll() (id graphql.ID, err error) {
 	`, productBulkQuery)
 
 	res := []*ProductBulkResult{}
-	id, err = s.client.BulkOperation.BulkQueryRunOnly(q, &res)
+	id, err = s.client.BulkOperation.BulkQueryRunOnlyWithContext(ctx, q, &res)
 	return id, err
 }
 
 func (s *ProductServiceOp) List(query string) ([]*ProductBulkResult, error) {
+	return s.ListWithContext(s.client.gql.Context(), query)
+}
+
+func (s *ProductServiceOp) ListWithContext(ctx context.Context, query string) ([]*ProductBulkResult, error) {
 	q := fmt.Sprintf(`
 		{
 			products(query: "$query"){
@@ -691,7 +756,7 @@ func (s *ProductServiceOp) List(query string) ([]*ProductBulkResult, error) {
 	q = strings.ReplaceAll(q, "$query", query)
 
 	res := []*ProductBulkResult{}
-	err := s.client.BulkOperation.BulkQuery(q, &res)
+	err := s.client.BulkOperation.BulkQueryWithContext(ctx, q, &res)
 	if err != nil {
 		return []*ProductBulkResult{}, err
 	}
@@ -700,7 +765,11 @@ func (s *ProductServiceOp) List(query string) ([]*ProductBulkResult, error) {
 }
 
 func (s *ProductServiceOp) Get(id graphql.ID) (*ProductQueryResult, error) {
-	out, err := s.getPage(id, "")
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
+
+func (s *ProductServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (*ProductQueryResult, error) {
+	out, err := s.getPage(ctx, id, "")
 	if err != nil {
 		return nil, err
 	}
@@ -709,7 +778,7 @@ func (s *ProductServiceOp) Get(id graphql.ID) (*ProductQueryResult, error) {
 	hasNextPage := out.ProductVariants.PageInfo.HasNextPage
 	for hasNextPage && len(nextPageData.ProductVariants.Edges) > 0 {
 		cursor := nextPageData.ProductVariants.Edges[len(nextPageData.ProductVariants.Edges)-1].Cursor
-		nextPageData, err := s.getPage(id, cursor)
+		nextPageData, err := s.getPage(ctx, id, cursor)
 		if err != nil {
 			return nil, err
 		}
@@ -720,7 +789,7 @@ func (s *ProductServiceOp) Get(id graphql.ID) (*ProductQueryResult, error) {
 	return out, nil
 }
 
-func (s *ProductServiceOp) getPage(id graphql.ID, cursor string) (*ProductQueryResult, error) {
+func (s *ProductServiceOp) getPage(ctx context.Context, id graphql.ID, cursor string) (*ProductQueryResult, error) {
 	q := fmt.Sprintf(`
 		query product($id: ID!, $cursor: String) {
 			product(id: $id){
@@ -740,7 +809,7 @@ func (s *ProductServiceOp) getPage(id graphql.ID, cursor string) (*ProductQueryR
 		Product *ProductQueryResult `json:"product"`
 	}{}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -750,6 +819,10 @@ func (s *ProductServiceOp) getPage(id graphql.ID, cursor string) (*ProductQueryR
 }
 
 func (s *ProductServiceOp) GetWithFields(id graphql.ID, fields string) (*ProductQueryResult, error) {
+	return s.GetWithFieldsWithContext(s.client.gql.Context(), id, fields)
+}
+
+func (s *ProductServiceOp) GetWithFieldsWithContext(ctx context.Context, id graphql.ID, fields string) (*ProductQueryResult, error) {
 	if fields == "" {
 		fields = `id`
 	}
@@ -768,7 +841,7 @@ func (s *ProductServiceOp) GetWithFields(id graphql.ID, fields string) (*Product
 		Product *ProductQueryResult `json:"product"`
 	}{}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -778,6 +851,10 @@ func (s *ProductServiceOp) GetWithFields(id graphql.ID, fields string) (*Product
 }
 
 func (s *ProductServiceOp) GetSingleProductCollection(id graphql.ID, cursor string) (*ProductQueryResult, error) {
+	return s.GetSingleProductCollectionWithContext(s.client.gql.Context(), id, cursor)
+}
+
+func (s *ProductServiceOp) GetSingleProductCollectionWithContext(ctx context.Context, id graphql.ID, cursor string) (*ProductQueryResult, error) {
 	q := ""
 	if cursor != "" {
 		q = fmt.Sprintf(`
@@ -808,7 +885,7 @@ func (s *ProductServiceOp) GetSingleProductCollection(id graphql.ID, cursor stri
 		Product *ProductQueryResult `json:"product"`
 	}{}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -818,6 +895,10 @@ func (s *ProductServiceOp) GetSingleProductCollection(id graphql.ID, cursor stri
 }
 
 func (s *ProductServiceOp) GetSingleProductVariant(id graphql.ID, cursor string) (*ProductQueryResult, error) {
+	return s.GetSingleProductVariantWithContext(s.client.gql.Context(), id, cursor)
+}
+
+func (s *ProductServiceOp) GetSingleProductVariantWithContext(ctx context.Context, id graphql.ID, cursor string) (*ProductQueryResult, error) {
 	q := ""
 	if cursor != "" {
 		q = fmt.Sprintf(`
@@ -848,7 +929,7 @@ func (s *ProductServiceOp) GetSingleProductVariant(id graphql.ID, cursor string)
 		Product *ProductQueryResult `json:"product"`
 	}{}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -858,6 +939,10 @@ func (s *ProductServiceOp) GetSingleProductVariant(id graphql.ID, cursor string)
 }
 
 func (s *ProductServiceOp) GetSingleProduct(id graphql.ID) (*ProductQueryResult, error) {
+	return s.GetSingleProductWithContext(s.client.gql.Context(), id)
+}
+
+func (s *ProductServiceOp) GetSingleProductWithContext(ctx context.Context, id graphql.ID) (*ProductQueryResult, error) {
 	q := fmt.Sprintf(`
 		query product($id: ID!) {
 			product(id: $id){
@@ -876,7 +961,7 @@ func (s *ProductServiceOp) GetSingleProduct(id graphql.ID) (*ProductQueryResult,
 		Product *ProductQueryResult `json:"product"`
 	}{}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -886,6 +971,10 @@ func (s *ProductServiceOp) GetSingleProduct(id graphql.ID) (*ProductQueryResult,
 }
 
 func (s *ProductServiceOp) ListWithFields(first int, cursor, query, fields string) (*ProductsQueryResult, error) {
+	return s.ListWithFieldsWithContext(s.client.gql.Context(), first, cursor, query, fields)
+}
+
+func (s *ProductServiceOp) ListWithFieldsWithContext(ctx context.Context, first int, cursor, query, fields string) (*ProductsQueryResult, error) {
 	if fields == "" {
 		fields = `id`
 	}
@@ -915,7 +1004,7 @@ func (s *ProductServiceOp) ListWithFields(first int, cursor, query, fields strin
 	out := &ProductsQueryResult{}
 
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.QueryString(context.Background(), q, vars, &out)
+		return s.client.gql.QueryString(ctx, q, vars, &out)
 	})
 	if err != nil {
 		return nil, err
@@ -924,95 +1013,190 @@ func (s *ProductServiceOp) ListWithFields(first int, cursor, query, fields strin
 	return out, nil
 }
 
+func (s *ProductServiceOp) Iter(opts ListOptions) *Iterator[*ProductQueryResult] {
+	return s.IterWithContext(s.client.gql.Context(), opts)
+}
+
+func (s *ProductServiceOp) IterWithContext(ctx context.Context, opts ListOptions) *Iterator[*ProductQueryResult] {
+	return Paginate(ctx, opts, s.listPage)
+}
+
+func (s *ProductServiceOp) listPage(ctx context.Context, opts ListOptions) (*Page[*ProductQueryResult], error) {
+	q := fmt.Sprintf(`
+		query products($query: String, $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+			products(query: $query, first: $first, last: $last, before: $before, after: $after, reverse: $reverse){
+				edges{
+					node{
+						%s
+					}
+				}
+				%s
+			}
+		}
+	`, productBaseQuery, pageInfoQuery)
+
+	out := ProductsQueryResult{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, pageVars(opts), &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[*ProductQueryResult]{PageInfo: out.Products.PageInfo}
+	for i := range out.Products.Edges {
+		page.Nodes = append(page.Nodes, &out.Products.Edges[i].Product)
+	}
+
+	return page, nil
+}
+
 func (s *ProductServiceOp) CreateBulk(products []*ProductCreate) error {
-	for _, p := range products {
-		err := s.Create(p)
-		if err != nil {
-			log.Warnf("Couldn't create product (%v): %s", p, err)
+	return s.CreateBulkWithContext(s.client.gql.Context(), products)
+}
+
+// CreateBulkWithContext creates products with a bulk mutation. When some products couldn't be created,
+// it returns a *BulkMutationError whose failures index products.
+func (s *ProductServiceOp) CreateBulkWithContext(ctx context.Context, products []*ProductCreate) error {
+	vars := make([]map[string]interface{}, len(products))
+	for i, p := range products {
+		vars[i] = map[string]interface{}{
+			"input": p.ProductInput,
+			"media": p.MediaInput,
 		}
 	}
 
-	return nil
+	results, err := BulkMutate[mutationProductCreate](ctx, s.client.BulkOperation, productCreateBulkMutation, vars)
+	if err != nil {
+		return err
+	}
+
+	return bulkMutationError(results, func(m *mutationProductCreate) []UserErrors {
+		return m.ProductCreateResult.UserErrors
+	})
 }
 
 func (s *ProductServiceOp) Create(product *ProductCreate) error {
+	return s.CreateWithContext(s.client.gql.Context(), product)
+}
+
+func (s *ProductServiceOp) CreateWithContext(ctx context.Context, product *ProductCreate) error {
 	m := mutationProductCreate{}
 
+	// media is a non-null list variable, so it must not be sent as null
+	media := product.MediaInput
+	if media == nil {
+		media = []CreateMediaInput{}
+	}
 	vars := map[string]interface{}{
 		"input": product.ProductInput,
-		"media": product.MediaInput,
+		"media": media,
 	}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.Mutate(context.Background(), &m, vars)
+		return s.client.gql.Mutate(ctx, &m, vars)
 	})
 	if err != nil {
 		return err
 	}
 
 	if len(m.ProductCreateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.ProductCreateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.ProductCreateResult.UserErrors}
 	}
 
 	return nil
 }
 
 func (s *ProductServiceOp) UpdateBulk(products []*ProductUpdate) error {
-	for _, p := range products {
-		err := s.Update(p)
-		if err != nil {
-			log.Warnf("Couldn't update product (%v): %s", p, err)
+	return s.UpdateBulkWithContext(s.client.gql.Context(), products)
+}
+
+// UpdateBulkWithContext updates products with a bulk mutation. When some products couldn't be updated,
+// it returns a *BulkMutationError whose failures index products.
+func (s *ProductServiceOp) UpdateBulkWithContext(ctx context.Context, products []*ProductUpdate) error {
+	vars := make([]map[string]interface{}, len(products))
+	for i, p := range products {
+		vars[i] = map[string]interface{}{
+			"input": p.ProductInput,
 		}
 	}
 
-	return nil
+	results, err := BulkMutate[mutationProductUpdate](ctx, s.client.BulkOperation, productUpdateBulkMutation, vars)
+	if err != nil {
+		return err
+	}
+
+	return bulkMutationError(results, func(m *mutationProductUpdate) []UserErrors {
+		return m.ProductUpdateResult.UserErrors
+	})
 }
 
 func (s *ProductServiceOp) Update(product *ProductUpdate) error {
+	return s.UpdateWithContext(s.client.gql.Context(), product)
+}
+
+func (s *ProductServiceOp) UpdateWithContext(ctx context.Context, product *ProductUpdate) error {
 	m := mutationProductUpdate{}
 
 	vars := map[string]interface{}{
 		"input": product.ProductInput,
 	}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.Mutate(context.Background(), &m, vars)
+		return s.client.gql.Mutate(ctx, &m, vars)
 	})
 	if err != nil {
 		return err
 	}
 
 	if len(m.ProductUpdateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.ProductUpdateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.ProductUpdateResult.UserErrors}
 	}
 
 	return nil
 }
 
 func (s *ProductServiceOp) DeleteBulk(products []*ProductDelete) error {
-	for _, p := range products {
-		err := s.Delete(p)
-		if err != nil {
-			log.Warnf("Couldn't delete product (%v): %s", p, err)
+	return s.DeleteBulkWithContext(s.client.gql.Context(), products)
+}
+
+// DeleteBulkWithContext deletes products with a bulk mutation. When some products couldn't be deleted,
+// it returns a *BulkMutationError whose failures index products.
+func (s *ProductServiceOp) DeleteBulkWithContext(ctx context.Context, products []*ProductDelete) error {
+	vars := make([]map[string]interface{}, len(products))
+	for i, p := range products {
+		vars[i] = map[string]interface{}{
+			"input": p.ProductInput,
 		}
 	}
 
-	return nil
+	results, err := BulkMutate[mutationProductDelete](ctx, s.client.BulkOperation, productDeleteBulkMutation, vars)
+	if err != nil {
+		return err
+	}
+
+	return bulkMutationError(results, func(m *mutationProductDelete) []UserErrors {
+		return m.ProductDeleteResult.UserErrors
+	})
 }
 
 func (s *ProductServiceOp) Delete(product *ProductDelete) error {
+	return s.DeleteWithContext(s.client.gql.Context(), product)
+}
+
+func (s *ProductServiceOp) DeleteWithContext(ctx context.Context, product *ProductDelete) error {
 	m := mutationProductDelete{}
 
 	vars := map[string]interface{}{
 		"input": product.ProductInput,
 	}
 	err := utils.ExecWithRetries(s.client.retries, func() error {
-		return s.client.gql.Mutate(context.Background(), &m, vars)
+		return s.client.gql.Mutate(ctx, &m, vars)
 	})
 	if err != nil {
 		return err
 	}
 
 	if len(m.ProductDeleteResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.ProductDeleteResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.ProductDeleteResult.UserErrors}
 	}
 
 	return nil
@@ -0,0 +1,46 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+)
+
+func TestProductGet(t *testing.T) {
+	c := newCassetteClient(t, "product_get")
+
+	product, err := c.Product.GetWithContext(context.Background(), graphql.ID("gid://shopify/Product/1"))
+	if err != nil {
+		t.Fatalf("get product: %v", err)
+	}
+	if product.Title != "Snowboard" {
+		t.Errorf("expected title (%v), got (%v)", "Snowboard", product.Title)
+	}
+	// variants are spread over two pages
+	if len(product.ProductVariants.Edges) != 3 {
+		t.Fatalf("expected 3 variants, got %d", len(product.ProductVariants.Edges))
+	}
+	if product.ProductVariants.Edges[2].Variant.SKU != "SNOW-L" {
+		t.Errorf("expected last variant SKU (%v), got (%v)", "SNOW-L", product.ProductVariants.Edges[2].Variant.SKU)
+	}
+}
+
+func TestProductCreateUserErrors(t *testing.T) {
+	c := newCassetteClient(t, "product_create_user_errors")
+
+	err := c.Product.CreateWithContext(context.Background(), &ProductCreate{
+		ProductInput: ProductInput{Handle: "snowboard"},
+	})
+	if !errors.Is(err, ErrUserErrors) {
+		t.Fatalf("expected (%v), got (%v)", ErrUserErrors, err)
+	}
+	var userErrs *UserErrorsError
+	if !errors.As(err, &userErrs) || len(userErrs.UserErrors) != 1 {
+		t.Fatalf("expected 1 user error, got (%v)", err)
+	}
+	if userErrs.UserErrors[0].Message != "Title can't be blank" {
+		t.Errorf("unexpected user error (%+v)", userErrs.UserErrors[0])
+	}
+}
@@ -0,0 +1,388 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
+)
+
+type RefundInput struct {
+	OrderID         graphql.ID              `json:"orderId"`
+	Note            graphql.String          `json:"note,omitempty"`
+	Notify          graphql.Boolean         `json:"notify,omitempty"`
+	Currency        CurrencyCode            `json:"currency,omitempty"`
+	Shipping        *ShippingRefundInput    `json:"shipping,omitempty"`
+	RefundLineItems []RefundLineItemInput   `json:"refundLineItems,omitempty"`
+	Transactions    []OrderTransactionInput `json:"transactions,omitempty"`
+}
+
+type ShippingRefundInput struct {
+	Amount     Money           `json:"amount,omitempty"`
+	FullRefund graphql.Boolean `json:"fullRefund,omitempty"`
+}
+
+type RefundLineItemInput struct {
+	LineItemID  graphql.ID                `json:"lineItemId"`
+	Quantity    graphql.Int               `json:"quantity"`
+	RestockType RefundLineItemRestockType `json:"restockType,omitempty"`
+	LocationID  graphql.ID                `json:"locationId,omitempty"`
+}
+
+type RefundLineItemRestockType string
+
+const (
+	RefundLineItemRestockTypeNoRestock RefundLineItemRestockType = "NO_RESTOCK"
+	RefundLineItemRestockTypeCancel    RefundLineItemRestockType = "CANCEL"
+	RefundLineItemRestockTypeReturn    RefundLineItemRestockType = "RETURN"
+)
+
+// OrderTransactionInput is a transaction of a refund, refunding an amount of the parent transaction.
+type OrderTransactionInput struct {
+	OrderID  graphql.ID           `json:"orderId"`
+	ParentID graphql.ID           `json:"parentId,omitempty"`
+	Amount   Money                `json:"amount"`
+	Gateway  graphql.String       `json:"gateway"`
+	Kind     OrderTransactionKind `json:"kind"`
+}
+
+type Refund struct {
+	ID               graphql.ID         `json:"id,omitempty"`
+	Note             graphql.String     `json:"note,omitempty"`
+	CreatedAt        time.Time          `json:"createdAt,omitempty"`
+	TotalRefundedSet MoneyBag           `json:"totalRefundedSet,omitempty"`
+	RefundLineItems  []RefundLineItem   `json:"refundLineItems,omitempty"`
+	Transactions     []OrderTransaction `json:"transactions,omitempty"`
+}
+
+type RefundLineItem struct {
+	LineItem    LineItem                  `json:"lineItem,omitempty"`
+	Quantity    graphql.Int               `json:"quantity,omitempty"`
+	RestockType RefundLineItemRestockType `json:"restockType,omitempty"`
+	SubtotalSet MoneyBag                  `json:"subtotalSet,omitempty"`
+	// Location is the location the line item is restocked at.
+	Location *Location `json:"location,omitempty"`
+}
+
+type SuggestedRefundInput struct {
+	RefundLineItems []RefundLineItemInput
+	// RefundShipping refunds the shipping left to refund, ShippingAmount refunding part of it instead.
+	RefundShipping bool
+	ShippingAmount Money
+	// SuggestFullRefund refunds all the line items and shipping left to refund, RefundLineItems being
+	// ignored.
+	SuggestFullRefund bool
+}
+
+// SuggestedRefund is a refund calculated by Shopify, with the transactions to refund it with.
+type SuggestedRefund struct {
+	AmountSet             MoneyBag                    `json:"amountSet,omitempty"`
+	SubtotalSet           MoneyBag                    `json:"subtotalSet,omitempty"`
+	TotalTaxSet           MoneyBag                    `json:"totalTaxSet,omitempty"`
+	MaximumRefundableSet  MoneyBag                    `json:"maximumRefundableSet,omitempty"`
+	RefundLineItems       []RefundLineItem            `json:"refundLineItems,omitempty"`
+	Shipping              ShippingRefund              `json:"shipping,omitempty"`
+	SuggestedTransactions []SuggestedOrderTransaction `json:"suggestedTransactions,omitempty"`
+}
+
+type ShippingRefund struct {
+	AmountSet            MoneyBag `json:"amountSet,omitempty"`
+	MaximumRefundableSet MoneyBag `json:"maximumRefundableSet,omitempty"`
+}
+
+type SuggestedOrderTransaction struct {
+	Kind                 OrderTransactionKind `json:"kind,omitempty"`
+	Gateway              graphql.String       `json:"gateway,omitempty"`
+	AmountSet            MoneyBag             `json:"amountSet,omitempty"`
+	MaximumRefundableSet MoneyBag             `json:"maximumRefundableSet,omitempty"`
+	ParentTransaction    *OrderTransaction    `json:"parentTransaction,omitempty"`
+}
+
+// RefundInput returns the input creating the suggested refund of the order orderID, in the presentment
+// currency of the order, which the amounts of a refund are in, restocking the line items at their
+// suggested location.
+func (r *SuggestedRefund) RefundInput(orderID graphql.ID) RefundInput {
+	input := RefundInput{OrderID: orderID, Currency: r.AmountSet.PresentmentMoney.CurrencyCode}
+	if amount := r.Shipping.AmountSet.PresentmentMoney.Amount; positive(amount) {
+		input.Shipping = &ShippingRefundInput{Amount: Money(amount)}
+	}
+	for _, li := range r.RefundLineItems {
+		item := RefundLineItemInput{
+			LineItemID:  li.LineItem.ID,
+			Quantity:    li.Quantity,
+			RestockType: li.RestockType,
+		}
+		if li.Location != nil {
+			item.LocationID = li.Location.ID
+		}
+		input.RefundLineItems = append(input.RefundLineItems, item)
+	}
+	for _, t := range r.SuggestedTransactions {
+		tx := OrderTransactionInput{
+			OrderID: orderID,
+			Amount:  Money(t.AmountSet.PresentmentMoney.Amount),
+			Gateway: t.Gateway,
+			Kind:    OrderTransactionKindRefund,
+		}
+		if t.ParentTransaction != nil {
+			tx.ParentID = t.ParentTransaction.ID
+		}
+		input.Transactions = append(input.Transactions, tx)
+	}
+	return input
+}
+
+func positive(amount Decimal) bool {
+	f, err := strconv.ParseFloat(string(amount), 64)
+	return err == nil && f > 0
+}
+
+const refundLineItemQuery = `
+	lineItem{
+		id
+		sku
+		title
+	}
+	quantity
+	restockType
+	subtotalSet{
+		presentmentMoney{
+			amount
+			currencyCode
+		}
+		shopMoney{
+			amount
+			currencyCode
+		}
+	}
+	location{
+		id
+	}
+`
+
+var refundQuery = fmt.Sprintf(`
+	id
+	note
+	createdAt
+	totalRefundedSet{
+		presentmentMoney{
+			amount
+			currencyCode
+		}
+		shopMoney{
+			amount
+			currencyCode
+		}
+	}
+	refundLineItems(first: 250){
+		edges{
+			node{
+				%s
+			}
+		}
+	}
+	transactions(first: 250){
+		edges{
+			node{
+				%s
+			}
+		}
+	}
+`, refundLineItemQuery, orderTransactionQuery)
+
+// refundNode decodes a refund with its line items and transactions connections.
+type refundNode struct {
+	Refund
+	RefundLineItems struct {
+		Edges []struct {
+			Node RefundLineItem `json:"node"`
+		} `json:"edges"`
+	} `json:"refundLineItems"`
+	Transactions struct {
+		Edges []struct {
+			Node OrderTransaction `json:"node"`
+		} `json:"edges"`
+	} `json:"transactions"`
+}
+
+func (n *refundNode) refund() *Refund {
+	r := n.Refund
+	for _, e := range n.RefundLineItems.Edges {
+		r.RefundLineItems = append(r.RefundLineItems, e.Node)
+	}
+	for _, e := range n.Transactions.Edges {
+		r.Transactions = append(r.Transactions, e.Node)
+	}
+	return &r
+}
+
+var refundCreateMutation = fmt.Sprintf(`
+	mutation refundCreate($input: RefundInput!) {
+		refundCreate(input: $input){
+			refund{
+				%s
+			}
+			userErrors{
+				field
+				message
+			}
+		}
+	}
+`, refundQuery)
+
+func (s *OrderServiceOp) SuggestRefund(orderID graphql.ID, input SuggestedRefundInput) (*SuggestedRefund, error) {
+	return s.SuggestRefundWithContext(s.client.gql.Context(), orderID, input)
+}
+
+func (s *OrderServiceOp) SuggestRefundWithContext(ctx context.Context, orderID graphql.ID, input SuggestedRefundInput) (*SuggestedRefund, error) {
+	q := fmt.Sprintf(`
+		query suggestedRefund($id: ID!, $refundLineItems: [RefundLineItemInput!], $refundShipping: Boolean, $shippingAmount: Money, $suggestFullRefund: Boolean) {
+			order(id: $id){
+				suggestedRefund(refundLineItems: $refundLineItems, refundShipping: $refundShipping, shippingAmount: $shippingAmount, suggestFullRefund: $suggestFullRefund){
+					amountSet{
+						presentmentMoney{
+							amount
+							currencyCode
+						}
+						shopMoney{
+							amount
+							currencyCode
+						}
+					}
+					subtotalSet{
+						presentmentMoney{
+							amount
+							currencyCode
+						}
+						shopMoney{
+							amount
+							currencyCode
+						}
+					}
+					totalTaxSet{
+						presentmentMoney{
+							amount
+							currencyCode
+						}
+						shopMoney{
+							amount
+							currencyCode
+						}
+					}
+					maximumRefundableSet{
+						presentmentMoney{
+							amount
+							currencyCode
+						}
+						shopMoney{
+							amount
+							currencyCode
+						}
+					}
+					refundLineItems{
+						%s
+					}
+					shipping{
+						amountSet{
+							presentmentMoney{
+								amount
+								currencyCode
+							}
+							shopMoney{
+								amount
+								currencyCode
+							}
+						}
+						maximumRefundableSet{
+							presentmentMoney{
+								amount
+								currencyCode
+							}
+							shopMoney{
+								amount
+								currencyCode
+							}
+						}
+					}
+					suggestedTransactions{
+						kind
+						gateway
+						amountSet{
+							presentmentMoney{
+								amount
+								currencyCode
+							}
+							shopMoney{
+								amount
+								currencyCode
+							}
+						}
+						maximumRefundableSet{
+							presentmentMoney{
+								amount
+								currencyCode
+							}
+							shopMoney{
+								amount
+								currencyCode
+							}
+						}
+						parentTransaction{
+							id
+						}
+					}
+				}
+			}
+		}
+	`, refundLineItemQuery)
+
+	vars := map[string]interface{}{
+		"id":                orderID,
+		"refundShipping":    input.RefundShipping,
+		"suggestFullRefund": input.SuggestFullRefund,
+	}
+	if len(input.RefundLineItems) > 0 {
+		vars["refundLineItems"] = input.RefundLineItems
+	}
+	if input.ShippingAmount != "" {
+		vars["shippingAmount"] = input.ShippingAmount
+	}
+
+	out := struct {
+		Order *struct {
+			SuggestedRefund *SuggestedRefund `json:"suggestedRefund"`
+		} `json:"order"`
+	}{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, vars, &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.Order == nil {
+		return nil, fmt.Errorf("order %v not found", orderID)
+	}
+
+	return out.Order.SuggestedRefund, nil
+}
+
+func (s *OrderServiceOp) CreateRefund(input RefundInput) (*Refund, error) {
+	return s.CreateRefundWithContext(s.client.gql.Context(), input)
+}
+
+func (s *OrderServiceOp) CreateRefundWithContext(ctx context.Context, input RefundInput) (*Refund, error) {
+	payload := struct {
+		Refund *refundNode `json:"refund"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, refundCreateMutation, map[string]interface{}{"input": input}, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Refund == nil {
+		return nil, fmt.Errorf("empty response to mutation")
+	}
+	return payload.Refund.refund(), nil
+}
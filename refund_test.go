@@ -0,0 +1,142 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestRefunds(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	o := srv.AddOrder(&shopifytest.Order{
+		LineItems: []*shopifytest.LineItem{
+			{ID: "gid://shopify/LineItem/1", Title: "Snowboard", Quantity: 2, Price: "10.00"},
+			{ID: "gid://shopify/LineItem/2", Title: "Wax", Quantity: 1, Price: "5.00"},
+		},
+		ShippingLine: &shopifytest.ShippingLine{Title: "Standard", Price: "5.00"},
+		Transactions: []*shopifytest.Transaction{{Kind: "AUTHORIZATION", Gateway: "bogus", Amount: "30.00"}},
+	})
+	authorization := o.Transactions[0].ID
+	location := srv.AddLocation(&shopifytest.Location{Name: "Warehouse"})
+
+	capture, err := client.Order.CaptureWithContext(ctx, shopify.OrderCaptureInput{ID: o.ID, ParentTransactionID: authorization, Amount: "20.00"})
+	if err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if capture.Kind != shopify.OrderTransactionKindCapture || capture.Gateway != "bogus" || capture.ParentTransaction == nil || capture.ParentTransaction.ID != authorization {
+		t.Errorf("unexpected capture: %+v", capture)
+	}
+	if _, err = client.Order.CaptureWithContext(ctx, shopify.OrderCaptureInput{ID: o.ID, ParentTransactionID: authorization, Amount: "20.00"}); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors capturing more than authorized, got %v", err)
+	}
+
+	order, err := client.Order.MarkAsPaidWithContext(ctx, o.ID)
+	if err != nil {
+		t.Fatalf("mark as paid: %v", err)
+	}
+	if order.DisplayFinancialStatus != "PAID" || order.TotalReceivedSet.ShopMoney.Amount != "30.00" || len(order.Transactions) != 3 {
+		t.Errorf("unexpected paid order: %+v", order)
+	}
+	if _, err = client.Order.MarkAsPaidWithContext(ctx, o.ID); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors marking a paid order as paid, got %v", err)
+	}
+
+	suggestion, err := client.Order.SuggestRefundWithContext(ctx, o.ID, shopify.SuggestedRefundInput{
+		RefundLineItems: []shopify.RefundLineItemInput{{
+			LineItemID:  "gid://shopify/LineItem/1",
+			Quantity:    1,
+			RestockType: shopify.RefundLineItemRestockTypeReturn,
+			LocationID:  location.ID,
+		}},
+		RefundShipping: true,
+	})
+	if err != nil {
+		t.Fatalf("suggest refund: %v", err)
+	}
+	if suggestion.AmountSet.ShopMoney.Amount != "15.00" || suggestion.Shipping.AmountSet.ShopMoney.Amount != "5.00" ||
+		len(suggestion.SuggestedTransactions) != 1 || suggestion.SuggestedTransactions[0].ParentTransaction.ID != capture.ID {
+		t.Errorf("unexpected suggested refund: %+v", suggestion)
+	}
+	input := suggestion.RefundInput(o.ID)
+	if len(input.RefundLineItems) != 1 || input.RefundLineItems[0].LocationID != location.ID || input.Currency != "USD" {
+		t.Errorf("unexpected refund input: %+v", input)
+	}
+	refund, err := client.Order.CreateRefundWithContext(ctx, input)
+	if err != nil {
+		t.Fatalf("create refund: %v", err)
+	}
+	if refund.TotalRefundedSet.ShopMoney.Amount != "15.00" || len(refund.RefundLineItems) != 1 || refund.RefundLineItems[0].Quantity != 1 ||
+		len(refund.Transactions) != 1 || refund.Transactions[0].Kind != shopify.OrderTransactionKindRefund {
+		t.Errorf("unexpected refund: %+v", refund)
+	}
+	if o.DisplayFinancialStatus != "PARTIALLY_REFUNDED" {
+		t.Errorf("expected the order to be partially refunded, got %s", o.DisplayFinancialStatus)
+	}
+	_, err = client.Order.CreateRefundWithContext(ctx, shopify.RefundInput{
+		OrderID:         o.ID,
+		RefundLineItems: []shopify.RefundLineItemInput{{LineItemID: "gid://shopify/LineItem/1", Quantity: 2}},
+	})
+	if !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors refunding more items than left, got %v", err)
+	}
+
+	suggestion, err = client.Order.SuggestRefundWithContext(ctx, o.ID, shopify.SuggestedRefundInput{SuggestFullRefund: true})
+	if err != nil {
+		t.Fatalf("suggest full refund: %v", err)
+	}
+	if suggestion.AmountSet.ShopMoney.Amount != "15.00" || len(suggestion.RefundLineItems) != 2 || len(suggestion.SuggestedTransactions) != 2 {
+		t.Errorf("unexpected suggested full refund: %+v", suggestion)
+	}
+	if _, err = client.Order.CreateRefundWithContext(ctx, suggestion.RefundInput(o.ID)); err != nil {
+		t.Fatalf("create full refund: %v", err)
+	}
+	transactions, err := client.Order.TransactionsWithContext(ctx, o.ID)
+	if err != nil {
+		t.Fatalf("transactions: %v", err)
+	}
+	if len(transactions) != 6 || o.DisplayFinancialStatus != "REFUNDED" {
+		t.Errorf("expected the order to be refunded, got %s and %d transactions", o.DisplayFinancialStatus, len(transactions))
+	}
+}
+
+func TestSuggestedRefundRefundInput(t *testing.T) {
+	bag := func(presentment, shop shopify.Decimal) shopify.MoneyBag {
+		return shopify.MoneyBag{
+			PresentmentMoney: shopify.MoneyV2{Amount: presentment, CurrencyCode: "EUR"},
+			ShopMoney:        shopify.MoneyV2{Amount: shop, CurrencyCode: "USD"},
+		}
+	}
+	suggestion := shopify.SuggestedRefund{
+		AmountSet: bag("13.80", "15.00"),
+		RefundLineItems: []shopify.RefundLineItem{{
+			LineItem:    shopify.LineItem{ID: "gid://shopify/LineItem/1"},
+			Quantity:    1,
+			RestockType: shopify.RefundLineItemRestockTypeReturn,
+			Location:    &shopify.Location{ID: "gid://shopify/Location/1"},
+		}},
+		Shipping: shopify.ShippingRefund{AmountSet: bag("4.60", "5.00")},
+		SuggestedTransactions: []shopify.SuggestedOrderTransaction{{
+			Gateway:           "bogus",
+			AmountSet:         bag("13.80", "15.00"),
+			ParentTransaction: &shopify.OrderTransaction{ID: "gid://shopify/OrderTransaction/1"},
+		}},
+	}
+
+	input := suggestion.RefundInput("gid://shopify/Order/1")
+	if input.Currency != "EUR" || input.Shipping == nil || input.Shipping.Amount != "4.60" {
+		t.Errorf("expected the refund in the presentment currency, got %+v", input)
+	}
+	if len(input.RefundLineItems) != 1 || input.RefundLineItems[0].LocationID != "gid://shopify/Location/1" {
+		t.Errorf("expected the line item restocked at its location, got %+v", input.RefundLineItems)
+	}
+	if len(input.Transactions) != 1 || input.Transactions[0].Amount != "13.80" || input.Transactions[0].ParentID != "gid://shopify/OrderTransaction/1" {
+		t.Errorf("expected the transaction in the presentment currency, got %+v", input.Transactions)
+	}
+}
@@ -0,0 +1,314 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+)
+
+// ReturnService manages the returns of fulfilled line items, requested by customers or created by
+// the merchant. It needs the Admin API version 2023-07, see graphqlclient.WithVersion.
+type ReturnService interface {
+	Get(id graphql.ID) (*Return, error)
+	GetWithContext(ctx context.Context, id graphql.ID) (*Return, error)
+	// Request requests a return on behalf of the customer, to be approved or declined by the merchant.
+	Request(input ReturnRequestInput) (*Return, error)
+	RequestWithContext(ctx context.Context, input ReturnRequestInput) (*Return, error)
+	// Create creates an open return, without going through a return request.
+	Create(input ReturnInput) (*Return, error)
+	CreateWithContext(ctx context.Context, input ReturnInput) (*Return, error)
+	ApproveRequest(id graphql.ID) (*Return, error)
+	ApproveRequestWithContext(ctx context.Context, id graphql.ID) (*Return, error)
+	DeclineRequest(id graphql.ID, decline ReturnDecline) (*Return, error)
+	DeclineRequestWithContext(ctx context.Context, id graphql.ID, decline ReturnDecline) (*Return, error)
+	// Close closes an open return once its line items were processed.
+	Close(id graphql.ID) (*Return, error)
+	CloseWithContext(ctx context.Context, id graphql.ID) (*Return, error)
+	Reopen(id graphql.ID) (*Return, error)
+	ReopenWithContext(ctx context.Context, id graphql.ID) (*Return, error)
+	// Cancel cancels a requested or open return.
+	Cancel(id graphql.ID, notifyCustomer bool) (*Return, error)
+	CancelWithContext(ctx context.Context, id graphql.ID, notifyCustomer bool) (*Return, error)
+}
+
+type ReturnServiceOp struct {
+	client *Client
+}
+
+type ReturnStatus string
+
+const (
+	ReturnStatusCanceled  ReturnStatus = "CANCELED"
+	ReturnStatusClosed    ReturnStatus = "CLOSED"
+	ReturnStatusDeclined  ReturnStatus = "DECLINED"
+	ReturnStatusOpen      ReturnStatus = "OPEN"
+	ReturnStatusRequested ReturnStatus = "REQUESTED"
+)
+
+type ReturnReason string
+
+const (
+	ReturnReasonColor          ReturnReason = "COLOR"
+	ReturnReasonDefective      ReturnReason = "DEFECTIVE"
+	ReturnReasonNotAsDescribed ReturnReason = "NOT_AS_DESCRIBED"
+	ReturnReasonOther          ReturnReason = "OTHER"
+	ReturnReasonSizeTooLarge   ReturnReason = "SIZE_TOO_LARGE"
+	ReturnReasonSizeTooSmall   ReturnReason = "SIZE_TOO_SMALL"
+	ReturnReasonStyle          ReturnReason = "STYLE"
+	ReturnReasonUnknown        ReturnReason = "UNKNOWN"
+	ReturnReasonUnwanted       ReturnReason = "UNWANTED"
+	ReturnReasonWrongItem      ReturnReason = "WRONG_ITEM"
+)
+
+type ReturnDeclineReason string
+
+const (
+	ReturnDeclineReasonFinalSale         ReturnDeclineReason = "FINAL_SALE"
+	ReturnDeclineReasonOther             ReturnDeclineReason = "OTHER"
+	ReturnDeclineReasonReturnPeriodEnded ReturnDeclineReason = "RETURN_PERIOD_ENDED"
+)
+
+type ReturnRequestInput struct {
+	OrderID         graphql.ID                   `json:"orderId"`
+	ReturnLineItems []ReturnRequestLineItemInput `json:"returnLineItems"`
+}
+
+type ReturnRequestLineItemInput struct {
+	FulfillmentLineItemID graphql.ID     `json:"fulfillmentLineItemId"`
+	Quantity              graphql.Int    `json:"quantity"`
+	ReturnReason          ReturnReason   `json:"returnReason"`
+	CustomerNote          graphql.String `json:"customerNote,omitempty"`
+}
+
+type ReturnInput struct {
+	OrderID         graphql.ID            `json:"orderId"`
+	ReturnLineItems []ReturnLineItemInput `json:"returnLineItems"`
+	NotifyCustomer  graphql.Boolean       `json:"notifyCustomer,omitempty"`
+}
+
+type ReturnLineItemInput struct {
+	FulfillmentLineItemID graphql.ID     `json:"fulfillmentLineItemId"`
+	Quantity              graphql.Int    `json:"quantity"`
+	ReturnReason          ReturnReason   `json:"returnReason"`
+	ReturnReasonNote      graphql.String `json:"returnReasonNote,omitempty"`
+}
+
+type ReturnDecline struct {
+	Reason ReturnDeclineReason `json:"reason,omitempty"`
+	Note   graphql.String      `json:"note,omitempty"`
+}
+
+type Return struct {
+	ID              graphql.ID       `json:"id,omitempty"`
+	Name            graphql.String   `json:"name,omitempty"`
+	Status          ReturnStatus     `json:"status,omitempty"`
+	TotalQuantity   graphql.Int      `json:"totalQuantity,omitempty"`
+	Order           OrderBase        `json:"order,omitempty"`
+	ReturnLineItems []ReturnLineItem `json:"returnLineItems,omitempty"`
+	// Decline is set when the return request was declined.
+	Decline *ReturnDecline `json:"decline,omitempty"`
+}
+
+type ReturnLineItem struct {
+	ID                  graphql.ID          `json:"id,omitempty"`
+	Quantity            graphql.Int         `json:"quantity,omitempty"`
+	ReturnReason        ReturnReason        `json:"returnReason,omitempty"`
+	ReturnReasonNote    graphql.String      `json:"returnReasonNote,omitempty"`
+	CustomerNote        graphql.String      `json:"customerNote,omitempty"`
+	FulfillmentLineItem FulfillmentLineItem `json:"fulfillmentLineItem,omitempty"`
+}
+
+const returnQuery = `
+	id
+	name
+	status
+	totalQuantity
+	order{
+		id
+		name
+	}
+	returnLineItems(first: 250){
+		edges{
+			node{
+				id
+				quantity
+				returnReason
+				returnReasonNote
+				customerNote
+				fulfillmentLineItem{
+					id
+					quantity
+					lineItem{
+						id
+						sku
+						title
+					}
+				}
+			}
+		}
+	}
+	decline{
+		reason
+		note
+	}
+`
+
+// returnNode decodes a return with its line items connection.
+type returnNode struct {
+	Return
+	ReturnLineItems struct {
+		Edges []struct {
+			Node ReturnLineItem `json:"node"`
+		} `json:"edges"`
+	} `json:"returnLineItems"`
+}
+
+func (n *returnNode) toReturn() *Return {
+	r := n.Return
+	for _, e := range n.ReturnLineItems.Edges {
+		r.ReturnLineItems = append(r.ReturnLineItems, e.Node)
+	}
+	return &r
+}
+
+var (
+	returnFields = fmt.Sprintf("return{%s}", returnQuery)
+
+	returnRequestMutation = mutationQuery(
+		"returnRequest($input: ReturnRequestInput!)",
+		"returnRequest(input: $input)",
+		returnFields)
+	returnCreateMutation = mutationQuery(
+		"returnCreate($returnInput: ReturnInput!)",
+		"returnCreate(returnInput: $returnInput)",
+		returnFields)
+	returnApproveRequestMutation = mutationQuery(
+		"returnApproveRequest($input: ReturnApproveRequestInput!)",
+		"returnApproveRequest(input: $input)",
+		returnFields)
+	returnDeclineRequestMutation = mutationQuery(
+		"returnDeclineRequest($input: ReturnDeclineRequestInput!)",
+		"returnDeclineRequest(input: $input)",
+		returnFields)
+	returnCloseMutation = mutationQuery(
+		"returnClose($id: ID!)",
+		"returnClose(id: $id)",
+		returnFields)
+	returnReopenMutation = mutationQuery(
+		"returnReopen($id: ID!)",
+		"returnReopen(id: $id)",
+		returnFields)
+	returnCancelMutation = mutationQuery(
+		"returnCancel($id: ID!, $notifyCustomer: Boolean)",
+		"returnCancel(id: $id, notifyCustomer: $notifyCustomer)",
+		returnFields)
+)
+
+func (s *ReturnServiceOp) Get(id graphql.ID) (*Return, error) {
+	return s.GetWithContext(s.client.gql.Context(), id)
+}
+
+func (s *ReturnServiceOp) GetWithContext(ctx context.Context, id graphql.ID) (*Return, error) {
+	q := fmt.Sprintf(`
+		query return($id: ID!) {
+			return(id: $id){
+				%s
+			}
+		}
+	`, returnQuery)
+
+	out := struct {
+		Return *returnNode `json:"return"`
+	}{}
+	err := s.client.query(ctx, q, map[string]interface{}{"id": id}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if out.Return == nil {
+		return nil, fmt.Errorf("return %v not found", id)
+	}
+
+	return out.Return.toReturn(), nil
+}
+
+func (s *ReturnServiceOp) Request(input ReturnRequestInput) (*Return, error) {
+	return s.RequestWithContext(s.client.gql.Context(), input)
+}
+
+func (s *ReturnServiceOp) RequestWithContext(ctx context.Context, input ReturnRequestInput) (*Return, error) {
+	return s.mutate(ctx, returnRequestMutation, map[string]interface{}{"input": input})
+}
+
+func (s *ReturnServiceOp) Create(input ReturnInput) (*Return, error) {
+	return s.CreateWithContext(s.client.gql.Context(), input)
+}
+
+func (s *ReturnServiceOp) CreateWithContext(ctx context.Context, input ReturnInput) (*Return, error) {
+	return s.mutate(ctx, returnCreateMutation, map[string]interface{}{"returnInput": input})
+}
+
+func (s *ReturnServiceOp) ApproveRequest(id graphql.ID) (*Return, error) {
+	return s.ApproveRequestWithContext(s.client.gql.Context(), id)
+}
+
+func (s *ReturnServiceOp) ApproveRequestWithContext(ctx context.Context, id graphql.ID) (*Return, error) {
+	vars := map[string]interface{}{
+		"input": map[string]interface{}{"id": id},
+	}
+	return s.mutate(ctx, returnApproveRequestMutation, vars)
+}
+
+func (s *ReturnServiceOp) DeclineRequest(id graphql.ID, decline ReturnDecline) (*Return, error) {
+	return s.DeclineRequestWithContext(s.client.gql.Context(), id, decline)
+}
+
+func (s *ReturnServiceOp) DeclineRequestWithContext(ctx context.Context, id graphql.ID, decline ReturnDecline) (*Return, error) {
+	input := map[string]interface{}{"id": id}
+	if decline.Reason != "" {
+		input["declineReason"] = decline.Reason
+	}
+	if decline.Note != "" {
+		input["declineNote"] = decline.Note
+	}
+	return s.mutate(ctx, returnDeclineRequestMutation, map[string]interface{}{"input": input})
+}
+
+func (s *ReturnServiceOp) Close(id graphql.ID) (*Return, error) {
+	return s.CloseWithContext(s.client.gql.Context(), id)
+}
+
+func (s *ReturnServiceOp) CloseWithContext(ctx context.Context, id graphql.ID) (*Return, error) {
+	return s.mutate(ctx, returnCloseMutation, map[string]interface{}{"id": id})
+}
+
+func (s *ReturnServiceOp) Reopen(id graphql.ID) (*Return, error) {
+	return s.ReopenWithContext(s.client.gql.Context(), id)
+}
+
+func (s *ReturnServiceOp) ReopenWithContext(ctx context.Context, id graphql.ID) (*Return, error) {
+	return s.mutate(ctx, returnReopenMutation, map[string]interface{}{"id": id})
+}
+
+func (s *ReturnServiceOp) Cancel(id graphql.ID, notifyCustomer bool) (*Return, error) {
+	return s.CancelWithContext(s.client.gql.Context(), id, notifyCustomer)
+}
+
+func (s *ReturnServiceOp) CancelWithContext(ctx context.Context, id graphql.ID, notifyCustomer bool) (*Return, error) {
+	return s.mutate(ctx, returnCancelMutation, map[string]interface{}{"id": id, "notifyCustomer": notifyCustomer})
+}
+
+// mutate runs one of the return mutations and returns the return of its payload.
+func (s *ReturnServiceOp) mutate(ctx context.Context, mutation string, vars map[string]interface{}) (*Return, error) {
+	payload := struct {
+		Return *returnNode `json:"return"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, mutation, vars, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Return == nil {
+		return nil, fmt.Errorf("empty response to mutation")
+	}
+	return payload.Return.toReturn(), nil
+}
@@ -0,0 +1,142 @@
+package shopify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestReturns(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	o := srv.AddOrder(&shopifytest.Order{
+		LineItems: []*shopifytest.LineItem{{ID: "gid://shopify/LineItem/1", Title: "Snowboard", Quantity: 2}},
+		FulfillmentOrders: []*shopifytest.FulfillmentOrder{{
+			Status:             "CLOSED",
+			AssignedLocationID: "gid://shopify/Location/1",
+			LineItems: []*shopifytest.FulfillmentOrderLineItem{{
+				ID:            "gid://shopify/FulfillmentOrderLineItem/1",
+				LineItemID:    "gid://shopify/LineItem/1",
+				TotalQuantity: 2,
+			}},
+		}},
+		Fulfillments: []*shopifytest.Fulfillment{{
+			LineItems: []*shopifytest.FulfillmentLineItem{{FulfillmentOrderLineItemID: "gid://shopify/FulfillmentOrderLineItem/1", Quantity: 2}},
+		}},
+	})
+
+	f, err := client.Fulfillment.GetWithContext(ctx, o.Fulfillments[0].ID)
+	if err != nil {
+		t.Fatalf("get fulfillment: %v", err)
+	}
+	if len(f.FulfillmentLineItems) != 1 || f.FulfillmentLineItems[0].Quantity != 2 || f.FulfillmentLineItems[0].LineItem.Title != "Snowboard" {
+		t.Fatalf("unexpected fulfillment line items: %+v", f.FulfillmentLineItems)
+	}
+	fulfillmentLineItemID := f.FulfillmentLineItems[0].ID
+
+	requested, err := client.Return.RequestWithContext(ctx, shopify.ReturnRequestInput{
+		OrderID: o.ID,
+		ReturnLineItems: []shopify.ReturnRequestLineItemInput{
+			{FulfillmentLineItemID: fulfillmentLineItemID, Quantity: 1, ReturnReason: shopify.ReturnReasonSizeTooSmall, CustomerNote: "Too short"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("request return: %v", err)
+	}
+	if requested.Status != shopify.ReturnStatusRequested || requested.Name != "#1001-R1" || requested.TotalQuantity != 1 ||
+		requested.ReturnLineItems[0].CustomerNote != "Too short" || requested.ReturnLineItems[0].FulfillmentLineItem.ID != fulfillmentLineItemID {
+		t.Errorf("unexpected requested return: %+v", requested)
+	}
+	_, err = client.Return.RequestWithContext(ctx, shopify.ReturnRequestInput{
+		OrderID:         o.ID,
+		ReturnLineItems: []shopify.ReturnRequestLineItemInput{{FulfillmentLineItemID: fulfillmentLineItemID, Quantity: 2, ReturnReason: shopify.ReturnReasonOther}},
+	})
+	if !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors returning more than fulfilled, got %v", err)
+	}
+	declined, err := client.Return.DeclineRequestWithContext(ctx, requested.ID, shopify.ReturnDecline{Reason: shopify.ReturnDeclineReasonFinalSale, Note: "Sale item"})
+	if err != nil {
+		t.Fatalf("decline return request: %v", err)
+	}
+	if declined.Status != shopify.ReturnStatusDeclined || declined.Decline == nil || declined.Decline.Reason != shopify.ReturnDeclineReasonFinalSale {
+		t.Errorf("unexpected declined return: %+v", declined)
+	}
+
+	created, err := client.Return.CreateWithContext(ctx, shopify.ReturnInput{
+		OrderID: o.ID,
+		ReturnLineItems: []shopify.ReturnLineItemInput{
+			{FulfillmentLineItemID: fulfillmentLineItemID, Quantity: 2, ReturnReason: shopify.ReturnReasonDefective, ReturnReasonNote: "Cracked"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create return: %v", err)
+	}
+	if created.Status != shopify.ReturnStatusOpen || created.TotalQuantity != 2 || created.Order.ID != o.ID {
+		t.Errorf("unexpected created return: %+v", created)
+	}
+	if _, err = client.Return.ApproveRequestWithContext(ctx, created.ID); !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors approving an open return, got %v", err)
+	}
+	if _, err = client.Return.CloseWithContext(ctx, created.ID); err != nil {
+		t.Fatalf("close return: %v", err)
+	}
+	if _, err = client.Return.ReopenWithContext(ctx, created.ID); err != nil {
+		t.Fatalf("reopen return: %v", err)
+	}
+	if _, err = client.Return.CancelWithContext(ctx, created.ID, false); err != nil {
+		t.Fatalf("cancel return: %v", err)
+	}
+	got, err := client.Return.GetWithContext(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("get return: %v", err)
+	}
+	if got.Status != shopify.ReturnStatusCanceled || len(got.ReturnLineItems) != 1 || got.ReturnLineItems[0].ReturnReasonNote != "Cracked" {
+		t.Errorf("unexpected canceled return: %+v", got)
+	}
+}
+
+func TestReturnRequestLostResponse(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	client.SetRetries(2)
+	ctx := context.Background()
+
+	o := srv.AddOrder(&shopifytest.Order{
+		LineItems: []*shopifytest.LineItem{{ID: "gid://shopify/LineItem/1", Title: "Snowboard", Quantity: 2}},
+		FulfillmentOrders: []*shopifytest.FulfillmentOrder{{
+			Status:             "CLOSED",
+			AssignedLocationID: "gid://shopify/Location/1",
+			LineItems: []*shopifytest.FulfillmentOrderLineItem{{
+				ID:            "gid://shopify/FulfillmentOrderLineItem/1",
+				LineItemID:    "gid://shopify/LineItem/1",
+				TotalQuantity: 2,
+			}},
+		}},
+		Fulfillments: []*shopifytest.Fulfillment{{
+			LineItems: []*shopifytest.FulfillmentLineItem{{FulfillmentOrderLineItemID: "gid://shopify/FulfillmentOrderLineItem/1", Quantity: 2}},
+		}},
+	})
+	f, err := client.Fulfillment.GetWithContext(ctx, o.Fulfillments[0].ID)
+	if err != nil {
+		t.Fatalf("get fulfillment: %v", err)
+	}
+
+	srv.DropResponses(1)
+	_, err = client.Return.RequestWithContext(ctx, shopify.ReturnRequestInput{
+		OrderID:         o.ID,
+		ReturnLineItems: []shopify.ReturnRequestLineItemInput{{FulfillmentLineItemID: f.FulfillmentLineItems[0].ID, Quantity: 1, ReturnReason: shopify.ReturnReasonOther}},
+	})
+	if err == nil {
+		t.Fatalf("expected the lost response to fail the return request")
+	}
+	if len(o.Returns) != 1 {
+		t.Errorf("expected the return to be requested once, got %d returns", len(o.Returns))
+	}
+}
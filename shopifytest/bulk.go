@@ -0,0 +1,392 @@
+package shopifytest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+type bulkOperationPayload struct {
+	BulkOperation *bulkOperationResolver
+	UserErrors    []*userError
+}
+
+type bulkOperationRunQueryArgs struct {
+	Query string
+}
+
+// BulkOperationRunQuery runs the query right away against the stored resources, so the
+// operation is already COMPLETED when the mutation returns.
+func (r *mutationResolver) BulkOperationRunQuery(ctx context.Context, args bulkOperationRunQueryArgs) *bulkOperationPayload {
+	resp := r.s.schema.Exec(ctx, args.Query, "", nil)
+	if len(resp.Errors) > 0 {
+		return &bulkOperationPayload{UserErrors: []*userError{newUserError("query", resp.Errors[0].Message)}}
+	}
+
+	var data map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(resp.Data))
+	d.UseNumber()
+	if err := d.Decode(&data); err != nil {
+		return &bulkOperationPayload{UserErrors: []*userError{newUserError("query", err.Error())}}
+	}
+
+	op := &BulkOperation{
+		ID:        r.s.newID("BulkOperation"),
+		Type:      "QUERY",
+		Status:    "COMPLETED",
+		Query:     args.Query,
+		CreatedAt: r.s.now(),
+	}
+	var buf bytes.Buffer
+	flattenBulkResult(data, "", func(line map[string]interface{}) {
+		b, _ := json.Marshal(line)
+		buf.Write(b)
+		buf.WriteByte('\n')
+		op.ObjectCount++
+		if _, ok := line["__parentId"]; !ok {
+			op.RootObjectCount++
+		}
+	})
+	r.s.completeBulkOperation(op, buf.Bytes())
+
+	return &bulkOperationPayload{BulkOperation: &bulkOperationResolver{op: op}, UserErrors: []*userError{}}
+}
+
+type bulkOperationRunMutationArgs struct {
+	Mutation         string
+	StagedUploadPath string
+	ClientIdentifier *string
+}
+
+// BulkOperationRunMutation runs the mutation right away once per line of the staged variables, so the
+// operation is already COMPLETED when the mutation returns. Each line of its result holds the response
+// to the mutation run with the variables at __lineNumber.
+func (r *mutationResolver) BulkOperationRunMutation(ctx context.Context, args bulkOperationRunMutationArgs) *bulkOperationPayload {
+	vars, ok := r.s.stagedUploads[args.StagedUploadPath]
+	if !ok || vars == nil {
+		return &bulkOperationPayload{UserErrors: []*userError{newUserError("stagedUploadPath", "The JSONL file could not be found. Try uploading the file again, and check that you've entered the URL correctly for the stagedUploadPath mutation argument.")}}
+	}
+	delete(r.s.stagedUploads, args.StagedUploadPath)
+	if !strings.HasPrefix(strings.TrimSpace(args.Mutation), "mutation") {
+		return &bulkOperationPayload{UserErrors: []*userError{newUserError("mutation", "Invalid mutation: the operation must be a mutation.")}}
+	}
+
+	op := &BulkOperation{
+		ID:        r.s.newID("BulkOperation"),
+		Type:      "MUTATION",
+		Status:    "COMPLETED",
+		Query:     args.Mutation,
+		CreatedAt: r.s.now(),
+	}
+	var buf bytes.Buffer
+	for i, l := range bytes.Split(vars, []byte("\n")) {
+		if len(bytes.TrimSpace(l)) == 0 {
+			continue
+		}
+		var v map[string]interface{}
+		if err := json.Unmarshal(l, &v); err != nil {
+			return &bulkOperationPayload{UserErrors: []*userError{newUserError("stagedUploadPath", fmt.Sprintf("Invalid JSONL on line %d: %s", i+1, err))}}
+		}
+
+		resp := r.s.schema.Exec(ctx, args.Mutation, "", v)
+		line := map[string]interface{}{"__lineNumber": i}
+		if resp.Data != nil {
+			line["data"] = json.RawMessage(resp.Data)
+		}
+		if len(resp.Errors) > 0 {
+			line["errors"] = resp.Errors
+		}
+		b, _ := json.Marshal(line)
+		buf.Write(b)
+		buf.WriteByte('\n')
+		op.ObjectCount++
+		op.RootObjectCount++
+	}
+	r.s.completeBulkOperation(op, buf.Bytes())
+
+	return &bulkOperationPayload{BulkOperation: &bulkOperationResolver{op: op}, UserErrors: []*userError{}}
+}
+
+// completeBulkOperation stores op with its result. When PendingBulkOperations is set, op is left
+// RUNNING with the result held back until FinishBulkOperations.
+func (s *Server) completeBulkOperation(op *BulkOperation, result []byte) {
+	op.result = result
+	s.bulkOperations = append(s.bulkOperations, op)
+	if s.PendingBulkOperations {
+		op.Status = "RUNNING"
+		return
+	}
+	s.finishBulkOperation(op)
+}
+
+func (s *Server) finishBulkOperation(op *BulkOperation) {
+	completedAt := s.now()
+	op.Status = "COMPLETED"
+	op.CompletedAt = &completedAt
+	op.FileSize = len(op.result)
+	if op.ObjectCount > 0 {
+		op.URL = s.URL + bulkOutputPath + string(legacyResourceID(op.ID)) + ".jsonl"
+	}
+}
+
+// FinishBulkOperations completes the bulk operations kept RUNNING by PendingBulkOperations, then
+// sends a BULK_OPERATIONS_FINISH webhook for each of them to the subscribed callback URLs. It returns
+// the first error met delivering the webhooks.
+func (s *Server) FinishBulkOperations() error {
+	s.mu.Lock()
+	var finished []*BulkOperation
+	for _, op := range s.bulkOperations {
+		if op.Status == "RUNNING" {
+			s.finishBulkOperation(op)
+			finished = append(finished, op)
+		}
+	}
+	var callbackURLs []string
+	for _, w := range s.webhooks {
+		if w.Topic == "BULK_OPERATIONS_FINISH" && w.CallbackURL != "" {
+			callbackURLs = append(callbackURLs, w.CallbackURL)
+		}
+	}
+	webhooks := make([][]byte, 0, len(finished))
+	for _, op := range finished {
+		b, _ := json.Marshal(map[string]interface{}{
+			"admin_graphql_api_id": op.ID,
+			"completed_at":         op.CompletedAt.Format(time.RFC3339),
+			"created_at":           op.CreatedAt.Format(time.RFC3339),
+			"error_code":           nil,
+			"status":               "completed",
+			"type":                 strings.ToLower(op.Type),
+		})
+		webhooks = append(webhooks, b)
+	}
+	s.mu.Unlock()
+
+	var firstErr error
+	for _, body := range webhooks {
+		for _, u := range callbackURLs {
+			if err := s.sendWebhook(u, "bulk_operations/finish", body); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// sendWebhook POSTs body to callbackURL with the headers Shopify sets on webhooks.
+func (s *Server) sendWebhook(callbackURL, topic string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(s.APISecret))
+	mac.Write(body)
+	s.mu.Lock()
+	s.lastID++
+	webhookID := fmt.Sprintf("%d", s.lastID)
+	s.mu.Unlock()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Shopify-Topic", topic)
+	req.Header.Set("X-Shopify-Hmac-Sha256", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-Shopify-Shop-Domain", s.ShopDomain)
+	req.Header.Set("X-Shopify-Webhook-Id", webhookID)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s to %s: %s", topic, callbackURL, resp.Status)
+	}
+	return nil
+}
+
+func (r *mutationResolver) BulkOperationCancel(args idArgs) *bulkOperationPayload {
+	op := r.s.bulkOperation(string(args.ID))
+	if op == nil {
+		return &bulkOperationPayload{UserErrors: []*userError{newUserError("id", "Bulk operation does not exist")}}
+	}
+	if op.Status != "CREATED" && op.Status != "RUNNING" {
+		return &bulkOperationPayload{
+			BulkOperation: &bulkOperationResolver{op: op},
+			UserErrors:    []*userError{newUserError("id", "A bulk operation cannot be canceled when it is "+strings.ToLower(op.Status))},
+		}
+	}
+
+	op.Status = "CANCELED"
+	completedAt := r.s.now()
+	op.CompletedAt = &completedAt
+
+	return &bulkOperationPayload{BulkOperation: &bulkOperationResolver{op: op}, UserErrors: []*userError{}}
+}
+
+// flattenBulkResult emits the nodes of the connections found in v the way Shopify writes bulk
+// operation results: each node is a line, and the nodes of connections nested in a node follow
+// it with a __parentId field set to the id of that node.
+func flattenBulkResult(v interface{}, parentID string, emit func(map[string]interface{})) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if edges, ok := obj["edges"].([]interface{}); ok {
+		for _, e := range edges {
+			edge, _ := e.(map[string]interface{})
+			node, ok := edge["node"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			line := make(map[string]interface{}, len(node)+1)
+			var nested []interface{}
+			for _, k := range sortedKeys(node) {
+				if isConnection(node[k]) {
+					nested = append(nested, node[k])
+					continue
+				}
+				line[k] = node[k]
+			}
+			if parentID != "" {
+				line["__parentId"] = parentID
+			}
+			emit(line)
+
+			id, _ := node["id"].(string)
+			for _, c := range nested {
+				flattenBulkResult(c, id, emit)
+			}
+		}
+		return
+	}
+
+	// objects outside connections, like the shop, are not part of the result
+	for _, k := range sortedKeys(obj) {
+		flattenBulkResult(obj[k], parentID, emit)
+	}
+}
+
+func isConnection(v interface{}) bool {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = obj["edges"]
+	return ok
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type stagedUploadsCreateArgs struct {
+	Input []struct {
+		Resource   string
+		Filename   string
+		MimeType   string
+		HttpMethod *string
+		FileSize   *scalar
+	}
+}
+
+type stagedUploadParameter struct {
+	Name  string
+	Value string
+}
+
+type stagedMediaUploadTarget struct {
+	URL         *scalar
+	ResourceURL *scalar
+	Parameters  []stagedUploadParameter
+}
+
+type stagedUploadsCreatePayload struct {
+	StagedTargets *[]stagedMediaUploadTarget
+	UserErrors    []*userError
+}
+
+// StagedUploadsCreate returns targets on the server itself, for multipart POST uploads.
+func (r *mutationResolver) StagedUploadsCreate(args stagedUploadsCreateArgs) *stagedUploadsCreatePayload {
+	targets := []stagedMediaUploadTarget{}
+	for _, in := range args.Input {
+		if in.HttpMethod != nil && *in.HttpMethod != "POST" {
+			return &stagedUploadsCreatePayload{UserErrors: []*userError{newUserError("httpMethod", "Only POST uploads are supported")}}
+		}
+
+		r.s.lastID++
+		key := fmt.Sprintf("tmp/%d/%s", r.s.lastID, in.Filename)
+		r.s.stagedUploads[key] = nil
+		url := scalar(r.s.URL + stagedUploadsPath)
+		resourceURL := scalar(r.s.URL + stagedUploadsPath + key)
+		targets = append(targets, stagedMediaUploadTarget{
+			URL:         &url,
+			ResourceURL: &resourceURL,
+			Parameters: []stagedUploadParameter{
+				{Name: "key", Value: key},
+				{Name: "Content-Type", Value: in.MimeType},
+				{Name: "success_action_status", Value: "201"},
+				{Name: "acl", Value: "private"},
+			},
+		})
+	}
+
+	return &stagedUploadsCreatePayload{StagedTargets: &targets, UserErrors: []*userError{}}
+}
+
+// serveStagedUpload stores the file of a multipart form under the key of a staged upload target.
+func (s *Server) serveStagedUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key := r.FormValue("key")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.stagedUploads[key]; !ok {
+		http.Error(w, "invalid key", http.StatusForbidden)
+		return
+	}
+	s.stagedUploads[key] = b
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) serveBulkOutput(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, bulkOutputPath), ".jsonl")
+
+	s.mu.Lock()
+	op := s.bulkOperation("gid://shopify/BulkOperation/" + id)
+	s.mu.Unlock()
+	if op == nil || op.result == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/jsonl")
+	_, _ = w.Write(op.result)
+}
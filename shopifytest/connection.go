@@ -0,0 +1,162 @@
+package shopifytest
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// connectionArgs are the arguments accepted by every connection.
+type connectionArgs struct {
+	First   *int32
+	After   *string
+	Last    *int32
+	Before  *string
+	Reverse *bool
+}
+
+// queryConnectionArgs are the arguments of connections supporting a search query.
+type queryConnectionArgs struct {
+	connectionArgs
+	Query *string
+}
+
+// namespaceConnectionArgs are the arguments of metafield connections.
+type namespaceConnectionArgs struct {
+	connectionArgs
+	Namespace *string
+}
+
+type pageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+	EndCursor       *string
+}
+
+type edge[T any] struct {
+	Cursor string
+	Node   T
+}
+
+type connection[T any] struct {
+	Edges    []*edge[T]
+	PageInfo pageInfo
+}
+
+// newConnection pages through nodes as Shopify does. Bulk operation queries don't set first or last,
+// in which case every node is returned.
+func newConnection[T any](nodes []T, idOf func(T) string, args connectionArgs) *connection[T] {
+	if args.Reverse != nil && *args.Reverse {
+		reversed := make([]T, len(nodes))
+		for i, n := range nodes {
+			reversed[len(nodes)-1-i] = n
+		}
+		nodes = reversed
+	}
+
+	start, end := 0, len(nodes)
+	for i, n := range nodes {
+		cursor := encodeCursor(idOf(n))
+		if args.After != nil && *args.After == cursor {
+			start = i + 1
+		}
+		if args.Before != nil && *args.Before == cursor {
+			end = i
+		}
+	}
+	if end < start {
+		end = start
+	}
+	hasNext, hasPrevious := end < len(nodes), start > 0
+	if args.First != nil && int(*args.First) < end-start {
+		end = start + int(*args.First)
+		hasNext = true
+	}
+	if args.Last != nil && int(*args.Last) < end-start {
+		start = end - int(*args.Last)
+		hasPrevious = true
+	}
+
+	c := &connection[T]{Edges: []*edge[T]{}}
+	for _, n := range nodes[start:end] {
+		c.Edges = append(c.Edges, &edge[T]{Cursor: encodeCursor(idOf(n)), Node: n})
+	}
+	c.PageInfo.HasNextPage = hasNext
+	c.PageInfo.HasPreviousPage = hasPrevious
+	if len(c.Edges) > 0 {
+		c.PageInfo.StartCursor = &c.Edges[0].Cursor
+		c.PageInfo.EndCursor = &c.Edges[len(c.Edges)-1].Cursor
+	}
+	return c
+}
+
+func encodeCursor(id string) string {
+	return base64.StdEncoding.EncodeToString([]byte("cursor:" + id))
+}
+
+// matchQuery reports whether a resource matches a search query made of space separated terms,
+// all of which must match. A term is either "field:value", compared to the values returned
+// by fields, or free text searched in text. Values ending with * match as a prefix.
+func matchQuery(query string, text string, fields func(name string) []string) bool {
+	for _, term := range splitQuery(query) {
+		name, value, ok := strings.Cut(term, ":")
+		if !ok {
+			if !strings.Contains(strings.ToLower(text), strings.ToLower(term)) {
+				return false
+			}
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		matched := false
+		for _, v := range fields(strings.ToLower(name)) {
+			if matchValue(v, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func matchValue(v, pattern string) bool {
+	v, pattern = strings.ToLower(v), strings.ToLower(pattern)
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(v, strings.TrimSuffix(pattern, "*"))
+	}
+	if v == pattern {
+		return true
+	}
+	// IDs can be searched by legacy resource ID
+	return strings.HasPrefix(v, "gid://") && v[strings.LastIndex(v, "/")+1:] == pattern
+}
+
+// splitQuery splits query on spaces outside of quotes.
+func splitQuery(query string) []string {
+	var (
+		terms []string
+		term  strings.Builder
+		quote rune
+	)
+	for _, r := range query {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == ' ':
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+			continue
+		}
+		term.WriteRune(r)
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
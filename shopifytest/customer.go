@@ -0,0 +1,629 @@
+package shopifytest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+// Queries
+
+func (r *queryResolver) Customer(args idArgs) *customerResolver {
+	c := r.s.customer(string(args.ID))
+	if c == nil {
+		return nil
+	}
+	return &customerResolver{c: c, s: r.s}
+}
+
+func (r *queryResolver) Customers(args queryConnectionArgs) *connection[*customerResolver] {
+	var resolvers []*customerResolver
+	for _, c := range r.s.customers {
+		if args.Query != nil && !matchQuery(*args.Query, c.FirstName+" "+c.LastName+" "+c.Email, customerFields(c)) {
+			continue
+		}
+		resolvers = append(resolvers, &customerResolver{c: c, s: r.s})
+	}
+	return newConnection(resolvers, func(r *customerResolver) string { return r.c.ID }, args.connectionArgs)
+}
+
+func customerFields(c *Customer) func(string) []string {
+	return func(name string) []string {
+		switch name {
+		case "id":
+			return []string{c.ID}
+		case "email":
+			return []string{c.Email}
+		case "phone":
+			return []string{c.Phone}
+		case "first_name":
+			return []string{c.FirstName}
+		case "last_name":
+			return []string{c.LastName}
+		case "tag":
+			return c.Tags
+		case "state":
+			return []string{c.State}
+		case "country":
+			var countries []string
+			for _, a := range c.Addresses {
+				countries = append(countries, a.Country, a.CountryCode)
+			}
+			return countries
+		}
+		return nil
+	}
+}
+
+// Customer
+
+type customerResolver struct {
+	c *Customer
+	s *Server
+}
+
+type emailMarketingConsent struct {
+	MarketingState      string
+	MarketingOptInLevel *string
+	ConsentUpdatedAt    *scalar
+}
+
+type smsMarketingConsent struct {
+	MarketingState       string
+	MarketingOptInLevel  string
+	ConsentUpdatedAt     *scalar
+	ConsentCollectedFrom *string
+}
+
+func (r *customerResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.c.ID)
+}
+
+func (r *customerResolver) LegacyResourceID() scalar {
+	return legacyResourceID(r.c.ID)
+}
+
+func (r *customerResolver) FirstName() *string {
+	return strPtr(r.c.FirstName)
+}
+
+func (r *customerResolver) LastName() *string {
+	return strPtr(r.c.LastName)
+}
+
+func (r *customerResolver) DisplayName() string {
+	if name := strings.TrimSpace(r.c.FirstName + " " + r.c.LastName); name != "" {
+		return name
+	}
+	if r.c.Email != "" {
+		return r.c.Email
+	}
+	return r.c.Phone
+}
+
+func (r *customerResolver) Email() *string {
+	return strPtr(r.c.Email)
+}
+
+func (r *customerResolver) Phone() *string {
+	return strPtr(r.c.Phone)
+}
+
+func (r *customerResolver) Note() *string {
+	return strPtr(r.c.Note)
+}
+
+func (r *customerResolver) Tags() []string {
+	return append([]string{}, r.c.Tags...)
+}
+
+func (r *customerResolver) State() string {
+	if r.c.State == "" {
+		return "DISABLED"
+	}
+	return r.c.State
+}
+
+func (r *customerResolver) VerifiedEmail() bool {
+	return r.c.VerifiedEmail
+}
+
+func (r *customerResolver) TaxExempt() bool {
+	return r.c.TaxExempt
+}
+
+func (r *customerResolver) Locale() string {
+	if r.c.Locale == "" {
+		return "en"
+	}
+	return r.c.Locale
+}
+
+// NumberOfOrders and AmountSpent are computed from the stored orders of the customer.
+func (r *customerResolver) NumberOfOrders() scalar {
+	return scalar(strconv.Itoa(len(r.orders())))
+}
+
+func (r *customerResolver) AmountSpent() moneyV2 {
+	var total float64
+	for _, o := range r.orders() {
+		total += parseAmount(string((&orderResolver{o: o, s: r.s}).TotalPriceSet().ShopMoney.Amount))
+	}
+	return r.s.money(formatAmount(total)).ShopMoney
+}
+
+func (r *customerResolver) orders() []*Order {
+	var orders []*Order
+	for _, o := range r.s.orders {
+		if o.Customer != nil && o.Customer.ID == r.c.ID {
+			orders = append(orders, o)
+		}
+	}
+	return orders
+}
+
+func (r *customerResolver) DefaultAddress() *mailingAddressResolver {
+	for _, a := range r.c.Addresses {
+		if a.ID == r.c.DefaultAddressID {
+			return &mailingAddressResolver{a: a}
+		}
+	}
+	return nil
+}
+
+func (r *customerResolver) Addresses(args firstArgs) []*mailingAddressResolver {
+	resolvers := []*mailingAddressResolver{}
+	for _, a := range r.c.Addresses {
+		if args.First != nil && len(resolvers) == int(*args.First) {
+			break
+		}
+		resolvers = append(resolvers, &mailingAddressResolver{a: a})
+	}
+	return resolvers
+}
+
+// EmailMarketingConsent is null when the customer has no email, and defaults to NOT_SUBSCRIBED otherwise.
+func (r *customerResolver) EmailMarketingConsent() *emailMarketingConsent {
+	if r.c.Email == "" {
+		return nil
+	}
+	consent := r.c.EmailMarketingConsent
+	if consent == nil {
+		consent = &MarketingConsent{State: "NOT_SUBSCRIBED"}
+	}
+	return &emailMarketingConsent{
+		MarketingState:      consent.State,
+		MarketingOptInLevel: strPtr(consent.OptInLevel),
+		ConsentUpdatedAt:    dateTimePtr(consent.UpdatedAt),
+	}
+}
+
+// SmsMarketingConsent is null when the customer has no phone, and defaults to NOT_SUBSCRIBED otherwise.
+func (r *customerResolver) SmsMarketingConsent() *smsMarketingConsent {
+	if r.c.Phone == "" {
+		return nil
+	}
+	consent := r.c.SmsMarketingConsent
+	if consent == nil {
+		consent = &MarketingConsent{State: "NOT_SUBSCRIBED"}
+	}
+	optInLevel := consent.OptInLevel
+	if optInLevel == "" {
+		optInLevel = "UNKNOWN"
+	}
+	return &smsMarketingConsent{
+		MarketingState:       consent.State,
+		MarketingOptInLevel:  optInLevel,
+		ConsentUpdatedAt:     dateTimePtr(consent.UpdatedAt),
+		ConsentCollectedFrom: strPtr("OTHER"),
+	}
+}
+
+func (r *customerResolver) CreatedAt() scalar {
+	return dateTime(r.c.CreatedAt)
+}
+
+func (r *customerResolver) UpdatedAt() scalar {
+	return dateTime(r.c.UpdatedAt)
+}
+
+func (r *customerResolver) Metafield(args metafieldArgs) *metafieldResolver {
+	return findMetafield(r.c.Metafields, "CUSTOMER", args)
+}
+
+func (r *customerResolver) Metafields(args namespaceConnectionArgs) *connection[*metafieldResolver] {
+	return metafieldConnection(r.c.Metafields, "CUSTOMER", args)
+}
+
+type mailingAddressResolver struct {
+	a *MailingAddress
+}
+
+func (r *mailingAddressResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.a.ID)
+}
+
+func (r *mailingAddressResolver) FirstName() *string {
+	return strPtr(r.a.FirstName)
+}
+
+func (r *mailingAddressResolver) LastName() *string {
+	return strPtr(r.a.LastName)
+}
+
+func (r *mailingAddressResolver) Name() *string {
+	return strPtr(strings.TrimSpace(r.a.FirstName + " " + r.a.LastName))
+}
+
+func (r *mailingAddressResolver) Company() *string {
+	return strPtr(r.a.Company)
+}
+
+func (r *mailingAddressResolver) Address1() *string {
+	return strPtr(r.a.Address1)
+}
+
+func (r *mailingAddressResolver) Address2() *string {
+	return strPtr(r.a.Address2)
+}
+
+func (r *mailingAddressResolver) City() *string {
+	return strPtr(r.a.City)
+}
+
+func (r *mailingAddressResolver) Province() *string {
+	return strPtr(r.a.Province)
+}
+
+func (r *mailingAddressResolver) ProvinceCode() *string {
+	return strPtr(r.a.ProvinceCode)
+}
+
+func (r *mailingAddressResolver) Country() *string {
+	return strPtr(r.a.Country)
+}
+
+func (r *mailingAddressResolver) CountryCodeV2() *string {
+	return strPtr(r.a.CountryCode)
+}
+
+func (r *mailingAddressResolver) Zip() *string {
+	return strPtr(r.a.Zip)
+}
+
+func (r *mailingAddressResolver) Phone() *string {
+	return strPtr(r.a.Phone)
+}
+
+// Mutations
+
+type mailingAddressInput struct {
+	ID           *graphqlserver.ID
+	Address1     *string
+	Address2     *string
+	City         *string
+	Company      *string
+	CountryCode  *string
+	FirstName    *string
+	LastName     *string
+	Phone        *string
+	ProvinceCode *string
+	Zip          *string
+}
+
+type marketingConsentInput struct {
+	MarketingState      string
+	MarketingOptInLevel *string
+	ConsentUpdatedAt    *scalar
+}
+
+type customerInput struct {
+	ID                    *graphqlserver.ID
+	FirstName             *string
+	LastName              *string
+	Email                 *string
+	Phone                 *string
+	Note                  *string
+	Tags                  *[]string
+	Locale                *string
+	TaxExempt             *bool
+	Addresses             *[]mailingAddressInput
+	Metafields            *[]metafieldInput
+	EmailMarketingConsent *marketingConsentInput
+	SmsMarketingConsent   *marketingConsentInput
+}
+
+type customerPayload struct {
+	Customer   *customerResolver
+	UserErrors []*userError
+}
+
+type customerArgs struct {
+	Input customerInput
+}
+
+func (r *mutationResolver) CustomerCreate(args customerArgs) *customerPayload {
+	if args.Input.ID != nil {
+		return customerFailed("id", "Id must be blank")
+	}
+	c := &Customer{}
+	if errs := r.s.applyCustomerInput(c, args.Input); len(errs) > 0 {
+		return &customerPayload{UserErrors: errs}
+	}
+	if c.FirstName == "" && c.LastName == "" && c.Email == "" && c.Phone == "" {
+		return customerFailed("customer", "A name, phone number, or email address must be present")
+	}
+	setConsent(&c.EmailMarketingConsent, args.Input.EmailMarketingConsent)
+	setConsent(&c.SmsMarketingConsent, args.Input.SmsMarketingConsent)
+	r.s.fillCustomer(c)
+	r.s.customers = append(r.s.customers, c)
+
+	return &customerPayload{Customer: &customerResolver{c: c, s: r.s}, UserErrors: []*userError{}}
+}
+
+func (r *mutationResolver) CustomerUpdate(args customerArgs) *customerPayload {
+	if args.Input.ID == nil {
+		return customerFailed("id", "Customer does not exist")
+	}
+	c := r.s.customer(string(*args.Input.ID))
+	if c == nil {
+		return customerFailed("id", "Customer does not exist")
+	}
+	if args.Input.EmailMarketingConsent != nil || args.Input.SmsMarketingConsent != nil {
+		return customerFailed("input", "To update marketing consent, use the customerEmailMarketingConsentUpdate or customerSmsMarketingConsentUpdate mutations instead")
+	}
+
+	// validate on a copy, so the customer is left as is on errors
+	updated := *c
+	if errs := r.s.applyCustomerInput(&updated, args.Input); len(errs) > 0 {
+		return &customerPayload{UserErrors: errs}
+	}
+	*c = updated
+	r.s.fillCustomer(c)
+	c.UpdatedAt = r.s.now()
+
+	return &customerPayload{Customer: &customerResolver{c: c, s: r.s}, UserErrors: []*userError{}}
+}
+
+func (s *Server) applyCustomerInput(c *Customer, input customerInput) []*userError {
+	if input.Email != nil && *input.Email != "" {
+		if !strings.Contains(*input.Email, "@") {
+			return []*userError{newUserError("email", "Email is invalid")}
+		}
+		for _, other := range s.customers {
+			if other.ID != c.ID && strings.EqualFold(other.Email, *input.Email) {
+				return []*userError{newUserError("email", "Email has already been taken")}
+			}
+		}
+	}
+
+	setString(&c.FirstName, input.FirstName)
+	setString(&c.LastName, input.LastName)
+	setString(&c.Email, input.Email)
+	setString(&c.Phone, input.Phone)
+	setString(&c.Note, input.Note)
+	setStrings(&c.Tags, input.Tags)
+	setString(&c.Locale, input.Locale)
+	if input.TaxExempt != nil {
+		c.TaxExempt = *input.TaxExempt
+	}
+	if input.Addresses != nil {
+		addresses, errs := s.applyAddressInputs(c.Addresses, *input.Addresses)
+		if len(errs) > 0 {
+			return errs
+		}
+		c.Addresses = addresses
+		if !containsAddress(addresses, c.DefaultAddressID) {
+			c.DefaultAddressID = ""
+		}
+	}
+	if input.Metafields != nil {
+		c.Metafields = s.applyMetafieldInputs(c.Metafields, *input.Metafields)
+	}
+	return nil
+}
+
+// applyAddressInputs returns the addresses replacing the existing ones: inputs with an ID update
+// the matching address, the others create a new one, and addresses with no input are dropped.
+func (s *Server) applyAddressInputs(existing []*MailingAddress, inputs []mailingAddressInput) ([]*MailingAddress, []*userError) {
+	addresses := []*MailingAddress{}
+	for i, input := range inputs {
+		a := &MailingAddress{}
+		if input.ID != nil {
+			var found bool
+			for _, e := range existing {
+				if e.ID == string(*input.ID) {
+					*a, found = *e, true
+					break
+				}
+			}
+			if !found {
+				return nil, []*userError{newUserError("addresses."+strconv.Itoa(i)+".id", "Address does not exist")}
+			}
+		} else {
+			a.ID = s.newID("MailingAddress")
+		}
+		setAddress(a, input)
+		addresses = append(addresses, a)
+	}
+	return addresses, nil
+}
+
+// setAddress sets the fields of a set in input.
+func setAddress(a *MailingAddress, input mailingAddressInput) {
+	setString(&a.Address1, input.Address1)
+	setString(&a.Address2, input.Address2)
+	setString(&a.City, input.City)
+	setString(&a.Company, input.Company)
+	setString(&a.CountryCode, input.CountryCode)
+	setString(&a.FirstName, input.FirstName)
+	setString(&a.LastName, input.LastName)
+	setString(&a.Phone, input.Phone)
+	setString(&a.ProvinceCode, input.ProvinceCode)
+	setString(&a.Zip, input.Zip)
+}
+
+func containsAddress(addresses []*MailingAddress, id string) bool {
+	for _, a := range addresses {
+		if a.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func setConsent(dst **MarketingConsent, input *marketingConsentInput) {
+	if input == nil {
+		return
+	}
+	consent := &MarketingConsent{State: input.MarketingState}
+	setString(&consent.OptInLevel, input.MarketingOptInLevel)
+	if input.ConsentUpdatedAt != nil {
+		if t, err := time.Parse(time.RFC3339, string(*input.ConsentUpdatedAt)); err == nil {
+			consent.UpdatedAt = &t
+		}
+	}
+	*dst = consent
+}
+
+type customerDeleteArgs struct {
+	Input struct {
+		ID graphqlserver.ID
+	}
+}
+
+// CustomerDelete refuses to delete customers with orders, like Shopify does.
+func (r *mutationResolver) CustomerDelete(args customerDeleteArgs) *deletePayload {
+	id := string(args.Input.ID)
+	for i, c := range r.s.customers {
+		if c.ID != id {
+			continue
+		}
+		if len((&customerResolver{c: c, s: r.s}).orders()) > 0 {
+			return deleteFailed("id", "Customer can’t be deleted because they have associated orders")
+		}
+		r.s.customers = append(r.s.customers[:i], r.s.customers[i+1:]...)
+		return deleted(id)
+	}
+	return deleteFailed("id", "Customer does not exist")
+}
+
+type customerUpdateDefaultAddressArgs struct {
+	CustomerID graphqlserver.ID
+	AddressID  graphqlserver.ID
+}
+
+func (r *mutationResolver) CustomerUpdateDefaultAddress(args customerUpdateDefaultAddressArgs) *customerPayload {
+	c := r.s.customer(string(args.CustomerID))
+	if c == nil {
+		return customerFailed("customerId", "Customer does not exist")
+	}
+	if !containsAddress(c.Addresses, string(args.AddressID)) {
+		return customerFailed("addressId", "Address does not exist")
+	}
+	c.DefaultAddressID = string(args.AddressID)
+	c.UpdatedAt = r.s.now()
+
+	return &customerPayload{Customer: &customerResolver{c: c, s: r.s}, UserErrors: []*userError{}}
+}
+
+type customerEmailMarketingConsentUpdateArgs struct {
+	Input struct {
+		CustomerID            graphqlserver.ID
+		EmailMarketingConsent marketingConsentInput
+	}
+}
+
+func (r *mutationResolver) CustomerEmailMarketingConsentUpdate(args customerEmailMarketingConsentUpdateArgs) *customerPayload {
+	c := r.s.customer(string(args.Input.CustomerID))
+	if c == nil {
+		return customerFailed("customerId", "Customer does not exist")
+	}
+	if c.Email == "" {
+		return customerFailed("emailMarketingConsent", "An email address is required to set the email marketing consent state")
+	}
+	setConsent(&c.EmailMarketingConsent, &args.Input.EmailMarketingConsent)
+	c.UpdatedAt = r.s.now()
+
+	return &customerPayload{Customer: &customerResolver{c: c, s: r.s}, UserErrors: []*userError{}}
+}
+
+type customerSmsMarketingConsentUpdateArgs struct {
+	Input struct {
+		CustomerID          graphqlserver.ID
+		SmsMarketingConsent marketingConsentInput
+	}
+}
+
+func (r *mutationResolver) CustomerSmsMarketingConsentUpdate(args customerSmsMarketingConsentUpdateArgs) *customerPayload {
+	c := r.s.customer(string(args.Input.CustomerID))
+	if c == nil {
+		return customerFailed("customerId", "Customer does not exist")
+	}
+	if c.Phone == "" {
+		return customerFailed("smsMarketingConsent", "A phone number is required to set the SMS consent state")
+	}
+	setConsent(&c.SmsMarketingConsent, &args.Input.SmsMarketingConsent)
+	c.UpdatedAt = r.s.now()
+
+	return &customerPayload{Customer: &customerResolver{c: c, s: r.s}, UserErrors: []*userError{}}
+}
+
+func customerFailed(field string, message string) *customerPayload {
+	return &customerPayload{UserErrors: []*userError{newUserError(field, message)}}
+}
+
+// Tags
+
+type tagsArgs struct {
+	ID   graphqlserver.ID
+	Tags []string
+}
+
+type tagsPayload struct {
+	Node       *nodeResolver
+	UserErrors []*userError
+}
+
+// TagsAdd adds tags to a product, order or customer.
+func (r *mutationResolver) TagsAdd(args tagsArgs) *tagsPayload {
+	return r.s.updateTags(string(args.ID), func(tags []string) []string {
+		for _, tag := range args.Tags {
+			if !contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		return tags
+	})
+}
+
+// TagsRemove removes tags from a product, order or customer.
+func (r *mutationResolver) TagsRemove(args tagsArgs) *tagsPayload {
+	return r.s.updateTags(string(args.ID), func(tags []string) []string {
+		for _, tag := range args.Tags {
+			tags = remove(tags, tag)
+		}
+		return tags
+	})
+}
+
+func (s *Server) updateTags(id string, update func([]string) []string) *tagsPayload {
+	var tags *[]string
+	var updatedAt *time.Time
+	if p := s.product(id); p != nil {
+		tags, updatedAt = &p.Tags, &p.UpdatedAt
+	} else if o := s.order(id); o != nil {
+		tags, updatedAt = &o.Tags, &o.UpdatedAt
+	} else if c := s.customer(id); c != nil {
+		tags, updatedAt = &c.Tags, &c.UpdatedAt
+	} else {
+		return &tagsPayload{UserErrors: []*userError{newUserError("id", "Resource does not exist")}}
+	}
+
+	*tags = append([]string{}, update(*tags)...)
+	*updatedAt = s.now()
+
+	return &tagsPayload{Node: s.node(id), UserErrors: []*userError{}}
+}
@@ -0,0 +1,998 @@
+package shopifytest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+// Queries
+
+func (r *queryResolver) CodeDiscountNode(args idArgs) *discountNodeResolver {
+	d := r.s.discount(string(args.ID))
+	if d == nil || d.Automatic {
+		return nil
+	}
+	return &discountNodeResolver{d: d, s: r.s}
+}
+
+type codeArgs struct {
+	Code string
+}
+
+func (r *queryResolver) CodeDiscountNodeByCode(args codeArgs) *discountNodeResolver {
+	if d := r.s.discountByCode(args.Code); d != nil {
+		return &discountNodeResolver{d: d, s: r.s}
+	}
+	return nil
+}
+
+func (r *queryResolver) AutomaticDiscountNode(args idArgs) *discountNodeResolver {
+	d := r.s.discount(string(args.ID))
+	if d == nil || !d.Automatic {
+		return nil
+	}
+	return &discountNodeResolver{d: d, s: r.s}
+}
+
+func (r *queryResolver) DiscountRedeemCodeBulkCreation(args idArgs) *redeemCodeBulkCreation {
+	return r.s.redeemCodeBulkCreation(string(args.ID))
+}
+
+// discountByCode returns the code discount redeemed with code, which is case insensitive.
+func (s *Server) discountByCode(code string) *Discount {
+	for _, d := range s.discounts {
+		for _, c := range d.Codes {
+			if strings.EqualFold(c.Code, code) {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// Discount
+
+// discountNodeResolver resolves both DiscountCodeNode and DiscountAutomaticNode.
+type discountNodeResolver struct {
+	d *Discount
+	s *Server
+}
+
+func (r *discountNodeResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.d.ID)
+}
+
+func (r *discountNodeResolver) CodeDiscount() *discountResolver {
+	return &discountResolver{d: r.d, s: r.s}
+}
+
+func (r *discountNodeResolver) AutomaticDiscount() *discountResolver {
+	return &discountResolver{d: r.d, s: r.s}
+}
+
+// discountResolver resolves the members of the DiscountCode and DiscountAutomatic unions.
+type discountResolver struct {
+	d *Discount
+	s *Server
+}
+
+func (r *discountResolver) is(automatic bool, typ string) (*discountResolver, bool) {
+	return r, r.d.Automatic == automatic && r.d.Type == typ
+}
+
+func (r *discountResolver) ToDiscountCodeBasic() (*discountResolver, bool) {
+	return r.is(false, "BASIC")
+}
+
+func (r *discountResolver) ToDiscountCodeBxgy() (*discountResolver, bool) {
+	return r.is(false, "BXGY")
+}
+
+func (r *discountResolver) ToDiscountCodeFreeShipping() (*discountResolver, bool) {
+	return r.is(false, "FREE_SHIPPING")
+}
+
+func (r *discountResolver) ToDiscountAutomaticBasic() (*discountResolver, bool) {
+	return r.is(true, "BASIC")
+}
+
+func (r *discountResolver) ToDiscountAutomaticBxgy() (*discountResolver, bool) {
+	return r.is(true, "BXGY")
+}
+
+func (r *discountResolver) ToDiscountAutomaticFreeShipping() (*discountResolver, bool) {
+	return r.is(true, "FREE_SHIPPING")
+}
+
+func (r *discountResolver) Title() string {
+	return r.d.Title
+}
+
+func (r *discountResolver) Status() string {
+	now := r.s.now()
+	switch {
+	case r.d.EndsAt != nil && !r.d.EndsAt.After(now):
+		return "EXPIRED"
+	case r.d.StartsAt.After(now):
+		return "SCHEDULED"
+	default:
+		return "ACTIVE"
+	}
+}
+
+func (r *discountResolver) Summary() string {
+	d := r.d
+	switch d.Type {
+	case "FREE_SHIPPING":
+		return "Free shipping on all products"
+	case "BXGY":
+		return fmt.Sprintf("Buy %d items, get %d items at %s off", d.BuysQuantity, d.GetsQuantity, percent(d.Percentage))
+	}
+	if d.Amount != "" {
+		return fmt.Sprintf("%s %s off", d.Amount, r.s.CurrencyCode)
+	}
+	return percent(d.Percentage) + " off"
+}
+
+func percent(f float64) string {
+	return strconv.FormatFloat(f*100, 'f', -1, 64) + "%"
+}
+
+func (r *discountResolver) StartsAt() scalar {
+	return dateTime(r.d.StartsAt)
+}
+
+func (r *discountResolver) EndsAt() *scalar {
+	return dateTimePtr(r.d.EndsAt)
+}
+
+func (r *discountResolver) CreatedAt() scalar {
+	return dateTime(r.d.CreatedAt)
+}
+
+func (r *discountResolver) UpdatedAt() scalar {
+	return dateTime(r.d.UpdatedAt)
+}
+
+func (r *discountResolver) AsyncUsageCount() int32 {
+	var count int
+	for _, c := range r.d.Codes {
+		count += c.UsageCount
+	}
+	return int32(count)
+}
+
+func (r *discountResolver) CombinesWith() DiscountCombinesWith {
+	return r.d.CombinesWith
+}
+
+func (r *discountResolver) UsageLimit() *int32 {
+	return limit(r.d.UsageLimit)
+}
+
+func (r *discountResolver) AppliesOncePerCustomer() bool {
+	return r.d.AppliesOncePerCustomer
+}
+
+func (r *discountResolver) UsesPerOrderLimit() *int32 {
+	return limit(r.d.UsesPerOrderLimit)
+}
+
+// limit returns nil for the zero limit, meaning unlimited.
+func limit(i int) *int32 {
+	if i == 0 {
+		return nil
+	}
+	return int32Ptr(i)
+}
+
+type redeemCodeResolver struct {
+	c *DiscountRedeemCode
+}
+
+func (r *redeemCodeResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.c.ID)
+}
+
+func (r *redeemCodeResolver) Code() string {
+	return r.c.Code
+}
+
+func (r *redeemCodeResolver) AsyncUsageCount() int32 {
+	return int32(r.c.UsageCount)
+}
+
+func (r *discountResolver) Codes(args connectionArgs) *connection[*redeemCodeResolver] {
+	var resolvers []*redeemCodeResolver
+	for _, c := range r.d.Codes {
+		resolvers = append(resolvers, &redeemCodeResolver{c: c})
+	}
+	return newConnection(resolvers, func(r *redeemCodeResolver) string { return r.c.ID }, args)
+}
+
+type discountCustomerGets struct {
+	Value                    *discountValue
+	Items                    *discountItems
+	AppliesOnOneTimePurchase bool
+	AppliesOnSubscription    bool
+}
+
+type discountCustomerBuys struct {
+	Value *discountBuysValue
+	Items *discountItems
+}
+
+func (r *discountResolver) CustomerGets() *discountCustomerGets {
+	if r.d.Type == "FREE_SHIPPING" {
+		return nil
+	}
+	value := &discountValue{}
+	switch {
+	case r.d.GetsQuantity > 0:
+		value.onQuantity = &discountOnQuantity{
+			Quantity: discountQuantity{Quantity: scalar(strconv.Itoa(r.d.GetsQuantity))},
+			Effect:   &discountValue{percentage: &discountPercentage{Percentage: r.d.Percentage}},
+		}
+	case r.d.Amount != "":
+		value.amount = &discountAmount{Amount: r.s.money(r.d.Amount).ShopMoney, AppliesOnEachItem: r.d.AppliesOnEachItem}
+	default:
+		value.percentage = &discountPercentage{Percentage: r.d.Percentage}
+	}
+	return &discountCustomerGets{Value: value, Items: r.s.discountItems(r.d.GetsItems), AppliesOnOneTimePurchase: true}
+}
+
+func (r *discountResolver) CustomerBuys() *discountCustomerBuys {
+	if r.d.Type != "BXGY" {
+		return nil
+	}
+	value := &discountBuysValue{}
+	if r.d.BuysAmount != "" {
+		value.amount = &discountPurchaseAmount{Amount: scalar(r.d.BuysAmount)}
+	} else {
+		value.quantity = &discountQuantity{Quantity: scalar(strconv.Itoa(r.d.BuysQuantity))}
+	}
+	return &discountCustomerBuys{Value: value, Items: r.s.discountItems(r.d.BuysItems)}
+}
+
+func (r *discountResolver) MinimumRequirement() *discountMinimumRequirement {
+	switch {
+	case r.d.MinimumQuantity > 0:
+		return &discountMinimumRequirement{quantity: &discountMinimumQuantity{
+			GreaterThanOrEqualToQuantity: scalar(strconv.Itoa(r.d.MinimumQuantity)),
+		}}
+	case r.d.MinimumSubtotal != "":
+		return &discountMinimumRequirement{subtotal: &discountMinimumSubtotal{
+			GreaterThanOrEqualToSubtotal: r.s.money(r.d.MinimumSubtotal).ShopMoney,
+		}}
+	}
+	return nil
+}
+
+func (r *discountResolver) DestinationSelection() *discountDestinationSelection {
+	if len(r.d.Countries) == 0 {
+		return &discountDestinationSelection{all: &discountCountryAll{AllCountries: true}}
+	}
+	return &discountDestinationSelection{countries: &discountCountries{
+		Countries:          append([]string{}, r.d.Countries...),
+		IncludeRestOfWorld: r.d.IncludeRestOfWorld,
+	}}
+}
+
+func (r *discountResolver) MaximumShippingPrice() *moneyV2 {
+	if r.d.MaximumShippingPrice == "" {
+		return nil
+	}
+	m := r.s.money(r.d.MaximumShippingPrice).ShopMoney
+	return &m
+}
+
+// discountValue resolves the DiscountCustomerGetsValue and DiscountEffect unions.
+type discountValue struct {
+	percentage *discountPercentage
+	amount     *discountAmount
+	onQuantity *discountOnQuantity
+}
+
+type discountPercentage struct {
+	Percentage float64
+}
+
+type discountAmount struct {
+	Amount            moneyV2
+	AppliesOnEachItem bool
+}
+
+type discountOnQuantity struct {
+	Quantity discountQuantity
+	Effect   *discountValue
+}
+
+type discountQuantity struct {
+	Quantity scalar
+}
+
+func (v *discountValue) ToDiscountPercentage() (*discountPercentage, bool) {
+	return v.percentage, v.percentage != nil
+}
+
+func (v *discountValue) ToDiscountAmount() (*discountAmount, bool) {
+	return v.amount, v.amount != nil
+}
+
+func (v *discountValue) ToDiscountOnQuantity() (*discountOnQuantity, bool) {
+	return v.onQuantity, v.onQuantity != nil
+}
+
+// discountBuysValue resolves the DiscountCustomerBuysValue union.
+type discountBuysValue struct {
+	quantity *discountQuantity
+	amount   *discountPurchaseAmount
+}
+
+type discountPurchaseAmount struct {
+	Amount scalar
+}
+
+func (v *discountBuysValue) ToDiscountQuantity() (*discountQuantity, bool) {
+	return v.quantity, v.quantity != nil
+}
+
+func (v *discountBuysValue) ToDiscountPurchaseAmount() (*discountPurchaseAmount, bool) {
+	return v.amount, v.amount != nil
+}
+
+// discountMinimumRequirement resolves the DiscountMinimumRequirement union.
+type discountMinimumRequirement struct {
+	quantity *discountMinimumQuantity
+	subtotal *discountMinimumSubtotal
+}
+
+type discountMinimumQuantity struct {
+	GreaterThanOrEqualToQuantity scalar
+}
+
+type discountMinimumSubtotal struct {
+	GreaterThanOrEqualToSubtotal moneyV2
+}
+
+func (m *discountMinimumRequirement) ToDiscountMinimumQuantity() (*discountMinimumQuantity, bool) {
+	return m.quantity, m.quantity != nil
+}
+
+func (m *discountMinimumRequirement) ToDiscountMinimumSubtotal() (*discountMinimumSubtotal, bool) {
+	return m.subtotal, m.subtotal != nil
+}
+
+// discountDestinationSelection resolves the DiscountShippingDestinationSelection union.
+type discountDestinationSelection struct {
+	all       *discountCountryAll
+	countries *discountCountries
+}
+
+type discountCountryAll struct {
+	AllCountries bool
+}
+
+type discountCountries struct {
+	Countries          []string
+	IncludeRestOfWorld bool
+}
+
+func (d *discountDestinationSelection) ToDiscountCountryAll() (*discountCountryAll, bool) {
+	return d.all, d.all != nil
+}
+
+func (d *discountDestinationSelection) ToDiscountCountries() (*discountCountries, bool) {
+	return d.countries, d.countries != nil
+}
+
+// discountItems resolves the DiscountItems union.
+type discountItems struct {
+	items DiscountItems
+	s     *Server
+}
+
+type allDiscountItems struct {
+	AllItems bool
+}
+
+func (s *Server) discountItems(items DiscountItems) *discountItems {
+	return &discountItems{items: items, s: s}
+}
+
+func (r *discountItems) ToAllDiscountItems() (*allDiscountItems, bool) {
+	all := len(r.items.ProductIDs) == 0 && len(r.items.VariantIDs) == 0 && len(r.items.CollectionIDs) == 0
+	return &allDiscountItems{AllItems: true}, all
+}
+
+func (r *discountItems) ToDiscountProducts() (*discountItems, bool) {
+	return r, len(r.items.ProductIDs) > 0 || len(r.items.VariantIDs) > 0
+}
+
+func (r *discountItems) ToDiscountCollections() (*discountItems, bool) {
+	return r, len(r.items.CollectionIDs) > 0
+}
+
+func (r *discountItems) Products(args connectionArgs) *connection[*productResolver] {
+	var products []*Product
+	for _, id := range r.items.ProductIDs {
+		if p := r.s.product(id); p != nil {
+			products = append(products, p)
+		}
+	}
+	return r.s.productConnection(products, queryConnectionArgs{connectionArgs: args})
+}
+
+func (r *discountItems) ProductVariants(args connectionArgs) *connection[*variantResolver] {
+	var resolvers []*variantResolver
+	for _, id := range r.items.VariantIDs {
+		if p, v := r.s.variant(id); v != nil {
+			resolvers = append(resolvers, &variantResolver{v: v, p: p, s: r.s})
+		}
+	}
+	return newConnection(resolvers, func(r *variantResolver) string { return r.v.ID }, args)
+}
+
+func (r *discountItems) Collections(args connectionArgs) *connection[*collectionResolver] {
+	var collections []*Collection
+	for _, id := range r.items.CollectionIDs {
+		if c := r.s.collection(id); c != nil {
+			collections = append(collections, c)
+		}
+	}
+	return r.s.collectionConnection(collections, queryConnectionArgs{connectionArgs: args})
+}
+
+// redeemCodeBulkCreation is a discountRedeemCodeBulkAdd run by the fake server, which creates the
+// codes right away.
+type redeemCodeBulkCreation struct {
+	id    string
+	codes []*redeemCodeBulkCreationCode
+}
+
+type redeemCodeBulkCreationCode struct {
+	Code   string
+	Errors []*userError
+}
+
+func (c *redeemCodeBulkCreation) ID() graphqlserver.ID {
+	return graphqlserver.ID(c.id)
+}
+
+func (c *redeemCodeBulkCreation) Done() bool {
+	return true
+}
+
+func (c *redeemCodeBulkCreation) CodesCount() int32 {
+	return int32(len(c.codes))
+}
+
+func (c *redeemCodeBulkCreation) ImportedCount() int32 {
+	return c.CodesCount() - c.FailedCount()
+}
+
+func (c *redeemCodeBulkCreation) FailedCount() int32 {
+	var failed int32
+	for _, code := range c.codes {
+		if len(code.Errors) > 0 {
+			failed++
+		}
+	}
+	return failed
+}
+
+func (c *redeemCodeBulkCreation) Codes(args connectionArgs) *connection[*redeemCodeBulkCreationCode] {
+	return newConnection(c.codes, func(code *redeemCodeBulkCreationCode) string { return code.Code }, args)
+}
+
+// Mutations
+
+type discountItemsInput struct {
+	All      *bool
+	Products *struct {
+		ProductsToAdd           *[]graphqlserver.ID
+		ProductsToRemove        *[]graphqlserver.ID
+		ProductVariantsToAdd    *[]graphqlserver.ID
+		ProductVariantsToRemove *[]graphqlserver.ID
+	}
+	Collections *struct {
+		Add    *[]graphqlserver.ID
+		Remove *[]graphqlserver.ID
+	}
+}
+
+// discountInput is any of the inputs of the discount mutations, the fields of which it gathers.
+type discountInput struct {
+	Title                  *string
+	Code                   *string
+	StartsAt               *scalar
+	EndsAt                 *scalar
+	UsageLimit             *int32
+	UsesPerOrderLimit      *int32
+	AppliesOncePerCustomer *bool
+	CustomerSelection      *struct {
+		All       *bool
+		Customers *struct {
+			Add    *[]graphqlserver.ID
+			Remove *[]graphqlserver.ID
+		}
+	}
+	CustomerGets *struct {
+		Value *struct {
+			Percentage     *float64
+			DiscountAmount *struct {
+				Amount            scalar
+				AppliesOnEachItem *bool
+			}
+			DiscountOnQuantity *struct {
+				Quantity scalar
+				Effect   struct {
+					Percentage *float64
+				}
+			}
+		}
+		Items                    *discountItemsInput
+		AppliesOnOneTimePurchase *bool
+		AppliesOnSubscription    *bool
+	}
+	CustomerBuys *struct {
+		Value *struct {
+			Quantity *scalar
+			Amount   *scalar
+		}
+		Items *discountItemsInput
+	}
+	MinimumRequirement *struct {
+		Quantity *struct {
+			GreaterThanOrEqualToQuantity scalar
+		}
+		Subtotal *struct {
+			GreaterThanOrEqualToSubtotal scalar
+		}
+	}
+	Destination *struct {
+		All       *bool
+		Countries *struct {
+			Add                *[]string
+			Remove             *[]string
+			IncludeRestOfWorld *bool
+		}
+	}
+	MaximumShippingPrice     *scalar
+	AppliesOnOneTimePurchase *bool
+	AppliesOnSubscription    *bool
+	CombinesWith             *struct {
+		OrderDiscounts    *bool
+		ProductDiscounts  *bool
+		ShippingDiscounts *bool
+	}
+}
+
+type discountCodePayload struct {
+	CodeDiscountNode *discountNodeResolver
+	UserErrors       []*userError
+}
+
+type discountAutomaticPayload struct {
+	AutomaticDiscountNode *discountNodeResolver
+	UserErrors            []*userError
+}
+
+type discountCodeCreateArgs struct {
+	BasicCodeDiscount        *discountInput
+	BxgyCodeDiscount         *discountInput
+	FreeShippingCodeDiscount *discountInput
+}
+
+type discountCodeUpdateArgs struct {
+	ID graphqlserver.ID
+	discountCodeCreateArgs
+}
+
+func (r *mutationResolver) DiscountCodeBasicCreate(args discountCodeCreateArgs) *discountCodePayload {
+	return r.s.createCodeDiscount("BASIC", *args.BasicCodeDiscount)
+}
+
+func (r *mutationResolver) DiscountCodeBasicUpdate(args discountCodeUpdateArgs) *discountCodePayload {
+	return r.s.updateCodeDiscount(string(args.ID), "BASIC", *args.BasicCodeDiscount)
+}
+
+func (r *mutationResolver) DiscountCodeBxgyCreate(args discountCodeCreateArgs) *discountCodePayload {
+	return r.s.createCodeDiscount("BXGY", *args.BxgyCodeDiscount)
+}
+
+func (r *mutationResolver) DiscountCodeBxgyUpdate(args discountCodeUpdateArgs) *discountCodePayload {
+	return r.s.updateCodeDiscount(string(args.ID), "BXGY", *args.BxgyCodeDiscount)
+}
+
+func (r *mutationResolver) DiscountCodeFreeShippingCreate(args discountCodeCreateArgs) *discountCodePayload {
+	return r.s.createCodeDiscount("FREE_SHIPPING", *args.FreeShippingCodeDiscount)
+}
+
+func (r *mutationResolver) DiscountCodeFreeShippingUpdate(args discountCodeUpdateArgs) *discountCodePayload {
+	return r.s.updateCodeDiscount(string(args.ID), "FREE_SHIPPING", *args.FreeShippingCodeDiscount)
+}
+
+func (r *mutationResolver) DiscountCodeActivate(args idArgs) *discountCodePayload {
+	d := r.s.discount(string(args.ID))
+	if d == nil || d.Automatic {
+		return &discountCodePayload{UserErrors: []*userError{newUserError("id", "Discount does not exist")}}
+	}
+	r.s.activateDiscount(d)
+	return &discountCodePayload{CodeDiscountNode: &discountNodeResolver{d: d, s: r.s}, UserErrors: []*userError{}}
+}
+
+func (r *mutationResolver) DiscountCodeDeactivate(args idArgs) *discountCodePayload {
+	d := r.s.discount(string(args.ID))
+	if d == nil || d.Automatic {
+		return &discountCodePayload{UserErrors: []*userError{newUserError("id", "Discount does not exist")}}
+	}
+	r.s.deactivateDiscount(d)
+	return &discountCodePayload{CodeDiscountNode: &discountNodeResolver{d: d, s: r.s}, UserErrors: []*userError{}}
+}
+
+func (r *mutationResolver) DiscountCodeDelete(args idArgs) *deletePayload {
+	return r.s.deleteDiscount(string(args.ID), false)
+}
+
+func (s *Server) createCodeDiscount(typ string, input discountInput) *discountCodePayload {
+	d := &Discount{Type: typ}
+	if errs := s.applyDiscountInput(d, input); len(errs) > 0 {
+		return &discountCodePayload{UserErrors: errs}
+	}
+	if len(d.Codes) == 0 {
+		return &discountCodePayload{UserErrors: []*userError{newUserError("code", "Code can't be blank")}}
+	}
+	s.fillDiscount(d)
+	s.discounts = append(s.discounts, d)
+
+	return &discountCodePayload{CodeDiscountNode: &discountNodeResolver{d: d, s: s}, UserErrors: []*userError{}}
+}
+
+func (s *Server) updateCodeDiscount(id string, typ string, input discountInput) *discountCodePayload {
+	d := s.discount(id)
+	if d == nil || d.Automatic || d.Type != typ {
+		return &discountCodePayload{UserErrors: []*userError{newUserError("id", "Discount does not exist")}}
+	}
+
+	// validate on a copy, so the discount is left as is on errors
+	updated := *d
+	if errs := s.applyDiscountInput(&updated, input); len(errs) > 0 {
+		return &discountCodePayload{UserErrors: errs}
+	}
+	*d = updated
+	d.UpdatedAt = s.now()
+
+	return &discountCodePayload{CodeDiscountNode: &discountNodeResolver{d: d, s: s}, UserErrors: []*userError{}}
+}
+
+type discountAutomaticCreateArgs struct {
+	AutomaticBasicDiscount        *discountInput
+	AutomaticBxgyDiscount         *discountInput
+	FreeShippingAutomaticDiscount *discountInput
+}
+
+type discountAutomaticUpdateArgs struct {
+	ID graphqlserver.ID
+	discountAutomaticCreateArgs
+}
+
+func (r *mutationResolver) DiscountAutomaticBasicCreate(args discountAutomaticCreateArgs) *discountAutomaticPayload {
+	return r.s.createAutomaticDiscount("BASIC", *args.AutomaticBasicDiscount)
+}
+
+func (r *mutationResolver) DiscountAutomaticBasicUpdate(args discountAutomaticUpdateArgs) *discountAutomaticPayload {
+	return r.s.updateAutomaticDiscount(string(args.ID), "BASIC", *args.AutomaticBasicDiscount)
+}
+
+func (r *mutationResolver) DiscountAutomaticBxgyCreate(args discountAutomaticCreateArgs) *discountAutomaticPayload {
+	return r.s.createAutomaticDiscount("BXGY", *args.AutomaticBxgyDiscount)
+}
+
+func (r *mutationResolver) DiscountAutomaticBxgyUpdate(args discountAutomaticUpdateArgs) *discountAutomaticPayload {
+	return r.s.updateAutomaticDiscount(string(args.ID), "BXGY", *args.AutomaticBxgyDiscount)
+}
+
+func (r *mutationResolver) DiscountAutomaticFreeShippingCreate(args discountAutomaticCreateArgs) *discountAutomaticPayload {
+	return r.s.createAutomaticDiscount("FREE_SHIPPING", *args.FreeShippingAutomaticDiscount)
+}
+
+func (r *mutationResolver) DiscountAutomaticFreeShippingUpdate(args discountAutomaticUpdateArgs) *discountAutomaticPayload {
+	return r.s.updateAutomaticDiscount(string(args.ID), "FREE_SHIPPING", *args.FreeShippingAutomaticDiscount)
+}
+
+func (r *mutationResolver) DiscountAutomaticActivate(args idArgs) *discountAutomaticPayload {
+	d := r.s.discount(string(args.ID))
+	if d == nil || !d.Automatic {
+		return &discountAutomaticPayload{UserErrors: []*userError{newUserError("id", "Discount does not exist")}}
+	}
+	r.s.activateDiscount(d)
+	return &discountAutomaticPayload{AutomaticDiscountNode: &discountNodeResolver{d: d, s: r.s}, UserErrors: []*userError{}}
+}
+
+func (r *mutationResolver) DiscountAutomaticDeactivate(args idArgs) *discountAutomaticPayload {
+	d := r.s.discount(string(args.ID))
+	if d == nil || !d.Automatic {
+		return &discountAutomaticPayload{UserErrors: []*userError{newUserError("id", "Discount does not exist")}}
+	}
+	r.s.deactivateDiscount(d)
+	return &discountAutomaticPayload{AutomaticDiscountNode: &discountNodeResolver{d: d, s: r.s}, UserErrors: []*userError{}}
+}
+
+func (r *mutationResolver) DiscountAutomaticDelete(args idArgs) *deletePayload {
+	return r.s.deleteDiscount(string(args.ID), true)
+}
+
+func (s *Server) createAutomaticDiscount(typ string, input discountInput) *discountAutomaticPayload {
+	d := &Discount{Type: typ, Automatic: true}
+	if errs := s.applyDiscountInput(d, input); len(errs) > 0 {
+		return &discountAutomaticPayload{UserErrors: errs}
+	}
+	s.fillDiscount(d)
+	s.discounts = append(s.discounts, d)
+
+	return &discountAutomaticPayload{AutomaticDiscountNode: &discountNodeResolver{d: d, s: s}, UserErrors: []*userError{}}
+}
+
+func (s *Server) updateAutomaticDiscount(id string, typ string, input discountInput) *discountAutomaticPayload {
+	d := s.discount(id)
+	if d == nil || !d.Automatic || d.Type != typ {
+		return &discountAutomaticPayload{UserErrors: []*userError{newUserError("id", "Discount does not exist")}}
+	}
+
+	updated := *d
+	if errs := s.applyDiscountInput(&updated, input); len(errs) > 0 {
+		return &discountAutomaticPayload{UserErrors: errs}
+	}
+	*d = updated
+	d.UpdatedAt = s.now()
+
+	return &discountAutomaticPayload{AutomaticDiscountNode: &discountNodeResolver{d: d, s: s}, UserErrors: []*userError{}}
+}
+
+// activateDiscount starts d now, unless it already started, and clears its end if it's over.
+func (s *Server) activateDiscount(d *Discount) {
+	now := s.now()
+	if d.StartsAt.After(now) {
+		d.StartsAt = now
+	}
+	if d.EndsAt != nil && !d.EndsAt.After(now) {
+		d.EndsAt = nil
+	}
+	d.UpdatedAt = now
+}
+
+// deactivateDiscount ends d now.
+func (s *Server) deactivateDiscount(d *Discount) {
+	now := s.now()
+	d.EndsAt = &now
+	d.UpdatedAt = now
+}
+
+func (s *Server) deleteDiscount(id string, automatic bool) *deletePayload {
+	for i, d := range s.discounts {
+		if d.ID == id && d.Automatic == automatic {
+			s.discounts = append(s.discounts[:i], s.discounts[i+1:]...)
+			return deleted(id)
+		}
+	}
+	return deleteFailed("id", "Discount does not exist")
+}
+
+func (s *Server) applyDiscountInput(d *Discount, input discountInput) []*userError {
+	if input.Title != nil && *input.Title == "" || input.Title == nil && d.Title == "" {
+		return []*userError{newUserError("title", "Title can't be blank")}
+	}
+	if input.Code != nil {
+		if *input.Code == "" {
+			return []*userError{newUserError("code", "Code can't be blank")}
+		}
+		if other := s.discountByCode(*input.Code); other != nil && other.ID != d.ID {
+			return []*userError{newUserError("code", "Code must be unique. Please try a different code.")}
+		}
+	}
+	startsAt, err := parseDateTime(input.StartsAt)
+	if err != nil {
+		return []*userError{newUserError("startsAt", "Starts at is invalid")}
+	}
+	endsAt, err := parseDateTime(input.EndsAt)
+	if err != nil {
+		return []*userError{newUserError("endsAt", "Ends at is invalid")}
+	}
+
+	setString(&d.Title, input.Title)
+	if input.Code != nil {
+		// the first code of a discount is its title in the admin, the others are redeem codes added later
+		d.Codes = append([]*DiscountRedeemCode{}, d.Codes...)
+		if len(d.Codes) == 0 {
+			d.Codes = append(d.Codes, &DiscountRedeemCode{ID: s.newID("DiscountRedeemCode")})
+		}
+		d.Codes[0] = &DiscountRedeemCode{ID: d.Codes[0].ID, Code: *input.Code, UsageCount: d.Codes[0].UsageCount}
+	}
+	if startsAt != nil {
+		d.StartsAt = *startsAt
+	}
+	if endsAt != nil {
+		d.EndsAt = endsAt
+	}
+	if input.UsageLimit != nil {
+		d.UsageLimit = int(*input.UsageLimit)
+	}
+	if input.UsesPerOrderLimit != nil {
+		d.UsesPerOrderLimit = int(*input.UsesPerOrderLimit)
+	}
+	if input.AppliesOncePerCustomer != nil {
+		d.AppliesOncePerCustomer = *input.AppliesOncePerCustomer
+	}
+	if input.CombinesWith != nil {
+		setBool(&d.CombinesWith.OrderDiscounts, input.CombinesWith.OrderDiscounts)
+		setBool(&d.CombinesWith.ProductDiscounts, input.CombinesWith.ProductDiscounts)
+		setBool(&d.CombinesWith.ShippingDiscounts, input.CombinesWith.ShippingDiscounts)
+	}
+
+	if gets := input.CustomerGets; gets != nil {
+		if v := gets.Value; v != nil {
+			d.Percentage, d.Amount, d.AppliesOnEachItem, d.GetsQuantity = 0, "", false, 0
+			switch {
+			case v.Percentage != nil:
+				d.Percentage = *v.Percentage
+			case v.DiscountAmount != nil:
+				d.Amount = string(v.DiscountAmount.Amount)
+				setBool(&d.AppliesOnEachItem, v.DiscountAmount.AppliesOnEachItem)
+			case v.DiscountOnQuantity != nil:
+				d.GetsQuantity, _ = strconv.Atoi(string(v.DiscountOnQuantity.Quantity))
+				if v.DiscountOnQuantity.Effect.Percentage != nil {
+					d.Percentage = *v.DiscountOnQuantity.Effect.Percentage
+				}
+			}
+			if d.Percentage < 0 || d.Percentage > 1 {
+				return []*userError{newUserError("customerGets.value.percentage", "Value must be between 0.0 and 1.0")}
+			}
+		}
+		if gets.Items != nil {
+			applyDiscountItemsInput(&d.GetsItems, *gets.Items)
+		}
+	}
+	if buys := input.CustomerBuys; buys != nil {
+		if v := buys.Value; v != nil {
+			d.BuysQuantity, d.BuysAmount = 0, ""
+			if v.Quantity != nil {
+				d.BuysQuantity, _ = strconv.Atoi(string(*v.Quantity))
+			}
+			if v.Amount != nil {
+				d.BuysAmount = string(*v.Amount)
+			}
+		}
+		if buys.Items != nil {
+			applyDiscountItemsInput(&d.BuysItems, *buys.Items)
+		}
+	}
+	if m := input.MinimumRequirement; m != nil {
+		d.MinimumQuantity, d.MinimumSubtotal = 0, ""
+		if m.Quantity != nil {
+			d.MinimumQuantity, _ = strconv.Atoi(string(m.Quantity.GreaterThanOrEqualToQuantity))
+		}
+		if m.Subtotal != nil {
+			d.MinimumSubtotal = string(m.Subtotal.GreaterThanOrEqualToSubtotal)
+		}
+	}
+	if dest := input.Destination; dest != nil {
+		if dest.All != nil && *dest.All {
+			d.Countries, d.IncludeRestOfWorld = nil, false
+		}
+		if c := dest.Countries; c != nil {
+			if c.Add != nil {
+				for _, country := range *c.Add {
+					if !contains(d.Countries, country) {
+						d.Countries = append(d.Countries, country)
+					}
+				}
+			}
+			if c.Remove != nil {
+				for _, country := range *c.Remove {
+					d.Countries = remove(d.Countries, country)
+				}
+			}
+			setBool(&d.IncludeRestOfWorld, c.IncludeRestOfWorld)
+		}
+	}
+	if input.MaximumShippingPrice != nil {
+		d.MaximumShippingPrice = string(*input.MaximumShippingPrice)
+	}
+	return nil
+}
+
+func applyDiscountItemsInput(items *DiscountItems, input discountItemsInput) {
+	if input.All != nil && *input.All {
+		*items = DiscountItems{}
+	}
+	if p := input.Products; p != nil {
+		items.CollectionIDs = nil
+		items.ProductIDs = updateIDs(items.ProductIDs, p.ProductsToAdd, p.ProductsToRemove)
+		items.VariantIDs = updateIDs(items.VariantIDs, p.ProductVariantsToAdd, p.ProductVariantsToRemove)
+	}
+	if c := input.Collections; c != nil {
+		items.ProductIDs, items.VariantIDs = nil, nil
+		items.CollectionIDs = updateIDs(items.CollectionIDs, c.Add, c.Remove)
+	}
+}
+
+func updateIDs(ids []string, add *[]graphqlserver.ID, del *[]graphqlserver.ID) []string {
+	ids = append([]string{}, ids...)
+	if add != nil {
+		for _, id := range *add {
+			if !contains(ids, string(id)) {
+				ids = append(ids, string(id))
+			}
+		}
+	}
+	if del != nil {
+		for _, id := range *del {
+			ids = remove(ids, string(id))
+		}
+	}
+	return ids
+}
+
+func setBool(dst *bool, src *bool) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+func parseDateTime(s *scalar) (*time.Time, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, string(*s))
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+type discountRedeemCodeBulkAddArgs struct {
+	DiscountID graphqlserver.ID
+	Codes      []struct {
+		Code string
+	}
+}
+
+type discountRedeemCodeBulkAddPayload struct {
+	BulkCreation *redeemCodeBulkCreation
+	UserErrors   []*userError
+}
+
+// DiscountRedeemCodeBulkAdd adds the valid codes right away, so the bulk creation is done when
+// the mutation returns.
+func (r *mutationResolver) DiscountRedeemCodeBulkAdd(args discountRedeemCodeBulkAddArgs) *discountRedeemCodeBulkAddPayload {
+	d := r.s.discount(string(args.DiscountID))
+	if d == nil || d.Automatic {
+		return &discountRedeemCodeBulkAddPayload{UserErrors: []*userError{newUserError("discountId", "Code discount does not exist")}}
+	}
+	if len(args.Codes) > 250 {
+		return &discountRedeemCodeBulkAddPayload{UserErrors: []*userError{newUserError("codes", "Codes can't contain more than 250 codes")}}
+	}
+
+	creation := &redeemCodeBulkCreation{id: r.s.newID("DiscountRedeemCodeBulkCreation")}
+	for _, input := range args.Codes {
+		code := &redeemCodeBulkCreationCode{Code: input.Code, Errors: []*userError{}}
+		switch {
+		case strings.TrimSpace(input.Code) == "":
+			code.Errors = append(code.Errors, newUserError("code", "Code can't be blank"))
+		case r.s.discountByCode(input.Code) != nil:
+			code.Errors = append(code.Errors, newUserError("code", "Code must be unique. Please try a different code."))
+		default:
+			d.Codes = append(d.Codes, &DiscountRedeemCode{ID: r.s.newID("DiscountRedeemCode"), Code: input.Code})
+		}
+		creation.codes = append(creation.codes, code)
+	}
+	d.UpdatedAt = r.s.now()
+	r.s.codeCreations = append(r.s.codeCreations, creation)
+
+	return &discountRedeemCodeBulkAddPayload{BulkCreation: creation, UserErrors: []*userError{}}
+}
@@ -0,0 +1,795 @@
+package shopifytest
+
+import (
+	"fmt"
+	"strings"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+// shippingRates are the rates offered to the draft orders with a shipping address.
+var shippingRates = []ShippingLine{
+	{Title: "Standard", Price: "5.00"},
+	{Title: "Express", Price: "15.00"},
+}
+
+func shippingRateHandle(rate ShippingLine) string {
+	return fmt.Sprintf("shopify-%s-%s", rate.Title, rate.Price)
+}
+
+// availableShippingRates returns the shipping rates offered to d.
+func availableShippingRates(d *DraftOrder) []ShippingLine {
+	if d.ShippingAddress == nil || len(d.LineItems) == 0 {
+		return nil
+	}
+	return shippingRates
+}
+
+// appliedDiscountAmount returns the amount the discount d takes off total, never more than total.
+func appliedDiscountAmount(d *AppliedDiscount, total float64) float64 {
+	if d == nil {
+		return 0
+	}
+	amount := d.Value
+	if d.ValueType == "PERCENTAGE" {
+		amount = total * d.Value / 100
+	}
+	if amount > total {
+		amount = total
+	}
+	return float64(cents(amount)) / 100
+}
+
+func draftLineItemTotal(li *DraftOrderLineItem) float64 {
+	return parseAmount(li.Price) * float64(li.Quantity)
+}
+
+func draftLineItemDiscountedTotal(li *DraftOrderLineItem) float64 {
+	total := draftLineItemTotal(li)
+	return total - appliedDiscountAmount(li.AppliedDiscount, total)
+}
+
+// draftOrderLinesTotal returns the total of the line items of d, after their own discounts.
+func draftOrderLinesTotal(d *DraftOrder) float64 {
+	var total float64
+	for _, li := range d.LineItems {
+		total += draftLineItemDiscountedTotal(li)
+	}
+	return total
+}
+
+// draftOrderSubtotal returns the total of the line items of d, after all discounts.
+func draftOrderSubtotal(d *DraftOrder) float64 {
+	total := draftOrderLinesTotal(d)
+	return total - appliedDiscountAmount(d.AppliedDiscount, total)
+}
+
+func draftOrderShipping(d *DraftOrder) float64 {
+	if d.ShippingLine == nil {
+		return 0
+	}
+	return parseAmount(d.ShippingLine.Price)
+}
+
+// Queries
+
+func (r *queryResolver) DraftOrder(args idArgs) *draftOrderResolver {
+	d := r.s.draftOrder(string(args.ID))
+	if d == nil {
+		return nil
+	}
+	return &draftOrderResolver{d: d, s: r.s}
+}
+
+func (r *queryResolver) DraftOrders(args queryConnectionArgs) *connection[*draftOrderResolver] {
+	var resolvers []*draftOrderResolver
+	for _, d := range r.s.draftOrders {
+		if args.Query != nil && !matchQuery(*args.Query, d.Name+" "+d.Email, draftOrderFields(d)) {
+			continue
+		}
+		resolvers = append(resolvers, &draftOrderResolver{d: d, s: r.s})
+	}
+	return newConnection(resolvers, func(r *draftOrderResolver) string { return r.d.ID }, args.connectionArgs)
+}
+
+func draftOrderFields(d *DraftOrder) func(string) []string {
+	return func(name string) []string {
+		switch name {
+		case "id":
+			return []string{d.ID}
+		case "name":
+			return []string{d.Name}
+		case "status":
+			return []string{d.Status}
+		case "email":
+			return []string{d.Email}
+		case "customer_id":
+			return []string{d.CustomerID}
+		case "tag":
+			return d.Tags
+		}
+		return nil
+	}
+}
+
+// Resolvers
+
+type draftOrderResolver struct {
+	d *DraftOrder
+	s *Server
+}
+
+func (r *draftOrderResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.d.ID)
+}
+
+func (r *draftOrderResolver) Name() string {
+	return r.d.Name
+}
+
+func (r *draftOrderResolver) Status() string {
+	return r.d.Status
+}
+
+func (r *draftOrderResolver) Email() *string {
+	return strPtr(r.d.Email)
+}
+
+func (r *draftOrderResolver) Phone() *string {
+	return strPtr(r.d.Phone)
+}
+
+func (r *draftOrderResolver) Note2() *string {
+	return strPtr(r.d.Note)
+}
+
+func (r *draftOrderResolver) Tags() []string {
+	return append([]string{}, r.d.Tags...)
+}
+
+func (r *draftOrderResolver) PoNumber() *string {
+	return strPtr(r.d.PoNumber)
+}
+
+func (r *draftOrderResolver) TaxExempt() bool {
+	return r.d.TaxExempt
+}
+
+func (r *draftOrderResolver) InvoiceURL() *scalar {
+	token := strings.TrimPrefix(r.d.ID, "gid://shopify/DraftOrder/")
+	return scalarPtr(fmt.Sprintf("https://%s/invoices/%s", r.s.ShopDomain, token))
+}
+
+func (r *draftOrderResolver) InvoiceSentAt() *scalar {
+	return dateTimePtr(r.d.InvoiceSentAt)
+}
+
+func (r *draftOrderResolver) Customer() *customerResolver {
+	c := r.s.customer(r.d.CustomerID)
+	if c == nil {
+		return nil
+	}
+	return &customerResolver{c: c, s: r.s}
+}
+
+func (r *draftOrderResolver) ShippingAddress() *mailingAddressResolver {
+	if r.d.ShippingAddress == nil {
+		return nil
+	}
+	return &mailingAddressResolver{a: r.d.ShippingAddress}
+}
+
+func (r *draftOrderResolver) BillingAddress() *mailingAddressResolver {
+	if r.d.BillingAddress == nil {
+		return nil
+	}
+	return &mailingAddressResolver{a: r.d.BillingAddress}
+}
+
+func (r *draftOrderResolver) LineItems(args connectionArgs) *connection[*draftOrderLineItemResolver] {
+	return newConnection(r.lineItems(), func(r *draftOrderLineItemResolver) string { return r.li.ID }, args)
+}
+
+func (r *draftOrderResolver) lineItems() []*draftOrderLineItemResolver {
+	resolvers := []*draftOrderLineItemResolver{}
+	for _, li := range r.d.LineItems {
+		resolvers = append(resolvers, &draftOrderLineItemResolver{li: li, s: r.s})
+	}
+	return resolvers
+}
+
+func (r *draftOrderResolver) AppliedDiscount() *appliedDiscountResolver {
+	if r.d.AppliedDiscount == nil {
+		return nil
+	}
+	total := draftOrderLinesTotal(r.d)
+	return &appliedDiscountResolver{d: r.d.AppliedDiscount, amount: appliedDiscountAmount(r.d.AppliedDiscount, total), s: r.s}
+}
+
+func (r *draftOrderResolver) ShippingLine() *shippingLine {
+	if r.d.ShippingLine == nil {
+		return nil
+	}
+	return &shippingLine{Title: r.d.ShippingLine.Title, OriginalPriceSet: r.s.money(r.d.ShippingLine.Price)}
+}
+
+func (r *draftOrderResolver) SubtotalPriceSet() moneyBag {
+	return r.s.money(formatAmount(draftOrderSubtotal(r.d)))
+}
+
+func (r *draftOrderResolver) TotalShippingPriceSet() moneyBag {
+	return r.s.money(formatAmount(draftOrderShipping(r.d)))
+}
+
+// TotalTaxSet is always zero, the fake server charging no taxes.
+func (r *draftOrderResolver) TotalTaxSet() moneyBag {
+	return r.s.money("0.00")
+}
+
+func (r *draftOrderResolver) TotalPriceSet() moneyBag {
+	return r.s.money(formatAmount(draftOrderSubtotal(r.d) + draftOrderShipping(r.d)))
+}
+
+func (r *draftOrderResolver) Order() *orderResolver {
+	o := r.s.order(r.d.OrderID)
+	if o == nil {
+		return nil
+	}
+	return &orderResolver{o: o, s: r.s}
+}
+
+func (r *draftOrderResolver) CompletedAt() *scalar {
+	return dateTimePtr(r.d.CompletedAt)
+}
+
+func (r *draftOrderResolver) CreatedAt() scalar {
+	return dateTime(r.d.CreatedAt)
+}
+
+func (r *draftOrderResolver) UpdatedAt() scalar {
+	return dateTime(r.d.UpdatedAt)
+}
+
+// calculatedDraftOrderResolver resolves a draft order priced by draftOrderCalculate and not stored.
+type calculatedDraftOrderResolver struct {
+	*draftOrderResolver
+}
+
+func (r *calculatedDraftOrderResolver) LineItems() []*draftOrderLineItemResolver {
+	return r.lineItems()
+}
+
+func (r *calculatedDraftOrderResolver) AvailableShippingRates() []*shippingRate {
+	rates := []*shippingRate{}
+	for _, rate := range availableShippingRates(r.d) {
+		rates = append(rates, &shippingRate{
+			Handle: shippingRateHandle(rate),
+			Title:  rate.Title,
+			Price:  r.s.money(rate.Price).ShopMoney,
+		})
+	}
+	return rates
+}
+
+type shippingRate struct {
+	Handle string
+	Title  string
+	Price  moneyV2
+}
+
+type draftOrderLineItemResolver struct {
+	li *DraftOrderLineItem
+	s  *Server
+}
+
+func (r *draftOrderLineItemResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.li.ID)
+}
+
+func (r *draftOrderLineItemResolver) Title() string {
+	return r.li.Title
+}
+
+func (r *draftOrderLineItemResolver) SKU() *string {
+	return strPtr(r.li.SKU)
+}
+
+func (r *draftOrderLineItemResolver) Quantity() int32 {
+	return int32(r.li.Quantity)
+}
+
+func (r *draftOrderLineItemResolver) Custom() bool {
+	return r.li.VariantID == ""
+}
+
+func (r *draftOrderLineItemResolver) Variant() *variantResolver {
+	p, v := r.s.variant(r.li.VariantID)
+	if v == nil {
+		return nil
+	}
+	return &variantResolver{v: v, p: p, s: r.s}
+}
+
+func (r *draftOrderLineItemResolver) OriginalUnitPriceSet() moneyBag {
+	return r.s.money(r.li.Price)
+}
+
+func (r *draftOrderLineItemResolver) OriginalTotalSet() moneyBag {
+	return r.s.money(formatAmount(draftLineItemTotal(r.li)))
+}
+
+func (r *draftOrderLineItemResolver) DiscountedTotalSet() moneyBag {
+	return r.s.money(formatAmount(draftLineItemDiscountedTotal(r.li)))
+}
+
+func (r *draftOrderLineItemResolver) AppliedDiscount() *appliedDiscountResolver {
+	if r.li.AppliedDiscount == nil {
+		return nil
+	}
+	amount := appliedDiscountAmount(r.li.AppliedDiscount, draftLineItemTotal(r.li))
+	return &appliedDiscountResolver{d: r.li.AppliedDiscount, amount: amount, s: r.s}
+}
+
+type appliedDiscountResolver struct {
+	d      *AppliedDiscount
+	amount float64
+	s      *Server
+}
+
+func (r *appliedDiscountResolver) Title() *string {
+	return strPtr(r.d.Title)
+}
+
+func (r *appliedDiscountResolver) Description() string {
+	return r.d.Description
+}
+
+func (r *appliedDiscountResolver) Value() float64 {
+	return r.d.Value
+}
+
+func (r *appliedDiscountResolver) ValueType() string {
+	return r.d.ValueType
+}
+
+func (r *appliedDiscountResolver) AmountSet() moneyBag {
+	return r.s.money(formatAmount(r.amount))
+}
+
+// Mutations
+
+type draftOrderAppliedDiscountInput struct {
+	Title       *string
+	Description *string
+	Value       float64
+	ValueType   string
+}
+
+type draftOrderInput struct {
+	CustomerID      *graphqlserver.ID
+	Email           *string
+	Phone           *string
+	Note            *string
+	Tags            *[]string
+	PoNumber        *string
+	TaxExempt       *bool
+	ShippingAddress *mailingAddressInput
+	BillingAddress  *mailingAddressInput
+	// UseCustomerDefaultAddress sets the addresses not in the input to the default address of the
+	// customer.
+	UseCustomerDefaultAddress *bool
+	LineItems                 *[]struct {
+		VariantID         *graphqlserver.ID
+		Quantity          int32
+		Title             *string
+		SKU               *string
+		OriginalUnitPrice *scalar
+		AppliedDiscount   *draftOrderAppliedDiscountInput
+	}
+	AppliedDiscount *draftOrderAppliedDiscountInput
+	ShippingLine    *struct {
+		Title              *string
+		Price              *scalar
+		ShippingRateHandle *string
+	}
+}
+
+type draftOrderPayload struct {
+	DraftOrder *draftOrderResolver
+	UserErrors []*userError
+}
+
+func draftOrderFailed(message string, field ...string) *draftOrderPayload {
+	return &draftOrderPayload{UserErrors: []*userError{fieldError(message, field...)}}
+}
+
+func appliedDiscount(input *draftOrderAppliedDiscountInput, field ...string) (*AppliedDiscount, *userError) {
+	if input.Value < 0 {
+		return nil, fieldError("Value must be greater than or equal to 0", append(field, "value")...)
+	}
+	if input.ValueType == "PERCENTAGE" && input.Value > 100 {
+		return nil, fieldError("Value must be less than or equal to 100", append(field, "value")...)
+	}
+	d := &AppliedDiscount{Value: input.Value, ValueType: input.ValueType}
+	setString(&d.Title, input.Title)
+	setString(&d.Description, input.Description)
+	return d, nil
+}
+
+// applyDraftOrderInput returns a copy of d updated with the fields set in input, or the user error
+// of the first invalid field of input.
+func (s *Server) applyDraftOrderInput(d *DraftOrder, input draftOrderInput) (*DraftOrder, *userError) {
+	next := *d
+	d = &next
+	if input.CustomerID != nil {
+		c := s.customer(string(*input.CustomerID))
+		if c == nil {
+			return nil, fieldError("Customer does not exist", "input", "customerId")
+		}
+		d.CustomerID = c.ID
+		if d.Email == "" {
+			d.Email = c.Email
+		}
+	}
+	setString(&d.Email, input.Email)
+	setString(&d.Phone, input.Phone)
+	setString(&d.Note, input.Note)
+	setStrings(&d.Tags, input.Tags)
+	setString(&d.PoNumber, input.PoNumber)
+	if input.TaxExempt != nil {
+		d.TaxExempt = *input.TaxExempt
+	}
+
+	if input.ShippingAddress != nil {
+		d.ShippingAddress = &MailingAddress{ID: s.newID("MailingAddress")}
+		setAddress(d.ShippingAddress, *input.ShippingAddress)
+	}
+	if input.BillingAddress != nil {
+		d.BillingAddress = &MailingAddress{ID: s.newID("MailingAddress")}
+		setAddress(d.BillingAddress, *input.BillingAddress)
+	}
+	if input.UseCustomerDefaultAddress != nil && *input.UseCustomerDefaultAddress {
+		if c := s.customer(d.CustomerID); c != nil {
+			for _, a := range c.Addresses {
+				if a.ID != c.DefaultAddressID {
+					continue
+				}
+				if input.ShippingAddress == nil {
+					shipping := *a
+					d.ShippingAddress = &shipping
+				}
+				if input.BillingAddress == nil {
+					billing := *a
+					d.BillingAddress = &billing
+				}
+			}
+		}
+	}
+
+	if input.LineItems != nil {
+		d.LineItems = nil
+		for i, itemInput := range *input.LineItems {
+			field := []string{"input", "lineItems", fmt.Sprint(i)}
+			if itemInput.Quantity <= 0 {
+				return nil, fieldError("Quantity must be greater than 0", append(field, "quantity")...)
+			}
+			li := &DraftOrderLineItem{ID: s.newID("DraftOrderLineItem"), Quantity: int(itemInput.Quantity)}
+			if itemInput.VariantID != nil {
+				p, v := s.variant(string(*itemInput.VariantID))
+				if v == nil {
+					return nil, fieldError("Product variant does not exist", append(field, "variantId")...)
+				}
+				li.VariantID, li.Title, li.SKU, li.Price = v.ID, p.Title, v.SKU, v.Price
+			} else {
+				setString(&li.Title, itemInput.Title)
+				setString(&li.SKU, itemInput.SKU)
+				if li.Title == "" {
+					return nil, fieldError("Title can't be blank", append(field, "title")...)
+				}
+				if itemInput.OriginalUnitPrice == nil {
+					return nil, fieldError("Original unit price can't be blank", append(field, "originalUnitPrice")...)
+				}
+				li.Price = formatAmount(parseAmount(string(*itemInput.OriginalUnitPrice)))
+			}
+			if itemInput.AppliedDiscount != nil {
+				discount, err := appliedDiscount(itemInput.AppliedDiscount, append(field, "appliedDiscount")...)
+				if err != nil {
+					return nil, err
+				}
+				li.AppliedDiscount = discount
+			}
+			d.LineItems = append(d.LineItems, li)
+		}
+	}
+	if input.AppliedDiscount != nil {
+		discount, err := appliedDiscount(input.AppliedDiscount, "input", "appliedDiscount")
+		if err != nil {
+			return nil, err
+		}
+		d.AppliedDiscount = discount
+	}
+
+	if line := input.ShippingLine; line != nil {
+		d.ShippingLine = &ShippingLine{}
+		if line.ShippingRateHandle != nil {
+			var found bool
+			for _, rate := range availableShippingRates(d) {
+				if shippingRateHandle(rate) == *line.ShippingRateHandle {
+					*d.ShippingLine, found = rate, true
+				}
+			}
+			if !found {
+				return nil, fieldError("Shipping rate is not available", "input", "shippingLine", "shippingRateHandle")
+			}
+		}
+		setString(&d.ShippingLine.Title, line.Title)
+		if line.Price != nil {
+			d.ShippingLine.Price = formatAmount(parseAmount(string(*line.Price)))
+		}
+	}
+	return d, nil
+}
+
+type draftOrderCreateArgs struct {
+	Input draftOrderInput
+}
+
+func (r *mutationResolver) DraftOrderCreate(args draftOrderCreateArgs) *draftOrderPayload {
+	d, err := r.s.applyDraftOrderInput(&DraftOrder{}, args.Input)
+	if err != nil {
+		return &draftOrderPayload{UserErrors: []*userError{err}}
+	}
+	if len(d.LineItems) == 0 {
+		return draftOrderFailed("Add at least 1 product", "input", "lineItems")
+	}
+	r.s.fillDraftOrder(d)
+	r.s.draftOrders = append(r.s.draftOrders, d)
+	return &draftOrderPayload{DraftOrder: &draftOrderResolver{d: d, s: r.s}, UserErrors: []*userError{}}
+}
+
+type draftOrderUpdateArgs struct {
+	ID    graphqlserver.ID
+	Input draftOrderInput
+}
+
+func (r *mutationResolver) DraftOrderUpdate(args draftOrderUpdateArgs) *draftOrderPayload {
+	d := r.s.draftOrder(string(args.ID))
+	if d == nil {
+		return draftOrderFailed("Draft order does not exist", "id")
+	}
+	if d.Status == "COMPLETED" {
+		return draftOrderFailed("Draft order has already been completed", "id")
+	}
+	next, err := r.s.applyDraftOrderInput(d, args.Input)
+	if err != nil {
+		return &draftOrderPayload{UserErrors: []*userError{err}}
+	}
+	*d = *next
+	d.UpdatedAt = r.s.now()
+	return &draftOrderPayload{DraftOrder: &draftOrderResolver{d: d, s: r.s}, UserErrors: []*userError{}}
+}
+
+type draftOrderCalculatePayload struct {
+	CalculatedDraftOrder *calculatedDraftOrderResolver
+	UserErrors           []*userError
+}
+
+// DraftOrderCalculate prices the draft order described by the input without storing it.
+func (r *mutationResolver) DraftOrderCalculate(args draftOrderCreateArgs) *draftOrderCalculatePayload {
+	d, err := r.s.applyDraftOrderInput(&DraftOrder{}, args.Input)
+	if err != nil {
+		return &draftOrderCalculatePayload{UserErrors: []*userError{err}}
+	}
+	return &draftOrderCalculatePayload{
+		CalculatedDraftOrder: &calculatedDraftOrderResolver{&draftOrderResolver{d: d, s: r.s}},
+		UserErrors:           []*userError{},
+	}
+}
+
+type draftOrderCompleteArgs struct {
+	ID             graphqlserver.ID
+	PaymentPending *bool
+}
+
+// DraftOrderComplete creates the order of a draft order, paid by a manual sale unless the payment
+// is pending. Its line items are assigned to the first active location, if any.
+func (r *mutationResolver) DraftOrderComplete(args draftOrderCompleteArgs) *draftOrderPayload {
+	d := r.s.draftOrder(string(args.ID))
+	if d == nil {
+		return draftOrderFailed("Draft order does not exist", "id")
+	}
+	if d.Status == "COMPLETED" {
+		return draftOrderFailed("Draft order has already been completed", "id")
+	}
+	if len(d.LineItems) == 0 {
+		return draftOrderFailed("Add at least 1 product", "id")
+	}
+
+	o := &Order{
+		Email:    d.Email,
+		Note:     d.Note,
+		Tags:     append([]string{}, d.Tags...),
+		Customer: r.s.customer(d.CustomerID),
+	}
+	if d.ShippingAddress != nil {
+		address := *d.ShippingAddress
+		o.ShippingAddress = &address
+	}
+	if d.ShippingLine != nil {
+		line := *d.ShippingLine
+		o.ShippingLine = &line
+	}
+	// The discount of the draft order is spread over its line items, in proportion to their totals.
+	ratio := 1.0
+	if total := draftOrderLinesTotal(d); total > 0 {
+		ratio = draftOrderSubtotal(d) / total
+	}
+	var fulfillmentOrder *FulfillmentOrder
+	for _, l := range r.s.locations {
+		if l.DeactivatedAt == nil {
+			fulfillmentOrder = &FulfillmentOrder{AssignedLocationID: l.ID}
+			break
+		}
+	}
+	for _, dli := range d.LineItems {
+		li := &LineItem{
+			ID:        r.s.newID("LineItem"),
+			SKU:       dli.SKU,
+			Title:     dli.Title,
+			Quantity:  dli.Quantity,
+			VariantID: dli.VariantID,
+			Price:     dli.Price,
+		}
+		if p, v := r.s.variant(dli.VariantID); v != nil {
+			li.ProductID, li.VariantTitle, li.Vendor = p.ID, v.Title, p.Vendor
+		}
+		if discounted := draftLineItemDiscountedTotal(dli) * ratio; cents(discounted) != cents(draftLineItemTotal(dli)) {
+			li.DiscountedPrice = formatAmount(discounted / float64(dli.Quantity))
+		}
+		o.LineItems = append(o.LineItems, li)
+		if fulfillmentOrder != nil {
+			fulfillmentOrder.LineItems = append(fulfillmentOrder.LineItems, &FulfillmentOrderLineItem{
+				LineItemID:        li.ID,
+				TotalQuantity:     li.Quantity,
+				RemainingQuantity: li.Quantity,
+			})
+		}
+	}
+	if fulfillmentOrder != nil {
+		o.FulfillmentOrders = []*FulfillmentOrder{fulfillmentOrder}
+	}
+	r.s.fillOrder(o)
+	if args.PaymentPending == nil || !*args.PaymentPending {
+		o.Transactions = append(o.Transactions, &Transaction{
+			ID:          r.s.newID("OrderTransaction"),
+			Kind:        "SALE",
+			Status:      "SUCCESS",
+			Gateway:     "manual",
+			Amount:      formatAmount(orderTotal(o)),
+			ProcessedAt: r.s.now(),
+		})
+	}
+	r.s.updateFinancialStatus(o)
+	r.s.orders = append(r.s.orders, o)
+
+	now := r.s.now()
+	d.Status = "COMPLETED"
+	d.OrderID = o.ID
+	d.CompletedAt = &now
+	d.UpdatedAt = now
+	return &draftOrderPayload{DraftOrder: &draftOrderResolver{d: d, s: r.s}, UserErrors: []*userError{}}
+}
+
+type draftOrderInvoiceSendArgs struct {
+	ID    graphqlserver.ID
+	Email *struct {
+		To            *string
+		From          *string
+		Bcc           *[]string
+		Subject       *string
+		CustomMessage *string
+	}
+}
+
+// DraftOrderInvoiceSend marks the invoice of a draft order as sent, to the email of the input or
+// else of the draft order.
+func (r *mutationResolver) DraftOrderInvoiceSend(args draftOrderInvoiceSendArgs) *draftOrderPayload {
+	d := r.s.draftOrder(string(args.ID))
+	if d == nil {
+		return draftOrderFailed("Draft order does not exist", "id")
+	}
+	if d.Status == "COMPLETED" {
+		return draftOrderFailed("Draft order has already been completed", "id")
+	}
+	to := d.Email
+	if args.Email != nil {
+		setString(&to, args.Email.To)
+	}
+	if to == "" {
+		return draftOrderFailed("To can't be blank", "email", "to")
+	}
+
+	now := r.s.now()
+	d.Status = "INVOICE_SENT"
+	d.InvoiceSentAt = &now
+	d.UpdatedAt = now
+	return &draftOrderPayload{DraftOrder: &draftOrderResolver{d: d, s: r.s}, UserErrors: []*userError{}}
+}
+
+type draftOrderDeleteArgs struct {
+	Input struct {
+		ID graphqlserver.ID
+	}
+}
+
+func (r *mutationResolver) DraftOrderDelete(args draftOrderDeleteArgs) *deletePayload {
+	id := string(args.Input.ID)
+	for i, d := range r.s.draftOrders {
+		if d.ID == id {
+			r.s.draftOrders = append(r.s.draftOrders[:i], r.s.draftOrders[i+1:]...)
+			return deleted(id)
+		}
+	}
+	return deleteFailed("id", "Draft order does not exist")
+}
+
+type job struct {
+	ID   graphqlserver.ID
+	Done bool
+}
+
+type jobPayload struct {
+	Job        *job
+	UserErrors []*userError
+}
+
+type draftOrderBulkTagsArgs struct {
+	IDs  *[]graphqlserver.ID
+	Tags []string
+}
+
+// DraftOrderBulkAddTags adds tags to draft orders right away, returning a job already done.
+func (r *mutationResolver) DraftOrderBulkAddTags(args draftOrderBulkTagsArgs) *jobPayload {
+	return r.s.bulkUpdateDraftOrderTags(args, func(tags []string) []string {
+		for _, tag := range args.Tags {
+			if !contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		return tags
+	})
+}
+
+// DraftOrderBulkRemoveTags removes tags from draft orders right away, returning a job already done.
+func (r *mutationResolver) DraftOrderBulkRemoveTags(args draftOrderBulkTagsArgs) *jobPayload {
+	return r.s.bulkUpdateDraftOrderTags(args, func(tags []string) []string {
+		for _, tag := range args.Tags {
+			tags = remove(tags, tag)
+		}
+		return tags
+	})
+}
+
+func (s *Server) bulkUpdateDraftOrderTags(args draftOrderBulkTagsArgs, update func([]string) []string) *jobPayload {
+	if len(args.Tags) == 0 {
+		return &jobPayload{UserErrors: []*userError{fieldError("Tags can't be blank", "tags")}}
+	}
+	if args.IDs == nil || len(*args.IDs) == 0 {
+		return &jobPayload{UserErrors: []*userError{fieldError("Ids can't be blank", "ids")}}
+	}
+	var drafts []*DraftOrder
+	for i, id := range *args.IDs {
+		d := s.draftOrder(string(id))
+		if d == nil {
+			return &jobPayload{UserErrors: []*userError{fieldError("Draft order does not exist", "ids", fmt.Sprint(i))}}
+		}
+		drafts = append(drafts, d)
+	}
+
+	now := s.now()
+	for _, d := range drafts {
+		d.Tags = append([]string{}, update(d.Tags)...)
+		d.UpdatedAt = now
+	}
+	return &jobPayload{Job: &job{ID: graphqlserver.ID(s.newID("Job")), Done: true}, UserErrors: []*userError{}}
+}
@@ -0,0 +1,670 @@
+package shopifytest
+
+import (
+	"fmt"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+// assignmentRequestStatuses are the request statuses of the fulfillment orders selected by each
+// FulfillmentOrderAssignmentStatus.
+var assignmentRequestStatuses = map[string]string{
+	"FULFILLMENT_REQUESTED":  "SUBMITTED",
+	"FULFILLMENT_ACCEPTED":   "ACCEPTED",
+	"CANCELLATION_REQUESTED": "CANCELLATION_REQUESTED",
+}
+
+// supportedActions returns the actions that can be taken on the fulfillment order in its status.
+func supportedActions(fo *FulfillmentOrder) []string {
+	switch fo.Status {
+	case "OPEN", "IN_PROGRESS":
+		return []string{"CREATE_FULFILLMENT", "MOVE", "HOLD"}
+	case "ON_HOLD":
+		return []string{"RELEASE_HOLD"}
+	case "SCHEDULED":
+		return []string{"MARK_AS_OPEN"}
+	}
+	return nil
+}
+
+// fulfilledQuantity returns the quantity of the order line item shipped by successful fulfillments.
+func (s *Server) fulfilledQuantity(lineItemID string) int {
+	quantity := 0
+	for _, o := range s.orders {
+		for _, f := range o.Fulfillments {
+			if f.Status != "SUCCESS" {
+				continue
+			}
+			for _, fli := range f.LineItems {
+				if li := fulfillmentOrderLineItem(o, fli.FulfillmentOrderLineItemID); li != nil && li.LineItemID == lineItemID {
+					quantity += fli.Quantity
+				}
+			}
+		}
+	}
+	return quantity
+}
+
+func fulfillmentOrderLineItem(o *Order, id string) *FulfillmentOrderLineItem {
+	for _, fo := range o.FulfillmentOrders {
+		for _, li := range fo.LineItems {
+			if li.ID == id {
+				return li
+			}
+		}
+	}
+	return nil
+}
+
+// updateFulfillmentStatus updates the fulfillment status of the order and its line items from its
+// fulfillments.
+func (s *Server) updateFulfillmentStatus(o *Order) {
+	fulfilled, unfulfilled := false, false
+	for _, li := range o.LineItems {
+		q := s.fulfilledQuantity(li.ID)
+		switch {
+		case q >= li.Quantity:
+			li.FulfillmentStatus = "fulfilled"
+		case q > 0:
+			li.FulfillmentStatus = "partial"
+		default:
+			li.FulfillmentStatus = "unfulfilled"
+		}
+		fulfilled = fulfilled || q > 0
+		unfulfilled = unfulfilled || q < li.Quantity
+	}
+	switch {
+	case !unfulfilled:
+		o.DisplayFulfillmentStatus = "FULFILLED"
+	case fulfilled:
+		o.DisplayFulfillmentStatus = "PARTIALLY_FULFILLED"
+	default:
+		o.DisplayFulfillmentStatus = "UNFULFILLED"
+	}
+	o.UpdatedAt = s.now()
+}
+
+// openStatus returns the status of the fulfillment order once it can be fulfilled again:
+// IN_PROGRESS when part of it was fulfilled and OPEN otherwise.
+func openStatus(fo *FulfillmentOrder) string {
+	for _, li := range fo.LineItems {
+		if li.RemainingQuantity < li.TotalQuantity {
+			return "IN_PROGRESS"
+		}
+	}
+	return "OPEN"
+}
+
+// unfulfilled returns copies of the line items of the fulfillment order with a remaining quantity,
+// setting the remaining quantity of the originals to zero.
+func (s *Server) unfulfilled(fo *FulfillmentOrder) []*FulfillmentOrderLineItem {
+	var items []*FulfillmentOrderLineItem
+	for _, li := range fo.LineItems {
+		if li.RemainingQuantity == 0 {
+			continue
+		}
+		items = append(items, &FulfillmentOrderLineItem{
+			ID:                s.newID("FulfillmentOrderLineItem"),
+			LineItemID:        li.LineItemID,
+			TotalQuantity:     li.RemainingQuantity,
+			RemainingQuantity: li.RemainingQuantity,
+		})
+		li.TotalQuantity -= li.RemainingQuantity
+		li.RemainingQuantity = 0
+	}
+	return items
+}
+
+// Queries
+
+func (r *queryResolver) Fulfillment(args idArgs) *fulfillmentResolver {
+	f, o := r.s.fulfillment(string(args.ID))
+	if f == nil {
+		return nil
+	}
+	return &fulfillmentResolver{f: f, o: o, s: r.s}
+}
+
+func (r *queryResolver) FulfillmentOrder(args idArgs) *fulfillmentOrderResolver {
+	fo, o := r.s.fulfillmentOrder(string(args.ID))
+	if fo == nil {
+		return nil
+	}
+	return &fulfillmentOrderResolver{fo: fo, o: o, s: r.s}
+}
+
+type assignedFulfillmentOrdersArgs struct {
+	connectionArgs
+	AssignmentStatus *string
+	LocationIds      *[]graphqlserver.ID
+}
+
+// AssignedFulfillmentOrders returns the fulfillment orders assigned to fulfillment service
+// locations, which are the legacy locations of the fake server.
+func (r *queryResolver) AssignedFulfillmentOrders(args assignedFulfillmentOrdersArgs) *connection[*fulfillmentOrderResolver] {
+	var resolvers []*fulfillmentOrderResolver
+	for _, o := range r.s.orders {
+		for _, fo := range o.FulfillmentOrders {
+			if l := r.s.location(fo.AssignedLocationID); l == nil || !l.Legacy {
+				continue
+			}
+			if args.AssignmentStatus != nil && fo.RequestStatus != assignmentRequestStatuses[*args.AssignmentStatus] {
+				continue
+			}
+			if args.LocationIds != nil && !containsID(*args.LocationIds, fo.AssignedLocationID) {
+				continue
+			}
+			resolvers = append(resolvers, &fulfillmentOrderResolver{fo: fo, o: o, s: r.s})
+		}
+	}
+	return newConnection(resolvers, func(r *fulfillmentOrderResolver) string { return r.fo.ID }, args.connectionArgs)
+}
+
+func containsID(ids []graphqlserver.ID, id string) bool {
+	for _, i := range ids {
+		if string(i) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Fulfillment
+
+type fulfillmentResolver struct {
+	f *Fulfillment
+	o *Order
+	s *Server
+}
+
+func (r *fulfillmentResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.f.ID)
+}
+
+func (r *fulfillmentResolver) Name() string {
+	return r.f.Name
+}
+
+func (r *fulfillmentResolver) Status() string {
+	return r.f.Status
+}
+
+func (r *fulfillmentResolver) TrackingInfo(args struct{ First *int32 }) []*fulfillmentTrackingInfoResolver {
+	resolvers := []*fulfillmentTrackingInfoResolver{}
+	for _, t := range r.f.TrackingInfo {
+		if args.First != nil && len(resolvers) == int(*args.First) {
+			break
+		}
+		resolvers = append(resolvers, &fulfillmentTrackingInfoResolver{t: t})
+	}
+	return resolvers
+}
+
+func (r *fulfillmentResolver) FulfillmentLineItems(args connectionArgs) *connection[*fulfillmentLineItemResolver] {
+	var resolvers []*fulfillmentLineItemResolver
+	for _, li := range r.f.LineItems {
+		resolvers = append(resolvers, &fulfillmentLineItemResolver{li: li, o: r.o, s: r.s})
+	}
+	return newConnection(resolvers, func(r *fulfillmentLineItemResolver) string { return r.li.ID }, args)
+}
+
+func (r *fulfillmentResolver) CreatedAt() scalar {
+	return dateTime(r.f.CreatedAt)
+}
+
+func (r *fulfillmentResolver) UpdatedAt() scalar {
+	return dateTime(r.f.UpdatedAt)
+}
+
+type fulfillmentLineItemResolver struct {
+	li *FulfillmentLineItem
+	o  *Order
+	s  *Server
+}
+
+func (r *fulfillmentLineItemResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.li.ID)
+}
+
+func (r *fulfillmentLineItemResolver) Quantity() int32 {
+	return int32(r.li.Quantity)
+}
+
+func (r *fulfillmentLineItemResolver) LineItem() *lineItemResolver {
+	li := fulfillmentOrderLineItem(r.o, r.li.FulfillmentOrderLineItemID)
+	return (&fulfillmentOrderLineItemResolver{li: li, o: r.o, s: r.s}).LineItem()
+}
+
+type fulfillmentTrackingInfoResolver struct {
+	t *FulfillmentTrackingInfo
+}
+
+func (r *fulfillmentTrackingInfoResolver) Company() *string {
+	return strPtr(r.t.Company)
+}
+
+func (r *fulfillmentTrackingInfoResolver) Number() *string {
+	return strPtr(r.t.Number)
+}
+
+func (r *fulfillmentTrackingInfoResolver) URL() *scalar {
+	return scalarPtr(r.t.URL)
+}
+
+type fulfillmentOrderAssignedLocation struct {
+	l *locationResolver
+}
+
+func (r *fulfillmentOrderAssignedLocation) Name() string {
+	return r.l.l.Name
+}
+
+func (r *fulfillmentOrderAssignedLocation) Location() *locationResolver {
+	if r.l.s.location(r.l.l.ID) == nil {
+		return nil
+	}
+	return r.l
+}
+
+type fulfillmentHoldResolver struct {
+	h *FulfillmentHold
+}
+
+func (r *fulfillmentHoldResolver) Reason() string {
+	return r.h.Reason
+}
+
+func (r *fulfillmentHoldResolver) ReasonNotes() *string {
+	return strPtr(r.h.ReasonNotes)
+}
+
+type fulfillmentOrderSupportedAction struct {
+	Action string
+}
+
+// Fulfillment mutations
+
+type fulfillmentTrackingInput struct {
+	Company *string
+	Number  *string
+	URL     *scalar
+}
+
+func (s *Server) trackingInfo(input *fulfillmentTrackingInput) []*FulfillmentTrackingInfo {
+	if input == nil || (input.Company == nil && input.Number == nil && input.URL == nil) {
+		return nil
+	}
+	t := &FulfillmentTrackingInfo{}
+	setString(&t.Company, input.Company)
+	setString(&t.Number, input.Number)
+	if input.URL != nil {
+		t.URL = string(*input.URL)
+	}
+	return []*FulfillmentTrackingInfo{t}
+}
+
+type fulfillmentPayload struct {
+	Fulfillment *fulfillmentResolver
+	UserErrors  []*userError
+}
+
+func fulfillmentFailed(message string, field ...string) *fulfillmentPayload {
+	return &fulfillmentPayload{UserErrors: []*userError{fieldError(message, field...)}}
+}
+
+type fulfillmentCreateV2Args struct {
+	Fulfillment struct {
+		LineItemsByFulfillmentOrder []struct {
+			FulfillmentOrderID        graphqlserver.ID
+			FulfillmentOrderLineItems *[]struct {
+				ID       graphqlserver.ID
+				Quantity int32
+			}
+		}
+		NotifyCustomer *bool
+		TrackingInfo   *fulfillmentTrackingInput
+	}
+}
+
+// FulfillmentCreateV2 fulfills quantities of fulfillment order line items, all the remaining ones
+// of a fulfillment order when its line items are omitted. The fulfillment orders become CLOSED once
+// fully fulfilled and IN_PROGRESS otherwise.
+func (r *mutationResolver) FulfillmentCreateV2(args fulfillmentCreateV2Args) *fulfillmentPayload {
+	input := args.Fulfillment
+	if len(input.LineItemsByFulfillmentOrder) == 0 {
+		return fulfillmentFailed("Line items by fulfillment order can't be blank", "fulfillment", "lineItemsByFulfillmentOrder")
+	}
+	var order *Order
+	var items []*FulfillmentLineItem
+	for i, byFO := range input.LineItemsByFulfillmentOrder {
+		field := []string{"fulfillment", "lineItemsByFulfillmentOrder", fmt.Sprint(i), "fulfillmentOrderId"}
+		fo, o := r.s.fulfillmentOrder(string(byFO.FulfillmentOrderID))
+		switch {
+		case fo == nil:
+			return fulfillmentFailed("Fulfillment order does not exist.", field...)
+		case order != nil && o != order:
+			return fulfillmentFailed("All fulfillment orders must belong to the same order.", field...)
+		case !contains(supportedActions(fo), "CREATE_FULFILLMENT"):
+			return fulfillmentFailed(fmt.Sprintf("Fulfillment order %s has an unfulfillable status= %s.", fo.ID, fo.Status), field...)
+		}
+		order = o
+		if byFO.FulfillmentOrderLineItems == nil {
+			for _, li := range fo.LineItems {
+				if li.RemainingQuantity > 0 {
+					items = append(items, &FulfillmentLineItem{
+						ID:                         r.s.newID("FulfillmentLineItem"),
+						FulfillmentOrderLineItemID: li.ID,
+						Quantity:                   li.RemainingQuantity,
+					})
+				}
+			}
+			continue
+		}
+		for j, item := range *byFO.FulfillmentOrderLineItems {
+			field := []string{"fulfillment", "lineItemsByFulfillmentOrder", fmt.Sprint(i), "fulfillmentOrderLineItems", fmt.Sprint(j)}
+			var li *FulfillmentOrderLineItem
+			for _, fli := range fo.LineItems {
+				if fli.ID == string(item.ID) {
+					li = fli
+				}
+			}
+			switch {
+			case li == nil:
+				return fulfillmentFailed("Fulfillment order line item does not exist.", append(field, "id")...)
+			case item.Quantity <= 0 || int(item.Quantity) > li.RemainingQuantity:
+				return fulfillmentFailed("Invalid fulfillment order line item quantity requested.", append(field, "quantity")...)
+			}
+			items = append(items, &FulfillmentLineItem{
+				ID:                         r.s.newID("FulfillmentLineItem"),
+				FulfillmentOrderLineItemID: li.ID,
+				Quantity:                   int(item.Quantity),
+			})
+		}
+	}
+	if len(items) == 0 {
+		return fulfillmentFailed("Fulfillment must contain at least one line item.", "fulfillment", "lineItemsByFulfillmentOrder")
+	}
+
+	now := r.s.now()
+	for _, item := range items {
+		fulfillmentOrderLineItem(order, item.FulfillmentOrderLineItemID).RemainingQuantity -= item.Quantity
+	}
+	for _, byFO := range input.LineItemsByFulfillmentOrder {
+		fo, _ := r.s.fulfillmentOrder(string(byFO.FulfillmentOrderID))
+		fo.Status = "CLOSED"
+		for _, li := range fo.LineItems {
+			if li.RemainingQuantity > 0 {
+				fo.Status = "IN_PROGRESS"
+			}
+		}
+		fo.UpdatedAt = now
+	}
+	f := &Fulfillment{
+		ID:           r.s.newID("Fulfillment"),
+		Name:         fmt.Sprintf("%s.%d", order.Name, len(order.Fulfillments)+1),
+		Status:       "SUCCESS",
+		TrackingInfo: r.s.trackingInfo(input.TrackingInfo),
+		LineItems:    items,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	order.Fulfillments = append(order.Fulfillments, f)
+	r.s.updateFulfillmentStatus(order)
+	return &fulfillmentPayload{Fulfillment: &fulfillmentResolver{f: f, o: order, s: r.s}, UserErrors: []*userError{}}
+}
+
+type fulfillmentTrackingInfoUpdateV2Args struct {
+	FulfillmentID     graphqlserver.ID
+	TrackingInfoInput fulfillmentTrackingInput
+	NotifyCustomer    *bool
+}
+
+// FulfillmentTrackingInfoUpdateV2 replaces the tracking information of a fulfillment.
+func (r *mutationResolver) FulfillmentTrackingInfoUpdateV2(args fulfillmentTrackingInfoUpdateV2Args) *fulfillmentPayload {
+	f, o := r.s.fulfillment(string(args.FulfillmentID))
+	if f == nil {
+		return fulfillmentFailed("Fulfillment does not exist.", "fulfillmentId")
+	}
+	if f.Status == "CANCELLED" {
+		return fulfillmentFailed("Fulfillment is cancelled.", "fulfillmentId")
+	}
+	f.TrackingInfo = r.s.trackingInfo(&args.TrackingInfoInput)
+	f.UpdatedAt = r.s.now()
+	return &fulfillmentPayload{Fulfillment: &fulfillmentResolver{f: f, o: o, s: r.s}, UserErrors: []*userError{}}
+}
+
+// FulfillmentCancel cancels a fulfillment, giving its quantities back to their fulfillment orders.
+func (r *mutationResolver) FulfillmentCancel(args idArgs) *fulfillmentPayload {
+	f, o := r.s.fulfillment(string(args.ID))
+	if f == nil {
+		return fulfillmentFailed("Fulfillment does not exist.", "id")
+	}
+	if f.Status != "SUCCESS" {
+		return fulfillmentFailed("Fulfillment cannot be cancelled.", "id")
+	}
+	now := r.s.now()
+	for _, item := range f.LineItems {
+		li := fulfillmentOrderLineItem(o, item.FulfillmentOrderLineItemID)
+		li.RemainingQuantity += item.Quantity
+		for _, fo := range o.FulfillmentOrders {
+			if contains([]string{"CLOSED", "IN_PROGRESS"}, fo.Status) && containsLineItem(fo, li) {
+				fo.Status = openStatus(fo)
+				fo.UpdatedAt = now
+			}
+		}
+	}
+	f.Status = "CANCELLED"
+	f.UpdatedAt = now
+	r.s.updateFulfillmentStatus(o)
+	return &fulfillmentPayload{Fulfillment: &fulfillmentResolver{f: f, o: o, s: r.s}, UserErrors: []*userError{}}
+}
+
+func containsLineItem(fo *FulfillmentOrder, li *FulfillmentOrderLineItem) bool {
+	for _, item := range fo.LineItems {
+		if item == li {
+			return true
+		}
+	}
+	return false
+}
+
+// Fulfillment order mutations
+
+type fulfillmentOrderPayload struct {
+	FulfillmentOrder            *fulfillmentOrderResolver
+	ReplacementFulfillmentOrder *fulfillmentOrderResolver
+	MovedFulfillmentOrder       *fulfillmentOrderResolver
+	OriginalFulfillmentOrder    *fulfillmentOrderResolver
+	RemainingFulfillmentOrder   *fulfillmentOrderResolver
+	UserErrors                  []*userError
+}
+
+func fulfillmentOrderFailed(field string, message string) *fulfillmentOrderPayload {
+	return &fulfillmentOrderPayload{UserErrors: []*userError{newUserError(field, message)}}
+}
+
+// mutateFulfillmentOrder looks the fulfillment order id up and applies mutate to it, returning the
+// payload with the fulfillment order, or the user error returned by mutate.
+func (s *Server) mutateFulfillmentOrder(id graphqlserver.ID, mutate func(fo *FulfillmentOrder, o *Order) *userError) *fulfillmentOrderPayload {
+	fo, o := s.fulfillmentOrder(string(id))
+	if fo == nil {
+		return fulfillmentOrderFailed("id", "Fulfillment order does not exist.")
+	}
+	if uerr := mutate(fo, o); uerr != nil {
+		return &fulfillmentOrderPayload{UserErrors: []*userError{uerr}}
+	}
+	fo.UpdatedAt = s.now()
+	return &fulfillmentOrderPayload{FulfillmentOrder: &fulfillmentOrderResolver{fo: fo, o: o, s: s}, UserErrors: []*userError{}}
+}
+
+type fulfillmentOrderHoldArgs struct {
+	ID              graphqlserver.ID
+	FulfillmentHold struct {
+		Reason         string
+		ReasonNotes    *string
+		NotifyMerchant *bool
+	}
+}
+
+func (r *mutationResolver) FulfillmentOrderHold(args fulfillmentOrderHoldArgs) *fulfillmentOrderPayload {
+	return r.s.mutateFulfillmentOrder(args.ID, func(fo *FulfillmentOrder, o *Order) *userError {
+		if !contains(supportedActions(fo), "HOLD") {
+			return newUserError("id", "The fulfillment order's status is not eligible for being put on hold.")
+		}
+		h := &FulfillmentHold{Reason: args.FulfillmentHold.Reason}
+		setString(&h.ReasonNotes, args.FulfillmentHold.ReasonNotes)
+		fo.Holds = append(fo.Holds, h)
+		fo.Status = "ON_HOLD"
+		return nil
+	})
+}
+
+func (r *mutationResolver) FulfillmentOrderReleaseHold(args idArgs) *fulfillmentOrderPayload {
+	return r.s.mutateFulfillmentOrder(args.ID, func(fo *FulfillmentOrder, o *Order) *userError {
+		if fo.Status != "ON_HOLD" {
+			return newUserError("id", "The fulfillment order is not on hold.")
+		}
+		fo.Holds = nil
+		fo.Status = openStatus(fo)
+		return nil
+	})
+}
+
+type fulfillmentOrderMoveArgs struct {
+	ID            graphqlserver.ID
+	NewLocationID graphqlserver.ID
+}
+
+// FulfillmentOrderMove assigns a fulfillment order to another location. A partially fulfilled
+// fulfillment order is closed instead, its unfulfilled line items moving to a new one.
+func (r *mutationResolver) FulfillmentOrderMove(args fulfillmentOrderMoveArgs) *fulfillmentOrderPayload {
+	fo, o := r.s.fulfillmentOrder(string(args.ID))
+	if fo == nil {
+		return fulfillmentOrderFailed("id", "Fulfillment order does not exist.")
+	}
+	l := r.s.location(string(args.NewLocationID))
+	switch {
+	case l == nil || l.DeactivatedAt != nil:
+		return fulfillmentOrderFailed("newLocationId", "Location not found or inactive.")
+	case l.ID == fo.AssignedLocationID:
+		return fulfillmentOrderFailed("newLocationId", "Cannot move a fulfillment order to the location it's assigned to.")
+	case !contains(supportedActions(fo), "MOVE"):
+		return fulfillmentOrderFailed("id", "Cannot move a fulfillment order in status "+fo.Status+".")
+	}
+
+	now := r.s.now()
+	moved := fo
+	if openStatus(fo) == "IN_PROGRESS" {
+		moved = &FulfillmentOrder{
+			ID:            r.s.newID("FulfillmentOrder"),
+			Status:        "OPEN",
+			RequestStatus: "UNSUBMITTED",
+			LineItems:     r.s.unfulfilled(fo),
+			CreatedAt:     now,
+		}
+		fo.Status = "CLOSED"
+		o.FulfillmentOrders = append(o.FulfillmentOrders, moved)
+	}
+	moved.AssignedLocationID = l.ID
+	moved.UpdatedAt = now
+	fo.UpdatedAt = now
+	return &fulfillmentOrderPayload{
+		MovedFulfillmentOrder:    &fulfillmentOrderResolver{fo: moved, o: o, s: r.s},
+		OriginalFulfillmentOrder: &fulfillmentOrderResolver{fo: fo, o: o, s: r.s},
+		UserErrors:               []*userError{},
+	}
+}
+
+type fulfillmentOrderRescheduleArgs struct {
+	ID        graphqlserver.ID
+	FulfillAt scalar
+}
+
+func (r *mutationResolver) FulfillmentOrderReschedule(args fulfillmentOrderRescheduleArgs) *fulfillmentOrderPayload {
+	return r.s.mutateFulfillmentOrder(args.ID, func(fo *FulfillmentOrder, o *Order) *userError {
+		if fo.Status != "SCHEDULED" {
+			return newUserError("id", "Fulfillment order must be scheduled.")
+		}
+		fulfillAt, err := parseDateTime(&args.FulfillAt)
+		if err != nil || fulfillAt == nil {
+			return newUserError("fulfillAt", "Fulfill at is invalid.")
+		}
+		fo.FulfillAt = fulfillAt
+		return nil
+	})
+}
+
+// FulfillmentOrderCancel cancels a fulfillment order, replacing it with an open fulfillment order
+// of its unfulfilled line items.
+func (r *mutationResolver) FulfillmentOrderCancel(args idArgs) *fulfillmentOrderPayload {
+	var replacement *FulfillmentOrder
+	payload := r.s.mutateFulfillmentOrder(args.ID, func(fo *FulfillmentOrder, o *Order) *userError {
+		if !contains([]string{"OPEN", "IN_PROGRESS", "ON_HOLD", "SCHEDULED"}, fo.Status) {
+			return newUserError("id", "Fulfillment order is not in cancelable request state and can't be canceled.")
+		}
+		if items := r.s.unfulfilled(fo); len(items) > 0 {
+			replacement = &FulfillmentOrder{
+				ID:                 r.s.newID("FulfillmentOrder"),
+				Status:             "OPEN",
+				RequestStatus:      "UNSUBMITTED",
+				AssignedLocationID: fo.AssignedLocationID,
+				LineItems:          items,
+				CreatedAt:          r.s.now(),
+				UpdatedAt:          r.s.now(),
+			}
+			o.FulfillmentOrders = append(o.FulfillmentOrders, replacement)
+		}
+		fo.Status = "CANCELLED"
+		fo.Holds = nil
+		return nil
+	})
+	if replacement != nil {
+		payload.ReplacementFulfillmentOrder = &fulfillmentOrderResolver{fo: replacement, o: payload.FulfillmentOrder.o, s: r.s}
+	}
+	return payload
+}
+
+type fulfillmentOrderMessageArgs struct {
+	ID      graphqlserver.ID
+	Message *string
+}
+
+// FulfillmentOrderClose marks a fulfillment order whose fulfillment request was accepted as
+// incomplete.
+func (r *mutationResolver) FulfillmentOrderClose(args fulfillmentOrderMessageArgs) *fulfillmentOrderPayload {
+	return r.s.mutateFulfillmentOrder(args.ID, func(fo *FulfillmentOrder, o *Order) *userError {
+		if fo.RequestStatus != "ACCEPTED" {
+			return newUserError("id", "Fulfillment order is not in an accepted request state.")
+		}
+		fo.Status = "INCOMPLETE"
+		fo.RequestStatus = "CLOSED"
+		return nil
+	})
+}
+
+func (r *mutationResolver) FulfillmentOrderAcceptFulfillmentRequest(args fulfillmentOrderMessageArgs) *fulfillmentOrderPayload {
+	return r.s.mutateFulfillmentOrder(args.ID, func(fo *FulfillmentOrder, o *Order) *userError {
+		if fo.RequestStatus != "SUBMITTED" {
+			return newUserError("id", "Cannot accept fulfillment request for the fulfillment order.")
+		}
+		fo.RequestStatus = "ACCEPTED"
+		fo.Status = "IN_PROGRESS"
+		return nil
+	})
+}
+
+type fulfillmentOrderRejectArgs struct {
+	ID      graphqlserver.ID
+	Reason  *string
+	Message *string
+}
+
+func (r *mutationResolver) FulfillmentOrderRejectFulfillmentRequest(args fulfillmentOrderRejectArgs) *fulfillmentOrderPayload {
+	return r.s.mutateFulfillmentOrder(args.ID, func(fo *FulfillmentOrder, o *Order) *userError {
+		if fo.RequestStatus != "SUBMITTED" {
+			return newUserError("id", "Cannot reject fulfillment request for the fulfillment order.")
+		}
+		fo.RequestStatus = "REJECTED"
+		return nil
+	})
+}
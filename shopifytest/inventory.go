@@ -0,0 +1,501 @@
+package shopifytest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+// onHandNames are the quantity names summed into on_hand.
+var onHandNames = []string{"available", "committed", "reserved", "damaged", "safety_stock", "quality_control"}
+
+// adjustableNames are the quantity names inventoryAdjustQuantities and inventoryMoveQuantities change.
+var adjustableNames = []string{"available", "damaged", "incoming", "quality_control", "reserved", "safety_stock"}
+
+var inventoryReasons = []string{
+	"correction", "cycle_count_available", "damaged", "movement_canceled", "movement_created", "movement_received",
+	"movement_updated", "other", "promotion", "quality_control", "received", "reservation_created",
+	"reservation_deleted", "reservation_updated", "restock", "safety_stock", "shrinkage",
+}
+
+func quantity(l *InventoryLevel, name string) int {
+	if name != "on_hand" {
+		return l.Quantities[name]
+	}
+	total := 0
+	for _, n := range onHandNames {
+		total += l.Quantities[n]
+	}
+	return total
+}
+
+// availableQuantity returns the available quantity of v at all its locations.
+func availableQuantity(v *Variant) int {
+	total := 0
+	for _, l := range v.InventoryLevels {
+		total += l.Quantities["available"]
+	}
+	return total
+}
+
+func inventoryLevel(v *Variant, locationID string) *InventoryLevel {
+	for _, l := range v.InventoryLevels {
+		if l.LocationID == locationID {
+			return l
+		}
+	}
+	return nil
+}
+
+// Queries
+
+func (r *queryResolver) InventoryItem(args idArgs) *inventoryItemResolver {
+	p, v := r.s.inventoryItem(string(args.ID))
+	if v == nil {
+		return nil
+	}
+	return &inventoryItemResolver{v: v, p: p, s: r.s}
+}
+
+func (r *queryResolver) InventoryLevel(args idArgs) *inventoryLevelResolver {
+	n := r.s.node(string(args.ID))
+	if n == nil {
+		return nil
+	}
+	l, _ := n.node.(*inventoryLevelResolver)
+	return l
+}
+
+// InventoryItem
+
+type inventoryItemResolver struct {
+	v *Variant
+	p *Product
+	s *Server
+}
+
+func (r *inventoryItemResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.v.InventoryItemID)
+}
+
+func (r *inventoryItemResolver) LegacyResourceID() scalar {
+	return legacyResourceID(r.v.InventoryItemID)
+}
+
+func (r *inventoryItemResolver) SKU() *string {
+	return strPtr(r.v.SKU)
+}
+
+func (r *inventoryItemResolver) Tracked() bool {
+	return true
+}
+
+func (r *inventoryItemResolver) RequiresShipping() bool {
+	return true
+}
+
+func (r *inventoryItemResolver) Variant() *variantResolver {
+	return &variantResolver{v: r.v, p: r.p, s: r.s}
+}
+
+func (r *inventoryItemResolver) InventoryLevel(args struct{ LocationID graphqlserver.ID }) *inventoryLevelResolver {
+	l := inventoryLevel(r.v, string(args.LocationID))
+	if l == nil {
+		return nil
+	}
+	return &inventoryLevelResolver{l: l, v: r.v, p: r.p, s: r.s}
+}
+
+func (r *inventoryItemResolver) InventoryLevels(args connectionArgs) *connection[*inventoryLevelResolver] {
+	var levels []*inventoryLevelResolver
+	for _, l := range r.v.InventoryLevels {
+		levels = append(levels, &inventoryLevelResolver{l: l, v: r.v, p: r.p, s: r.s})
+	}
+	return newConnection(levels, func(r *inventoryLevelResolver) string { return r.l.ID }, args)
+}
+
+func (r *inventoryItemResolver) CreatedAt() scalar {
+	return dateTime(r.v.CreatedAt)
+}
+
+func (r *inventoryItemResolver) UpdatedAt() scalar {
+	return dateTime(r.v.UpdatedAt)
+}
+
+func (r *variantResolver) InventoryItem() *inventoryItemResolver {
+	return &inventoryItemResolver{v: r.v, p: r.p, s: r.s}
+}
+
+// InventoryLevel
+
+type inventoryLevelResolver struct {
+	l *InventoryLevel
+	v *Variant
+	p *Product
+	s *Server
+}
+
+func (r *inventoryLevelResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.l.ID)
+}
+
+func (r *inventoryLevelResolver) Available() int32 {
+	return int32(r.l.Quantities["available"])
+}
+
+type inventoryQuantity struct {
+	Name      string
+	Quantity  int32
+	UpdatedAt *scalar
+}
+
+func (r *inventoryLevelResolver) Quantities(args struct{ Names []string }) []*inventoryQuantity {
+	var quantities []*inventoryQuantity
+	for _, name := range args.Names {
+		quantities = append(quantities, &inventoryQuantity{
+			Name:      name,
+			Quantity:  int32(quantity(r.l, name)),
+			UpdatedAt: dateTimePtr(&r.l.UpdatedAt),
+		})
+	}
+	return quantities
+}
+
+func (r *inventoryLevelResolver) Item() *inventoryItemResolver {
+	return &inventoryItemResolver{v: r.v, p: r.p, s: r.s}
+}
+
+func (r *inventoryLevelResolver) Location() *locationResolver {
+	return r.s.resolveLocation(r.l.LocationID)
+}
+
+func (r *inventoryLevelResolver) CreatedAt() scalar {
+	return dateTime(r.l.CreatedAt)
+}
+
+func (r *inventoryLevelResolver) UpdatedAt() scalar {
+	return dateTime(r.l.UpdatedAt)
+}
+
+// resolveLocation resolves the location with id, which levels of variants added to the server
+// may reference without it being added.
+func (s *Server) resolveLocation(id string) *locationResolver {
+	l := s.location(id)
+	if l == nil {
+		l = &Location{ID: id}
+	}
+	return &locationResolver{l: l, s: s}
+}
+
+// InventoryAdjustmentGroup
+
+type inventoryAdjustmentGroup struct {
+	id                   string
+	CreatedAt            scalar
+	Reason               string
+	ReferenceDocumentURI *string
+	Changes              []*inventoryChange
+}
+
+func (g *inventoryAdjustmentGroup) ID() graphqlserver.ID {
+	return graphqlserver.ID(g.id)
+}
+
+type inventoryChange struct {
+	Name                string
+	Delta               int32
+	QuantityAfterChange *int32
+	LedgerDocumentURI   *string
+	Item                *inventoryItemResolver
+	Location            *locationResolver
+}
+
+// Mutations
+
+type inventoryActivateArgs struct {
+	InventoryItemID graphqlserver.ID
+	LocationID      graphqlserver.ID
+	Available       *int32
+}
+
+type inventoryActivatePayload struct {
+	InventoryLevel *inventoryLevelResolver
+	UserErrors     []*userError
+}
+
+// InventoryActivate stocks an inventory item at a location, or returns its level there when it's
+// already stocked.
+func (r *mutationResolver) InventoryActivate(args inventoryActivateArgs) *inventoryActivatePayload {
+	p, v := r.s.inventoryItem(string(args.InventoryItemID))
+	if v == nil {
+		return &inventoryActivatePayload{UserErrors: []*userError{newUserError("inventoryItemId", "Inventory item does not exist")}}
+	}
+	if r.s.location(string(args.LocationID)) == nil {
+		return &inventoryActivatePayload{UserErrors: []*userError{newUserError("locationId", "Location does not exist")}}
+	}
+	l := inventoryLevel(v, string(args.LocationID))
+	if l == nil {
+		l = &InventoryLevel{LocationID: string(args.LocationID)}
+		r.s.fillInventoryLevel(l, r.s.now())
+		if args.Available != nil {
+			l.Quantities["available"] = int(*args.Available)
+		}
+		v.InventoryLevels = append(v.InventoryLevels, l)
+		v.InventoryQuantity = availableQuantity(v)
+	}
+	return &inventoryActivatePayload{InventoryLevel: &inventoryLevelResolver{l: l, v: v, p: p, s: r.s}, UserErrors: []*userError{}}
+}
+
+type inventoryAdjustmentPayload struct {
+	InventoryAdjustmentGroup *inventoryAdjustmentGroup
+	UserErrors               []*userError
+}
+
+func inventoryAdjustmentFailed(errs []*userError) *inventoryAdjustmentPayload {
+	return &inventoryAdjustmentPayload{UserErrors: errs}
+}
+
+// inventoryTarget is a level whose quantities a mutation changes.
+type inventoryTarget struct {
+	l *InventoryLevel
+	v *Variant
+	p *Product
+}
+
+// inventoryTarget returns the level of the item at the location, or the user error of the field
+// path when it doesn't exist.
+func (s *Server) inventoryTarget(itemID, locationID graphqlserver.ID, path ...string) (*inventoryTarget, *userError) {
+	p, v := s.inventoryItem(string(itemID))
+	if v == nil {
+		return nil, fieldError("The specified inventory item could not be found.", append(path, "inventoryItemId")...)
+	}
+	if loc := s.location(string(locationID)); loc == nil {
+		return nil, fieldError("The specified location could not be found.", append(path, "locationId")...)
+	} else if loc.DeactivatedAt != nil {
+		return nil, fieldError("The specified location is not active.", append(path, "locationId")...)
+	}
+	l := inventoryLevel(v, string(locationID))
+	if l == nil {
+		return nil, fieldError("The specified inventory item is not stocked at the location.", append(path, "inventoryItemId")...)
+	}
+	return &inventoryTarget{l: l, v: v, p: p}, nil
+}
+
+func fieldError(message string, field ...string) *userError {
+	return &userError{Field: &field, Message: message}
+}
+
+// newAdjustmentGroup returns an empty adjustment group, or a user error when reason isn't one of
+// Shopify's inventory reasons.
+func (s *Server) newAdjustmentGroup(reason string, referenceDocumentURI *string) (*inventoryAdjustmentGroup, *userError) {
+	if !contains(inventoryReasons, reason) {
+		return nil, fieldError("The specified reason is invalid. Valid values: "+strings.Join(inventoryReasons, ", ")+".", "input", "reason")
+	}
+	return &inventoryAdjustmentGroup{
+		id:                   s.newID("InventoryAdjustmentGroup"),
+		CreatedAt:            dateTime(s.now()),
+		Reason:               reason,
+		ReferenceDocumentURI: referenceDocumentURI,
+		Changes:              []*inventoryChange{},
+	}, nil
+}
+
+// change adds delta to the quantity of name of the target, recording the change to the group.
+func (g *inventoryAdjustmentGroup) change(s *Server, t *inventoryTarget, name string, delta int, ledgerDocumentURI *string, now time.Time) {
+	t.l.Quantities[name] += delta
+	t.l.UpdatedAt = now
+	t.v.InventoryQuantity = availableQuantity(t.v)
+	g.record(s, t, name, delta, ledgerDocumentURI)
+}
+
+func (g *inventoryAdjustmentGroup) record(s *Server, t *inventoryTarget, name string, delta int, ledgerDocumentURI *string) {
+	after := int32(quantity(t.l, name))
+	g.Changes = append(g.Changes, &inventoryChange{
+		Name:                name,
+		Delta:               int32(delta),
+		QuantityAfterChange: &after,
+		LedgerDocumentURI:   ledgerDocumentURI,
+		Item:                &inventoryItemResolver{v: t.v, p: t.p, s: s},
+		Location:            s.resolveLocation(t.l.LocationID),
+	})
+}
+
+// checkQuantityName returns the user error of the field path when name can't be adjusted or a
+// needed ledger document URI is missing.
+func checkQuantityName(name string, ledgerDocumentURI *string, path ...string) *userError {
+	if !contains(adjustableNames, name) {
+		return fieldError("The quantity name must be one of "+strings.Join(adjustableNames, ", ")+".", append(path, "name")...)
+	}
+	if name != "available" && (ledgerDocumentURI == nil || *ledgerDocumentURI == "") {
+		return fieldError("A ledger document URI is required except when adjusting available.", append(path, "ledgerDocumentUri")...)
+	}
+	return nil
+}
+
+type inventorySetOnHandQuantitiesArgs struct {
+	Input struct {
+		Reason               string
+		ReferenceDocumentURI *string
+		SetQuantities        []struct {
+			InventoryItemID graphqlserver.ID
+			LocationID      graphqlserver.ID
+			Quantity        int32
+		}
+	}
+}
+
+// InventorySetOnHandQuantities sets on hand quantities by adjusting the available quantities by the
+// difference. No quantity changes when there are user errors.
+func (r *mutationResolver) InventorySetOnHandQuantities(args inventorySetOnHandQuantitiesArgs) *inventoryAdjustmentPayload {
+	g, uerr := r.s.newAdjustmentGroup(args.Input.Reason, args.Input.ReferenceDocumentURI)
+	if uerr != nil {
+		return inventoryAdjustmentFailed([]*userError{uerr})
+	}
+	var errs []*userError
+	targets := make([]*inventoryTarget, len(args.Input.SetQuantities))
+	for i, q := range args.Input.SetQuantities {
+		targets[i], uerr = r.s.inventoryTarget(q.InventoryItemID, q.LocationID, "input", "setQuantities", strconv.Itoa(i))
+		if uerr != nil {
+			errs = append(errs, uerr)
+		}
+	}
+	if len(errs) > 0 {
+		return inventoryAdjustmentFailed(errs)
+	}
+
+	now := r.s.now()
+	for i, q := range args.Input.SetQuantities {
+		t := targets[i]
+		if delta := int(q.Quantity) - quantity(t.l, "on_hand"); delta != 0 {
+			g.change(r.s, t, "available", delta, nil, now)
+			g.record(r.s, t, "on_hand", delta, nil)
+		}
+	}
+	return r.s.adjusted(g)
+}
+
+type inventoryAdjustQuantitiesArgs struct {
+	Input struct {
+		Reason               string
+		Name                 string
+		ReferenceDocumentURI *string
+		Changes              []struct {
+			InventoryItemID   graphqlserver.ID
+			LocationID        graphqlserver.ID
+			Delta             int32
+			LedgerDocumentURI *string
+		}
+	}
+}
+
+// InventoryAdjustQuantities adds deltas to quantities of a name. No quantity changes when there
+// are user errors.
+func (r *mutationResolver) InventoryAdjustQuantities(args inventoryAdjustQuantitiesArgs) *inventoryAdjustmentPayload {
+	g, uerr := r.s.newAdjustmentGroup(args.Input.Reason, args.Input.ReferenceDocumentURI)
+	if uerr != nil {
+		return inventoryAdjustmentFailed([]*userError{uerr})
+	}
+	if !contains(adjustableNames, args.Input.Name) {
+		return inventoryAdjustmentFailed([]*userError{checkQuantityName(args.Input.Name, nil, "input")})
+	}
+	var errs []*userError
+	targets := make([]*inventoryTarget, len(args.Input.Changes))
+	for i, c := range args.Input.Changes {
+		path := []string{"input", "changes", strconv.Itoa(i)}
+		targets[i], uerr = r.s.inventoryTarget(c.InventoryItemID, c.LocationID, path...)
+		if uerr == nil {
+			uerr = checkQuantityName(args.Input.Name, c.LedgerDocumentURI, path...)
+		}
+		if uerr != nil {
+			errs = append(errs, uerr)
+		}
+	}
+	if len(errs) > 0 {
+		return inventoryAdjustmentFailed(errs)
+	}
+
+	now := r.s.now()
+	for i, c := range args.Input.Changes {
+		g.change(r.s, targets[i], args.Input.Name, int(c.Delta), c.LedgerDocumentURI, now)
+		if contains(onHandNames, args.Input.Name) {
+			g.record(r.s, targets[i], "on_hand", int(c.Delta), nil)
+		}
+	}
+	return r.s.adjusted(g)
+}
+
+type inventoryMoveQuantityTerminal struct {
+	LocationID        graphqlserver.ID
+	Name              string
+	LedgerDocumentURI *string
+}
+
+type inventoryMoveQuantitiesArgs struct {
+	Input struct {
+		Reason               string
+		ReferenceDocumentURI *string
+		Changes              []struct {
+			InventoryItemID graphqlserver.ID
+			Quantity        int32
+			From            inventoryMoveQuantityTerminal
+			To              inventoryMoveQuantityTerminal
+		}
+	}
+}
+
+// InventoryMoveQuantities moves quantities between names at a location. No quantity changes when
+// there are user errors.
+func (r *mutationResolver) InventoryMoveQuantities(args inventoryMoveQuantitiesArgs) *inventoryAdjustmentPayload {
+	g, uerr := r.s.newAdjustmentGroup(args.Input.Reason, args.Input.ReferenceDocumentURI)
+	if uerr != nil {
+		return inventoryAdjustmentFailed([]*userError{uerr})
+	}
+	var errs []*userError
+	targets := make([]*inventoryTarget, len(args.Input.Changes))
+	for i, c := range args.Input.Changes {
+		path := []string{"input", "changes", strconv.Itoa(i)}
+		switch {
+		case c.Quantity <= 0:
+			uerr = fieldError("The quantity must be greater than 0.", append(path, "quantity")...)
+		case c.From.LocationID != c.To.LocationID:
+			uerr = fieldError("The quantities can't be moved between different locations.", append(path, "to", "locationId")...)
+		case c.From.Name == c.To.Name:
+			uerr = fieldError("The quantity names must be different.", append(path, "to", "name")...)
+		default:
+			targets[i], uerr = r.s.inventoryTarget(c.InventoryItemID, c.From.LocationID, path...)
+		}
+		if uerr == nil {
+			uerr = checkQuantityName(c.From.Name, c.From.LedgerDocumentURI, append(path, "from")...)
+		}
+		if uerr == nil {
+			uerr = checkQuantityName(c.To.Name, c.To.LedgerDocumentURI, append(path, "to")...)
+		}
+		if uerr != nil {
+			errs = append(errs, uerr)
+		}
+	}
+	if len(errs) > 0 {
+		return inventoryAdjustmentFailed(errs)
+	}
+
+	now := r.s.now()
+	for i, c := range args.Input.Changes {
+		g.change(r.s, targets[i], c.From.Name, -int(c.Quantity), c.From.LedgerDocumentURI, now)
+		g.change(r.s, targets[i], c.To.Name, int(c.Quantity), c.To.LedgerDocumentURI, now)
+		// Moving from or to incoming, the only name not counted in on_hand, changes on_hand.
+		switch {
+		case !contains(onHandNames, c.From.Name) && contains(onHandNames, c.To.Name):
+			g.record(r.s, targets[i], "on_hand", int(c.Quantity), nil)
+		case contains(onHandNames, c.From.Name) && !contains(onHandNames, c.To.Name):
+			g.record(r.s, targets[i], "on_hand", -int(c.Quantity), nil)
+		}
+	}
+	return r.s.adjusted(g)
+}
+
+func (s *Server) adjusted(g *inventoryAdjustmentGroup) *inventoryAdjustmentPayload {
+	s.adjustmentGroups = append(s.adjustmentGroups, g)
+	return &inventoryAdjustmentPayload{InventoryAdjustmentGroup: g, UserErrors: []*userError{}}
+}
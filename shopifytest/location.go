@@ -0,0 +1,474 @@
+package shopifytest
+
+import (
+	"strings"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+// countryNames are the names of the countries of CountryCode in the schema.
+var countryNames = map[string]string{
+	"AU": "Australia",
+	"CA": "Canada",
+	"DE": "Germany",
+	"ES": "Spain",
+	"FR": "France",
+	"GB": "United Kingdom",
+	"IT": "Italy",
+	"JP": "Japan",
+	"NL": "Netherlands",
+	"US": "United States",
+	"VN": "Vietnam",
+}
+
+// Queries
+
+func (r *queryResolver) Location(args idArgs) *locationResolver {
+	l := r.s.location(string(args.ID))
+	if l == nil {
+		return nil
+	}
+	return &locationResolver{l: l, s: r.s}
+}
+
+type locationsArgs struct {
+	queryConnectionArgs
+	IncludeInactive *bool
+	IncludeLegacy   *bool
+}
+
+func (r *queryResolver) Locations(args locationsArgs) *connection[*locationResolver] {
+	var resolvers []*locationResolver
+	for _, l := range r.s.locations {
+		if l.DeactivatedAt != nil && (args.IncludeInactive == nil || !*args.IncludeInactive) {
+			continue
+		}
+		if l.Legacy && (args.IncludeLegacy == nil || !*args.IncludeLegacy) {
+			continue
+		}
+		if args.Query != nil && !matchQuery(*args.Query, l.Name, locationFields(l)) {
+			continue
+		}
+		resolvers = append(resolvers, &locationResolver{l: l, s: r.s})
+	}
+	return newConnection(resolvers, func(r *locationResolver) string { return r.l.ID }, args.connectionArgs)
+}
+
+func locationFields(l *Location) func(string) []string {
+	return func(name string) []string {
+		switch name {
+		case "id", "location_id":
+			return []string{l.ID}
+		case "name":
+			return []string{l.Name}
+		case "active":
+			return []string{boolString(l.DeactivatedAt == nil)}
+		case "legacy":
+			return []string{boolString(l.Legacy)}
+		case "address1":
+			return []string{l.Address.Address1}
+		case "city":
+			return []string{l.Address.City}
+		case "province":
+			return []string{l.Address.Province, l.Address.ProvinceCode}
+		case "country":
+			return []string{countryNames[l.Address.CountryCode], l.Address.CountryCode}
+		case "zip":
+			return []string{l.Address.Zip}
+		}
+		return nil
+	}
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+// Location
+
+type locationResolver struct {
+	l *Location
+	s *Server
+}
+
+func (r *locationResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.l.ID)
+}
+
+func (r *locationResolver) LegacyResourceID() scalar {
+	return legacyResourceID(r.l.ID)
+}
+
+func (r *locationResolver) Name() string {
+	return r.l.Name
+}
+
+func (r *locationResolver) Address() *locationAddressResolver {
+	return &locationAddressResolver{a: r.l.Address}
+}
+
+func (r *locationResolver) FulfillsOnlineOrders() bool {
+	return r.l.FulfillsOnlineOrders
+}
+
+func (r *locationResolver) IsActive() bool {
+	return r.l.DeactivatedAt == nil
+}
+
+func (r *locationResolver) ShipsInventory() bool {
+	return r.l.ShipsInventory
+}
+
+func (r *locationResolver) IsFulfillmentService() bool {
+	return r.l.Legacy
+}
+
+func (r *locationResolver) HasActiveInventory() bool {
+	return len(r.inventoryLevels()) > 0
+}
+
+func (r *locationResolver) Activatable() bool {
+	return r.l.DeactivatedAt != nil
+}
+
+func (r *locationResolver) Deactivatable() bool {
+	return r.l.DeactivatedAt == nil && !r.s.onlyOnlineLocation(r.l)
+}
+
+func (r *locationResolver) DeactivatedAt() *string {
+	if r.l.DeactivatedAt == nil {
+		return nil
+	}
+	return strPtr(string(dateTime(*r.l.DeactivatedAt)))
+}
+
+func (r *locationResolver) CreatedAt() scalar {
+	return dateTime(r.l.CreatedAt)
+}
+
+func (r *locationResolver) UpdatedAt() scalar {
+	return dateTime(r.l.UpdatedAt)
+}
+
+func (r *locationResolver) InventoryLevel(args struct{ InventoryItemID graphqlserver.ID }) *inventoryLevelResolver {
+	p, v := r.s.inventoryItem(string(args.InventoryItemID))
+	if v == nil {
+		return nil
+	}
+	l := inventoryLevel(v, r.l.ID)
+	if l == nil {
+		return nil
+	}
+	return &inventoryLevelResolver{l: l, v: v, p: p, s: r.s}
+}
+
+func (r *locationResolver) InventoryLevels(args connectionArgs) *connection[*inventoryLevelResolver] {
+	return newConnection(r.inventoryLevels(), func(r *inventoryLevelResolver) string { return r.l.ID }, args)
+}
+
+func (r *locationResolver) inventoryLevels() []*inventoryLevelResolver {
+	var levels []*inventoryLevelResolver
+	for _, p := range r.s.products {
+		for _, v := range p.Variants {
+			if l := inventoryLevel(v, r.l.ID); l != nil {
+				levels = append(levels, &inventoryLevelResolver{l: l, v: v, p: p, s: r.s})
+			}
+		}
+	}
+	return levels
+}
+
+// onlyOnlineLocation reports whether l is the only active location fulfilling online orders.
+func (s *Server) onlyOnlineLocation(l *Location) bool {
+	if !l.FulfillsOnlineOrders || l.DeactivatedAt != nil {
+		return false
+	}
+	for _, other := range s.locations {
+		if other != l && other.FulfillsOnlineOrders && other.DeactivatedAt == nil {
+			return false
+		}
+	}
+	return true
+}
+
+type locationAddressResolver struct {
+	a LocationAddress
+}
+
+func (r *locationAddressResolver) Address1() *string {
+	return strPtr(r.a.Address1)
+}
+
+func (r *locationAddressResolver) Address2() *string {
+	return strPtr(r.a.Address2)
+}
+
+func (r *locationAddressResolver) City() *string {
+	return strPtr(r.a.City)
+}
+
+func (r *locationAddressResolver) Country() *string {
+	return strPtr(countryNames[r.a.CountryCode])
+}
+
+func (r *locationAddressResolver) CountryCode() *string {
+	return strPtr(r.a.CountryCode)
+}
+
+func (r *locationAddressResolver) Province() *string {
+	return strPtr(r.a.Province)
+}
+
+func (r *locationAddressResolver) ProvinceCode() *string {
+	return strPtr(r.a.ProvinceCode)
+}
+
+func (r *locationAddressResolver) Zip() *string {
+	return strPtr(r.a.Zip)
+}
+
+func (r *locationAddressResolver) Phone() *string {
+	return strPtr(r.a.Phone)
+}
+
+func (r *locationAddressResolver) Formatted() []string {
+	var lines []string
+	for _, line := range []string{
+		r.a.Address1,
+		r.a.Address2,
+		strings.TrimSpace(strings.Join([]string{r.a.City, r.a.ProvinceCode, r.a.Zip}, " ")),
+		countryNames[r.a.CountryCode],
+	} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Mutations
+
+type locationAddressInput struct {
+	Address1     *string
+	Address2     *string
+	City         *string
+	CountryCode  *string
+	ProvinceCode *string
+	Zip          *string
+	Phone        *string
+}
+
+type locationInput struct {
+	Name                 *string
+	Address              *locationAddressInput
+	FulfillsOnlineOrders *bool
+}
+
+type locationPayload struct {
+	Location   *locationResolver
+	UserErrors []*userError
+}
+
+func locationFailed(field string, message string) *locationPayload {
+	return &locationPayload{UserErrors: []*userError{newUserError(field, message)}}
+}
+
+type locationAddArgs struct {
+	Input struct {
+		Name    string
+		Address struct {
+			Address1     *string
+			Address2     *string
+			City         *string
+			CountryCode  string
+			ProvinceCode *string
+			Zip          *string
+			Phone        *string
+		}
+		FulfillsOnlineOrders *bool
+	}
+}
+
+func (r *mutationResolver) LocationAdd(args locationAddArgs) *locationPayload {
+	a := args.Input.Address
+	input := locationInput{
+		Name: &args.Input.Name,
+		Address: &locationAddressInput{
+			Address1:     a.Address1,
+			Address2:     a.Address2,
+			City:         a.City,
+			CountryCode:  &a.CountryCode,
+			ProvinceCode: a.ProvinceCode,
+			Zip:          a.Zip,
+			Phone:        a.Phone,
+		},
+		FulfillsOnlineOrders: args.Input.FulfillsOnlineOrders,
+	}
+	l := &Location{FulfillsOnlineOrders: true}
+	if uerr := r.s.applyLocationInput(l, input); uerr != nil {
+		return &locationPayload{UserErrors: []*userError{uerr}}
+	}
+	r.s.fillLocation(l)
+	r.s.locations = append(r.s.locations, l)
+	return &locationPayload{Location: &locationResolver{l: l, s: r.s}, UserErrors: []*userError{}}
+}
+
+type locationEditArgs struct {
+	ID    graphqlserver.ID
+	Input locationInput
+}
+
+func (r *mutationResolver) LocationEdit(args locationEditArgs) *locationPayload {
+	l := r.s.location(string(args.ID))
+	if l == nil {
+		return locationFailed("id", "Location not found.")
+	}
+	if args.Input.FulfillsOnlineOrders != nil && !*args.Input.FulfillsOnlineOrders && r.s.onlyOnlineLocation(l) {
+		return locationFailed("input.fulfillsOnlineOrders", "At least one location must fulfill online orders.")
+	}
+	edited := *l
+	if uerr := r.s.applyLocationInput(&edited, args.Input); uerr != nil {
+		return &locationPayload{UserErrors: []*userError{uerr}}
+	}
+	*l = edited
+	l.UpdatedAt = r.s.now()
+	return &locationPayload{Location: &locationResolver{l: l, s: r.s}, UserErrors: []*userError{}}
+}
+
+func (s *Server) applyLocationInput(l *Location, input locationInput) *userError {
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return newUserError("input.name", "Name can't be blank")
+		}
+		for _, other := range s.locations {
+			if other.ID != l.ID && strings.EqualFold(other.Name, name) {
+				return newUserError("input.name", "Name has already been taken")
+			}
+		}
+		l.Name = name
+	}
+	if a := input.Address; a != nil {
+		setString(&l.Address.Address1, a.Address1)
+		setString(&l.Address.Address2, a.Address2)
+		setString(&l.Address.City, a.City)
+		setString(&l.Address.CountryCode, a.CountryCode)
+		setString(&l.Address.ProvinceCode, a.ProvinceCode)
+		setString(&l.Address.Zip, a.Zip)
+		setString(&l.Address.Phone, a.Phone)
+		if a.ProvinceCode != nil {
+			l.Address.Province = *a.ProvinceCode
+		}
+	}
+	setBool(&l.FulfillsOnlineOrders, input.FulfillsOnlineOrders)
+	return nil
+}
+
+type locationActivatePayload struct {
+	Location                   *locationResolver
+	LocationActivateUserErrors []*userError
+}
+
+// LocationActivate activates a location, doing nothing when it's active.
+func (r *mutationResolver) LocationActivate(args struct{ LocationID graphqlserver.ID }) *locationActivatePayload {
+	l := r.s.location(string(args.LocationID))
+	if l == nil {
+		return &locationActivatePayload{LocationActivateUserErrors: []*userError{newUserError("locationId", "Location not found.")}}
+	}
+	if l.DeactivatedAt != nil {
+		l.DeactivatedAt = nil
+		l.UpdatedAt = r.s.now()
+	}
+	return &locationActivatePayload{Location: &locationResolver{l: l, s: r.s}, LocationActivateUserErrors: []*userError{}}
+}
+
+type locationDeactivateArgs struct {
+	LocationID            graphqlserver.ID
+	DestinationLocationID *graphqlserver.ID
+}
+
+type locationDeactivatePayload struct {
+	Location                     *locationResolver
+	LocationDeactivateUserErrors []*userError
+}
+
+func locationDeactivateFailed(field string, message string) *locationDeactivatePayload {
+	return &locationDeactivatePayload{LocationDeactivateUserErrors: []*userError{newUserError(field, message)}}
+}
+
+// LocationDeactivate deactivates a location, moving its inventory and open fulfillment orders to
+// the destination location. It refuses to deactivate a location with any of them without a
+// destination, and the only location fulfilling online orders.
+func (r *mutationResolver) LocationDeactivate(args locationDeactivateArgs) *locationDeactivatePayload {
+	l := r.s.location(string(args.LocationID))
+	if l == nil {
+		return locationDeactivateFailed("locationId", "Location not found.")
+	}
+	if l.DeactivatedAt != nil {
+		return &locationDeactivatePayload{Location: &locationResolver{l: l, s: r.s}, LocationDeactivateUserErrors: []*userError{}}
+	}
+	var dest *Location
+	if args.DestinationLocationID != nil {
+		dest = r.s.location(string(*args.DestinationLocationID))
+		switch {
+		case dest == nil || dest.DeactivatedAt != nil:
+			return locationDeactivateFailed("destinationLocationId", "Destination location not found or inactive.")
+		case dest == l:
+			return locationDeactivateFailed("destinationLocationId", "Destination location is the same as the location to deactivate.")
+		}
+	}
+	if r.s.onlyOnlineLocation(l) {
+		return locationDeactivateFailed("locationId", "At least one location must fulfill online orders.")
+	}
+	levels := (&locationResolver{l: l, s: r.s}).inventoryLevels()
+	fulfillmentOrders := r.s.openFulfillmentOrders(l.ID)
+	if dest == nil && len(levels) > 0 {
+		return locationDeactivateFailed("locationId", "Location could not be deactivated without specifying where to relocate inventory stocked at the location.")
+	}
+	if dest == nil && len(fulfillmentOrders) > 0 {
+		return locationDeactivateFailed("locationId", "Location could not be deactivated because it has open fulfillment orders.")
+	}
+
+	now := r.s.now()
+	for _, lr := range levels {
+		moved := inventoryLevel(lr.v, dest.ID)
+		if moved == nil {
+			moved = &InventoryLevel{LocationID: dest.ID}
+			r.s.fillInventoryLevel(moved, now)
+			lr.v.InventoryLevels = append(lr.v.InventoryLevels, moved)
+		}
+		for name, q := range lr.l.Quantities {
+			moved.Quantities[name] += q
+		}
+		moved.UpdatedAt = now
+		for i, level := range lr.v.InventoryLevels {
+			if level == lr.l {
+				lr.v.InventoryLevels = append(lr.v.InventoryLevels[:i], lr.v.InventoryLevels[i+1:]...)
+				break
+			}
+		}
+	}
+	for _, fo := range fulfillmentOrders {
+		fo.AssignedLocationID = dest.ID
+	}
+	l.DeactivatedAt = &now
+	l.UpdatedAt = now
+	return &locationDeactivatePayload{Location: &locationResolver{l: l, s: r.s}, LocationDeactivateUserErrors: []*userError{}}
+}
+
+// openFulfillmentOrders returns the fulfillment orders assigned to the location that aren't closed
+// or cancelled.
+func (s *Server) openFulfillmentOrders(locationID string) []*FulfillmentOrder {
+	var open []*FulfillmentOrder
+	for _, o := range s.orders {
+		for _, fo := range o.FulfillmentOrders {
+			if fo.AssignedLocationID == locationID && fo.Status != "CLOSED" && fo.Status != "CANCELLED" {
+				open = append(open, fo)
+			}
+		}
+	}
+	return open
+}
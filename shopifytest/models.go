@@ -0,0 +1,506 @@
+package shopifytest
+
+import "time"
+
+// Product is a product stored by the fake server.
+type Product struct {
+	ID              string
+	Title           string
+	Handle          string
+	Status          string
+	DescriptionHTML string
+	ProductType     string
+	Vendor          string
+	Tags            []string
+	TemplateSuffix  string
+	SEO             SEO
+	Options         []ProductOption
+	Variants        []*Variant
+	Images          []*Image
+	Media           []*Media
+	Metafields      []*Metafield
+	PublishedAt     *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ProductOption is a product property, like size or color, and its values.
+type ProductOption struct {
+	Name   string
+	Values []string
+}
+
+// Variant is a product variant stored by the fake server.
+type Variant struct {
+	ID                string
+	Title             string
+	SKU               string
+	Barcode           string
+	Price             string
+	CompareAtPrice    string
+	InventoryQuantity int
+	InventoryPolicy   string
+	InventoryItemID   string
+	// InventoryLevels are the quantities of the variant at the locations where it's stocked. When set,
+	// InventoryQuantity is kept to the sum of their available quantities.
+	InventoryLevels []*InventoryLevel
+	Weight          float64
+	WeightUnit      string
+	SelectedOptions []SelectedOption
+	Metafields      []*Metafield
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// InventoryLevel is the inventory of a variant at a location. Quantities maps quantity names, like
+// available and reserved, to quantities; on_hand is derived from them.
+type InventoryLevel struct {
+	ID         string
+	LocationID string
+	Quantities map[string]int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Location is a place where inventory is stocked. Legacy locations are the locations of fulfillment
+// services.
+type Location struct {
+	ID                   string
+	Name                 string
+	Address              LocationAddress
+	FulfillsOnlineOrders bool
+	ShipsInventory       bool
+	Legacy               bool
+	// DeactivatedAt is nil for active locations.
+	DeactivatedAt *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// LocationAddress is the address of a location. Its country is named after CountryCode.
+type LocationAddress struct {
+	Address1     string
+	Address2     string
+	City         string
+	Province     string
+	ProvinceCode string
+	CountryCode  string
+	Zip          string
+	Phone        string
+}
+
+// SelectedOption is the value of a product option for a variant.
+type SelectedOption struct {
+	Name  string
+	Value string
+}
+
+// SEO holds the title and description shown in search engines.
+type SEO struct {
+	Title       string
+	Description string
+}
+
+// Image is a product or collection image.
+type Image struct {
+	ID      string
+	AltText string
+	Src     string
+	Width   int
+	Height  int
+}
+
+// Media is a product media. ContentType is one of IMAGE, VIDEO, MODEL_3D and EXTERNAL_VIDEO.
+type Media struct {
+	ID          string
+	ContentType string
+	Alt         string
+	MimeType    string
+	Image       *Image
+	SourceURL   string
+	Format      string
+	Duration    int
+}
+
+// Collection is a collection stored by the fake server.
+type Collection struct {
+	ID              string
+	Title           string
+	Handle          string
+	DescriptionHTML string
+	TemplateSuffix  string
+	SEO             SEO
+	Image           *Image
+	ProductIDs      []string
+	Metafields      []*Metafield
+	UpdatedAt       time.Time
+}
+
+// Metafield is a metafield stored by the fake server.
+type Metafield struct {
+	ID          string
+	Namespace   string
+	Key         string
+	Value       string
+	Type        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Order is an order stored by the fake server.
+type Order struct {
+	ID                       string
+	Name                     string
+	Email                    string
+	Note                     string
+	Tags                     []string
+	ClientIP                 string
+	Closed                   bool
+	DisplayFinancialStatus   string
+	DisplayFulfillmentStatus string
+	Customer                 *Customer
+	ShippingAddress          *MailingAddress
+	ShippingLine             *ShippingLine
+	LineItems                []*LineItem
+	FulfillmentOrders        []*FulfillmentOrder
+	Fulfillments             []*Fulfillment
+	Transactions             []*Transaction
+	Refunds                  []*Refund
+	Returns                  []*Return
+	Metafields               []*Metafield
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+}
+
+// Customer is a customer stored by the fake server, or the customer of an order.
+type Customer struct {
+	ID                    string
+	FirstName             string
+	LastName              string
+	Email                 string
+	Phone                 string
+	Note                  string
+	Tags                  []string
+	State                 string
+	VerifiedEmail         bool
+	TaxExempt             bool
+	Locale                string
+	Addresses             []*MailingAddress
+	DefaultAddressID      string
+	EmailMarketingConsent *MarketingConsent
+	SmsMarketingConsent   *MarketingConsent
+	Metafields            []*Metafield
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// MarketingConsent is the email or SMS marketing consent of a customer.
+type MarketingConsent struct {
+	State      string
+	OptInLevel string
+	UpdatedAt  *time.Time
+}
+
+// MailingAddress is an address of a customer, or the shipping address of an order.
+type MailingAddress struct {
+	ID           string
+	FirstName    string
+	LastName     string
+	Company      string
+	Address1     string
+	Address2     string
+	City         string
+	Province     string
+	ProvinceCode string
+	Country      string
+	CountryCode  string
+	Zip          string
+	Phone        string
+}
+
+// ShippingLine is the shipping method of an order.
+type ShippingLine struct {
+	Title string
+	Price string
+}
+
+// LineItem is a line of an order.
+type LineItem struct {
+	ID                string
+	SKU               string
+	Title             string
+	VariantTitle      string
+	Vendor            string
+	Quantity          int
+	FulfillmentStatus string
+	ProductID         string
+	VariantID         string
+	Price             string
+	// DiscountedPrice is the price of each unit after discounts, Price when empty.
+	DiscountedPrice string
+}
+
+// FulfillmentOrder is a group of line items to be fulfilled from a location.
+type FulfillmentOrder struct {
+	ID                 string
+	Status             string
+	RequestStatus      string
+	AssignedLocationID string
+	FulfillAt          *time.Time
+	Holds              []*FulfillmentHold
+	LineItems          []*FulfillmentOrderLineItem
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// FulfillmentHold is a hold put on a fulfillment order.
+type FulfillmentHold struct {
+	Reason      string
+	ReasonNotes string
+}
+
+// FulfillmentOrderLineItem is a line item of a fulfillment order.
+type FulfillmentOrderLineItem struct {
+	ID                string
+	LineItemID        string
+	TotalQuantity     int
+	RemainingQuantity int
+}
+
+// Fulfillment is a shipment of fulfillment order line items of an order.
+type Fulfillment struct {
+	ID           string
+	Name         string
+	Status       string
+	TrackingInfo []*FulfillmentTrackingInfo
+	LineItems    []*FulfillmentLineItem
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// FulfillmentTrackingInfo is the tracking information of a fulfillment.
+type FulfillmentTrackingInfo struct {
+	Company string
+	Number  string
+	URL     string
+}
+
+// FulfillmentLineItem is a quantity of a fulfillment order line item shipped by a fulfillment.
+type FulfillmentLineItem struct {
+	ID                         string
+	FulfillmentOrderLineItemID string
+	Quantity                   int
+}
+
+// Transaction is a payment transaction of an order. ParentID is the ID of the authorization of a
+// capture or void, or of the transaction refunded by a refund.
+type Transaction struct {
+	ID          string
+	ParentID    string
+	Kind        string
+	Status      string
+	Gateway     string
+	Amount      string
+	Test        bool
+	ProcessedAt time.Time
+}
+
+// Refund is a refund of line items and shipping of an order, paid back by refund transactions.
+type Refund struct {
+	ID             string
+	Note           string
+	LineItems      []*RefundLineItem
+	Shipping       string
+	TransactionIDs []string
+	CreatedAt      time.Time
+}
+
+// RefundLineItem is a quantity of an order line item refunded by a refund.
+type RefundLineItem struct {
+	ID          string
+	LineItemID  string
+	Quantity    int
+	RestockType string
+	Subtotal    string
+	LocationID  string
+}
+
+// Return is a return of fulfilled line items of an order. Status is one of REQUESTED, OPEN, CLOSED,
+// DECLINED and CANCELED.
+type Return struct {
+	ID            string
+	Name          string
+	Status        string
+	LineItems     []*ReturnLineItem
+	DeclineReason string
+	DeclineNote   string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ReturnLineItem is a quantity of a fulfillment line item returned by a return.
+type ReturnLineItem struct {
+	ID                    string
+	FulfillmentLineItemID string
+	Quantity              int
+	ReturnReason          string
+	ReturnReasonNote      string
+	CustomerNote          string
+}
+
+// DraftOrder is a draft order stored by the fake server. Status is one of OPEN, INVOICE_SENT and
+// COMPLETED, OrderID being set once completed.
+type DraftOrder struct {
+	ID              string
+	Name            string
+	Status          string
+	Email           string
+	Phone           string
+	Note            string
+	Tags            []string
+	PoNumber        string
+	TaxExempt       bool
+	CustomerID      string
+	ShippingAddress *MailingAddress
+	BillingAddress  *MailingAddress
+	LineItems       []*DraftOrderLineItem
+	AppliedDiscount *AppliedDiscount
+	ShippingLine    *ShippingLine
+	OrderID         string
+	InvoiceSentAt   *time.Time
+	CompletedAt     *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// DraftOrderLineItem is a line of a draft order, of a variant or custom when VariantID is empty.
+type DraftOrderLineItem struct {
+	ID              string
+	VariantID       string
+	Title           string
+	SKU             string
+	Quantity        int
+	Price           string
+	AppliedDiscount *AppliedDiscount
+}
+
+// AppliedDiscount is a discount applied to a draft order or one of its line items. ValueType is
+// FIXED_AMOUNT, Value being an amount off the whole line or order, or PERCENTAGE.
+type AppliedDiscount struct {
+	Title       string
+	Description string
+	Value       float64
+	ValueType   string
+}
+
+// Discount is a code or automatic discount stored by the fake server. Type is one of BASIC, BXGY
+// and FREE_SHIPPING. Automatic discounts have no Codes.
+type Discount struct {
+	ID                     string
+	Automatic              bool
+	Type                   string
+	Title                  string
+	Codes                  []*DiscountRedeemCode
+	StartsAt               time.Time
+	EndsAt                 *time.Time
+	UsageLimit             int
+	AppliesOncePerCustomer bool
+	CombinesWith           DiscountCombinesWith
+
+	// What the customer gets, for BASIC and BXGY discounts: Percentage, between 0.0 and 1.0,
+	// or Amount off the GetsItems. BXGY discounts set GetsQuantity, with Percentage as its effect.
+	Percentage        float64
+	Amount            string
+	AppliesOnEachItem bool
+	GetsQuantity      int
+	GetsItems         DiscountItems
+
+	// What the customer buys, for BXGY discounts: either BuysQuantity or BuysAmount of BuysItems.
+	BuysQuantity      int
+	BuysAmount        string
+	BuysItems         DiscountItems
+	UsesPerOrderLimit int
+
+	// The minimum requirement of BASIC and FREE_SHIPPING discounts, if any.
+	MinimumQuantity int
+	MinimumSubtotal string
+
+	// The destinations of FREE_SHIPPING discounts, all countries when Countries is empty.
+	Countries            []string
+	IncludeRestOfWorld   bool
+	MaximumShippingPrice string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DiscountRedeemCode is a code redeeming a code discount.
+type DiscountRedeemCode struct {
+	ID         string
+	Code       string
+	UsageCount int
+}
+
+// DiscountCombinesWith tells which other classes of discounts a discount combines with.
+type DiscountCombinesWith struct {
+	OrderDiscounts    bool
+	ProductDiscounts  bool
+	ShippingDiscounts bool
+}
+
+// DiscountItems are the items a discount applies to, all of them when no ID is set.
+type DiscountItems struct {
+	ProductIDs    []string
+	VariantIDs    []string
+	CollectionIDs []string
+}
+
+// WebhookSubscription is a webhook subscription stored by the fake server.
+// Exactly one of CallbackURL and ARN is set.
+type WebhookSubscription struct {
+	ID                  string
+	Topic               string
+	CallbackURL         string
+	ARN                 string
+	PubSubProject       string
+	PubSubTopic         string
+	Format              string
+	IncludeFields       []string
+	MetafieldNamespaces []string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (w *WebhookSubscription) isPubSub() bool {
+	return w.PubSubProject != "" || w.PubSubTopic != ""
+}
+
+// address returns the endpoint of w the way Shopify writes it in callbackUrl.
+func (w *WebhookSubscription) address() string {
+	switch {
+	case w.ARN != "":
+		return w.ARN
+	case w.isPubSub():
+		return "pubsub://" + w.PubSubProject + ":" + w.PubSubTopic
+	default:
+		return w.CallbackURL
+	}
+}
+
+// BulkOperation is a bulk operation run by the fake server.
+type BulkOperation struct {
+	ID              string
+	Type            string
+	Status          string
+	ErrorCode       string
+	Query           string
+	ObjectCount     int
+	RootObjectCount int
+	FileSize        int
+	URL             string
+	CreatedAt       time.Time
+	CompletedAt     *time.Time
+
+	result []byte
+}
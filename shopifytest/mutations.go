@@ -0,0 +1,684 @@
+package shopifytest
+
+import (
+	"strings"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+type mutationResolver struct {
+	s *Server
+}
+
+type seoInput struct {
+	Title       *string
+	Description *string
+}
+
+type metafieldInput struct {
+	ID          *graphqlserver.ID
+	Namespace   *string
+	Key         *string
+	Value       *string
+	Type        *string
+	Description *string
+}
+
+type productVariantInput struct {
+	ID              *graphqlserver.ID
+	Title           *string
+	SKU             *string
+	Barcode         *string
+	Price           *scalar
+	CompareAtPrice  *scalar
+	Position        *int32
+	InventoryPolicy *string
+	Weight          *float64
+	WeightUnit      *string
+	Options         *[]string
+	Metafields      *[]metafieldInput
+}
+
+type productInput struct {
+	ID                 *graphqlserver.ID
+	Title              *string
+	Handle             *string
+	DescriptionHTML    *string
+	ProductType        *string
+	Vendor             *string
+	Tags               *[]string
+	Status             *string
+	TemplateSuffix     *string
+	SEO                *seoInput
+	Options            *[]string
+	Variants           *[]productVariantInput
+	CollectionsToJoin  *[]graphqlserver.ID
+	CollectionsToLeave *[]graphqlserver.ID
+	Metafields         *[]metafieldInput
+}
+
+type createMediaInput struct {
+	OriginalSource   string
+	Alt              *string
+	MediaContentType string
+}
+
+type imageInput struct {
+	ID      *graphqlserver.ID
+	AltText *string
+	Src     *string
+}
+
+type collectionInput struct {
+	ID              *graphqlserver.ID
+	Title           *string
+	Handle          *string
+	DescriptionHTML *string
+	TemplateSuffix  *string
+	SEO             *seoInput
+	Image           *imageInput
+	Products        *[]graphqlserver.ID
+	Metafields      *[]metafieldInput
+}
+
+type orderInput struct {
+	ID         graphqlserver.ID
+	Note       *string
+	Email      *string
+	Tags       *[]string
+	Metafields *[]metafieldInput
+}
+
+type webhookSubscriptionInput struct {
+	CallbackURL         *scalar
+	Format              *string
+	IncludeFields       *[]string
+	MetafieldNamespaces *[]string
+}
+
+type eventBridgeWebhookSubscriptionInput struct {
+	ARN                 *string
+	Format              *string
+	IncludeFields       *[]string
+	MetafieldNamespaces *[]string
+}
+
+type pubSubWebhookSubscriptionInput struct {
+	PubSubProject       *string
+	PubSubTopic         *string
+	Format              *string
+	IncludeFields       *[]string
+	MetafieldNamespaces *[]string
+}
+
+type productPayload struct {
+	Product        *productResolver
+	ProductVariant *variantResolver
+	UserErrors     []*userError
+}
+
+type collectionPayload struct {
+	Collection *collectionResolver
+	UserErrors []*userError
+}
+
+type orderPayload struct {
+	Order      *orderResolver
+	UserErrors []*userError
+}
+
+type webhookSubscriptionPayload struct {
+	WebhookSubscription *webhookSubscriptionResolver
+	UserErrors          []*userError
+}
+
+type deletePayload struct {
+	DeletedID  *graphqlserver.ID
+	UserErrors []*userError
+}
+
+func (p *deletePayload) DeletedProductID() *graphqlserver.ID {
+	return p.DeletedID
+}
+
+func (p *deletePayload) DeletedCollectionID() *graphqlserver.ID {
+	return p.DeletedID
+}
+
+func (p *deletePayload) DeletedCustomerID() *graphqlserver.ID {
+	return p.DeletedID
+}
+
+func (p *deletePayload) DeletedCodeDiscountID() *graphqlserver.ID {
+	return p.DeletedID
+}
+
+func (p *deletePayload) DeletedAutomaticDiscountID() *graphqlserver.ID {
+	return p.DeletedID
+}
+
+func (p *deletePayload) DeletedWebhookSubscriptionID() *graphqlserver.ID {
+	return p.DeletedID
+}
+
+func deleted(id string) *deletePayload {
+	gid := graphqlserver.ID(id)
+	return &deletePayload{DeletedID: &gid, UserErrors: []*userError{}}
+}
+
+func deleteFailed(field string, message string) *deletePayload {
+	return &deletePayload{UserErrors: []*userError{newUserError(field, message)}}
+}
+
+// Products
+
+type productCreateArgs struct {
+	Input productInput
+	Media *[]createMediaInput
+}
+
+func (r *mutationResolver) ProductCreate(args productCreateArgs) *productPayload {
+	if args.Input.Title == nil || *args.Input.Title == "" {
+		return &productPayload{UserErrors: []*userError{newUserError("title", "Title can't be blank")}}
+	}
+
+	now := r.s.now()
+	p := &Product{Status: "ACTIVE", CreatedAt: now}
+	r.s.applyProductInput(p, args.Input)
+	if len(p.Variants) == 0 {
+		p.Variants = []*Variant{{Title: "Default Title", Price: "0.00"}}
+	}
+	if args.Media != nil {
+		for _, m := range *args.Media {
+			media := &Media{ContentType: m.MediaContentType, SourceURL: m.OriginalSource}
+			if m.Alt != nil {
+				media.Alt = *m.Alt
+			}
+			if m.MediaContentType == "IMAGE" {
+				media.Image = &Image{AltText: media.Alt, Src: m.OriginalSource}
+			}
+			p.Media = append(p.Media, media)
+		}
+	}
+	r.s.addProduct(p)
+
+	return &productPayload{Product: &productResolver{p: p, s: r.s}, UserErrors: []*userError{}}
+}
+
+type productUpdateArgs struct {
+	Input productInput
+}
+
+func (r *mutationResolver) ProductUpdate(args productUpdateArgs) *productPayload {
+	var p *Product
+	if args.Input.ID != nil {
+		p = r.s.product(string(*args.Input.ID))
+	}
+	if p == nil {
+		return &productPayload{UserErrors: []*userError{newUserError("id", "Product does not exist")}}
+	}
+	if args.Input.Title != nil && *args.Input.Title == "" {
+		return &productPayload{UserErrors: []*userError{newUserError("title", "Title can't be blank")}}
+	}
+
+	r.s.applyProductInput(p, args.Input)
+	r.s.fillProduct(p)
+
+	return &productPayload{Product: &productResolver{p: p, s: r.s}, UserErrors: []*userError{}}
+}
+
+type productDeleteArgs struct {
+	Input struct {
+		ID graphqlserver.ID
+	}
+}
+
+func (r *mutationResolver) ProductDelete(args productDeleteArgs) *deletePayload {
+	id := string(args.Input.ID)
+	for i, p := range r.s.products {
+		if p.ID == id {
+			r.s.products = append(r.s.products[:i], r.s.products[i+1:]...)
+			for _, c := range r.s.collections {
+				c.ProductIDs = remove(c.ProductIDs, id)
+			}
+			return deleted(id)
+		}
+	}
+	return deleteFailed("id", "Product does not exist")
+}
+
+type productVariantUpdateArgs struct {
+	Input productVariantInput
+}
+
+func (r *mutationResolver) ProductVariantUpdate(args productVariantUpdateArgs) *productPayload {
+	var (
+		p *Product
+		v *Variant
+	)
+	if args.Input.ID != nil {
+		p, v = r.s.variant(string(*args.Input.ID))
+	}
+	if v == nil {
+		return &productPayload{UserErrors: []*userError{newUserError("id", "Product variant does not exist")}}
+	}
+
+	applyVariantInput(p, v, args.Input)
+	r.s.fillProduct(p)
+
+	return &productPayload{
+		Product:        &productResolver{p: p, s: r.s},
+		ProductVariant: &variantResolver{v: v, p: p, s: r.s},
+		UserErrors:     []*userError{},
+	}
+}
+
+func (s *Server) applyProductInput(p *Product, input productInput) {
+	setString(&p.Title, input.Title)
+	setString(&p.Handle, input.Handle)
+	setString(&p.DescriptionHTML, input.DescriptionHTML)
+	setString(&p.ProductType, input.ProductType)
+	setString(&p.Vendor, input.Vendor)
+	setString(&p.Status, input.Status)
+	setString(&p.TemplateSuffix, input.TemplateSuffix)
+	if input.Tags != nil {
+		p.Tags = append([]string{}, *input.Tags...)
+	}
+	if input.SEO != nil {
+		setString(&p.SEO.Title, input.SEO.Title)
+		setString(&p.SEO.Description, input.SEO.Description)
+	}
+	if input.Options != nil {
+		p.Options = nil
+		for _, name := range *input.Options {
+			p.Options = append(p.Options, ProductOption{Name: name})
+		}
+	}
+	if input.Variants != nil {
+		// variants missing from the input are removed from the product
+		var variants []*Variant
+		for _, vi := range *input.Variants {
+			var v *Variant
+			if vi.ID != nil {
+				_, v = s.variant(string(*vi.ID))
+			}
+			if v == nil {
+				v = &Variant{}
+			}
+			applyVariantInput(p, v, vi)
+			variants = append(variants, v)
+		}
+		p.Variants = variants
+	}
+	if input.Metafields != nil {
+		p.Metafields = s.applyMetafieldInputs(p.Metafields, *input.Metafields)
+	}
+	if input.CollectionsToJoin != nil {
+		for _, id := range *input.CollectionsToJoin {
+			if c := s.collection(string(id)); c != nil && !contains(c.ProductIDs, p.ID) {
+				c.ProductIDs = append(c.ProductIDs, p.ID)
+			}
+		}
+	}
+	if input.CollectionsToLeave != nil {
+		for _, id := range *input.CollectionsToLeave {
+			if c := s.collection(string(id)); c != nil {
+				c.ProductIDs = remove(c.ProductIDs, p.ID)
+			}
+		}
+	}
+	p.UpdatedAt = s.now()
+}
+
+func applyVariantInput(p *Product, v *Variant, input productVariantInput) {
+	setString(&v.Title, input.Title)
+	setString(&v.SKU, input.SKU)
+	setString(&v.Barcode, input.Barcode)
+	setString(&v.InventoryPolicy, input.InventoryPolicy)
+	setString(&v.WeightUnit, input.WeightUnit)
+	if input.Price != nil {
+		v.Price = string(*input.Price)
+	}
+	if input.CompareAtPrice != nil {
+		v.CompareAtPrice = string(*input.CompareAtPrice)
+	}
+	if input.Weight != nil {
+		v.Weight = *input.Weight
+	}
+	if input.Options != nil {
+		v.SelectedOptions = nil
+		for i, value := range *input.Options {
+			if i >= len(p.Options) {
+				p.Options = append(p.Options, ProductOption{Name: "Title"})
+			}
+			v.SelectedOptions = append(v.SelectedOptions, SelectedOption{Name: p.Options[i].Name, Value: value})
+			if !contains(p.Options[i].Values, value) {
+				p.Options[i].Values = append(p.Options[i].Values, value)
+			}
+		}
+		if input.Title == nil {
+			v.Title = strings.Join(*input.Options, " / ")
+		}
+	}
+}
+
+// Collections
+
+type collectionArgs struct {
+	Input collectionInput
+}
+
+func (r *mutationResolver) CollectionCreate(args collectionArgs) *collectionPayload {
+	if args.Input.Title == nil || *args.Input.Title == "" {
+		return &collectionPayload{UserErrors: []*userError{newUserError("title", "Title can't be blank")}}
+	}
+
+	c := &Collection{}
+	r.s.applyCollectionInput(c, args.Input)
+	r.s.addCollection(c)
+
+	return &collectionPayload{Collection: &collectionResolver{c: c, s: r.s}, UserErrors: []*userError{}}
+}
+
+func (r *mutationResolver) CollectionUpdate(args collectionArgs) *collectionPayload {
+	var c *Collection
+	if args.Input.ID != nil {
+		c = r.s.collection(string(*args.Input.ID))
+	}
+	if c == nil {
+		return &collectionPayload{UserErrors: []*userError{newUserError("id", "Collection does not exist")}}
+	}
+
+	r.s.applyCollectionInput(c, args.Input)
+	r.s.fillCollection(c)
+
+	return &collectionPayload{Collection: &collectionResolver{c: c, s: r.s}, UserErrors: []*userError{}}
+}
+
+type collectionDeleteArgs struct {
+	Input struct {
+		ID graphqlserver.ID
+	}
+}
+
+func (r *mutationResolver) CollectionDelete(args collectionDeleteArgs) *deletePayload {
+	id := string(args.Input.ID)
+	for i, c := range r.s.collections {
+		if c.ID == id {
+			r.s.collections = append(r.s.collections[:i], r.s.collections[i+1:]...)
+			return deleted(id)
+		}
+	}
+	return deleteFailed("id", "Collection does not exist")
+}
+
+func (s *Server) applyCollectionInput(c *Collection, input collectionInput) {
+	setString(&c.Title, input.Title)
+	setString(&c.Handle, input.Handle)
+	setString(&c.DescriptionHTML, input.DescriptionHTML)
+	setString(&c.TemplateSuffix, input.TemplateSuffix)
+	if input.SEO != nil {
+		setString(&c.SEO.Title, input.SEO.Title)
+		setString(&c.SEO.Description, input.SEO.Description)
+	}
+	if input.Image != nil {
+		if c.Image == nil {
+			c.Image = &Image{}
+		}
+		setString(&c.Image.AltText, input.Image.AltText)
+		setString(&c.Image.Src, input.Image.Src)
+	}
+	if input.Products != nil {
+		for _, id := range *input.Products {
+			if !contains(c.ProductIDs, string(id)) {
+				c.ProductIDs = append(c.ProductIDs, string(id))
+			}
+		}
+	}
+	if input.Metafields != nil {
+		c.Metafields = s.applyMetafieldInputs(c.Metafields, *input.Metafields)
+	}
+	c.UpdatedAt = s.now()
+}
+
+// Orders
+
+type orderUpdateArgs struct {
+	Input orderInput
+}
+
+func (r *mutationResolver) OrderUpdate(args orderUpdateArgs) *orderPayload {
+	o := r.s.order(string(args.Input.ID))
+	if o == nil {
+		return &orderPayload{UserErrors: []*userError{newUserError("id", "Order does not exist")}}
+	}
+
+	setString(&o.Note, args.Input.Note)
+	setString(&o.Email, args.Input.Email)
+	if args.Input.Tags != nil {
+		o.Tags = append([]string{}, *args.Input.Tags...)
+	}
+	if args.Input.Metafields != nil {
+		o.Metafields = r.s.applyMetafieldInputs(o.Metafields, *args.Input.Metafields)
+	}
+	o.UpdatedAt = r.s.now()
+
+	return &orderPayload{Order: &orderResolver{o: o, s: r.s}, UserErrors: []*userError{}}
+}
+
+// Metafields
+
+type metafieldDeleteArgs struct {
+	Input struct {
+		ID graphqlserver.ID
+	}
+}
+
+func (r *mutationResolver) MetafieldDelete(args metafieldDeleteArgs) *deletePayload {
+	id := string(args.Input.ID)
+	for _, owner := range r.s.metafieldOwners() {
+		for i, m := range *owner {
+			if m.ID == id {
+				*owner = append((*owner)[:i], (*owner)[i+1:]...)
+				return deleted(id)
+			}
+		}
+	}
+	return deleteFailed("id", "Metafield does not exist")
+}
+
+// applyMetafieldInputs creates or updates metafields, matching them by ID or by namespace and key.
+func (s *Server) applyMetafieldInputs(metafields []*Metafield, inputs []metafieldInput) []*Metafield {
+	for _, input := range inputs {
+		var m *Metafield
+		for _, existing := range metafields {
+			if input.ID != nil && string(*input.ID) == existing.ID ||
+				input.Namespace != nil && input.Key != nil && *input.Namespace == existing.Namespace && *input.Key == existing.Key {
+				m = existing
+				break
+			}
+		}
+		if m == nil {
+			m = &Metafield{}
+			metafields = append(metafields, m)
+		}
+		setString(&m.Namespace, input.Namespace)
+		setString(&m.Key, input.Key)
+		setString(&m.Value, input.Value)
+		setString(&m.Type, input.Type)
+		setString(&m.Description, input.Description)
+		s.fillMetafield(m)
+		m.UpdatedAt = s.now()
+	}
+	return metafields
+}
+
+// Webhooks
+
+type webhookSubscriptionCreateArgs struct {
+	Topic               string
+	WebhookSubscription webhookSubscriptionInput
+}
+
+func (r *mutationResolver) WebhookSubscriptionCreate(args webhookSubscriptionCreateArgs) *webhookSubscriptionPayload {
+	input := args.WebhookSubscription
+	if input.CallbackURL == nil || *input.CallbackURL == "" {
+		return &webhookSubscriptionPayload{UserErrors: []*userError{newUserError("callbackUrl", "Address can't be blank")}}
+	}
+
+	w := &WebhookSubscription{Topic: args.Topic, CallbackURL: string(*input.CallbackURL)}
+	setString(&w.Format, input.Format)
+	setStrings(&w.IncludeFields, input.IncludeFields)
+	setStrings(&w.MetafieldNamespaces, input.MetafieldNamespaces)
+	return r.s.createWebhookSubscription(w)
+}
+
+type eventBridgeWebhookSubscriptionCreateArgs struct {
+	Topic               string
+	WebhookSubscription eventBridgeWebhookSubscriptionInput
+}
+
+func (r *mutationResolver) EventBridgeWebhookSubscriptionCreate(args eventBridgeWebhookSubscriptionCreateArgs) *webhookSubscriptionPayload {
+	input := args.WebhookSubscription
+	if input.ARN == nil || *input.ARN == "" {
+		return &webhookSubscriptionPayload{UserErrors: []*userError{newUserError("arn", "Address can't be blank")}}
+	}
+
+	w := &WebhookSubscription{Topic: args.Topic, ARN: *input.ARN}
+	setString(&w.Format, input.Format)
+	setStrings(&w.IncludeFields, input.IncludeFields)
+	setStrings(&w.MetafieldNamespaces, input.MetafieldNamespaces)
+	return r.s.createWebhookSubscription(w)
+}
+
+type pubSubWebhookSubscriptionCreateArgs struct {
+	Topic               string
+	WebhookSubscription pubSubWebhookSubscriptionInput
+}
+
+func (r *mutationResolver) PubSubWebhookSubscriptionCreate(args pubSubWebhookSubscriptionCreateArgs) *webhookSubscriptionPayload {
+	input := args.WebhookSubscription
+	if input.PubSubProject == nil || *input.PubSubProject == "" || input.PubSubTopic == nil || *input.PubSubTopic == "" {
+		return &webhookSubscriptionPayload{UserErrors: []*userError{newUserError("pubSubTopic", "Address can't be blank")}}
+	}
+
+	w := &WebhookSubscription{Topic: args.Topic, PubSubProject: *input.PubSubProject, PubSubTopic: *input.PubSubTopic}
+	setString(&w.Format, input.Format)
+	setStrings(&w.IncludeFields, input.IncludeFields)
+	setStrings(&w.MetafieldNamespaces, input.MetafieldNamespaces)
+	return r.s.createWebhookSubscription(w)
+}
+
+func (s *Server) createWebhookSubscription(w *WebhookSubscription) *webhookSubscriptionPayload {
+	for _, existing := range s.webhooks {
+		if existing.Topic == w.Topic && existing.address() == w.address() {
+			return &webhookSubscriptionPayload{UserErrors: []*userError{newUserError("address", "Address for this topic has already been taken")}}
+		}
+	}
+	s.addWebhookSubscription(w)
+	return &webhookSubscriptionPayload{WebhookSubscription: &webhookSubscriptionResolver{w: w}, UserErrors: []*userError{}}
+}
+
+type webhookSubscriptionUpdateArgs struct {
+	ID                  graphqlserver.ID
+	WebhookSubscription webhookSubscriptionInput
+}
+
+func (r *mutationResolver) WebhookSubscriptionUpdate(args webhookSubscriptionUpdateArgs) *webhookSubscriptionPayload {
+	w := r.s.webhookSubscription(string(args.ID))
+	if w == nil || w.ARN != "" || w.isPubSub() {
+		return &webhookSubscriptionPayload{UserErrors: []*userError{newUserError("id", "Webhook subscription does not exist")}}
+	}
+
+	input := args.WebhookSubscription
+	if input.CallbackURL != nil {
+		if *input.CallbackURL == "" {
+			return &webhookSubscriptionPayload{UserErrors: []*userError{newUserError("callbackUrl", "Address can't be blank")}}
+		}
+		w.CallbackURL = string(*input.CallbackURL)
+	}
+	setString(&w.Format, input.Format)
+	setStrings(&w.IncludeFields, input.IncludeFields)
+	setStrings(&w.MetafieldNamespaces, input.MetafieldNamespaces)
+	w.UpdatedAt = r.s.now()
+	return &webhookSubscriptionPayload{WebhookSubscription: &webhookSubscriptionResolver{w: w}, UserErrors: []*userError{}}
+}
+
+type eventBridgeWebhookSubscriptionUpdateArgs struct {
+	ID                  graphqlserver.ID
+	WebhookSubscription eventBridgeWebhookSubscriptionInput
+}
+
+func (r *mutationResolver) EventBridgeWebhookSubscriptionUpdate(args eventBridgeWebhookSubscriptionUpdateArgs) *webhookSubscriptionPayload {
+	w := r.s.webhookSubscription(string(args.ID))
+	if w == nil || w.ARN == "" {
+		return &webhookSubscriptionPayload{UserErrors: []*userError{newUserError("id", "Webhook subscription does not exist")}}
+	}
+
+	input := args.WebhookSubscription
+	if input.ARN != nil {
+		if *input.ARN == "" {
+			return &webhookSubscriptionPayload{UserErrors: []*userError{newUserError("arn", "Address can't be blank")}}
+		}
+		w.ARN = *input.ARN
+	}
+	setString(&w.Format, input.Format)
+	setStrings(&w.IncludeFields, input.IncludeFields)
+	setStrings(&w.MetafieldNamespaces, input.MetafieldNamespaces)
+	w.UpdatedAt = r.s.now()
+	return &webhookSubscriptionPayload{WebhookSubscription: &webhookSubscriptionResolver{w: w}, UserErrors: []*userError{}}
+}
+
+type pubSubWebhookSubscriptionUpdateArgs struct {
+	ID                  graphqlserver.ID
+	WebhookSubscription pubSubWebhookSubscriptionInput
+}
+
+func (r *mutationResolver) PubSubWebhookSubscriptionUpdate(args pubSubWebhookSubscriptionUpdateArgs) *webhookSubscriptionPayload {
+	w := r.s.webhookSubscription(string(args.ID))
+	if w == nil || !w.isPubSub() {
+		return &webhookSubscriptionPayload{UserErrors: []*userError{newUserError("id", "Webhook subscription does not exist")}}
+	}
+
+	input := args.WebhookSubscription
+	setString(&w.PubSubProject, input.PubSubProject)
+	setString(&w.PubSubTopic, input.PubSubTopic)
+	setString(&w.Format, input.Format)
+	setStrings(&w.IncludeFields, input.IncludeFields)
+	setStrings(&w.MetafieldNamespaces, input.MetafieldNamespaces)
+	w.UpdatedAt = r.s.now()
+	return &webhookSubscriptionPayload{WebhookSubscription: &webhookSubscriptionResolver{w: w}, UserErrors: []*userError{}}
+}
+
+func (r *mutationResolver) WebhookSubscriptionDelete(args idArgs) *deletePayload {
+	id := string(args.ID)
+	for i, w := range r.s.webhooks {
+		if w.ID == id {
+			r.s.webhooks = append(r.s.webhooks[:i], r.s.webhooks[i+1:]...)
+			return deleted(id)
+		}
+	}
+	return deleteFailed("id", "Webhook subscription does not exist")
+}
+
+func setString(dst *string, src *string) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+func setStrings(dst *[]string, src *[]string) {
+	if src != nil {
+		*dst = append([]string{}, *src...)
+	}
+}
+
+func remove(values []string, value string) []string {
+	var kept []string
+	for _, v := range values {
+		if v != value {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
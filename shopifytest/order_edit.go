@@ -0,0 +1,553 @@
+package shopifytest
+
+import (
+	"strings"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+// orderEdit is an order editing session, whose changes are staged on its calculated line items
+// until committed.
+type orderEdit struct {
+	id        string
+	o         *Order
+	lineItems []*calculatedLineItem
+	committed bool
+}
+
+// calculatedLineItem is a line item of an order being edited, either of the order or added by the
+// session.
+type calculatedLineItem struct {
+	id string
+	// li is the line item of the order, nil when the line item was added by the session.
+	li              *LineItem
+	title           string
+	sku             string
+	variantTitle    string
+	productID       string
+	variantID       string
+	price           string
+	discountedPrice string
+	quantity        int
+	locationID      string
+	restocking      bool
+}
+
+func (s *Server) orderEdit(id string) *orderEdit {
+	for _, e := range s.orderEdits {
+		if e.id == id {
+			return e
+		}
+	}
+	return nil
+}
+
+func (e *orderEdit) lineItem(id string) *calculatedLineItem {
+	for _, li := range e.lineItems {
+		if li.id == id {
+			return li
+		}
+	}
+	return nil
+}
+
+// editedFulfilledQuantity returns the quantity of the line item that can't be edited anymore.
+func (s *Server) editedFulfilledQuantity(li *calculatedLineItem) int {
+	if li.li == nil {
+		return 0
+	}
+	return li.li.Quantity - int((&lineItemResolver{li: li.li, s: s}).FulfillableQuantity())
+}
+
+// Resolvers
+
+type calculatedOrderResolver struct {
+	e *orderEdit
+	s *Server
+}
+
+func (r *calculatedOrderResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.e.id)
+}
+
+func (r *calculatedOrderResolver) OriginalOrder() *orderResolver {
+	return &orderResolver{o: r.e.o, s: r.s}
+}
+
+func (r *calculatedOrderResolver) LineItems(args connectionArgs) *connection[*calculatedLineItemResolver] {
+	var resolvers []*calculatedLineItemResolver
+	for _, li := range r.e.lineItems {
+		resolvers = append(resolvers, &calculatedLineItemResolver{li: li, s: r.s})
+	}
+	return newConnection(resolvers, func(r *calculatedLineItemResolver) string { return r.li.id }, args)
+}
+
+func (r *calculatedOrderResolver) SubtotalLineItemsQuantity() int32 {
+	quantity := 0
+	for _, li := range r.e.lineItems {
+		quantity += li.quantity
+	}
+	return int32(quantity)
+}
+
+func (r *calculatedOrderResolver) subtotal() float64 {
+	var subtotal float64
+	for _, li := range r.e.lineItems {
+		subtotal += parseAmount(li.discountedPrice) * float64(li.quantity)
+	}
+	return subtotal
+}
+
+func (r *calculatedOrderResolver) SubtotalPriceSet() moneyBag {
+	return r.s.money(formatAmount(r.subtotal()))
+}
+
+func (r *calculatedOrderResolver) total() float64 {
+	total := r.subtotal()
+	if r.e.o.ShippingLine != nil {
+		total += parseAmount(r.e.o.ShippingLine.Price)
+	}
+	return total
+}
+
+func (r *calculatedOrderResolver) TotalPriceSet() moneyBag {
+	return r.s.money(formatAmount(r.total()))
+}
+
+func (r *calculatedOrderResolver) TotalOutstandingSet() moneyBag {
+	received := parseAmount(string((&orderResolver{o: r.e.o, s: r.s}).TotalReceivedSet().ShopMoney.Amount))
+	return r.s.money(formatAmount(r.total() - received))
+}
+
+func (r *calculatedOrderResolver) Committed() bool {
+	return r.e.committed
+}
+
+type calculatedLineItemResolver struct {
+	li *calculatedLineItem
+	s  *Server
+}
+
+func (r *calculatedLineItemResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.li.id)
+}
+
+func (r *calculatedLineItemResolver) Title() string {
+	return r.li.title
+}
+
+func (r *calculatedLineItemResolver) SKU() *string {
+	return strPtr(r.li.sku)
+}
+
+func (r *calculatedLineItemResolver) VariantTitle() *string {
+	return strPtr(r.li.variantTitle)
+}
+
+func (r *calculatedLineItemResolver) Variant() *variantResolver {
+	p, v := r.s.variant(r.li.variantID)
+	if v == nil {
+		return nil
+	}
+	return &variantResolver{v: v, p: p, s: r.s}
+}
+
+func (r *calculatedLineItemResolver) Quantity() int32 {
+	return int32(r.li.quantity)
+}
+
+func (r *calculatedLineItemResolver) EditableQuantity() int32 {
+	return int32(r.li.quantity - r.s.editedFulfilledQuantity(r.li))
+}
+
+func (r *calculatedLineItemResolver) Restockable() bool {
+	return r.li.li != nil && r.li.variantID != ""
+}
+
+func (r *calculatedLineItemResolver) Restocking() bool {
+	return r.li.restocking
+}
+
+func (r *calculatedLineItemResolver) HasStagedLineItemDiscount() bool {
+	return r.li.discountedPrice != r.li.price
+}
+
+func (r *calculatedLineItemResolver) OriginalUnitPriceSet() moneyBag {
+	return r.s.money(r.li.price)
+}
+
+func (r *calculatedLineItemResolver) DiscountedUnitPriceSet() moneyBag {
+	return r.s.money(r.li.discountedPrice)
+}
+
+func (r *calculatedLineItemResolver) EditableSubtotalSet() moneyBag {
+	return r.s.money(formatAmount(parseAmount(r.li.discountedPrice) * float64(r.EditableQuantity())))
+}
+
+// Mutations
+
+type orderEditPayload struct {
+	CalculatedOrder    *calculatedOrderResolver
+	CalculatedLineItem *calculatedLineItemResolver
+	UserErrors         []*userError
+}
+
+func orderEditFailed(field string, message string) *orderEditPayload {
+	return &orderEditPayload{UserErrors: []*userError{newUserError(field, message)}}
+}
+
+// OrderEditBegin begins an editing session of an order, starting from its current line items.
+func (r *mutationResolver) OrderEditBegin(args idArgs) *orderEditPayload {
+	o := r.s.order(string(args.ID))
+	if o == nil {
+		return orderEditFailed("id", "The order does not exist.")
+	}
+	e := &orderEdit{id: r.s.newID("CalculatedOrder"), o: o}
+	for _, li := range o.LineItems {
+		e.lineItems = append(e.lineItems, &calculatedLineItem{
+			id:              "gid://shopify/CalculatedLineItem/" + string(legacyResourceID(li.ID)),
+			li:              li,
+			title:           li.Title,
+			sku:             li.SKU,
+			variantTitle:    li.VariantTitle,
+			productID:       li.ProductID,
+			variantID:       li.VariantID,
+			price:           li.Price,
+			discountedPrice: discountedPrice(li),
+			quantity:        li.Quantity,
+		})
+	}
+	r.s.orderEdits = append(r.s.orderEdits, e)
+	return &orderEditPayload{CalculatedOrder: &calculatedOrderResolver{e: e, s: r.s}, UserErrors: []*userError{}}
+}
+
+// editOrder looks the calculated order id up and applies edit to it, returning the payload with the
+// calculated order and the line item returned by edit, or the user error returned by edit.
+func (s *Server) editOrder(id graphqlserver.ID, edit func(e *orderEdit) (*calculatedLineItem, *userError)) *orderEditPayload {
+	e := s.orderEdit(string(id))
+	switch {
+	case e == nil:
+		return orderEditFailed("id", "The calculated order does not exist.")
+	case e.committed:
+		return orderEditFailed("id", "The calculated order has already been committed.")
+	}
+	li, uerr := edit(e)
+	if uerr != nil {
+		return &orderEditPayload{UserErrors: []*userError{uerr}}
+	}
+	return &orderEditPayload{
+		CalculatedOrder:    &calculatedOrderResolver{e: e, s: s},
+		CalculatedLineItem: &calculatedLineItemResolver{li: li, s: s},
+		UserErrors:         []*userError{},
+	}
+}
+
+// checkEditLocation returns a user error when locationID isn't the ID of an active location.
+func (s *Server) checkEditLocation(locationID *graphqlserver.ID) *userError {
+	if locationID == nil {
+		return nil
+	}
+	if l := s.location(string(*locationID)); l == nil || l.DeactivatedAt != nil {
+		return newUserError("locationId", "Location does not exist or is inactive.")
+	}
+	return nil
+}
+
+type orderEditAddVariantArgs struct {
+	ID              graphqlserver.ID
+	VariantID       graphqlserver.ID
+	Quantity        int32
+	LocationID      *graphqlserver.ID
+	AllowDuplicates *bool
+}
+
+func (r *mutationResolver) OrderEditAddVariant(args orderEditAddVariantArgs) *orderEditPayload {
+	return r.s.editOrder(args.ID, func(e *orderEdit) (*calculatedLineItem, *userError) {
+		p, v := r.s.variant(string(args.VariantID))
+		if v == nil {
+			return nil, newUserError("variantId", "Variant does not exist.")
+		}
+		if args.Quantity < 1 {
+			return nil, newUserError("quantity", "Quantity must be greater than 0.")
+		}
+		if args.AllowDuplicates == nil || !*args.AllowDuplicates {
+			for _, li := range e.lineItems {
+				if li.variantID == v.ID {
+					return nil, newUserError("variantId", "The variant is already in the order.")
+				}
+			}
+		}
+		if uerr := r.s.checkEditLocation(args.LocationID); uerr != nil {
+			return nil, uerr
+		}
+		title := p.Title
+		if v.Title != "" && v.Title != "Default Title" {
+			title += " - " + v.Title
+		}
+		li := &calculatedLineItem{
+			id:              r.s.newID("CalculatedLineItem"),
+			title:           title,
+			sku:             v.SKU,
+			variantTitle:    v.Title,
+			productID:       p.ID,
+			variantID:       v.ID,
+			price:           v.Price,
+			discountedPrice: v.Price,
+			quantity:        int(args.Quantity),
+		}
+		if args.LocationID != nil {
+			li.locationID = string(*args.LocationID)
+		}
+		e.lineItems = append(e.lineItems, li)
+		return li, nil
+	})
+}
+
+type orderEditAddCustomItemArgs struct {
+	ID    graphqlserver.ID
+	Title string
+	Price struct {
+		Amount       scalar
+		CurrencyCode string
+	}
+	Quantity         int32
+	RequiresShipping *bool
+	Taxable          *bool
+	LocationID       *graphqlserver.ID
+}
+
+func (r *mutationResolver) OrderEditAddCustomItem(args orderEditAddCustomItemArgs) *orderEditPayload {
+	return r.s.editOrder(args.ID, func(e *orderEdit) (*calculatedLineItem, *userError) {
+		switch {
+		case strings.TrimSpace(args.Title) == "":
+			return nil, newUserError("title", "Title can't be blank.")
+		case args.Quantity < 1:
+			return nil, newUserError("quantity", "Quantity must be greater than 0.")
+		case parseAmount(string(args.Price.Amount)) < 0:
+			return nil, fieldError("Price must be greater than or equal to 0.", "price", "amount")
+		case args.Price.CurrencyCode != r.s.CurrencyCode:
+			return nil, fieldError("Currency must be the currency of the order.", "price", "currencyCode")
+		}
+		if uerr := r.s.checkEditLocation(args.LocationID); uerr != nil {
+			return nil, uerr
+		}
+		price := formatAmount(parseAmount(string(args.Price.Amount)))
+		li := &calculatedLineItem{
+			id:              r.s.newID("CalculatedLineItem"),
+			title:           strings.TrimSpace(args.Title),
+			price:           price,
+			discountedPrice: price,
+			quantity:        int(args.Quantity),
+		}
+		if args.LocationID != nil {
+			li.locationID = string(*args.LocationID)
+		}
+		e.lineItems = append(e.lineItems, li)
+		return li, nil
+	})
+}
+
+type orderEditSetQuantityArgs struct {
+	ID         graphqlserver.ID
+	LineItemID graphqlserver.ID
+	Quantity   int32
+	Restock    *bool
+}
+
+// OrderEditSetQuantity changes the quantity of a line item, removing it when zero and added by the
+// session.
+func (r *mutationResolver) OrderEditSetQuantity(args orderEditSetQuantityArgs) *orderEditPayload {
+	return r.s.editOrder(args.ID, func(e *orderEdit) (*calculatedLineItem, *userError) {
+		li := e.lineItem(string(args.LineItemID))
+		switch {
+		case li == nil:
+			return nil, newUserError("lineItemId", "Line item does not exist.")
+		case args.Quantity < 0:
+			return nil, newUserError("quantity", "Quantity must be greater than or equal to 0.")
+		case int(args.Quantity) < r.s.editedFulfilledQuantity(li):
+			return nil, newUserError("quantity", "Quantity cannot be less than the fulfilled quantity.")
+		}
+		li.quantity = int(args.Quantity)
+		li.restocking = li.li != nil && li.quantity < li.li.Quantity && args.Restock != nil && *args.Restock
+		if li.li == nil && li.quantity == 0 {
+			for i, added := range e.lineItems {
+				if added == li {
+					e.lineItems = append(e.lineItems[:i], e.lineItems[i+1:]...)
+					break
+				}
+			}
+		}
+		return li, nil
+	})
+}
+
+type orderEditAddLineItemDiscountArgs struct {
+	ID         graphqlserver.ID
+	LineItemID graphqlserver.ID
+	Discount   struct {
+		Description *string
+		FixedValue  *struct {
+			Amount       scalar
+			CurrencyCode string
+		}
+		PercentValue *float64
+	}
+}
+
+// OrderEditAddLineItemDiscount discounts each unit of a line item added by the session.
+func (r *mutationResolver) OrderEditAddLineItemDiscount(args orderEditAddLineItemDiscountArgs) *orderEditPayload {
+	return r.s.editOrder(args.ID, func(e *orderEdit) (*calculatedLineItem, *userError) {
+		li := e.lineItem(string(args.LineItemID))
+		d := args.Discount
+		switch {
+		case li == nil:
+			return nil, newUserError("lineItemId", "Line item does not exist.")
+		case li.li != nil:
+			return nil, newUserError("lineItemId", "Only line items added during the edit can be discounted.")
+		case li.discountedPrice != li.price:
+			return nil, newUserError("lineItemId", "The line item already has a discount.")
+		case (d.FixedValue == nil) == (d.PercentValue == nil):
+			return nil, newUserError("discount", "Exactly one of fixed value and percent value must be given.")
+		}
+		price := parseAmount(li.price)
+		var discount float64
+		if d.PercentValue != nil {
+			if *d.PercentValue <= 0 || *d.PercentValue > 100 {
+				return nil, fieldError("Percent value must be between 0 and 100.", "discount", "percentValue")
+			}
+			discount = price * *d.PercentValue / 100
+		} else {
+			discount = parseAmount(string(d.FixedValue.Amount))
+			if discount <= 0 || discount > price {
+				return nil, fieldError("Fixed value must be greater than 0 and at most the price of the line item.", "discount", "fixedValue")
+			}
+		}
+		li.discountedPrice = formatAmount(price - discount)
+		return li, nil
+	})
+}
+
+type orderEditCommitArgs struct {
+	ID             graphqlserver.ID
+	NotifyCustomer *bool
+	StaffNote      *string
+}
+
+type orderEditCommitPayload struct {
+	Order      *orderResolver
+	UserErrors []*userError
+}
+
+// OrderEditCommit applies the changes of an editing session to its order, assigning the quantities
+// added to fulfillment orders and removing the quantities removed from them.
+func (r *mutationResolver) OrderEditCommit(args orderEditCommitArgs) *orderEditCommitPayload {
+	e := r.s.orderEdit(string(args.ID))
+	switch {
+	case e == nil:
+		return &orderEditCommitPayload{UserErrors: []*userError{newUserError("id", "The calculated order does not exist.")}}
+	case e.committed:
+		return &orderEditCommitPayload{UserErrors: []*userError{newUserError("id", "The calculated order has already been committed.")}}
+	}
+	o := e.o
+	for _, li := range e.lineItems {
+		if li.li == nil {
+			added := &LineItem{
+				ID:                r.s.newID("LineItem"),
+				SKU:               li.sku,
+				Title:             li.title,
+				VariantTitle:      li.variantTitle,
+				Quantity:          li.quantity,
+				FulfillmentStatus: "unfulfilled",
+				ProductID:         li.productID,
+				VariantID:         li.variantID,
+				Price:             li.price,
+			}
+			if li.discountedPrice != li.price {
+				added.DiscountedPrice = li.discountedPrice
+			}
+			o.LineItems = append(o.LineItems, added)
+			r.s.assignQuantity(o, added.ID, li.quantity, li.locationID)
+			continue
+		}
+		if delta := li.quantity - li.li.Quantity; delta > 0 {
+			r.s.assignQuantity(o, li.li.ID, delta, li.locationID)
+		} else if delta < 0 {
+			unassignQuantity(o, li.li.ID, -delta)
+		}
+		li.li.Quantity = li.quantity
+	}
+	if len(o.Fulfillments) > 0 {
+		r.s.updateFulfillmentStatus(o)
+	}
+	o.UpdatedAt = r.s.now()
+	e.committed = true
+	return &orderEditCommitPayload{Order: &orderResolver{o: o, s: r.s}, UserErrors: []*userError{}}
+}
+
+// assignQuantity adds quantity of the line item to an open fulfillment order of the order, at
+// locationID when given, creating one when there isn't any.
+func (s *Server) assignQuantity(o *Order, lineItemID string, quantity int, locationID string) {
+	var fo *FulfillmentOrder
+	for _, candidate := range o.FulfillmentOrders {
+		if candidate.Status == "OPEN" && (locationID == "" || candidate.AssignedLocationID == locationID) {
+			fo = candidate
+			break
+		}
+	}
+	if fo == nil {
+		if locationID == "" && len(o.FulfillmentOrders) > 0 {
+			locationID = o.FulfillmentOrders[0].AssignedLocationID
+		}
+		fo = &FulfillmentOrder{
+			ID:                 s.newID("FulfillmentOrder"),
+			Status:             "OPEN",
+			RequestStatus:      "UNSUBMITTED",
+			AssignedLocationID: locationID,
+			CreatedAt:          s.now(),
+		}
+		o.FulfillmentOrders = append(o.FulfillmentOrders, fo)
+	}
+	fo.UpdatedAt = s.now()
+	for _, li := range fo.LineItems {
+		if li.LineItemID == lineItemID {
+			li.TotalQuantity += quantity
+			li.RemainingQuantity += quantity
+			return
+		}
+	}
+	fo.LineItems = append(fo.LineItems, &FulfillmentOrderLineItem{
+		ID:                s.newID("FulfillmentOrderLineItem"),
+		LineItemID:        lineItemID,
+		TotalQuantity:     quantity,
+		RemainingQuantity: quantity,
+	})
+}
+
+// unassignQuantity removes quantity of the line item from the remaining quantities of the
+// fulfillment orders of the order that can be fulfilled, closing those left without any.
+func unassignQuantity(o *Order, lineItemID string, quantity int) {
+	for _, fo := range o.FulfillmentOrders {
+		if !contains(supportedActions(fo), "CREATE_FULFILLMENT") && fo.Status != "ON_HOLD" && fo.Status != "SCHEDULED" {
+			continue
+		}
+		for _, li := range fo.LineItems {
+			if li.LineItemID != lineItemID || quantity == 0 {
+				continue
+			}
+			removed := li.RemainingQuantity
+			if removed > quantity {
+				removed = quantity
+			}
+			li.RemainingQuantity -= removed
+			li.TotalQuantity -= removed
+			quantity -= removed
+		}
+		remaining := 0
+		for _, li := range fo.LineItems {
+			remaining += li.RemainingQuantity
+		}
+		if remaining == 0 {
+			fo.Status = "CLOSED"
+		}
+	}
+}
@@ -0,0 +1,600 @@
+package shopifytest
+
+import (
+	"fmt"
+	"math"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+// cents returns the amount in cents, for comparing amounts without rounding errors.
+func cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func (o *Order) transaction(id string) *Transaction {
+	for _, t := range o.Transactions {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func orderLineItem(o *Order, id string) *LineItem {
+	for _, li := range o.LineItems {
+		if li.ID == id {
+			return li
+		}
+	}
+	return nil
+}
+
+// childAmount returns the amount of the successful transactions of the order of one of kinds whose
+// parent is the transaction t.
+func childAmount(o *Order, t *Transaction, kinds ...string) float64 {
+	var amount float64
+	for _, c := range o.Transactions {
+		if c.ParentID == t.ID && c.Status == "SUCCESS" && contains(kinds, c.Kind) {
+			amount += parseAmount(c.Amount)
+		}
+	}
+	return amount
+}
+
+// refundable returns the amount of the transaction left to refund, for successful sales and captures.
+func refundable(o *Order, t *Transaction) float64 {
+	if t.Status != "SUCCESS" || (t.Kind != "SALE" && t.Kind != "CAPTURE") {
+		return 0
+	}
+	return parseAmount(t.Amount) - childAmount(o, t, "REFUND")
+}
+
+// capturable returns the amount of the transaction left to capture, for successful authorizations.
+func capturable(o *Order, t *Transaction) float64 {
+	if t.Status != "SUCCESS" || t.Kind != "AUTHORIZATION" {
+		return 0
+	}
+	return parseAmount(t.Amount) - childAmount(o, t, "CAPTURE", "VOID")
+}
+
+// refundableQuantity returns the quantity of the line item not refunded yet.
+func refundableQuantity(o *Order, li *LineItem) int {
+	quantity := li.Quantity
+	for _, r := range o.Refunds {
+		for _, rli := range r.LineItems {
+			if rli.LineItemID == li.ID {
+				quantity -= rli.Quantity
+			}
+		}
+	}
+	return quantity
+}
+
+// refundableShipping returns the shipping price of the order not refunded yet.
+func refundableShipping(o *Order) float64 {
+	if o.ShippingLine == nil {
+		return 0
+	}
+	shipping := parseAmount(o.ShippingLine.Price)
+	for _, r := range o.Refunds {
+		shipping -= parseAmount(r.Shipping)
+	}
+	return shipping
+}
+
+// updateFinancialStatus updates the financial status of the order from its transactions.
+func (s *Server) updateFinancialStatus(o *Order) {
+	var paid, refunded, authorized float64
+	for _, t := range o.Transactions {
+		if t.Status != "SUCCESS" {
+			continue
+		}
+		switch t.Kind {
+		case "SALE", "CAPTURE":
+			paid += parseAmount(t.Amount)
+		case "REFUND":
+			refunded += parseAmount(t.Amount)
+		case "AUTHORIZATION":
+			authorized += capturable(o, t)
+		}
+	}
+	switch {
+	case cents(refunded) > 0 && cents(refunded) >= cents(paid):
+		o.DisplayFinancialStatus = "REFUNDED"
+	case cents(refunded) > 0:
+		o.DisplayFinancialStatus = "PARTIALLY_REFUNDED"
+	case cents(paid) > 0 && cents(paid) >= cents(orderTotal(o)):
+		o.DisplayFinancialStatus = "PAID"
+	case cents(paid) > 0:
+		o.DisplayFinancialStatus = "PARTIALLY_PAID"
+	case cents(authorized) > 0:
+		o.DisplayFinancialStatus = "AUTHORIZED"
+	default:
+		o.DisplayFinancialStatus = "PENDING"
+	}
+	o.UpdatedAt = s.now()
+}
+
+// Queries
+
+func (r *queryResolver) Refund(args idArgs) *refundResolver {
+	refund, o := r.s.refund(string(args.ID))
+	if refund == nil {
+		return nil
+	}
+	return &refundResolver{r: refund, o: o, s: r.s}
+}
+
+func (r *orderResolver) Refunds(args firstArgs) []*refundResolver {
+	resolvers := []*refundResolver{}
+	for _, refund := range r.o.Refunds {
+		if args.First != nil && len(resolvers) == int(*args.First) {
+			break
+		}
+		resolvers = append(resolvers, &refundResolver{r: refund, o: r.o, s: r.s})
+	}
+	return resolvers
+}
+
+type refundLineItemInput struct {
+	LineItemID  graphqlserver.ID
+	Quantity    int32
+	RestockType *string
+	LocationID  *graphqlserver.ID
+}
+
+type suggestedRefundArgs struct {
+	RefundLineItems   *[]refundLineItemInput
+	RefundShipping    *bool
+	ShippingAmount    *scalar
+	SuggestFullRefund *bool
+}
+
+// SuggestedRefund suggests refunding the line items and shipping selected by args, capped to the
+// amount left to refund and split across the refundable transactions in order.
+func (r *orderResolver) SuggestedRefund(args suggestedRefundArgs) (*suggestedRefund, error) {
+	o := r.o
+	var items []*RefundLineItem
+	if args.SuggestFullRefund != nil && *args.SuggestFullRefund {
+		for _, li := range o.LineItems {
+			if q := refundableQuantity(o, li); q > 0 {
+				items = append(items, &RefundLineItem{
+					LineItemID:  li.ID,
+					Quantity:    q,
+					RestockType: "NO_RESTOCK",
+					Subtotal:    formatAmount(parseAmount(discountedPrice(li)) * float64(q)),
+				})
+			}
+		}
+	} else if args.RefundLineItems != nil {
+		var uerr *userError
+		items, uerr = refundLineItems(o, *args.RefundLineItems, "refundLineItems")
+		if uerr != nil {
+			return nil, fmt.Errorf("%s", uerr.Message)
+		}
+	}
+
+	var subtotal float64
+	for _, li := range items {
+		subtotal += parseAmount(li.Subtotal)
+	}
+	maxShipping := refundableShipping(o)
+	var shipping float64
+	switch {
+	case args.SuggestFullRefund != nil && *args.SuggestFullRefund, args.RefundShipping != nil && *args.RefundShipping:
+		shipping = maxShipping
+	case args.ShippingAmount != nil:
+		shipping = parseAmount(string(*args.ShippingAmount))
+		if cents(shipping) > cents(maxShipping) {
+			return nil, fmt.Errorf("shipping amount exceeds the refundable shipping amount")
+		}
+	}
+
+	var maxRefundable float64
+	for _, t := range o.Transactions {
+		maxRefundable += refundable(o, t)
+	}
+	amount := subtotal + shipping
+	if amount > maxRefundable {
+		amount = maxRefundable
+	}
+
+	suggestion := &suggestedRefund{
+		AmountSet:            r.s.money(formatAmount(amount)),
+		SubtotalSet:          r.s.money(formatAmount(subtotal)),
+		TotalTaxSet:          r.s.money(formatAmount(0)),
+		MaximumRefundableSet: r.s.money(formatAmount(maxRefundable)),
+		RefundLineItems:      []*refundLineItemResolver{},
+		Shipping: shippingRefund{
+			AmountSet:            r.s.money(formatAmount(shipping)),
+			MaximumRefundableSet: r.s.money(formatAmount(maxShipping)),
+		},
+		SuggestedTransactions: []*suggestedTransaction{},
+	}
+	for _, li := range items {
+		suggestion.RefundLineItems = append(suggestion.RefundLineItems, &refundLineItemResolver{li: li, o: o, s: r.s})
+	}
+	left := amount
+	for _, t := range o.Transactions {
+		max := refundable(o, t)
+		if cents(left) <= 0 || cents(max) <= 0 {
+			continue
+		}
+		a := left
+		if a > max {
+			a = max
+		}
+		left -= a
+		suggestion.SuggestedTransactions = append(suggestion.SuggestedTransactions, &suggestedTransaction{
+			Kind:                 "SUGGESTED_REFUND",
+			Gateway:              strPtr(t.Gateway),
+			AmountSet:            r.s.money(formatAmount(a)),
+			MaximumRefundableSet: r.s.money(formatAmount(max)),
+			ParentTransaction:    &transactionResolver{t: t, s: r.s},
+		})
+	}
+	return suggestion, nil
+}
+
+// refundLineItems returns the refund line items of inputs, checking their quantities against the
+// quantities left to refund.
+func refundLineItems(o *Order, inputs []refundLineItemInput, field ...string) ([]*RefundLineItem, *userError) {
+	requested := map[string]int{}
+	var items []*RefundLineItem
+	for i, input := range inputs {
+		field := append(field, fmt.Sprint(i))
+		li := orderLineItem(o, string(input.LineItemID))
+		if li == nil {
+			return nil, fieldError("Line item does not exist.", append(field, "lineItemId")...)
+		}
+		requested[li.ID] += int(input.Quantity)
+		if input.Quantity <= 0 || requested[li.ID] > refundableQuantity(o, li) {
+			return nil, fieldError("Quantity cannot refund more items than were purchased.", append(field, "quantity")...)
+		}
+		restockType := "NO_RESTOCK"
+		setString(&restockType, input.RestockType)
+		item := &RefundLineItem{
+			LineItemID:  li.ID,
+			Quantity:    int(input.Quantity),
+			RestockType: restockType,
+			Subtotal:    formatAmount(parseAmount(discountedPrice(li)) * float64(input.Quantity)),
+		}
+		if input.LocationID != nil {
+			item.LocationID = string(*input.LocationID)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Resolvers
+
+type refundResolver struct {
+	r *Refund
+	o *Order
+	s *Server
+}
+
+func (r *refundResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.r.ID)
+}
+
+func (r *refundResolver) Note() *string {
+	return strPtr(r.r.Note)
+}
+
+func (r *refundResolver) CreatedAt() *scalar {
+	return dateTimePtr(&r.r.CreatedAt)
+}
+
+func (r *refundResolver) Order() *orderResolver {
+	return &orderResolver{o: r.o, s: r.s}
+}
+
+func (r *refundResolver) TotalRefundedSet() moneyBag {
+	var total float64
+	for _, id := range r.r.TransactionIDs {
+		if t := r.o.transaction(id); t != nil && t.Status == "SUCCESS" {
+			total += parseAmount(t.Amount)
+		}
+	}
+	return r.s.money(formatAmount(total))
+}
+
+func (r *refundResolver) RefundLineItems(args connectionArgs) *connection[*refundLineItemResolver] {
+	var resolvers []*refundLineItemResolver
+	for _, li := range r.r.LineItems {
+		resolvers = append(resolvers, &refundLineItemResolver{li: li, o: r.o, s: r.s})
+	}
+	return newConnection(resolvers, func(r *refundLineItemResolver) string { return r.li.ID }, args)
+}
+
+func (r *refundResolver) Transactions(args connectionArgs) *connection[*transactionResolver] {
+	var resolvers []*transactionResolver
+	for _, id := range r.r.TransactionIDs {
+		if t := r.o.transaction(id); t != nil {
+			resolvers = append(resolvers, &transactionResolver{t: t, s: r.s})
+		}
+	}
+	return newConnection(resolvers, func(r *transactionResolver) string { return r.t.ID }, args)
+}
+
+type refundLineItemResolver struct {
+	li *RefundLineItem
+	o  *Order
+	s  *Server
+}
+
+func (r *refundLineItemResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.li.ID)
+}
+
+func (r *refundLineItemResolver) LineItem() *lineItemResolver {
+	if li := orderLineItem(r.o, r.li.LineItemID); li != nil {
+		return &lineItemResolver{li: li, s: r.s}
+	}
+	return &lineItemResolver{li: &LineItem{ID: r.li.LineItemID}, s: r.s}
+}
+
+func (r *refundLineItemResolver) Quantity() int32 {
+	return int32(r.li.Quantity)
+}
+
+func (r *refundLineItemResolver) RestockType() string {
+	return r.li.RestockType
+}
+
+func (r *refundLineItemResolver) Location() *locationResolver {
+	if l := r.s.location(r.li.LocationID); l != nil {
+		return &locationResolver{l: l, s: r.s}
+	}
+	return nil
+}
+
+func (r *refundLineItemResolver) SubtotalSet() moneyBag {
+	return r.s.money(r.li.Subtotal)
+}
+
+type suggestedRefund struct {
+	AmountSet             moneyBag
+	SubtotalSet           moneyBag
+	TotalTaxSet           moneyBag
+	MaximumRefundableSet  moneyBag
+	RefundLineItems       []*refundLineItemResolver
+	Shipping              shippingRefund
+	SuggestedTransactions []*suggestedTransaction
+}
+
+type shippingRefund struct {
+	AmountSet            moneyBag
+	MaximumRefundableSet moneyBag
+}
+
+type suggestedTransaction struct {
+	Kind                 string
+	Gateway              *string
+	AmountSet            moneyBag
+	MaximumRefundableSet moneyBag
+	ParentTransaction    *transactionResolver
+}
+
+// Mutations
+
+type orderCaptureArgs struct {
+	Input struct {
+		ID                  graphqlserver.ID
+		ParentTransactionID graphqlserver.ID
+		Amount              scalar
+		Currency            *string
+		FinalCapture        *bool
+	}
+}
+
+type orderCapturePayload struct {
+	Transaction *transactionResolver
+	UserErrors  []*userError
+}
+
+func orderCaptureFailed(message string, field ...string) *orderCapturePayload {
+	return &orderCapturePayload{UserErrors: []*userError{fieldError(message, field...)}}
+}
+
+// OrderCapture captures an amount of an authorization, voiding the amount left to capture on a
+// final capture.
+func (r *mutationResolver) OrderCapture(args orderCaptureArgs) *orderCapturePayload {
+	input := args.Input
+	o := r.s.order(string(input.ID))
+	if o == nil {
+		return orderCaptureFailed("Order does not exist.", "input", "id")
+	}
+	parent := o.transaction(string(input.ParentTransactionID))
+	if parent == nil || parent.Kind != "AUTHORIZATION" || parent.Status != "SUCCESS" {
+		return orderCaptureFailed("Parent transaction must be a successful authorization.", "input", "parentTransactionId")
+	}
+	amount := parseAmount(string(input.Amount))
+	if cents(amount) <= 0 || cents(amount) > cents(capturable(o, parent)) {
+		return orderCaptureFailed("Amount must be positive and at most the capturable amount.", "input", "amount")
+	}
+
+	now := r.s.now()
+	t := &Transaction{
+		ID:          r.s.newID("OrderTransaction"),
+		ParentID:    parent.ID,
+		Kind:        "CAPTURE",
+		Status:      "SUCCESS",
+		Gateway:     parent.Gateway,
+		Amount:      formatAmount(amount),
+		Test:        parent.Test,
+		ProcessedAt: now,
+	}
+	o.Transactions = append(o.Transactions, t)
+	if input.FinalCapture != nil && *input.FinalCapture {
+		if left := capturable(o, parent); cents(left) > 0 {
+			o.Transactions = append(o.Transactions, &Transaction{
+				ID:          r.s.newID("OrderTransaction"),
+				ParentID:    parent.ID,
+				Kind:        "VOID",
+				Status:      "SUCCESS",
+				Gateway:     parent.Gateway,
+				Amount:      formatAmount(left),
+				Test:        parent.Test,
+				ProcessedAt: now,
+			})
+		}
+	}
+	r.s.updateFinancialStatus(o)
+	return &orderCapturePayload{Transaction: &transactionResolver{t: t, s: r.s}, UserErrors: []*userError{}}
+}
+
+type orderMarkAsPaidArgs struct {
+	Input struct {
+		ID graphqlserver.ID
+	}
+}
+
+// OrderMarkAsPaid records a manual sale of the amount of the order left to pay.
+func (r *mutationResolver) OrderMarkAsPaid(args orderMarkAsPaidArgs) *orderPayload {
+	o := r.s.order(string(args.Input.ID))
+	if o == nil {
+		return &orderPayload{UserErrors: []*userError{fieldError("Order does not exist.", "input", "id")}}
+	}
+	outstanding := orderTotal(o)
+	for _, t := range o.Transactions {
+		if t.Status == "SUCCESS" && (t.Kind == "SALE" || t.Kind == "CAPTURE") {
+			outstanding -= parseAmount(t.Amount)
+		}
+	}
+	if cents(outstanding) <= 0 {
+		return &orderPayload{UserErrors: []*userError{fieldError("Order cannot be marked as paid.", "input", "id")}}
+	}
+
+	o.Transactions = append(o.Transactions, &Transaction{
+		ID:          r.s.newID("OrderTransaction"),
+		Kind:        "SALE",
+		Status:      "SUCCESS",
+		Gateway:     "manual",
+		Amount:      formatAmount(outstanding),
+		ProcessedAt: r.s.now(),
+	})
+	r.s.updateFinancialStatus(o)
+	return &orderPayload{Order: &orderResolver{o: o, s: r.s}, UserErrors: []*userError{}}
+}
+
+type refundCreateArgs struct {
+	Input struct {
+		OrderID  graphqlserver.ID
+		Note     *string
+		Notify   *bool
+		Currency *string
+		Shipping *struct {
+			Amount     *scalar
+			FullRefund *bool
+		}
+		RefundLineItems *[]refundLineItemInput
+		Transactions    *[]struct {
+			OrderID  graphqlserver.ID
+			ParentID *graphqlserver.ID
+			Amount   scalar
+			Gateway  string
+			Kind     string
+		}
+	}
+}
+
+type refundCreatePayload struct {
+	Order      *orderResolver
+	Refund     *refundResolver
+	UserErrors []*userError
+}
+
+func refundCreateFailed(uerr *userError) *refundCreatePayload {
+	return &refundCreatePayload{UserErrors: []*userError{uerr}}
+}
+
+// RefundCreate refunds line items and shipping of an order, creating a refund transaction for each
+// of the input transactions. The transactions refund their parent sale or capture, up to the amount
+// left to refund.
+func (r *mutationResolver) RefundCreate(args refundCreateArgs) *refundCreatePayload {
+	input := args.Input
+	o := r.s.order(string(input.OrderID))
+	if o == nil {
+		return refundCreateFailed(fieldError("Order does not exist.", "input", "orderId"))
+	}
+	var items []*RefundLineItem
+	if input.RefundLineItems != nil {
+		var uerr *userError
+		items, uerr = refundLineItems(o, *input.RefundLineItems, "input", "refundLineItems")
+		if uerr != nil {
+			return refundCreateFailed(uerr)
+		}
+	}
+	var shipping float64
+	if input.Shipping != nil {
+		switch {
+		case input.Shipping.FullRefund != nil && *input.Shipping.FullRefund:
+			shipping = refundableShipping(o)
+		case input.Shipping.Amount != nil:
+			shipping = parseAmount(string(*input.Shipping.Amount))
+		}
+		if cents(shipping) < 0 || cents(shipping) > cents(refundableShipping(o)) {
+			return refundCreateFailed(fieldError("Shipping refund amount exceeds the refundable shipping amount.", "input", "shipping", "amount"))
+		}
+	}
+
+	now := r.s.now()
+	var transactions []*Transaction
+	if input.Transactions != nil {
+		requested := map[string]float64{}
+		for i, tx := range *input.Transactions {
+			field := []string{"input", "transactions", fmt.Sprint(i)}
+			if tx.Kind != "REFUND" {
+				return refundCreateFailed(fieldError("Transaction kind must be REFUND.", append(field, "kind")...))
+			}
+			var parent *Transaction
+			if tx.ParentID != nil {
+				parent = o.transaction(string(*tx.ParentID))
+			}
+			if parent == nil {
+				return refundCreateFailed(fieldError("Parent transaction does not exist.", append(field, "parentId")...))
+			}
+			amount := parseAmount(string(tx.Amount))
+			requested[parent.ID] += amount
+			if cents(amount) <= 0 || cents(requested[parent.ID]) > cents(refundable(o, parent)) {
+				return refundCreateFailed(fieldError("Amount exceeds the refundable amount of the parent transaction.", append(field, "amount")...))
+			}
+			transactions = append(transactions, &Transaction{
+				ID:          r.s.newID("OrderTransaction"),
+				ParentID:    parent.ID,
+				Kind:        "REFUND",
+				Status:      "SUCCESS",
+				Gateway:     tx.Gateway,
+				Amount:      formatAmount(amount),
+				Test:        parent.Test,
+				ProcessedAt: now,
+			})
+		}
+	}
+
+	refund := &Refund{
+		ID:        r.s.newID("Refund"),
+		LineItems: items,
+		Shipping:  formatAmount(shipping),
+		CreatedAt: now,
+	}
+	setString(&refund.Note, input.Note)
+	for _, li := range items {
+		li.ID = r.s.newID("RefundLineItem")
+	}
+	for _, t := range transactions {
+		refund.TransactionIDs = append(refund.TransactionIDs, t.ID)
+	}
+	o.Transactions = append(o.Transactions, transactions...)
+	o.Refunds = append(o.Refunds, refund)
+	r.s.updateFinancialStatus(o)
+	return &refundCreatePayload{
+		Order:      &orderResolver{o: o, s: r.s},
+		Refund:     &refundResolver{r: refund, o: o, s: r.s},
+		UserErrors: []*userError{},
+	}
+}
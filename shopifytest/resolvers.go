@@ -0,0 +1,1506 @@
+package shopifytest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+type userError struct {
+	Field   *[]string
+	Message string
+}
+
+func newUserError(field string, message string) *userError {
+	return &userError{Field: &[]string{field}, Message: message}
+}
+
+type moneyV2 struct {
+	Amount       scalar
+	CurrencyCode string
+}
+
+type moneyBag struct {
+	PresentmentMoney moneyV2
+	ShopMoney        moneyV2
+}
+
+type seo struct {
+	Title       *string
+	Description *string
+}
+
+type metafieldArgs struct {
+	Namespace string
+	Key       string
+}
+
+type firstArgs struct {
+	First *int32
+}
+
+type idArgs struct {
+	ID graphqlserver.ID
+}
+
+func (s *Server) money(amount string) moneyBag {
+	if amount == "" {
+		amount = "0.0"
+	}
+	m := moneyV2{Amount: scalar(amount), CurrencyCode: s.CurrencyCode}
+	return moneyBag{PresentmentMoney: m, ShopMoney: m}
+}
+
+func legacyResourceID(id string) scalar {
+	return scalar(id[strings.LastIndex(id, "/")+1:])
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+func description(html string, truncateAt *int32) string {
+	text := htmlTag.ReplaceAllString(html, "")
+	if truncateAt != nil && int(*truncateAt) < len(text) {
+		text = text[:*truncateAt]
+	}
+	return text
+}
+
+func parseAmount(amount string) float64 {
+	f, _ := strconv.ParseFloat(amount, 64)
+	return f
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// Query
+
+type queryResolver struct {
+	s *Server
+}
+
+func (r *queryResolver) Node(args idArgs) *nodeResolver {
+	return r.s.node(string(args.ID))
+}
+
+func (r *queryResolver) Product(args idArgs) *productResolver {
+	p := r.s.product(string(args.ID))
+	if p == nil {
+		return nil
+	}
+	return &productResolver{p: p, s: r.s}
+}
+
+func (r *queryResolver) Products(args queryConnectionArgs) *connection[*productResolver] {
+	return r.s.productConnection(r.s.products, args)
+}
+
+func (r *queryResolver) ProductVariant(args idArgs) *variantResolver {
+	p, v := r.s.variant(string(args.ID))
+	if v == nil {
+		return nil
+	}
+	return &variantResolver{v: v, p: p, s: r.s}
+}
+
+func (r *queryResolver) Collection(args idArgs) *collectionResolver {
+	c := r.s.collection(string(args.ID))
+	if c == nil {
+		return nil
+	}
+	return &collectionResolver{c: c, s: r.s}
+}
+
+func (r *queryResolver) Collections(args queryConnectionArgs) *connection[*collectionResolver] {
+	return r.s.collectionConnection(r.s.collections, args)
+}
+
+func (r *queryResolver) Order(args idArgs) *orderResolver {
+	o := r.s.order(string(args.ID))
+	if o == nil {
+		return nil
+	}
+	return &orderResolver{o: o, s: r.s}
+}
+
+func (r *queryResolver) Orders(args queryConnectionArgs) *connection[*orderResolver] {
+	var resolvers []*orderResolver
+	for _, o := range r.s.orders {
+		if args.Query != nil && !matchQuery(*args.Query, o.Name, orderFields(o)) {
+			continue
+		}
+		resolvers = append(resolvers, &orderResolver{o: o, s: r.s})
+	}
+	return newConnection(resolvers, func(r *orderResolver) string { return r.o.ID }, args.connectionArgs)
+}
+
+func (r *queryResolver) Shop() *shopResolver {
+	return &shopResolver{s: r.s}
+}
+
+func (r *queryResolver) WebhookSubscription(args idArgs) *webhookSubscriptionResolver {
+	w := r.s.webhookSubscription(string(args.ID))
+	if w == nil {
+		return nil
+	}
+	return &webhookSubscriptionResolver{w: w}
+}
+
+type webhookSubscriptionsArgs struct {
+	connectionArgs
+	Topics      *[]string
+	CallbackURL *scalar
+	Format      *string
+}
+
+func (r *queryResolver) WebhookSubscriptions(args webhookSubscriptionsArgs) *connection[*webhookSubscriptionResolver] {
+	var resolvers []*webhookSubscriptionResolver
+	for _, w := range r.s.webhooks {
+		if args.Topics != nil && len(*args.Topics) > 0 && !contains(*args.Topics, w.Topic) {
+			continue
+		}
+		if args.CallbackURL != nil && string(*args.CallbackURL) != w.CallbackURL {
+			continue
+		}
+		if args.Format != nil && *args.Format != w.Format {
+			continue
+		}
+		resolvers = append(resolvers, &webhookSubscriptionResolver{w: w})
+	}
+	return newConnection(resolvers, func(r *webhookSubscriptionResolver) string { return r.w.ID }, args.connectionArgs)
+}
+
+type currentBulkOperationArgs struct {
+	Type *string
+}
+
+func (r *queryResolver) CurrentBulkOperation(args currentBulkOperationArgs) *bulkOperationResolver {
+	typ := "QUERY"
+	if args.Type != nil {
+		typ = *args.Type
+	}
+	for i := len(r.s.bulkOperations) - 1; i >= 0; i-- {
+		if op := r.s.bulkOperations[i]; op.Type == typ {
+			return &bulkOperationResolver{op: op}
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Node
+
+type nodeResolver struct {
+	node interface{ ID() graphqlserver.ID }
+}
+
+func (r *nodeResolver) ID() graphqlserver.ID {
+	return r.node.ID()
+}
+
+func (r *nodeResolver) ToMetafield() (*metafieldResolver, bool) {
+	n, ok := r.node.(*metafieldResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToProduct() (*productResolver, bool) {
+	n, ok := r.node.(*productResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToProductVariant() (*variantResolver, bool) {
+	n, ok := r.node.(*variantResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToInventoryItem() (*inventoryItemResolver, bool) {
+	n, ok := r.node.(*inventoryItemResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToInventoryLevel() (*inventoryLevelResolver, bool) {
+	n, ok := r.node.(*inventoryLevelResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToInventoryAdjustmentGroup() (*inventoryAdjustmentGroup, bool) {
+	n, ok := r.node.(*inventoryAdjustmentGroup)
+	return n, ok
+}
+
+func (r *nodeResolver) ToLocation() (*locationResolver, bool) {
+	n, ok := r.node.(*locationResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToCollection() (*collectionResolver, bool) {
+	n, ok := r.node.(*collectionResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToFulfillmentOrder() (*fulfillmentOrderResolver, bool) {
+	n, ok := r.node.(*fulfillmentOrderResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToFulfillment() (*fulfillmentResolver, bool) {
+	n, ok := r.node.(*fulfillmentResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToRefund() (*refundResolver, bool) {
+	n, ok := r.node.(*refundResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToReturn() (*returnResolver, bool) {
+	n, ok := r.node.(*returnResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToDraftOrder() (*draftOrderResolver, bool) {
+	n, ok := r.node.(*draftOrderResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToCustomer() (*customerResolver, bool) {
+	n, ok := r.node.(*customerResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToDiscountCodeNode() (*discountNodeResolver, bool) {
+	n, ok := r.node.(*discountNodeResolver)
+	return n, ok && !n.d.Automatic
+}
+
+func (r *nodeResolver) ToDiscountAutomaticNode() (*discountNodeResolver, bool) {
+	n, ok := r.node.(*discountNodeResolver)
+	return n, ok && n.d.Automatic
+}
+
+func (r *nodeResolver) ToDiscountRedeemCodeBulkCreation() (*redeemCodeBulkCreation, bool) {
+	n, ok := r.node.(*redeemCodeBulkCreation)
+	return n, ok
+}
+
+func (r *nodeResolver) ToOrder() (*orderResolver, bool) {
+	n, ok := r.node.(*orderResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToWebhookSubscription() (*webhookSubscriptionResolver, bool) {
+	n, ok := r.node.(*webhookSubscriptionResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToBulkOperation() (*bulkOperationResolver, bool) {
+	n, ok := r.node.(*bulkOperationResolver)
+	return n, ok
+}
+
+// Shop
+
+type shopResolver struct {
+	s *Server
+}
+
+func (r *shopResolver) ID() graphqlserver.ID {
+	return "gid://shopify/Shop/1"
+}
+
+func (r *shopResolver) Name() string {
+	return r.s.ShopName
+}
+
+func (r *shopResolver) CurrencyCode() string {
+	return r.s.CurrencyCode
+}
+
+func (r *shopResolver) Metafield(args metafieldArgs) *metafieldResolver {
+	return findMetafield(r.s.shopMetafields, "SHOP", args)
+}
+
+func (r *shopResolver) Metafields(args namespaceConnectionArgs) *connection[*metafieldResolver] {
+	return metafieldConnection(r.s.shopMetafields, "SHOP", args)
+}
+
+// Metafield
+
+type metafieldResolver struct {
+	m         *Metafield
+	ownerType string
+}
+
+func (r *metafieldResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.m.ID)
+}
+
+func (r *metafieldResolver) LegacyResourceID() scalar {
+	return legacyResourceID(r.m.ID)
+}
+
+func (r *metafieldResolver) Namespace() string {
+	return r.m.Namespace
+}
+
+func (r *metafieldResolver) Key() string {
+	return r.m.Key
+}
+
+func (r *metafieldResolver) Value() string {
+	return r.m.Value
+}
+
+func (r *metafieldResolver) Type() string {
+	return r.m.Type
+}
+
+func (r *metafieldResolver) Description() *string {
+	return strPtr(r.m.Description)
+}
+
+func (r *metafieldResolver) OwnerType() string {
+	return r.ownerType
+}
+
+func (r *metafieldResolver) CreatedAt() scalar {
+	return dateTime(r.m.CreatedAt)
+}
+
+func (r *metafieldResolver) UpdatedAt() scalar {
+	return dateTime(r.m.UpdatedAt)
+}
+
+func findMetafield(metafields []*Metafield, ownerType string, args metafieldArgs) *metafieldResolver {
+	for _, m := range metafields {
+		if m.Namespace == args.Namespace && m.Key == args.Key {
+			return &metafieldResolver{m: m, ownerType: ownerType}
+		}
+	}
+	return nil
+}
+
+func metafieldConnection(metafields []*Metafield, ownerType string, args namespaceConnectionArgs) *connection[*metafieldResolver] {
+	var resolvers []*metafieldResolver
+	for _, m := range metafields {
+		if args.Namespace != nil && *args.Namespace != m.Namespace {
+			continue
+		}
+		resolvers = append(resolvers, &metafieldResolver{m: m, ownerType: ownerType})
+	}
+	return newConnection(resolvers, func(r *metafieldResolver) string { return r.m.ID }, args.connectionArgs)
+}
+
+// Image and media
+
+type imageResolver struct {
+	img *Image
+}
+
+func (r *imageResolver) ID() *graphqlserver.ID {
+	if r.img.ID == "" {
+		return nil
+	}
+	id := graphqlserver.ID(r.img.ID)
+	return &id
+}
+
+func (r *imageResolver) AltText() *string {
+	return strPtr(r.img.AltText)
+}
+
+func (r *imageResolver) Height() *int32 {
+	return int32Ptr(r.img.Height)
+}
+
+func (r *imageResolver) Width() *int32 {
+	return int32Ptr(r.img.Width)
+}
+
+func (r *imageResolver) Src() scalar {
+	return scalar(r.img.Src)
+}
+
+func (r *imageResolver) URL() scalar {
+	return scalar(r.img.Src)
+}
+
+func newImageResolver(img *Image) *imageResolver {
+	if img == nil {
+		return nil
+	}
+	return &imageResolver{img: img}
+}
+
+type mediaPreviewImage struct {
+	Image *imageResolver
+}
+
+type videoSource struct {
+	URL      string
+	Format   string
+	MimeType string
+	Height   int32
+	Width    int32
+}
+
+type model3dSource struct {
+	URL      string
+	Format   string
+	Filesize int32
+	MimeType string
+}
+
+type mediaResolver struct {
+	m *Media
+}
+
+func (r *mediaResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.m.ID)
+}
+
+func (r *mediaResolver) Alt() *string {
+	return strPtr(r.m.Alt)
+}
+
+func (r *mediaResolver) MediaContentType() string {
+	return r.m.ContentType
+}
+
+func (r *mediaResolver) Preview() *mediaPreviewImage {
+	return &mediaPreviewImage{Image: newImageResolver(r.m.Image)}
+}
+
+func (r *mediaResolver) MimeType() *string {
+	return strPtr(r.m.MimeType)
+}
+
+func (r *mediaResolver) Image() *imageResolver {
+	return newImageResolver(r.m.Image)
+}
+
+func (r *mediaResolver) Duration() *int32 {
+	return int32Ptr(r.m.Duration)
+}
+
+func (r *mediaResolver) OriginURL() scalar {
+	return scalar(r.m.SourceURL)
+}
+
+func (r *mediaResolver) EmbedURL() scalar {
+	return scalar(r.m.SourceURL)
+}
+
+func (r *mediaResolver) ToMediaImage() (*mediaResolver, bool) {
+	return r, r.m.ContentType == "IMAGE"
+}
+
+func (r *mediaResolver) ToVideo() (*videoResolver, bool) {
+	return &videoResolver{r}, r.m.ContentType == "VIDEO"
+}
+
+func (r *mediaResolver) ToModel3d() (*model3dResolver, bool) {
+	return &model3dResolver{r}, r.m.ContentType == "MODEL_3D"
+}
+
+func (r *mediaResolver) ToExternalVideo() (*mediaResolver, bool) {
+	return r, r.m.ContentType == "EXTERNAL_VIDEO"
+}
+
+type videoResolver struct {
+	*mediaResolver
+}
+
+func (r *videoResolver) OriginalSource() *videoSource {
+	src := &videoSource{URL: r.m.SourceURL, Format: r.m.Format, MimeType: r.m.MimeType}
+	if r.m.Image != nil {
+		src.Height, src.Width = int32(r.m.Image.Height), int32(r.m.Image.Width)
+	}
+	return src
+}
+
+type model3dResolver struct {
+	*mediaResolver
+}
+
+func (r *model3dResolver) OriginalSource() *model3dSource {
+	return &model3dSource{URL: r.m.SourceURL, Format: r.m.Format, MimeType: r.m.MimeType}
+}
+
+// Product
+
+type productResolver struct {
+	p *Product
+	s *Server
+}
+
+type productOption struct {
+	ID       graphqlserver.ID
+	Name     string
+	Position int32
+	Values   []string
+}
+
+type productPriceRange struct {
+	MinVariantPrice moneyV2
+	MaxVariantPrice moneyV2
+}
+
+type truncateArgs struct {
+	TruncateAt *int32
+}
+
+func (r *productResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.p.ID)
+}
+
+func (r *productResolver) LegacyResourceID() scalar {
+	return legacyResourceID(r.p.ID)
+}
+
+func (r *productResolver) Handle() string {
+	return r.p.Handle
+}
+
+func (r *productResolver) Title() string {
+	return r.p.Title
+}
+
+func (r *productResolver) Status() string {
+	return r.p.Status
+}
+
+func (r *productResolver) Description(args truncateArgs) string {
+	return description(r.p.DescriptionHTML, args.TruncateAt)
+}
+
+func (r *productResolver) DescriptionHTML() scalar {
+	return scalar(r.p.DescriptionHTML)
+}
+
+func (r *productResolver) ProductType() string {
+	return r.p.ProductType
+}
+
+func (r *productResolver) Vendor() string {
+	return r.p.Vendor
+}
+
+func (r *productResolver) Tags() []string {
+	return append([]string{}, r.p.Tags...)
+}
+
+func (r *productResolver) TemplateSuffix() *string {
+	return strPtr(r.p.TemplateSuffix)
+}
+
+func (r *productResolver) TracksInventory() bool {
+	return true
+}
+
+func (r *productResolver) TotalInventory() int32 {
+	var total int
+	for _, v := range r.p.Variants {
+		total += v.InventoryQuantity
+	}
+	return int32(total)
+}
+
+func (r *productResolver) OnlineStoreURL() *scalar {
+	if r.p.PublishedAt == nil {
+		return nil
+	}
+	return scalarPtr(fmt.Sprintf("https://%s/products/%s", r.s.ShopDomain, r.p.Handle))
+}
+
+func (r *productResolver) PublishedAt() *scalar {
+	return dateTimePtr(r.p.PublishedAt)
+}
+
+func (r *productResolver) CreatedAt() scalar {
+	return dateTime(r.p.CreatedAt)
+}
+
+func (r *productResolver) UpdatedAt() scalar {
+	return dateTime(r.p.UpdatedAt)
+}
+
+func (r *productResolver) SEO() seo {
+	return seo{Title: strPtr(r.p.SEO.Title), Description: strPtr(r.p.SEO.Description)}
+}
+
+func (r *productResolver) Options(args firstArgs) []productOption {
+	options := []productOption{}
+	for i, o := range r.p.Options {
+		if args.First != nil && i >= int(*args.First) {
+			break
+		}
+		options = append(options, productOption{
+			ID:       graphqlserver.ID(fmt.Sprintf("gid://shopify/ProductOption/%s%d", legacyResourceID(r.p.ID), i+1)),
+			Name:     o.Name,
+			Position: int32(i + 1),
+			Values:   append([]string{}, o.Values...),
+		})
+	}
+	return options
+}
+
+func (r *productResolver) PriceRangeV2() productPriceRange {
+	var min, max string
+	for _, v := range r.p.Variants {
+		if min == "" || parseAmount(v.Price) < parseAmount(min) {
+			min = v.Price
+		}
+		if max == "" || parseAmount(v.Price) > parseAmount(max) {
+			max = v.Price
+		}
+	}
+	return productPriceRange{
+		MinVariantPrice: r.s.money(min).ShopMoney,
+		MaxVariantPrice: r.s.money(max).ShopMoney,
+	}
+}
+
+func (r *productResolver) Variants(args connectionArgs) *connection[*variantResolver] {
+	var resolvers []*variantResolver
+	for _, v := range r.p.Variants {
+		resolvers = append(resolvers, &variantResolver{v: v, p: r.p, s: r.s})
+	}
+	return newConnection(resolvers, func(r *variantResolver) string { return r.v.ID }, args)
+}
+
+func (r *productResolver) Collections(args queryConnectionArgs) *connection[*collectionResolver] {
+	var collections []*Collection
+	for _, c := range r.s.collections {
+		if contains(c.ProductIDs, r.p.ID) {
+			collections = append(collections, c)
+		}
+	}
+	return r.s.collectionConnection(collections, args)
+}
+
+func (r *productResolver) Images(args connectionArgs) *connection[*imageResolver] {
+	var resolvers []*imageResolver
+	for _, img := range r.p.Images {
+		resolvers = append(resolvers, &imageResolver{img: img})
+	}
+	return newConnection(resolvers, func(r *imageResolver) string { return r.img.ID }, args)
+}
+
+func (r *productResolver) Media(args connectionArgs) *connection[*mediaResolver] {
+	var resolvers []*mediaResolver
+	for _, m := range r.p.Media {
+		resolvers = append(resolvers, &mediaResolver{m: m})
+	}
+	return newConnection(resolvers, func(r *mediaResolver) string { return r.m.ID }, args)
+}
+
+func (r *productResolver) Metafield(args metafieldArgs) *metafieldResolver {
+	return findMetafield(r.p.Metafields, "PRODUCT", args)
+}
+
+func (r *productResolver) Metafields(args namespaceConnectionArgs) *connection[*metafieldResolver] {
+	return metafieldConnection(r.p.Metafields, "PRODUCT", args)
+}
+
+func (s *Server) productConnection(products []*Product, args queryConnectionArgs) *connection[*productResolver] {
+	var resolvers []*productResolver
+	for _, p := range products {
+		if args.Query != nil && !matchQuery(*args.Query, p.Title, productFields(p)) {
+			continue
+		}
+		resolvers = append(resolvers, &productResolver{p: p, s: s})
+	}
+	return newConnection(resolvers, func(r *productResolver) string { return r.p.ID }, args.connectionArgs)
+}
+
+func productFields(p *Product) func(string) []string {
+	return func(name string) []string {
+		switch name {
+		case "id":
+			return []string{p.ID}
+		case "title":
+			return []string{p.Title}
+		case "handle":
+			return []string{p.Handle}
+		case "status":
+			return []string{p.Status}
+		case "vendor":
+			return []string{p.Vendor}
+		case "product_type":
+			return []string{p.ProductType}
+		case "tag":
+			return p.Tags
+		case "sku":
+			var skus []string
+			for _, v := range p.Variants {
+				skus = append(skus, v.SKU)
+			}
+			return skus
+		}
+		return nil
+	}
+}
+
+// ProductVariant
+
+type variantResolver struct {
+	v *Variant
+	p *Product
+	s *Server
+}
+
+func (r *variantResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.v.ID)
+}
+
+func (r *variantResolver) LegacyResourceID() scalar {
+	return legacyResourceID(r.v.ID)
+}
+
+func (r *variantResolver) Title() string {
+	return r.v.Title
+}
+
+func (r *variantResolver) DisplayName() string {
+	return r.p.Title + " - " + r.v.Title
+}
+
+func (r *variantResolver) SKU() *string {
+	return strPtr(r.v.SKU)
+}
+
+func (r *variantResolver) Barcode() *string {
+	return strPtr(r.v.Barcode)
+}
+
+func (r *variantResolver) Price() scalar {
+	return scalar(r.v.Price)
+}
+
+func (r *variantResolver) CompareAtPrice() *scalar {
+	return scalarPtr(r.v.CompareAtPrice)
+}
+
+func (r *variantResolver) Position() int32 {
+	for i, v := range r.p.Variants {
+		if v == r.v {
+			return int32(i + 1)
+		}
+	}
+	return 0
+}
+
+func (r *variantResolver) InventoryQuantity() *int32 {
+	return int32Ptr(r.v.InventoryQuantity)
+}
+
+func (r *variantResolver) InventoryPolicy() string {
+	return r.v.InventoryPolicy
+}
+
+func (r *variantResolver) InventoryManagement() string {
+	return "SHOPIFY"
+}
+
+func (r *variantResolver) Weight() *float64 {
+	return &r.v.Weight
+}
+
+func (r *variantResolver) WeightUnit() string {
+	return r.v.WeightUnit
+}
+
+func (r *variantResolver) SelectedOptions() []SelectedOption {
+	return append([]SelectedOption{}, r.v.SelectedOptions...)
+}
+
+func (r *variantResolver) Image() *imageResolver {
+	return nil
+}
+
+func (r *variantResolver) Product() *productResolver {
+	return &productResolver{p: r.p, s: r.s}
+}
+
+func (r *variantResolver) CreatedAt() scalar {
+	return dateTime(r.v.CreatedAt)
+}
+
+func (r *variantResolver) UpdatedAt() scalar {
+	return dateTime(r.v.UpdatedAt)
+}
+
+func (r *variantResolver) Metafield(args metafieldArgs) *metafieldResolver {
+	return findMetafield(r.v.Metafields, "PRODUCTVARIANT", args)
+}
+
+func (r *variantResolver) Metafields(args namespaceConnectionArgs) *connection[*metafieldResolver] {
+	return metafieldConnection(r.v.Metafields, "PRODUCTVARIANT", args)
+}
+
+// Collection
+
+type collectionResolver struct {
+	c *Collection
+	s *Server
+}
+
+func (r *collectionResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.c.ID)
+}
+
+func (r *collectionResolver) LegacyResourceID() scalar {
+	return legacyResourceID(r.c.ID)
+}
+
+func (r *collectionResolver) Handle() string {
+	return r.c.Handle
+}
+
+func (r *collectionResolver) Title() string {
+	return r.c.Title
+}
+
+func (r *collectionResolver) Description(args truncateArgs) string {
+	return description(r.c.DescriptionHTML, args.TruncateAt)
+}
+
+func (r *collectionResolver) DescriptionHTML() scalar {
+	return scalar(r.c.DescriptionHTML)
+}
+
+func (r *collectionResolver) TemplateSuffix() *string {
+	return strPtr(r.c.TemplateSuffix)
+}
+
+func (r *collectionResolver) ProductsCount() int32 {
+	return int32(len(r.c.ProductIDs))
+}
+
+func (r *collectionResolver) SEO() seo {
+	return seo{Title: strPtr(r.c.SEO.Title), Description: strPtr(r.c.SEO.Description)}
+}
+
+func (r *collectionResolver) Image() *imageResolver {
+	return newImageResolver(r.c.Image)
+}
+
+func (r *collectionResolver) UpdatedAt() scalar {
+	return dateTime(r.c.UpdatedAt)
+}
+
+func (r *collectionResolver) Products(args connectionArgs) *connection[*productResolver] {
+	var products []*Product
+	for _, id := range r.c.ProductIDs {
+		if p := r.s.product(id); p != nil {
+			products = append(products, p)
+		}
+	}
+	return r.s.productConnection(products, queryConnectionArgs{connectionArgs: args})
+}
+
+func (r *collectionResolver) Metafield(args metafieldArgs) *metafieldResolver {
+	return findMetafield(r.c.Metafields, "COLLECTION", args)
+}
+
+func (r *collectionResolver) Metafields(args namespaceConnectionArgs) *connection[*metafieldResolver] {
+	return metafieldConnection(r.c.Metafields, "COLLECTION", args)
+}
+
+func (s *Server) collectionConnection(collections []*Collection, args queryConnectionArgs) *connection[*collectionResolver] {
+	var resolvers []*collectionResolver
+	for _, c := range collections {
+		if args.Query != nil && !matchQuery(*args.Query, c.Title, collectionFields(c)) {
+			continue
+		}
+		resolvers = append(resolvers, &collectionResolver{c: c, s: s})
+	}
+	return newConnection(resolvers, func(r *collectionResolver) string { return r.c.ID }, args.connectionArgs)
+}
+
+func collectionFields(c *Collection) func(string) []string {
+	return func(name string) []string {
+		switch name {
+		case "id":
+			return []string{c.ID}
+		case "title":
+			return []string{c.Title}
+		case "handle":
+			return []string{c.Handle}
+		case "product_id":
+			return c.ProductIDs
+		}
+		return nil
+	}
+}
+
+// Order
+
+type orderResolver struct {
+	o *Order
+	s *Server
+}
+
+type shippingLine struct {
+	Title            string
+	OriginalPriceSet moneyBag
+}
+
+type taxLine struct {
+	Title          string
+	Rate           *float64
+	RatePercentage *float64
+	PriceSet       moneyBag
+}
+
+func (r *orderResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.o.ID)
+}
+
+func (r *orderResolver) LegacyResourceID() scalar {
+	return legacyResourceID(r.o.ID)
+}
+
+func (r *orderResolver) Name() string {
+	return r.o.Name
+}
+
+func (r *orderResolver) Email() *string {
+	return strPtr(r.o.Email)
+}
+
+func (r *orderResolver) Note() *string {
+	return strPtr(r.o.Note)
+}
+
+func (r *orderResolver) Tags() []string {
+	return append([]string{}, r.o.Tags...)
+}
+
+func (r *orderResolver) ClientIP() *string {
+	return strPtr(r.o.ClientIP)
+}
+
+func (r *orderResolver) Closed() bool {
+	return r.o.Closed
+}
+
+func (r *orderResolver) CreatedAt() scalar {
+	return dateTime(r.o.CreatedAt)
+}
+
+func (r *orderResolver) UpdatedAt() scalar {
+	return dateTime(r.o.UpdatedAt)
+}
+
+func (r *orderResolver) DisplayFinancialStatus() *string {
+	return strPtr(r.o.DisplayFinancialStatus)
+}
+
+func (r *orderResolver) DisplayFulfillmentStatus() string {
+	return r.o.DisplayFulfillmentStatus
+}
+
+func (r *orderResolver) Customer() *customerResolver {
+	if r.o.Customer == nil {
+		return nil
+	}
+	return &customerResolver{c: r.o.Customer, s: r.s}
+}
+
+func (r *orderResolver) ShippingAddress() *mailingAddressResolver {
+	if r.o.ShippingAddress == nil {
+		return nil
+	}
+	return &mailingAddressResolver{a: r.o.ShippingAddress}
+}
+
+func (r *orderResolver) ShippingLine() *shippingLine {
+	if r.o.ShippingLine == nil {
+		return nil
+	}
+	return &shippingLine{Title: r.o.ShippingLine.Title, OriginalPriceSet: r.s.money(r.o.ShippingLine.Price)}
+}
+
+func (r *orderResolver) TaxLines() []taxLine {
+	return []taxLine{}
+}
+
+func (r *orderResolver) TotalPriceSet() moneyBag {
+	return r.s.money(formatAmount(orderTotal(r.o)))
+}
+
+// orderTotal returns the total price of the order's line items, after discounts, and shipping.
+func orderTotal(o *Order) float64 {
+	var total float64
+	for _, li := range o.LineItems {
+		total += parseAmount(discountedPrice(li)) * float64(li.Quantity)
+	}
+	if o.ShippingLine != nil {
+		total += parseAmount(o.ShippingLine.Price)
+	}
+	return total
+}
+
+func (r *orderResolver) TotalReceivedSet() moneyBag {
+	var total float64
+	for _, t := range r.o.Transactions {
+		if t.Status != "SUCCESS" {
+			continue
+		}
+		switch t.Kind {
+		case "SALE", "CAPTURE":
+			total += parseAmount(t.Amount)
+		case "REFUND":
+			total -= parseAmount(t.Amount)
+		}
+	}
+	return r.s.money(formatAmount(total))
+}
+
+func (r *orderResolver) Transactions(args firstArgs) []*transactionResolver {
+	resolvers := []*transactionResolver{}
+	for i, t := range r.o.Transactions {
+		if args.First != nil && i >= int(*args.First) {
+			break
+		}
+		resolvers = append(resolvers, &transactionResolver{t: t, s: r.s})
+	}
+	return resolvers
+}
+
+func (r *orderResolver) LineItems(args connectionArgs) *connection[*lineItemResolver] {
+	var resolvers []*lineItemResolver
+	for _, li := range r.o.LineItems {
+		resolvers = append(resolvers, &lineItemResolver{li: li, s: r.s})
+	}
+	return newConnection(resolvers, func(r *lineItemResolver) string { return r.li.ID }, args)
+}
+
+func (r *orderResolver) FulfillmentOrders(args queryConnectionArgs) *connection[*fulfillmentOrderResolver] {
+	var resolvers []*fulfillmentOrderResolver
+	for _, fo := range r.o.FulfillmentOrders {
+		if args.Query != nil && !matchQuery(*args.Query, "", fulfillmentOrderFields(fo)) {
+			continue
+		}
+		resolvers = append(resolvers, &fulfillmentOrderResolver{fo: fo, o: r.o, s: r.s})
+	}
+	return newConnection(resolvers, func(r *fulfillmentOrderResolver) string { return r.fo.ID }, args.connectionArgs)
+}
+
+func (r *orderResolver) Fulfillments(args struct{ First *int32 }) []*fulfillmentResolver {
+	var resolvers []*fulfillmentResolver
+	for _, f := range r.o.Fulfillments {
+		if args.First != nil && len(resolvers) == int(*args.First) {
+			break
+		}
+		resolvers = append(resolvers, &fulfillmentResolver{f: f, o: r.o, s: r.s})
+	}
+	return resolvers
+}
+
+func (r *orderResolver) Metafield(args metafieldArgs) *metafieldResolver {
+	return findMetafield(r.o.Metafields, "ORDER", args)
+}
+
+func (r *orderResolver) Metafields(args namespaceConnectionArgs) *connection[*metafieldResolver] {
+	return metafieldConnection(r.o.Metafields, "ORDER", args)
+}
+
+func orderFields(o *Order) func(string) []string {
+	return func(name string) []string {
+		switch name {
+		case "id":
+			return []string{o.ID}
+		case "name":
+			return []string{o.Name}
+		case "email":
+			return []string{o.Email}
+		case "tag":
+			return o.Tags
+		case "financial_status":
+			return []string{o.DisplayFinancialStatus}
+		case "fulfillment_status":
+			return []string{o.DisplayFulfillmentStatus}
+		case "status":
+			if o.Closed {
+				return []string{"closed"}
+			}
+			return []string{"open"}
+		}
+		return nil
+	}
+}
+
+type transactionResolver struct {
+	t *Transaction
+	s *Server
+}
+
+func (r *transactionResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.t.ID)
+}
+
+func (r *transactionResolver) Kind() string {
+	return r.t.Kind
+}
+
+func (r *transactionResolver) Status() string {
+	return r.t.Status
+}
+
+func (r *transactionResolver) Gateway() *string {
+	return strPtr(r.t.Gateway)
+}
+
+func (r *transactionResolver) ParentTransaction() *transactionResolver {
+	for _, o := range r.s.orders {
+		if t := o.transaction(r.t.ParentID); t != nil {
+			return &transactionResolver{t: t, s: r.s}
+		}
+	}
+	return nil
+}
+
+func (r *transactionResolver) Test() bool {
+	return r.t.Test
+}
+
+func (r *transactionResolver) ProcessedAt() *scalar {
+	if r.t.ProcessedAt.IsZero() {
+		return nil
+	}
+	return dateTimePtr(&r.t.ProcessedAt)
+}
+
+func (r *transactionResolver) AmountSet() moneyBag {
+	return r.s.money(r.t.Amount)
+}
+
+type lineItemResolver struct {
+	li *LineItem
+	s  *Server
+}
+
+func (r *lineItemResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.li.ID)
+}
+
+func (r *lineItemResolver) SKU() *string {
+	return strPtr(r.li.SKU)
+}
+
+func (r *lineItemResolver) Title() string {
+	return r.li.Title
+}
+
+func (r *lineItemResolver) VariantTitle() *string {
+	return strPtr(r.li.VariantTitle)
+}
+
+func (r *lineItemResolver) Vendor() *string {
+	return strPtr(r.li.Vendor)
+}
+
+func (r *lineItemResolver) Quantity() int32 {
+	return int32(r.li.Quantity)
+}
+
+func (r *lineItemResolver) FulfillableQuantity() int32 {
+	if r.li.FulfillmentStatus == "fulfilled" {
+		return 0
+	}
+	return int32(r.li.Quantity - r.s.fulfilledQuantity(r.li.ID))
+}
+
+func (r *lineItemResolver) FulfillmentStatus() string {
+	return r.li.FulfillmentStatus
+}
+
+func (r *lineItemResolver) Product() *productResolver {
+	p := r.s.product(r.li.ProductID)
+	if p == nil {
+		return nil
+	}
+	return &productResolver{p: p, s: r.s}
+}
+
+func (r *lineItemResolver) Variant() *variantResolver {
+	p, v := r.s.variant(r.li.VariantID)
+	if v == nil {
+		return nil
+	}
+	return &variantResolver{v: v, p: p, s: r.s}
+}
+
+func (r *lineItemResolver) OriginalUnitPriceSet() moneyBag {
+	return r.s.money(r.li.Price)
+}
+
+func (r *lineItemResolver) OriginalTotalSet() moneyBag {
+	return r.s.money(formatAmount(parseAmount(r.li.Price) * float64(r.li.Quantity)))
+}
+
+func (r *lineItemResolver) DiscountedUnitPriceSet() moneyBag {
+	return r.s.money(discountedPrice(r.li))
+}
+
+func (r *lineItemResolver) DiscountedTotalSet() moneyBag {
+	return r.s.money(formatAmount(parseAmount(discountedPrice(r.li)) * float64(r.li.Quantity)))
+}
+
+func discountedPrice(li *LineItem) string {
+	if li.DiscountedPrice != "" {
+		return li.DiscountedPrice
+	}
+	return li.Price
+}
+
+type fulfillmentOrderResolver struct {
+	fo *FulfillmentOrder
+	o  *Order
+	s  *Server
+}
+
+func (r *fulfillmentOrderResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.fo.ID)
+}
+
+func (r *fulfillmentOrderResolver) OrderID() graphqlserver.ID {
+	return graphqlserver.ID(r.o.ID)
+}
+
+func (r *fulfillmentOrderResolver) Status() string {
+	return r.fo.Status
+}
+
+func (r *fulfillmentOrderResolver) RequestStatus() string {
+	return r.fo.RequestStatus
+}
+
+func (r *fulfillmentOrderResolver) FulfillAt() *scalar {
+	return dateTimePtr(r.fo.FulfillAt)
+}
+
+func (r *fulfillmentOrderResolver) AssignedLocation() *fulfillmentOrderAssignedLocation {
+	return &fulfillmentOrderAssignedLocation{l: r.s.resolveLocation(r.fo.AssignedLocationID)}
+}
+
+func (r *fulfillmentOrderResolver) FulfillmentHolds() []*fulfillmentHoldResolver {
+	resolvers := []*fulfillmentHoldResolver{}
+	for _, h := range r.fo.Holds {
+		resolvers = append(resolvers, &fulfillmentHoldResolver{h: h})
+	}
+	return resolvers
+}
+
+func (r *fulfillmentOrderResolver) SupportedActions() []*fulfillmentOrderSupportedAction {
+	actions := []*fulfillmentOrderSupportedAction{}
+	for _, a := range supportedActions(r.fo) {
+		actions = append(actions, &fulfillmentOrderSupportedAction{Action: a})
+	}
+	return actions
+}
+
+func (r *fulfillmentOrderResolver) CreatedAt() scalar {
+	return dateTime(r.fo.CreatedAt)
+}
+
+func (r *fulfillmentOrderResolver) UpdatedAt() scalar {
+	return dateTime(r.fo.UpdatedAt)
+}
+
+func (r *fulfillmentOrderResolver) LineItems(args connectionArgs) *connection[*fulfillmentOrderLineItemResolver] {
+	var resolvers []*fulfillmentOrderLineItemResolver
+	for _, li := range r.fo.LineItems {
+		resolvers = append(resolvers, &fulfillmentOrderLineItemResolver{li: li, o: r.o, s: r.s})
+	}
+	return newConnection(resolvers, func(r *fulfillmentOrderLineItemResolver) string { return r.li.ID }, args)
+}
+
+func fulfillmentOrderFields(fo *FulfillmentOrder) func(string) []string {
+	return func(name string) []string {
+		switch name {
+		case "id":
+			return []string{fo.ID}
+		case "status":
+			return []string{fo.Status}
+		case "assigned_location_id":
+			return []string{fo.AssignedLocationID}
+		}
+		return nil
+	}
+}
+
+type fulfillmentOrderLineItemResolver struct {
+	li *FulfillmentOrderLineItem
+	o  *Order
+	s  *Server
+}
+
+func (r *fulfillmentOrderLineItemResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.li.ID)
+}
+
+func (r *fulfillmentOrderLineItemResolver) TotalQuantity() int32 {
+	return int32(r.li.TotalQuantity)
+}
+
+func (r *fulfillmentOrderLineItemResolver) RemainingQuantity() int32 {
+	return int32(r.li.RemainingQuantity)
+}
+
+func (r *fulfillmentOrderLineItemResolver) LineItem() *lineItemResolver {
+	for _, li := range r.o.LineItems {
+		if li.ID == r.li.LineItemID {
+			return &lineItemResolver{li: li, s: r.s}
+		}
+	}
+	return &lineItemResolver{li: &LineItem{ID: r.li.LineItemID}, s: r.s}
+}
+
+// WebhookSubscription
+
+type webhookSubscriptionResolver struct {
+	w *WebhookSubscription
+}
+
+type webhookHTTPEndpoint struct {
+	CallbackURL scalar
+}
+
+type webhookEventBridgeEndpoint struct {
+	ARN string
+}
+
+type webhookPubSubEndpoint struct {
+	PubSubProject string
+	PubSubTopic   string
+}
+
+type webhookEndpointResolver struct {
+	w *WebhookSubscription
+}
+
+func (r *webhookEndpointResolver) ToWebhookHttpEndpoint() (*webhookHTTPEndpoint, bool) {
+	return &webhookHTTPEndpoint{CallbackURL: scalar(r.w.CallbackURL)}, r.w.ARN == "" && !r.w.isPubSub()
+}
+
+func (r *webhookEndpointResolver) ToWebhookEventBridgeEndpoint() (*webhookEventBridgeEndpoint, bool) {
+	return &webhookEventBridgeEndpoint{ARN: r.w.ARN}, r.w.ARN != ""
+}
+
+func (r *webhookEndpointResolver) ToWebhookPubSubEndpoint() (*webhookPubSubEndpoint, bool) {
+	return &webhookPubSubEndpoint{PubSubProject: r.w.PubSubProject, PubSubTopic: r.w.PubSubTopic}, r.w.isPubSub()
+}
+
+func (r *webhookSubscriptionResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.w.ID)
+}
+
+func (r *webhookSubscriptionResolver) LegacyResourceID() scalar {
+	return legacyResourceID(r.w.ID)
+}
+
+func (r *webhookSubscriptionResolver) Topic() string {
+	return r.w.Topic
+}
+
+func (r *webhookSubscriptionResolver) Endpoint() *webhookEndpointResolver {
+	return &webhookEndpointResolver{w: r.w}
+}
+
+func (r *webhookSubscriptionResolver) CallbackURL() scalar {
+	return scalar(r.w.address())
+}
+
+func (r *webhookSubscriptionResolver) Format() string {
+	return r.w.Format
+}
+
+func (r *webhookSubscriptionResolver) IncludeFields() []string {
+	return append([]string{}, r.w.IncludeFields...)
+}
+
+func (r *webhookSubscriptionResolver) MetafieldNamespaces() []string {
+	return append([]string{}, r.w.MetafieldNamespaces...)
+}
+
+func (r *webhookSubscriptionResolver) CreatedAt() scalar {
+	return dateTime(r.w.CreatedAt)
+}
+
+func (r *webhookSubscriptionResolver) UpdatedAt() scalar {
+	return dateTime(r.w.UpdatedAt)
+}
+
+// BulkOperation
+
+type bulkOperationResolver struct {
+	op *BulkOperation
+}
+
+func (r *bulkOperationResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.op.ID)
+}
+
+func (r *bulkOperationResolver) Status() string {
+	return r.op.Status
+}
+
+func (r *bulkOperationResolver) ErrorCode() *string {
+	return strPtr(r.op.ErrorCode)
+}
+
+func (r *bulkOperationResolver) Type() string {
+	return r.op.Type
+}
+
+func (r *bulkOperationResolver) Query() string {
+	return r.op.Query
+}
+
+func (r *bulkOperationResolver) CreatedAt() scalar {
+	return dateTime(r.op.CreatedAt)
+}
+
+func (r *bulkOperationResolver) CompletedAt() *scalar {
+	return dateTimePtr(r.op.CompletedAt)
+}
+
+func (r *bulkOperationResolver) ObjectCount() scalar {
+	return scalar(strconv.Itoa(r.op.ObjectCount))
+}
+
+func (r *bulkOperationResolver) RootObjectCount() scalar {
+	return scalar(strconv.Itoa(r.op.RootObjectCount))
+}
+
+func (r *bulkOperationResolver) FileSize() *scalar {
+	if r.op.URL == "" {
+		return nil
+	}
+	s := scalar(strconv.Itoa(r.op.FileSize))
+	return &s
+}
+
+func (r *bulkOperationResolver) URL() *scalar {
+	return scalarPtr(r.op.URL)
+}
+
+func (r *bulkOperationResolver) PartialDataURL() *scalar {
+	return nil
+}
@@ -0,0 +1,305 @@
+package shopifytest
+
+import (
+	"fmt"
+
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+// fulfillmentLineItem returns the line item of a successful fulfillment of the order.
+func fulfillmentLineItem(o *Order, id string) *FulfillmentLineItem {
+	for _, f := range o.Fulfillments {
+		if f.Status != "SUCCESS" {
+			continue
+		}
+		for _, li := range f.LineItems {
+			if li.ID == id {
+				return li
+			}
+		}
+	}
+	return nil
+}
+
+// returnableQuantity returns the quantity of the fulfillment line item not part of a return yet,
+// declined and canceled returns giving their quantities back.
+func returnableQuantity(o *Order, li *FulfillmentLineItem) int {
+	quantity := li.Quantity
+	for _, rt := range o.Returns {
+		if rt.Status == "DECLINED" || rt.Status == "CANCELED" {
+			continue
+		}
+		for _, rli := range rt.LineItems {
+			if rli.FulfillmentLineItemID == li.ID {
+				quantity -= rli.Quantity
+			}
+		}
+	}
+	return quantity
+}
+
+// Queries
+
+func (r *queryResolver) Return(args idArgs) *returnResolver {
+	rt, o := r.s.orderReturn(string(args.ID))
+	if rt == nil {
+		return nil
+	}
+	return &returnResolver{rt: rt, o: o, s: r.s}
+}
+
+func (r *orderResolver) Returns(args connectionArgs) *connection[*returnResolver] {
+	var resolvers []*returnResolver
+	for _, rt := range r.o.Returns {
+		resolvers = append(resolvers, &returnResolver{rt: rt, o: r.o, s: r.s})
+	}
+	return newConnection(resolvers, func(r *returnResolver) string { return r.rt.ID }, args)
+}
+
+// Resolvers
+
+type returnResolver struct {
+	rt *Return
+	o  *Order
+	s  *Server
+}
+
+func (r *returnResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.rt.ID)
+}
+
+func (r *returnResolver) Name() string {
+	return r.rt.Name
+}
+
+func (r *returnResolver) Status() string {
+	return r.rt.Status
+}
+
+func (r *returnResolver) TotalQuantity() int32 {
+	var quantity int32
+	for _, li := range r.rt.LineItems {
+		quantity += int32(li.Quantity)
+	}
+	return quantity
+}
+
+func (r *returnResolver) Order() *orderResolver {
+	return &orderResolver{o: r.o, s: r.s}
+}
+
+func (r *returnResolver) ReturnLineItems(args connectionArgs) *connection[*returnLineItemResolver] {
+	var resolvers []*returnLineItemResolver
+	for _, li := range r.rt.LineItems {
+		resolvers = append(resolvers, &returnLineItemResolver{li: li, o: r.o, s: r.s})
+	}
+	return newConnection(resolvers, func(r *returnLineItemResolver) string { return r.li.ID }, args)
+}
+
+func (r *returnResolver) Decline() *returnDecline {
+	if r.rt.Status != "DECLINED" {
+		return nil
+	}
+	return &returnDecline{Reason: r.rt.DeclineReason, Note: strPtr(r.rt.DeclineNote)}
+}
+
+type returnDecline struct {
+	Reason string
+	Note   *string
+}
+
+type returnLineItemResolver struct {
+	li *ReturnLineItem
+	o  *Order
+	s  *Server
+}
+
+func (r *returnLineItemResolver) ID() graphqlserver.ID {
+	return graphqlserver.ID(r.li.ID)
+}
+
+func (r *returnLineItemResolver) Quantity() int32 {
+	return int32(r.li.Quantity)
+}
+
+func (r *returnLineItemResolver) ReturnReason() string {
+	return r.li.ReturnReason
+}
+
+func (r *returnLineItemResolver) ReturnReasonNote() string {
+	return r.li.ReturnReasonNote
+}
+
+func (r *returnLineItemResolver) CustomerNote() *string {
+	return strPtr(r.li.CustomerNote)
+}
+
+func (r *returnLineItemResolver) FulfillmentLineItem() *fulfillmentLineItemResolver {
+	return &fulfillmentLineItemResolver{li: fulfillmentLineItem(r.o, r.li.FulfillmentLineItemID), o: r.o, s: r.s}
+}
+
+// Mutations
+
+type returnPayload struct {
+	Return     *returnResolver
+	UserErrors []*userError
+}
+
+func returnFailed(message string, field ...string) *returnPayload {
+	return &returnPayload{UserErrors: []*userError{fieldError(message, field...)}}
+}
+
+// createReturn checks the line items of a return of the order orderID and adds the return to the
+// order with status. field is the path of the input.
+func (s *Server) createReturn(orderID graphqlserver.ID, items []*ReturnLineItem, status string, field string) *returnPayload {
+	o := s.order(string(orderID))
+	if o == nil {
+		return returnFailed("Order does not exist.", field, "orderId")
+	}
+	if len(items) == 0 {
+		return returnFailed("Return must contain at least one line item.", field, "returnLineItems")
+	}
+	requested := map[string]int{}
+	for i, li := range items {
+		itemField := []string{field, "returnLineItems", fmt.Sprint(i)}
+		fli := fulfillmentLineItem(o, li.FulfillmentLineItemID)
+		if fli == nil {
+			return returnFailed("Fulfillment line item does not exist.", append(itemField, "fulfillmentLineItemId")...)
+		}
+		requested[fli.ID] += li.Quantity
+		if li.Quantity <= 0 || requested[fli.ID] > returnableQuantity(o, fli) {
+			return returnFailed("Quantity exceeds the returnable quantity.", append(itemField, "quantity")...)
+		}
+	}
+
+	now := s.now()
+	for _, li := range items {
+		li.ID = s.newID("ReturnLineItem")
+	}
+	rt := &Return{
+		ID:        s.newID("Return"),
+		Name:      fmt.Sprintf("%s-R%d", o.Name, len(o.Returns)+1),
+		Status:    status,
+		LineItems: items,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	o.Returns = append(o.Returns, rt)
+	o.UpdatedAt = now
+	return &returnPayload{Return: &returnResolver{rt: rt, o: o, s: s}, UserErrors: []*userError{}}
+}
+
+// mutateReturn looks the return id up and moves it from one of the statuses from to the status to.
+func (s *Server) mutateReturn(id graphqlserver.ID, field string, from []string, to string) *returnPayload {
+	rt, o := s.orderReturn(string(id))
+	if rt == nil {
+		return returnFailed("Return does not exist.", field)
+	}
+	if !contains(from, rt.Status) {
+		return returnFailed(fmt.Sprintf("Return cannot move from status %s to %s.", rt.Status, to), field)
+	}
+	rt.Status = to
+	rt.UpdatedAt = s.now()
+	return &returnPayload{Return: &returnResolver{rt: rt, o: o, s: s}, UserErrors: []*userError{}}
+}
+
+type returnRequestArgs struct {
+	Input struct {
+		OrderID         graphqlserver.ID
+		ReturnLineItems []struct {
+			FulfillmentLineItemID graphqlserver.ID
+			Quantity              int32
+			ReturnReason          string
+			CustomerNote          *string
+		}
+	}
+}
+
+// ReturnRequest requests a return, to be approved or declined.
+func (r *mutationResolver) ReturnRequest(args returnRequestArgs) *returnPayload {
+	var items []*ReturnLineItem
+	for _, input := range args.Input.ReturnLineItems {
+		li := &ReturnLineItem{
+			FulfillmentLineItemID: string(input.FulfillmentLineItemID),
+			Quantity:              int(input.Quantity),
+			ReturnReason:          input.ReturnReason,
+		}
+		setString(&li.CustomerNote, input.CustomerNote)
+		items = append(items, li)
+	}
+	return r.s.createReturn(args.Input.OrderID, items, "REQUESTED", "input")
+}
+
+type returnCreateArgs struct {
+	ReturnInput struct {
+		OrderID         graphqlserver.ID
+		ReturnLineItems []struct {
+			FulfillmentLineItemID graphqlserver.ID
+			Quantity              int32
+			ReturnReason          string
+			ReturnReasonNote      *string
+		}
+		NotifyCustomer *bool
+	}
+}
+
+// ReturnCreate creates an open return.
+func (r *mutationResolver) ReturnCreate(args returnCreateArgs) *returnPayload {
+	var items []*ReturnLineItem
+	for _, input := range args.ReturnInput.ReturnLineItems {
+		li := &ReturnLineItem{
+			FulfillmentLineItemID: string(input.FulfillmentLineItemID),
+			Quantity:              int(input.Quantity),
+			ReturnReason:          input.ReturnReason,
+		}
+		setString(&li.ReturnReasonNote, input.ReturnReasonNote)
+		items = append(items, li)
+	}
+	return r.s.createReturn(args.ReturnInput.OrderID, items, "OPEN", "returnInput")
+}
+
+type returnApproveRequestArgs struct {
+	Input struct {
+		ID             graphqlserver.ID
+		NotifyCustomer *bool
+	}
+}
+
+func (r *mutationResolver) ReturnApproveRequest(args returnApproveRequestArgs) *returnPayload {
+	return r.s.mutateReturn(args.Input.ID, "id", []string{"REQUESTED"}, "OPEN")
+}
+
+type returnDeclineRequestArgs struct {
+	Input struct {
+		ID             graphqlserver.ID
+		DeclineReason  string
+		DeclineNote    *string
+		NotifyCustomer *bool
+	}
+}
+
+func (r *mutationResolver) ReturnDeclineRequest(args returnDeclineRequestArgs) *returnPayload {
+	payload := r.s.mutateReturn(args.Input.ID, "id", []string{"REQUESTED"}, "DECLINED")
+	if payload.Return != nil {
+		payload.Return.rt.DeclineReason = args.Input.DeclineReason
+		setString(&payload.Return.rt.DeclineNote, args.Input.DeclineNote)
+	}
+	return payload
+}
+
+func (r *mutationResolver) ReturnClose(args idArgs) *returnPayload {
+	return r.s.mutateReturn(args.ID, "id", []string{"OPEN"}, "CLOSED")
+}
+
+func (r *mutationResolver) ReturnReopen(args idArgs) *returnPayload {
+	return r.s.mutateReturn(args.ID, "id", []string{"CLOSED"}, "OPEN")
+}
+
+type returnCancelArgs struct {
+	ID             graphqlserver.ID
+	NotifyCustomer *bool
+}
+
+func (r *mutationResolver) ReturnCancel(args returnCancelArgs) *returnPayload {
+	return r.s.mutateReturn(args.ID, "id", []string{"REQUESTED", "OPEN"}, "CANCELED")
+}
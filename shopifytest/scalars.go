@@ -0,0 +1,72 @@
+package shopifytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// scalar resolves the custom scalars of the Admin API, which are all serialized as strings.
+type scalar string
+
+// ImplementsGraphQLType maps the Go type to the custom scalars of the schema.
+func (scalar) ImplementsGraphQLType(name string) bool {
+	switch name {
+	case "DateTime", "Decimal", "Money", "URL", "HTML", "UnsignedInt64":
+		return true
+	}
+	return false
+}
+
+// UnmarshalGraphQL accepts both string and numeric input values.
+func (s *scalar) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case string:
+		*s = scalar(v)
+	case int32:
+		*s = scalar(strconv.Itoa(int(v)))
+	case float64:
+		*s = scalar(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("wrong type for scalar: %T", input)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s scalar) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
+}
+
+func dateTime(t time.Time) scalar {
+	return scalar(t.UTC().Format(time.RFC3339))
+}
+
+func dateTimePtr(t *time.Time) *scalar {
+	if t == nil {
+		return nil
+	}
+	s := dateTime(*t)
+	return &s
+}
+
+func scalarPtr(s string) *scalar {
+	if s == "" {
+		return nil
+	}
+	v := scalar(s)
+	return &v
+}
+
+func strPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func int32Ptr(i int) *int32 {
+	v := int32(i)
+	return &v
+}
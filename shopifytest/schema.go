@@ -0,0 +1,2447 @@
+package shopifytest
+
+// schema is the subset of the Shopify Admin API schema served by the fake server.
+// It covers the fields queried by the services of the shopify package.
+const schema = `
+schema {
+	query: QueryRoot
+	mutation: Mutation
+}
+
+scalar DateTime
+scalar Decimal
+scalar Money
+scalar URL
+scalar HTML
+scalar UnsignedInt64
+
+interface Node {
+	id: ID!
+}
+
+type QueryRoot {
+	node(id: ID!): Node
+	product(id: ID!): Product
+	products(first: Int, after: String, last: Int, before: String, reverse: Boolean, query: String): ProductConnection!
+	productVariant(id: ID!): ProductVariant
+	inventoryItem(id: ID!): InventoryItem
+	inventoryLevel(id: ID!): InventoryLevel
+	location(id: ID!): Location
+	locations(first: Int, after: String, last: Int, before: String, reverse: Boolean, query: String, includeInactive: Boolean, includeLegacy: Boolean): LocationConnection!
+	collection(id: ID!): Collection
+	collections(first: Int, after: String, last: Int, before: String, reverse: Boolean, query: String): CollectionConnection!
+	order(id: ID!): Order
+	fulfillment(id: ID!): Fulfillment
+	fulfillmentOrder(id: ID!): FulfillmentOrder
+	refund(id: ID!): Refund
+	return(id: ID!): Return
+	draftOrder(id: ID!): DraftOrder
+	draftOrders(first: Int, after: String, last: Int, before: String, reverse: Boolean, query: String): DraftOrderConnection!
+	assignedFulfillmentOrders(first: Int, after: String, last: Int, before: String, reverse: Boolean, assignmentStatus: FulfillmentOrderAssignmentStatus, locationIds: [ID!]): FulfillmentOrderConnection!
+	orders(first: Int, after: String, last: Int, before: String, reverse: Boolean, query: String): OrderConnection!
+	customer(id: ID!): Customer
+	customers(first: Int, after: String, last: Int, before: String, reverse: Boolean, query: String): CustomerConnection!
+	codeDiscountNode(id: ID!): DiscountCodeNode
+	codeDiscountNodeByCode(code: String!): DiscountCodeNode
+	automaticDiscountNode(id: ID!): DiscountAutomaticNode
+	discountRedeemCodeBulkCreation(id: ID!): DiscountRedeemCodeBulkCreation
+	shop: Shop!
+	webhookSubscription(id: ID!): WebhookSubscription
+	webhookSubscriptions(first: Int, after: String, last: Int, before: String, reverse: Boolean, topics: [WebhookSubscriptionTopic!], callbackUrl: URL, format: WebhookSubscriptionFormat): WebhookSubscriptionConnection!
+	currentBulkOperation(type: BulkOperationType): BulkOperation
+}
+
+type Mutation {
+	productCreate(input: ProductInput!, media: [CreateMediaInput!]): ProductCreatePayload
+	productUpdate(input: ProductInput!): ProductUpdatePayload
+	productDelete(input: ProductDeleteInput!): ProductDeletePayload
+	productVariantUpdate(input: ProductVariantInput!): ProductVariantUpdatePayload
+	collectionCreate(input: CollectionInput!): CollectionCreatePayload
+	collectionUpdate(input: CollectionInput!): CollectionUpdatePayload
+	collectionDelete(input: CollectionDeleteInput!): CollectionDeletePayload
+	orderUpdate(input: OrderInput!): OrderUpdatePayload
+	orderCapture(input: OrderCaptureInput!): OrderCapturePayload
+	orderMarkAsPaid(input: OrderMarkAsPaidInput!): OrderMarkAsPaidPayload
+	refundCreate(input: RefundInput!): RefundCreatePayload
+	returnRequest(input: ReturnRequestInput!): ReturnRequestPayload
+	returnCreate(returnInput: ReturnInput!): ReturnCreatePayload
+	returnApproveRequest(input: ReturnApproveRequestInput!): ReturnApproveRequestPayload
+	returnDeclineRequest(input: ReturnDeclineRequestInput!): ReturnDeclineRequestPayload
+	returnClose(id: ID!): ReturnClosePayload
+	returnReopen(id: ID!): ReturnReopenPayload
+	returnCancel(id: ID!, notifyCustomer: Boolean): ReturnCancelPayload
+	draftOrderCreate(input: DraftOrderInput!): DraftOrderCreatePayload
+	draftOrderUpdate(id: ID!, input: DraftOrderInput!): DraftOrderUpdatePayload
+	draftOrderCalculate(input: DraftOrderInput!): DraftOrderCalculatePayload
+	draftOrderComplete(id: ID!, paymentPending: Boolean): DraftOrderCompletePayload
+	draftOrderInvoiceSend(id: ID!, email: EmailInput): DraftOrderInvoiceSendPayload
+	draftOrderDelete(input: DraftOrderDeleteInput!): DraftOrderDeletePayload
+	draftOrderBulkAddTags(ids: [ID!], tags: [String!]!): DraftOrderBulkAddTagsPayload
+	draftOrderBulkRemoveTags(ids: [ID!], tags: [String!]!): DraftOrderBulkRemoveTagsPayload
+	orderEditBegin(id: ID!): OrderEditBeginPayload
+	orderEditAddVariant(id: ID!, variantId: ID!, quantity: Int!, locationId: ID, allowDuplicates: Boolean): OrderEditAddVariantPayload
+	orderEditAddCustomItem(id: ID!, title: String!, price: MoneyInput!, quantity: Int!, requiresShipping: Boolean, taxable: Boolean, locationId: ID): OrderEditAddCustomItemPayload
+	orderEditSetQuantity(id: ID!, lineItemId: ID!, quantity: Int!, restock: Boolean): OrderEditSetQuantityPayload
+	orderEditAddLineItemDiscount(id: ID!, lineItemId: ID!, discount: OrderEditAppliedDiscountInput!): OrderEditAddLineItemDiscountPayload
+	orderEditCommit(id: ID!, notifyCustomer: Boolean, staffNote: String): OrderEditCommitPayload
+	fulfillmentCreateV2(fulfillment: FulfillmentV2Input!): FulfillmentCreateV2Payload
+	fulfillmentTrackingInfoUpdateV2(fulfillmentId: ID!, trackingInfoInput: FulfillmentTrackingInput!, notifyCustomer: Boolean): FulfillmentTrackingInfoUpdateV2Payload
+	fulfillmentCancel(id: ID!): FulfillmentCancelPayload
+	fulfillmentOrderHold(id: ID!, fulfillmentHold: FulfillmentOrderHoldInput!): FulfillmentOrderHoldPayload
+	fulfillmentOrderReleaseHold(id: ID!): FulfillmentOrderReleaseHoldPayload
+	fulfillmentOrderMove(id: ID!, newLocationId: ID!): FulfillmentOrderMovePayload
+	fulfillmentOrderReschedule(id: ID!, fulfillAt: DateTime!): FulfillmentOrderReschedulePayload
+	fulfillmentOrderCancel(id: ID!): FulfillmentOrderCancelPayload
+	fulfillmentOrderClose(id: ID!, message: String): FulfillmentOrderClosePayload
+	fulfillmentOrderAcceptFulfillmentRequest(id: ID!, message: String): FulfillmentOrderAcceptFulfillmentRequestPayload
+	fulfillmentOrderRejectFulfillmentRequest(id: ID!, reason: FulfillmentOrderRejectionReason, message: String): FulfillmentOrderRejectFulfillmentRequestPayload
+	customerCreate(input: CustomerInput!): CustomerCreatePayload
+	customerUpdate(input: CustomerInput!): CustomerUpdatePayload
+	customerDelete(input: CustomerDeleteInput!): CustomerDeletePayload
+	customerUpdateDefaultAddress(customerId: ID!, addressId: ID!): CustomerUpdateDefaultAddressPayload
+	customerEmailMarketingConsentUpdate(input: CustomerEmailMarketingConsentUpdateInput!): CustomerEmailMarketingConsentUpdatePayload
+	customerSmsMarketingConsentUpdate(input: CustomerSmsMarketingConsentUpdateInput!): CustomerSmsMarketingConsentUpdatePayload
+	tagsAdd(id: ID!, tags: [String!]!): TagsAddPayload
+	tagsRemove(id: ID!, tags: [String!]!): TagsRemovePayload
+	discountCodeBasicCreate(basicCodeDiscount: DiscountCodeBasicInput!): DiscountCodeBasicCreatePayload
+	discountCodeBasicUpdate(id: ID!, basicCodeDiscount: DiscountCodeBasicInput!): DiscountCodeBasicUpdatePayload
+	discountCodeBxgyCreate(bxgyCodeDiscount: DiscountCodeBxgyInput!): DiscountCodeBxgyCreatePayload
+	discountCodeBxgyUpdate(id: ID!, bxgyCodeDiscount: DiscountCodeBxgyInput!): DiscountCodeBxgyUpdatePayload
+	discountCodeFreeShippingCreate(freeShippingCodeDiscount: DiscountCodeFreeShippingInput!): DiscountCodeFreeShippingCreatePayload
+	discountCodeFreeShippingUpdate(id: ID!, freeShippingCodeDiscount: DiscountCodeFreeShippingInput!): DiscountCodeFreeShippingUpdatePayload
+	discountCodeActivate(id: ID!): DiscountCodeActivatePayload
+	discountCodeDeactivate(id: ID!): DiscountCodeDeactivatePayload
+	discountCodeDelete(id: ID!): DiscountCodeDeletePayload
+	discountRedeemCodeBulkAdd(discountId: ID!, codes: [DiscountRedeemCodeInput!]!): DiscountRedeemCodeBulkAddPayload
+	discountAutomaticBasicCreate(automaticBasicDiscount: DiscountAutomaticBasicInput!): DiscountAutomaticBasicCreatePayload
+	discountAutomaticBasicUpdate(id: ID!, automaticBasicDiscount: DiscountAutomaticBasicInput!): DiscountAutomaticBasicUpdatePayload
+	discountAutomaticBxgyCreate(automaticBxgyDiscount: DiscountAutomaticBxgyInput!): DiscountAutomaticBxgyCreatePayload
+	discountAutomaticBxgyUpdate(id: ID!, automaticBxgyDiscount: DiscountAutomaticBxgyInput!): DiscountAutomaticBxgyUpdatePayload
+	discountAutomaticFreeShippingCreate(freeShippingAutomaticDiscount: DiscountAutomaticFreeShippingInput!): DiscountAutomaticFreeShippingCreatePayload
+	discountAutomaticFreeShippingUpdate(id: ID!, freeShippingAutomaticDiscount: DiscountAutomaticFreeShippingInput!): DiscountAutomaticFreeShippingUpdatePayload
+	discountAutomaticActivate(id: ID!): DiscountAutomaticActivatePayload
+	discountAutomaticDeactivate(id: ID!): DiscountAutomaticDeactivatePayload
+	discountAutomaticDelete(id: ID!): DiscountAutomaticDeletePayload
+	locationAdd(input: LocationAddInput!): LocationAddPayload
+	locationEdit(id: ID!, input: LocationEditInput!): LocationEditPayload
+	locationActivate(locationId: ID!): LocationActivatePayload
+	locationDeactivate(locationId: ID!, destinationLocationId: ID): LocationDeactivatePayload
+	inventoryActivate(inventoryItemId: ID!, locationId: ID!, available: Int): InventoryActivatePayload
+	inventorySetOnHandQuantities(input: InventorySetOnHandQuantitiesInput!): InventorySetOnHandQuantitiesPayload
+	inventoryAdjustQuantities(input: InventoryAdjustQuantitiesInput!): InventoryAdjustQuantitiesPayload
+	inventoryMoveQuantities(input: InventoryMoveQuantitiesInput!): InventoryMoveQuantitiesPayload
+	metafieldDelete(input: MetafieldDeleteInput!): MetafieldDeletePayload
+	webhookSubscriptionCreate(topic: WebhookSubscriptionTopic!, webhookSubscription: WebhookSubscriptionInput!): WebhookSubscriptionCreatePayload
+	eventBridgeWebhookSubscriptionCreate(topic: WebhookSubscriptionTopic!, webhookSubscription: EventBridgeWebhookSubscriptionInput!): EventBridgeWebhookSubscriptionCreatePayload
+	webhookSubscriptionUpdate(id: ID!, webhookSubscription: WebhookSubscriptionInput!): WebhookSubscriptionUpdatePayload
+	eventBridgeWebhookSubscriptionUpdate(id: ID!, webhookSubscription: EventBridgeWebhookSubscriptionInput!): EventBridgeWebhookSubscriptionUpdatePayload
+	pubSubWebhookSubscriptionCreate(topic: WebhookSubscriptionTopic!, webhookSubscription: PubSubWebhookSubscriptionInput!): PubSubWebhookSubscriptionCreatePayload
+	pubSubWebhookSubscriptionUpdate(id: ID!, webhookSubscription: PubSubWebhookSubscriptionInput!): PubSubWebhookSubscriptionUpdatePayload
+	webhookSubscriptionDelete(id: ID!): WebhookSubscriptionDeletePayload
+	bulkOperationRunQuery(query: String!): BulkOperationRunQueryPayload
+	bulkOperationRunMutation(mutation: String!, stagedUploadPath: String!, clientIdentifier: String): BulkOperationRunMutationPayload
+	bulkOperationCancel(id: ID!): BulkOperationCancelPayload
+	stagedUploadsCreate(input: [StagedUploadInput!]!): StagedUploadsCreatePayload
+}
+
+type UserError {
+	field: [String!]
+	message: String!
+}
+
+type PageInfo {
+	hasNextPage: Boolean!
+	hasPreviousPage: Boolean!
+	startCursor: String
+	endCursor: String
+}
+
+enum CurrencyCode { USD EUR GBP CAD AUD JPY VND }
+
+type MoneyV2 {
+	amount: Decimal!
+	currencyCode: CurrencyCode!
+}
+
+type MoneyBag {
+	presentmentMoney: MoneyV2!
+	shopMoney: MoneyV2!
+}
+
+type SEO {
+	title: String
+	description: String
+}
+
+type Image {
+	id: ID
+	altText: String
+	height: Int
+	width: Int
+	src: URL!
+	url: URL!
+}
+
+type Shop {
+	id: ID!
+	name: String!
+	currencyCode: CurrencyCode!
+	metafield(namespace: String!, key: String!): Metafield
+	metafields(first: Int, after: String, last: Int, before: String, reverse: Boolean, namespace: String): MetafieldConnection!
+}
+
+enum MetafieldOwnerType { SHOP PRODUCT PRODUCTVARIANT COLLECTION ORDER CUSTOMER }
+
+type Metafield implements Node {
+	id: ID!
+	legacyResourceId: UnsignedInt64!
+	namespace: String!
+	key: String!
+	value: String!
+	type: String!
+	description: String
+	ownerType: MetafieldOwnerType!
+	createdAt: DateTime!
+	updatedAt: DateTime!
+}
+
+type MetafieldConnection {
+	edges: [MetafieldEdge!]!
+	pageInfo: PageInfo!
+}
+
+type MetafieldEdge {
+	cursor: String!
+	node: Metafield!
+}
+
+enum ProductStatus { ACTIVE ARCHIVED DRAFT }
+
+type ProductOption {
+	id: ID!
+	name: String!
+	position: Int!
+	values: [String!]!
+}
+
+type ProductPriceRangeV2 {
+	minVariantPrice: MoneyV2!
+	maxVariantPrice: MoneyV2!
+}
+
+type Product implements Node {
+	id: ID!
+	legacyResourceId: UnsignedInt64!
+	handle: String!
+	title: String!
+	status: ProductStatus!
+	description(truncateAt: Int): String!
+	descriptionHtml: HTML!
+	productType: String!
+	vendor: String!
+	tags: [String!]!
+	templateSuffix: String
+	tracksInventory: Boolean!
+	totalInventory: Int!
+	onlineStoreUrl: URL
+	publishedAt: DateTime
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	seo: SEO!
+	options(first: Int): [ProductOption!]!
+	priceRangeV2: ProductPriceRangeV2!
+	variants(first: Int, after: String, last: Int, before: String, reverse: Boolean): ProductVariantConnection!
+	collections(first: Int, after: String, last: Int, before: String, reverse: Boolean, query: String): CollectionConnection!
+	images(first: Int, after: String, last: Int, before: String, reverse: Boolean): ImageConnection!
+	media(first: Int, after: String, last: Int, before: String, reverse: Boolean): MediaConnection!
+	metafield(namespace: String!, key: String!): Metafield
+	metafields(first: Int, after: String, last: Int, before: String, reverse: Boolean, namespace: String): MetafieldConnection!
+}
+
+type ProductConnection {
+	edges: [ProductEdge!]!
+	pageInfo: PageInfo!
+}
+
+type ProductEdge {
+	cursor: String!
+	node: Product!
+}
+
+type ImageConnection {
+	edges: [ImageEdge!]!
+	pageInfo: PageInfo!
+}
+
+type ImageEdge {
+	cursor: String!
+	node: Image!
+}
+
+enum MediaContentType { IMAGE VIDEO MODEL_3D EXTERNAL_VIDEO }
+
+type MediaPreviewImage {
+	image: Image
+}
+
+interface Media {
+	alt: String
+	mediaContentType: MediaContentType!
+	preview: MediaPreviewImage
+}
+
+type MediaImage implements Media {
+	id: ID!
+	alt: String
+	mediaContentType: MediaContentType!
+	preview: MediaPreviewImage
+	mimeType: String
+	image: Image
+}
+
+type VideoSource {
+	url: String!
+	format: String!
+	mimeType: String!
+	height: Int!
+	width: Int!
+}
+
+type Video implements Media {
+	id: ID!
+	alt: String
+	mediaContentType: MediaContentType!
+	preview: MediaPreviewImage
+	duration: Int
+	originalSource: VideoSource
+}
+
+type Model3dSource {
+	url: String!
+	format: String!
+	filesize: Int!
+	mimeType: String!
+}
+
+type Model3d implements Media {
+	id: ID!
+	alt: String
+	mediaContentType: MediaContentType!
+	preview: MediaPreviewImage
+	originalSource: Model3dSource
+}
+
+type ExternalVideo implements Media {
+	id: ID!
+	alt: String
+	mediaContentType: MediaContentType!
+	preview: MediaPreviewImage
+	originUrl: URL!
+	embedUrl: URL!
+}
+
+type MediaConnection {
+	edges: [MediaEdge!]!
+	pageInfo: PageInfo!
+}
+
+type MediaEdge {
+	cursor: String!
+	node: Media!
+}
+
+enum ProductVariantInventoryPolicy { DENY CONTINUE }
+enum ProductVariantInventoryManagement { SHOPIFY NOT_MANAGED FULFILLMENT_SERVICE }
+enum WeightUnit { KILOGRAMS GRAMS POUNDS OUNCES }
+
+type SelectedOption {
+	name: String!
+	value: String!
+}
+
+type ProductVariant implements Node {
+	id: ID!
+	legacyResourceId: UnsignedInt64!
+	title: String!
+	displayName: String!
+	sku: String
+	barcode: String
+	price: Money!
+	compareAtPrice: Money
+	position: Int!
+	inventoryQuantity: Int
+	inventoryPolicy: ProductVariantInventoryPolicy!
+	inventoryManagement: ProductVariantInventoryManagement!
+	weight: Float
+	weightUnit: WeightUnit!
+	selectedOptions: [SelectedOption!]!
+	image: Image
+	inventoryItem: InventoryItem!
+	product: Product!
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	metafield(namespace: String!, key: String!): Metafield
+	metafields(first: Int, after: String, last: Int, before: String, reverse: Boolean, namespace: String): MetafieldConnection!
+}
+
+type ProductVariantConnection {
+	edges: [ProductVariantEdge!]!
+	pageInfo: PageInfo!
+}
+
+type ProductVariantEdge {
+	cursor: String!
+	node: ProductVariant!
+}
+
+type LocationAddress {
+	address1: String
+	address2: String
+	city: String
+	country: String
+	countryCode: String
+	province: String
+	provinceCode: String
+	zip: String
+	phone: String
+	formatted: [String!]!
+}
+
+type Location implements Node {
+	id: ID!
+	legacyResourceId: UnsignedInt64!
+	name: String!
+	address: LocationAddress!
+	fulfillsOnlineOrders: Boolean!
+	isActive: Boolean!
+	shipsInventory: Boolean!
+	isFulfillmentService: Boolean!
+	hasActiveInventory: Boolean!
+	activatable: Boolean!
+	deactivatable: Boolean!
+	deactivatedAt: String
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	inventoryLevel(inventoryItemId: ID!): InventoryLevel
+	inventoryLevels(first: Int, after: String, last: Int, before: String, reverse: Boolean): InventoryLevelConnection!
+}
+
+type LocationConnection {
+	edges: [LocationEdge!]!
+	pageInfo: PageInfo!
+}
+
+type LocationEdge {
+	cursor: String!
+	node: Location!
+}
+
+type InventoryItem implements Node {
+	id: ID!
+	legacyResourceId: UnsignedInt64!
+	sku: String
+	tracked: Boolean!
+	requiresShipping: Boolean!
+	variant: ProductVariant!
+	inventoryLevel(locationId: ID!): InventoryLevel
+	inventoryLevels(first: Int, after: String, last: Int, before: String, reverse: Boolean): InventoryLevelConnection!
+	createdAt: DateTime!
+	updatedAt: DateTime!
+}
+
+type InventoryQuantity {
+	name: String!
+	quantity: Int!
+	updatedAt: DateTime
+}
+
+type InventoryLevel implements Node {
+	id: ID!
+	available: Int!
+	quantities(names: [String!]!): [InventoryQuantity!]!
+	item: InventoryItem!
+	location: Location!
+	createdAt: DateTime!
+	updatedAt: DateTime!
+}
+
+type InventoryLevelConnection {
+	edges: [InventoryLevelEdge!]!
+	pageInfo: PageInfo!
+}
+
+type InventoryLevelEdge {
+	cursor: String!
+	node: InventoryLevel!
+}
+
+type InventoryChange {
+	name: String!
+	delta: Int!
+	quantityAfterChange: Int
+	ledgerDocumentUri: String
+	item: InventoryItem
+	location: Location
+}
+
+type InventoryAdjustmentGroup implements Node {
+	id: ID!
+	createdAt: DateTime!
+	reason: String!
+	referenceDocumentUri: String
+	changes: [InventoryChange!]!
+}
+
+type Collection implements Node {
+	id: ID!
+	legacyResourceId: UnsignedInt64!
+	handle: String!
+	title: String!
+	description(truncateAt: Int): String!
+	descriptionHtml: HTML!
+	templateSuffix: String
+	productsCount: Int!
+	seo: SEO!
+	image: Image
+	updatedAt: DateTime!
+	products(first: Int, after: String, last: Int, before: String, reverse: Boolean): ProductConnection!
+	metafield(namespace: String!, key: String!): Metafield
+	metafields(first: Int, after: String, last: Int, before: String, reverse: Boolean, namespace: String): MetafieldConnection!
+}
+
+type CollectionConnection {
+	edges: [CollectionEdge!]!
+	pageInfo: PageInfo!
+}
+
+type CollectionEdge {
+	cursor: String!
+	node: Collection!
+}
+
+enum CountryCode { AU CA DE ES FR GB IT JP NL US VN }
+
+type MailingAddress {
+	id: ID!
+	firstName: String
+	lastName: String
+	name: String
+	company: String
+	address1: String
+	address2: String
+	city: String
+	province: String
+	provinceCode: String
+	country: String
+	countryCodeV2: CountryCode
+	zip: String
+	phone: String
+}
+
+enum CustomerState { DECLINED DISABLED ENABLED INVITED }
+enum CustomerEmailMarketingState { INVALID NOT_SUBSCRIBED PENDING REDACTED SUBSCRIBED UNSUBSCRIBED }
+enum CustomerSmsMarketingState { NOT_SUBSCRIBED PENDING REDACTED SUBSCRIBED UNSUBSCRIBED }
+enum CustomerMarketingOptInLevel { CONFIRMED_OPT_IN SINGLE_OPT_IN UNKNOWN }
+enum CustomerConsentCollectedFrom { OTHER SHOPIFY }
+
+type CustomerEmailMarketingConsentState {
+	marketingState: CustomerEmailMarketingState!
+	marketingOptInLevel: CustomerMarketingOptInLevel
+	consentUpdatedAt: DateTime
+}
+
+type CustomerSmsMarketingConsentState {
+	marketingState: CustomerSmsMarketingState!
+	marketingOptInLevel: CustomerMarketingOptInLevel!
+	consentUpdatedAt: DateTime
+	consentCollectedFrom: CustomerConsentCollectedFrom
+}
+
+type Customer implements Node {
+	id: ID!
+	legacyResourceId: UnsignedInt64!
+	firstName: String
+	lastName: String
+	displayName: String!
+	email: String
+	phone: String
+	note: String
+	tags: [String!]!
+	state: CustomerState!
+	verifiedEmail: Boolean!
+	taxExempt: Boolean!
+	locale: String!
+	numberOfOrders: UnsignedInt64!
+	amountSpent: MoneyV2!
+	defaultAddress: MailingAddress
+	addresses(first: Int): [MailingAddress!]!
+	emailMarketingConsent: CustomerEmailMarketingConsentState
+	smsMarketingConsent: CustomerSmsMarketingConsentState
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	metafield(namespace: String!, key: String!): Metafield
+	metafields(first: Int, after: String, last: Int, before: String, reverse: Boolean, namespace: String): MetafieldConnection!
+}
+
+type CustomerConnection {
+	edges: [CustomerEdge!]!
+	pageInfo: PageInfo!
+}
+
+type CustomerEdge {
+	cursor: String!
+	node: Customer!
+}
+
+type ShippingLine {
+	title: String!
+	originalPriceSet: MoneyBag!
+}
+
+type TaxLine {
+	title: String!
+	rate: Float
+	ratePercentage: Float
+	priceSet: MoneyBag!
+}
+
+enum OrderTransactionKind { AUTHORIZATION CAPTURE CHANGE EMV_AUTHORIZATION REFUND SALE SUGGESTED_REFUND VOID }
+enum OrderTransactionStatus { AWAITING_RESPONSE ERROR FAILURE PENDING SUCCESS UNKNOWN }
+
+type OrderTransaction {
+	id: ID!
+	kind: OrderTransactionKind!
+	status: OrderTransactionStatus!
+	gateway: String
+	test: Boolean!
+	processedAt: DateTime
+	amountSet: MoneyBag!
+	parentTransaction: OrderTransaction
+}
+
+type OrderTransactionConnection {
+	edges: [OrderTransactionEdge!]!
+	pageInfo: PageInfo!
+}
+
+type OrderTransactionEdge {
+	cursor: String!
+	node: OrderTransaction!
+}
+
+enum RefundLineItemRestockType { CANCEL LEGACY_RESTOCK NO_RESTOCK RETURN }
+
+type RefundLineItem {
+	id: ID!
+	lineItem: LineItem!
+	quantity: Int!
+	restockType: RefundLineItemRestockType!
+	subtotalSet: MoneyBag!
+	location: Location
+}
+
+type RefundLineItemConnection {
+	edges: [RefundLineItemEdge!]!
+	pageInfo: PageInfo!
+}
+
+type RefundLineItemEdge {
+	cursor: String!
+	node: RefundLineItem!
+}
+
+type Refund implements Node {
+	id: ID!
+	note: String
+	createdAt: DateTime
+	order: Order!
+	totalRefundedSet: MoneyBag!
+	refundLineItems(first: Int, after: String, last: Int, before: String, reverse: Boolean): RefundLineItemConnection!
+	transactions(first: Int, after: String, last: Int, before: String, reverse: Boolean): OrderTransactionConnection!
+}
+
+enum SuggestedOrderTransactionKind { SUGGESTED_REFUND }
+
+type SuggestedOrderTransaction {
+	kind: SuggestedOrderTransactionKind!
+	gateway: String
+	amountSet: MoneyBag!
+	maximumRefundableSet: MoneyBag!
+	parentTransaction: OrderTransaction
+}
+
+type ShippingRefund {
+	amountSet: MoneyBag!
+	maximumRefundableSet: MoneyBag!
+}
+
+type SuggestedRefund {
+	amountSet: MoneyBag!
+	subtotalSet: MoneyBag!
+	totalTaxSet: MoneyBag!
+	maximumRefundableSet: MoneyBag!
+	refundLineItems: [RefundLineItem!]!
+	shipping: ShippingRefund!
+	suggestedTransactions: [SuggestedOrderTransaction!]!
+}
+
+type LineItem {
+	id: ID!
+	sku: String
+	title: String!
+	variantTitle: String
+	vendor: String
+	quantity: Int!
+	fulfillableQuantity: Int!
+	fulfillmentStatus: String!
+	product: Product
+	variant: ProductVariant
+	originalUnitPriceSet: MoneyBag!
+	originalTotalSet: MoneyBag!
+	discountedUnitPriceSet: MoneyBag!
+	discountedTotalSet: MoneyBag!
+}
+
+type LineItemConnection {
+	edges: [LineItemEdge!]!
+	pageInfo: PageInfo!
+}
+
+type LineItemEdge {
+	cursor: String!
+	node: LineItem!
+}
+
+enum FulfillmentOrderStatus { CANCELLED CLOSED INCOMPLETE IN_PROGRESS ON_HOLD OPEN SCHEDULED }
+
+enum FulfillmentOrderRequestStatus { UNSUBMITTED SUBMITTED ACCEPTED REJECTED CANCELLATION_REQUESTED CANCELLATION_ACCEPTED CANCELLATION_REJECTED CLOSED }
+
+enum FulfillmentOrderAction { CREATE_FULFILLMENT REQUEST_FULFILLMENT CANCEL_FULFILLMENT_ORDER REQUEST_CANCELLATION MOVE HOLD RELEASE_HOLD MARK_AS_OPEN EXTERNAL }
+
+enum FulfillmentOrderAssignmentStatus { FULFILLMENT_REQUESTED FULFILLMENT_ACCEPTED CANCELLATION_REQUESTED }
+
+enum FulfillmentOrderRejectionReason { INCORRECT_ADDRESS INELIGIBLE_PRODUCT INVENTORY_OUT_OF_STOCK UNDELIVERABLE_DESTINATION OTHER }
+
+enum FulfillmentHoldReason { AWAITING_PAYMENT HIGH_RISK_OF_FRAUD INCORRECT_ADDRESS INVENTORY_OUT_OF_STOCK UNKNOWN_DELIVERY_DATE AWAITING_RETURN_ITEMS OTHER }
+
+enum FulfillmentStatus { SUCCESS CANCELLED ERROR FAILURE }
+
+type FulfillmentOrderLineItem {
+	id: ID!
+	totalQuantity: Int!
+	remainingQuantity: Int!
+	lineItem: LineItem!
+}
+
+type FulfillmentOrderLineItemConnection {
+	edges: [FulfillmentOrderLineItemEdge!]!
+	pageInfo: PageInfo!
+}
+
+type FulfillmentOrderLineItemEdge {
+	cursor: String!
+	node: FulfillmentOrderLineItem!
+}
+
+type FulfillmentOrderAssignedLocation {
+	name: String!
+	location: Location
+}
+
+type FulfillmentHold {
+	reason: FulfillmentHoldReason!
+	reasonNotes: String
+}
+
+type FulfillmentOrderSupportedAction {
+	action: FulfillmentOrderAction!
+}
+
+type FulfillmentOrder implements Node {
+	id: ID!
+	orderId: ID!
+	status: FulfillmentOrderStatus!
+	requestStatus: FulfillmentOrderRequestStatus!
+	fulfillAt: DateTime
+	assignedLocation: FulfillmentOrderAssignedLocation!
+	fulfillmentHolds: [FulfillmentHold!]!
+	supportedActions: [FulfillmentOrderSupportedAction!]!
+	lineItems(first: Int, after: String, last: Int, before: String, reverse: Boolean): FulfillmentOrderLineItemConnection!
+	createdAt: DateTime!
+	updatedAt: DateTime!
+}
+
+type FulfillmentOrderConnection {
+	edges: [FulfillmentOrderEdge!]!
+	pageInfo: PageInfo!
+}
+
+type FulfillmentOrderEdge {
+	cursor: String!
+	node: FulfillmentOrder!
+}
+
+type FulfillmentTrackingInfo {
+	company: String
+	number: String
+	url: URL
+}
+
+type FulfillmentLineItem {
+	id: ID!
+	quantity: Int!
+	lineItem: LineItem!
+}
+
+type FulfillmentLineItemConnection {
+	edges: [FulfillmentLineItemEdge!]!
+	pageInfo: PageInfo!
+}
+
+type FulfillmentLineItemEdge {
+	cursor: String!
+	node: FulfillmentLineItem!
+}
+
+type Fulfillment implements Node {
+	id: ID!
+	name: String!
+	status: FulfillmentStatus!
+	trackingInfo(first: Int): [FulfillmentTrackingInfo!]!
+	fulfillmentLineItems(first: Int, after: String, last: Int, before: String, reverse: Boolean): FulfillmentLineItemConnection!
+	createdAt: DateTime!
+	updatedAt: DateTime!
+}
+
+type Order implements Node {
+	id: ID!
+	legacyResourceId: UnsignedInt64!
+	name: String!
+	email: String
+	note: String
+	tags: [String!]!
+	clientIp: String
+	closed: Boolean!
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	displayFinancialStatus: String
+	displayFulfillmentStatus: String!
+	customer: Customer
+	shippingAddress: MailingAddress
+	shippingLine: ShippingLine
+	taxLines: [TaxLine!]!
+	totalPriceSet: MoneyBag!
+	totalReceivedSet: MoneyBag!
+	transactions(first: Int): [OrderTransaction!]!
+	lineItems(first: Int, after: String, last: Int, before: String, reverse: Boolean): LineItemConnection!
+	fulfillmentOrders(first: Int, after: String, last: Int, before: String, reverse: Boolean, query: String): FulfillmentOrderConnection!
+	fulfillments(first: Int): [Fulfillment!]!
+	refunds(first: Int): [Refund!]!
+	returns(first: Int, after: String, last: Int, before: String, reverse: Boolean): ReturnConnection!
+	suggestedRefund(refundLineItems: [RefundLineItemInput!], refundShipping: Boolean, shippingAmount: Money, suggestFullRefund: Boolean): SuggestedRefund!
+	metafield(namespace: String!, key: String!): Metafield
+	metafields(first: Int, after: String, last: Int, before: String, reverse: Boolean, namespace: String): MetafieldConnection!
+}
+
+enum ReturnStatus { CANCELED CLOSED DECLINED OPEN REQUESTED }
+
+enum ReturnReason { COLOR DEFECTIVE NOT_AS_DESCRIBED OTHER SIZE_TOO_LARGE SIZE_TOO_SMALL STYLE UNKNOWN UNWANTED WRONG_ITEM }
+
+enum ReturnDeclineReason { FINAL_SALE OTHER RETURN_PERIOD_ENDED }
+
+type ReturnDecline {
+	reason: ReturnDeclineReason!
+	note: String
+}
+
+type ReturnLineItem {
+	id: ID!
+	quantity: Int!
+	returnReason: ReturnReason!
+	returnReasonNote: String!
+	customerNote: String
+	fulfillmentLineItem: FulfillmentLineItem!
+}
+
+type ReturnLineItemConnection {
+	edges: [ReturnLineItemEdge!]!
+	pageInfo: PageInfo!
+}
+
+type ReturnLineItemEdge {
+	cursor: String!
+	node: ReturnLineItem!
+}
+
+type Return implements Node {
+	id: ID!
+	name: String!
+	status: ReturnStatus!
+	totalQuantity: Int!
+	order: Order!
+	returnLineItems(first: Int, after: String, last: Int, before: String, reverse: Boolean): ReturnLineItemConnection!
+	decline: ReturnDecline
+}
+
+type ReturnConnection {
+	edges: [ReturnEdge!]!
+	pageInfo: PageInfo!
+}
+
+type ReturnEdge {
+	cursor: String!
+	node: Return!
+}
+
+type CalculatedLineItem {
+	id: ID!
+	title: String!
+	sku: String
+	variantTitle: String
+	variant: ProductVariant
+	quantity: Int!
+	editableQuantity: Int!
+	restockable: Boolean!
+	restocking: Boolean!
+	hasStagedLineItemDiscount: Boolean!
+	originalUnitPriceSet: MoneyBag!
+	discountedUnitPriceSet: MoneyBag!
+	editableSubtotalSet: MoneyBag!
+}
+
+type CalculatedLineItemConnection {
+	edges: [CalculatedLineItemEdge!]!
+	pageInfo: PageInfo!
+}
+
+type CalculatedLineItemEdge {
+	cursor: String!
+	node: CalculatedLineItem!
+}
+
+type CalculatedOrder {
+	id: ID!
+	originalOrder: Order!
+	lineItems(first: Int, after: String, last: Int, before: String, reverse: Boolean): CalculatedLineItemConnection!
+	subtotalLineItemsQuantity: Int!
+	subtotalPriceSet: MoneyBag!
+	totalPriceSet: MoneyBag!
+	totalOutstandingSet: MoneyBag!
+	committed: Boolean!
+}
+
+type OrderConnection {
+	edges: [OrderEdge!]!
+	pageInfo: PageInfo!
+}
+
+type OrderEdge {
+	cursor: String!
+	node: Order!
+}
+
+enum DiscountStatus { ACTIVE EXPIRED SCHEDULED }
+
+type DiscountCombinesWith {
+	orderDiscounts: Boolean!
+	productDiscounts: Boolean!
+	shippingDiscounts: Boolean!
+}
+
+type DiscountRedeemCode {
+	id: ID!
+	code: String!
+	asyncUsageCount: Int!
+}
+
+type DiscountRedeemCodeConnection {
+	edges: [DiscountRedeemCodeEdge!]!
+	pageInfo: PageInfo!
+}
+
+type DiscountRedeemCodeEdge {
+	cursor: String!
+	node: DiscountRedeemCode!
+}
+
+type AllDiscountItems {
+	allItems: Boolean!
+}
+
+type DiscountProducts {
+	products(first: Int, after: String, last: Int, before: String, reverse: Boolean): ProductConnection!
+	productVariants(first: Int, after: String, last: Int, before: String, reverse: Boolean): ProductVariantConnection!
+}
+
+type DiscountCollections {
+	collections(first: Int, after: String, last: Int, before: String, reverse: Boolean): CollectionConnection!
+}
+
+union DiscountItems = AllDiscountItems | DiscountProducts | DiscountCollections
+
+type DiscountPercentage {
+	percentage: Float!
+}
+
+type DiscountAmount {
+	amount: MoneyV2!
+	appliesOnEachItem: Boolean!
+}
+
+type DiscountQuantity {
+	quantity: UnsignedInt64!
+}
+
+union DiscountEffect = DiscountPercentage
+
+type DiscountOnQuantity {
+	quantity: DiscountQuantity!
+	effect: DiscountEffect!
+}
+
+union DiscountCustomerGetsValue = DiscountAmount | DiscountOnQuantity | DiscountPercentage
+
+type DiscountCustomerGets {
+	value: DiscountCustomerGetsValue!
+	items: DiscountItems!
+	appliesOnOneTimePurchase: Boolean!
+	appliesOnSubscription: Boolean!
+}
+
+type DiscountPurchaseAmount {
+	amount: Decimal!
+}
+
+union DiscountCustomerBuysValue = DiscountPurchaseAmount | DiscountQuantity
+
+type DiscountCustomerBuys {
+	value: DiscountCustomerBuysValue!
+	items: DiscountItems!
+}
+
+type DiscountMinimumQuantity {
+	greaterThanOrEqualToQuantity: UnsignedInt64!
+}
+
+type DiscountMinimumSubtotal {
+	greaterThanOrEqualToSubtotal: MoneyV2!
+}
+
+union DiscountMinimumRequirement = DiscountMinimumQuantity | DiscountMinimumSubtotal
+
+type DiscountCountryAll {
+	allCountries: Boolean!
+}
+
+type DiscountCountries {
+	countries: [CountryCode!]!
+	includeRestOfWorld: Boolean!
+}
+
+union DiscountShippingDestinationSelection = DiscountCountryAll | DiscountCountries
+
+type DiscountCodeBasic {
+	title: String!
+	status: DiscountStatus!
+	summary: String!
+	startsAt: DateTime!
+	endsAt: DateTime
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	asyncUsageCount: Int!
+	combinesWith: DiscountCombinesWith!
+	usageLimit: Int
+	appliesOncePerCustomer: Boolean!
+	codes(first: Int, after: String, last: Int, before: String, reverse: Boolean): DiscountRedeemCodeConnection!
+	customerGets: DiscountCustomerGets!
+	minimumRequirement: DiscountMinimumRequirement
+}
+
+type DiscountCodeBxgy {
+	title: String!
+	status: DiscountStatus!
+	summary: String!
+	startsAt: DateTime!
+	endsAt: DateTime
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	asyncUsageCount: Int!
+	combinesWith: DiscountCombinesWith!
+	usageLimit: Int
+	appliesOncePerCustomer: Boolean!
+	codes(first: Int, after: String, last: Int, before: String, reverse: Boolean): DiscountRedeemCodeConnection!
+	customerBuys: DiscountCustomerBuys!
+	customerGets: DiscountCustomerGets!
+	usesPerOrderLimit: Int
+}
+
+type DiscountCodeFreeShipping {
+	title: String!
+	status: DiscountStatus!
+	summary: String!
+	startsAt: DateTime!
+	endsAt: DateTime
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	asyncUsageCount: Int!
+	combinesWith: DiscountCombinesWith!
+	usageLimit: Int
+	appliesOncePerCustomer: Boolean!
+	codes(first: Int, after: String, last: Int, before: String, reverse: Boolean): DiscountRedeemCodeConnection!
+	minimumRequirement: DiscountMinimumRequirement
+	destinationSelection: DiscountShippingDestinationSelection!
+	maximumShippingPrice: MoneyV2
+}
+
+union DiscountCode = DiscountCodeBasic | DiscountCodeBxgy | DiscountCodeFreeShipping
+
+type DiscountCodeNode implements Node {
+	id: ID!
+	codeDiscount: DiscountCode!
+}
+
+type DiscountAutomaticBasic {
+	title: String!
+	status: DiscountStatus!
+	summary: String!
+	startsAt: DateTime!
+	endsAt: DateTime
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	asyncUsageCount: Int!
+	combinesWith: DiscountCombinesWith!
+	customerGets: DiscountCustomerGets!
+	minimumRequirement: DiscountMinimumRequirement
+}
+
+type DiscountAutomaticBxgy {
+	title: String!
+	status: DiscountStatus!
+	summary: String!
+	startsAt: DateTime!
+	endsAt: DateTime
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	asyncUsageCount: Int!
+	combinesWith: DiscountCombinesWith!
+	customerBuys: DiscountCustomerBuys!
+	customerGets: DiscountCustomerGets!
+	usesPerOrderLimit: Int
+}
+
+type DiscountAutomaticFreeShipping {
+	title: String!
+	status: DiscountStatus!
+	summary: String!
+	startsAt: DateTime!
+	endsAt: DateTime
+	createdAt: DateTime!
+	updatedAt: DateTime!
+	asyncUsageCount: Int!
+	combinesWith: DiscountCombinesWith!
+	minimumRequirement: DiscountMinimumRequirement
+	destinationSelection: DiscountShippingDestinationSelection!
+	maximumShippingPrice: MoneyV2
+}
+
+union DiscountAutomatic = DiscountAutomaticBasic | DiscountAutomaticBxgy | DiscountAutomaticFreeShipping
+
+type DiscountAutomaticNode implements Node {
+	id: ID!
+	automaticDiscount: DiscountAutomatic!
+}
+
+type DiscountRedeemCodeBulkCreationCode {
+	code: String!
+	errors: [UserError!]!
+}
+
+type DiscountRedeemCodeBulkCreationCodeConnection {
+	edges: [DiscountRedeemCodeBulkCreationCodeEdge!]!
+	pageInfo: PageInfo!
+}
+
+type DiscountRedeemCodeBulkCreationCodeEdge {
+	cursor: String!
+	node: DiscountRedeemCodeBulkCreationCode!
+}
+
+type DiscountRedeemCodeBulkCreation implements Node {
+	id: ID!
+	done: Boolean!
+	codesCount: Int!
+	importedCount: Int!
+	failedCount: Int!
+	codes(first: Int, after: String, last: Int, before: String, reverse: Boolean): DiscountRedeemCodeBulkCreationCodeConnection!
+}
+
+enum WebhookSubscriptionTopic {
+	APP_UNINSTALLED
+	BULK_OPERATIONS_FINISH
+	CARTS_CREATE
+	CARTS_UPDATE
+	CHECKOUTS_UPDATE
+	COLLECTIONS_CREATE
+	COLLECTIONS_DELETE
+	COLLECTIONS_UPDATE
+	CUSTOMERS_CREATE
+	CUSTOMERS_DELETE
+	CUSTOMERS_UPDATE
+	CUSTOMER_GROUPS_CREATE
+	CUSTOMER_GROUPS_UPDATE
+	FULFILLMENTS_CREATE
+	FULFILLMENTS_UPDATE
+	INVENTORY_LEVELS_UPDATE
+	ORDERS_CANCELLED
+	ORDERS_CREATE
+	ORDERS_DELETE
+	ORDERS_FULFILLED
+	ORDERS_PAID
+	ORDERS_UPDATED
+	PRODUCTS_CREATE
+	PRODUCTS_DELETE
+	PRODUCTS_UPDATE
+	SHOP_UPDATE
+	THEMES_CREATE
+	THEMES_PUBLISH
+	THEMES_UPDATE
+}
+
+enum WebhookSubscriptionFormat { JSON XML }
+
+type WebhookHttpEndpoint {
+	callbackUrl: URL!
+}
+
+type WebhookEventBridgeEndpoint {
+	arn: String!
+}
+
+type WebhookPubSubEndpoint {
+	pubSubProject: String!
+	pubSubTopic: String!
+}
+
+union WebhookSubscriptionEndpoint = WebhookHttpEndpoint | WebhookEventBridgeEndpoint | WebhookPubSubEndpoint
+
+type WebhookSubscription implements Node {
+	id: ID!
+	legacyResourceId: UnsignedInt64!
+	topic: WebhookSubscriptionTopic!
+	endpoint: WebhookSubscriptionEndpoint!
+	callbackUrl: URL!
+	format: WebhookSubscriptionFormat!
+	includeFields: [String!]!
+	metafieldNamespaces: [String!]!
+	createdAt: DateTime!
+	updatedAt: DateTime!
+}
+
+type WebhookSubscriptionConnection {
+	edges: [WebhookSubscriptionEdge!]!
+	pageInfo: PageInfo!
+}
+
+type WebhookSubscriptionEdge {
+	cursor: String!
+	node: WebhookSubscription!
+}
+
+enum BulkOperationStatus { CANCELED CANCELING COMPLETED CREATED EXPIRED FAILED RUNNING }
+enum BulkOperationErrorCode { ACCESS_DENIED INTERNAL_SERVER_ERROR TIMEOUT }
+enum BulkOperationType { MUTATION QUERY }
+
+type BulkOperation implements Node {
+	id: ID!
+	status: BulkOperationStatus!
+	errorCode: BulkOperationErrorCode
+	type: BulkOperationType!
+	query: String!
+	createdAt: DateTime!
+	completedAt: DateTime
+	objectCount: UnsignedInt64!
+	rootObjectCount: UnsignedInt64!
+	fileSize: UnsignedInt64
+	url: URL
+	partialDataUrl: URL
+}
+
+input SEOInput {
+	title: String
+	description: String
+}
+
+input MetafieldInput {
+	id: ID
+	namespace: String
+	key: String
+	value: String
+	type: String
+	description: String
+}
+
+input SelectedOptionInput {
+	name: String!
+	value: String!
+}
+
+input ProductVariantInput {
+	id: ID
+	title: String
+	sku: String
+	barcode: String
+	price: Money
+	compareAtPrice: Money
+	position: Int
+	inventoryPolicy: ProductVariantInventoryPolicy
+	weight: Float
+	weightUnit: WeightUnit
+	options: [String!]
+	metafields: [MetafieldInput!]
+}
+
+input ProductInput {
+	id: ID
+	title: String
+	handle: String
+	descriptionHtml: String
+	productType: String
+	vendor: String
+	tags: [String!]
+	status: ProductStatus
+	templateSuffix: String
+	seo: SEOInput
+	options: [String!]
+	variants: [ProductVariantInput!]
+	collectionsToJoin: [ID!]
+	collectionsToLeave: [ID!]
+	metafields: [MetafieldInput!]
+}
+
+input CreateMediaInput {
+	originalSource: String!
+	alt: String
+	mediaContentType: MediaContentType!
+}
+
+input ProductDeleteInput {
+	id: ID!
+}
+
+type ProductCreatePayload {
+	product: Product
+	userErrors: [UserError!]!
+}
+
+type ProductUpdatePayload {
+	product: Product
+	userErrors: [UserError!]!
+}
+
+type ProductDeletePayload {
+	deletedProductId: ID
+	userErrors: [UserError!]!
+}
+
+type ProductVariantUpdatePayload {
+	product: Product
+	productVariant: ProductVariant
+	userErrors: [UserError!]!
+}
+
+input ImageInput {
+	id: ID
+	altText: String
+	src: String
+}
+
+input CollectionInput {
+	id: ID
+	title: String
+	handle: String
+	descriptionHtml: String
+	templateSuffix: String
+	seo: SEOInput
+	image: ImageInput
+	products: [ID!]
+	metafields: [MetafieldInput!]
+}
+
+input CollectionDeleteInput {
+	id: ID!
+}
+
+type CollectionCreatePayload {
+	collection: Collection
+	userErrors: [UserError!]!
+}
+
+type CollectionUpdatePayload {
+	collection: Collection
+	userErrors: [UserError!]!
+}
+
+type CollectionDeletePayload {
+	deletedCollectionId: ID
+	userErrors: [UserError!]!
+}
+
+input OrderInput {
+	id: ID!
+	note: String
+	email: String
+	tags: [String!]
+	metafields: [MetafieldInput!]
+}
+
+type OrderUpdatePayload {
+	order: Order
+	userErrors: [UserError!]!
+}
+
+input MoneyInput {
+	amount: Decimal!
+	currencyCode: CurrencyCode!
+}
+
+input OrderCaptureInput {
+	id: ID!
+	parentTransactionId: ID!
+	amount: Money!
+	currency: CurrencyCode
+	finalCapture: Boolean
+}
+
+type OrderCapturePayload {
+	transaction: OrderTransaction
+	userErrors: [UserError!]!
+}
+
+input OrderMarkAsPaidInput {
+	id: ID!
+}
+
+type OrderMarkAsPaidPayload {
+	order: Order
+	userErrors: [UserError!]!
+}
+
+input ShippingRefundInput {
+	amount: Money
+	fullRefund: Boolean
+}
+
+input RefundLineItemInput {
+	lineItemId: ID!
+	quantity: Int!
+	restockType: RefundLineItemRestockType
+	locationId: ID
+}
+
+input OrderTransactionInput {
+	orderId: ID!
+	parentId: ID
+	amount: Money!
+	gateway: String!
+	kind: OrderTransactionKind!
+}
+
+input RefundInput {
+	orderId: ID!
+	note: String
+	notify: Boolean
+	currency: CurrencyCode
+	shipping: ShippingRefundInput
+	refundLineItems: [RefundLineItemInput!]
+	transactions: [OrderTransactionInput!]
+}
+
+type RefundCreatePayload {
+	order: Order
+	refund: Refund
+	userErrors: [UserError!]!
+}
+
+input ReturnRequestLineItemInput {
+	fulfillmentLineItemId: ID!
+	quantity: Int!
+	returnReason: ReturnReason!
+	customerNote: String
+}
+
+input ReturnRequestInput {
+	orderId: ID!
+	returnLineItems: [ReturnRequestLineItemInput!]!
+}
+
+input ReturnLineItemInput {
+	fulfillmentLineItemId: ID!
+	quantity: Int!
+	returnReason: ReturnReason!
+	returnReasonNote: String
+}
+
+input ReturnInput {
+	orderId: ID!
+	returnLineItems: [ReturnLineItemInput!]!
+	notifyCustomer: Boolean
+}
+
+input ReturnApproveRequestInput {
+	id: ID!
+	notifyCustomer: Boolean
+}
+
+input ReturnDeclineRequestInput {
+	id: ID!
+	declineReason: ReturnDeclineReason!
+	declineNote: String
+	notifyCustomer: Boolean
+}
+
+type ReturnRequestPayload {
+	return: Return
+	userErrors: [UserError!]!
+}
+
+type ReturnCreatePayload {
+	return: Return
+	userErrors: [UserError!]!
+}
+
+type ReturnApproveRequestPayload {
+	return: Return
+	userErrors: [UserError!]!
+}
+
+type ReturnDeclineRequestPayload {
+	return: Return
+	userErrors: [UserError!]!
+}
+
+type ReturnClosePayload {
+	return: Return
+	userErrors: [UserError!]!
+}
+
+type ReturnReopenPayload {
+	return: Return
+	userErrors: [UserError!]!
+}
+
+type ReturnCancelPayload {
+	return: Return
+	userErrors: [UserError!]!
+}
+
+enum DraftOrderStatus { COMPLETED INVOICE_SENT OPEN }
+
+enum DraftOrderAppliedDiscountType { FIXED_AMOUNT PERCENTAGE }
+
+type DraftOrderAppliedDiscount {
+	title: String
+	description: String!
+	value: Float!
+	valueType: DraftOrderAppliedDiscountType!
+	amountSet: MoneyBag!
+}
+
+type DraftOrderLineItem {
+	id: ID!
+	title: String!
+	sku: String
+	quantity: Int!
+	custom: Boolean!
+	variant: ProductVariant
+	originalUnitPriceSet: MoneyBag!
+	originalTotalSet: MoneyBag!
+	discountedTotalSet: MoneyBag!
+	appliedDiscount: DraftOrderAppliedDiscount
+}
+
+type DraftOrderLineItemConnection {
+	edges: [DraftOrderLineItemEdge!]!
+	pageInfo: PageInfo!
+}
+
+type DraftOrderLineItemEdge {
+	cursor: String!
+	node: DraftOrderLineItem!
+}
+
+type DraftOrder implements Node {
+	id: ID!
+	name: String!
+	status: DraftOrderStatus!
+	email: String
+	phone: String
+	note2: String
+	tags: [String!]!
+	poNumber: String
+	taxExempt: Boolean!
+	invoiceUrl: URL
+	invoiceSentAt: DateTime
+	customer: Customer
+	shippingAddress: MailingAddress
+	billingAddress: MailingAddress
+	lineItems(first: Int, after: String, last: Int, before: String, reverse: Boolean): DraftOrderLineItemConnection!
+	appliedDiscount: DraftOrderAppliedDiscount
+	shippingLine: ShippingLine
+	subtotalPriceSet: MoneyBag!
+	totalShippingPriceSet: MoneyBag!
+	totalTaxSet: MoneyBag!
+	totalPriceSet: MoneyBag!
+	order: Order
+	completedAt: DateTime
+	createdAt: DateTime!
+	updatedAt: DateTime!
+}
+
+type DraftOrderConnection {
+	edges: [DraftOrderEdge!]!
+	pageInfo: PageInfo!
+}
+
+type DraftOrderEdge {
+	cursor: String!
+	node: DraftOrder!
+}
+
+type CalculatedDraftOrderLineItem {
+	title: String!
+	sku: String
+	quantity: Int!
+	custom: Boolean!
+	variant: ProductVariant
+	originalUnitPriceSet: MoneyBag!
+	originalTotalSet: MoneyBag!
+	discountedTotalSet: MoneyBag!
+	appliedDiscount: DraftOrderAppliedDiscount
+}
+
+type ShippingRate {
+	handle: String!
+	title: String!
+	price: MoneyV2!
+}
+
+type CalculatedDraftOrder {
+	lineItems: [CalculatedDraftOrderLineItem!]!
+	appliedDiscount: DraftOrderAppliedDiscount
+	availableShippingRates: [ShippingRate!]!
+	subtotalPriceSet: MoneyBag!
+	totalShippingPriceSet: MoneyBag!
+	totalTaxSet: MoneyBag!
+	totalPriceSet: MoneyBag!
+}
+
+type Job {
+	id: ID!
+	done: Boolean!
+}
+
+input DraftOrderAppliedDiscountInput {
+	title: String
+	description: String
+	value: Float!
+	valueType: DraftOrderAppliedDiscountType!
+}
+
+input DraftOrderLineItemInput {
+	variantId: ID
+	quantity: Int!
+	title: String
+	sku: String
+	originalUnitPrice: Money
+	appliedDiscount: DraftOrderAppliedDiscountInput
+}
+
+input ShippingLineInput {
+	title: String
+	price: Money
+	shippingRateHandle: String
+}
+
+input DraftOrderInput {
+	customerId: ID
+	email: String
+	phone: String
+	note: String
+	tags: [String!]
+	poNumber: String
+	taxExempt: Boolean
+	shippingAddress: MailingAddressInput
+	billingAddress: MailingAddressInput
+	useCustomerDefaultAddress: Boolean
+	lineItems: [DraftOrderLineItemInput!]
+	appliedDiscount: DraftOrderAppliedDiscountInput
+	shippingLine: ShippingLineInput
+}
+
+input EmailInput {
+	to: String
+	from: String
+	bcc: [String!]
+	subject: String
+	customMessage: String
+}
+
+input DraftOrderDeleteInput {
+	id: ID!
+}
+
+type DraftOrderCreatePayload {
+	draftOrder: DraftOrder
+	userErrors: [UserError!]!
+}
+
+type DraftOrderUpdatePayload {
+	draftOrder: DraftOrder
+	userErrors: [UserError!]!
+}
+
+type DraftOrderCalculatePayload {
+	calculatedDraftOrder: CalculatedDraftOrder
+	userErrors: [UserError!]!
+}
+
+type DraftOrderCompletePayload {
+	draftOrder: DraftOrder
+	userErrors: [UserError!]!
+}
+
+type DraftOrderInvoiceSendPayload {
+	draftOrder: DraftOrder
+	userErrors: [UserError!]!
+}
+
+type DraftOrderDeletePayload {
+	deletedId: ID
+	userErrors: [UserError!]!
+}
+
+type DraftOrderBulkAddTagsPayload {
+	job: Job
+	userErrors: [UserError!]!
+}
+
+type DraftOrderBulkRemoveTagsPayload {
+	job: Job
+	userErrors: [UserError!]!
+}
+
+input OrderEditAppliedDiscountInput {
+	description: String
+	fixedValue: MoneyInput
+	percentValue: Float
+}
+
+type OrderEditBeginPayload {
+	calculatedOrder: CalculatedOrder
+	userErrors: [UserError!]!
+}
+
+type OrderEditAddVariantPayload {
+	calculatedLineItem: CalculatedLineItem
+	calculatedOrder: CalculatedOrder
+	userErrors: [UserError!]!
+}
+
+type OrderEditAddCustomItemPayload {
+	calculatedLineItem: CalculatedLineItem
+	calculatedOrder: CalculatedOrder
+	userErrors: [UserError!]!
+}
+
+type OrderEditSetQuantityPayload {
+	calculatedLineItem: CalculatedLineItem
+	calculatedOrder: CalculatedOrder
+	userErrors: [UserError!]!
+}
+
+type OrderEditAddLineItemDiscountPayload {
+	calculatedLineItem: CalculatedLineItem
+	calculatedOrder: CalculatedOrder
+	userErrors: [UserError!]!
+}
+
+type OrderEditCommitPayload {
+	order: Order
+	userErrors: [UserError!]!
+}
+
+input FulfillmentOrderLineItemInput {
+	id: ID!
+	quantity: Int!
+}
+
+input FulfillmentOrderLineItemsInput {
+	fulfillmentOrderId: ID!
+	fulfillmentOrderLineItems: [FulfillmentOrderLineItemInput!]
+}
+
+input FulfillmentTrackingInput {
+	company: String
+	number: String
+	url: URL
+}
+
+input FulfillmentV2Input {
+	lineItemsByFulfillmentOrder: [FulfillmentOrderLineItemsInput!]!
+	notifyCustomer: Boolean
+	trackingInfo: FulfillmentTrackingInput
+}
+
+type FulfillmentCreateV2Payload {
+	fulfillment: Fulfillment
+	userErrors: [UserError!]!
+}
+
+type FulfillmentTrackingInfoUpdateV2Payload {
+	fulfillment: Fulfillment
+	userErrors: [UserError!]!
+}
+
+type FulfillmentCancelPayload {
+	fulfillment: Fulfillment
+	userErrors: [UserError!]!
+}
+
+input FulfillmentOrderHoldInput {
+	reason: FulfillmentHoldReason!
+	reasonNotes: String
+	notifyMerchant: Boolean
+}
+
+type FulfillmentOrderHoldPayload {
+	fulfillmentOrder: FulfillmentOrder
+	userErrors: [UserError!]!
+}
+
+type FulfillmentOrderReleaseHoldPayload {
+	fulfillmentOrder: FulfillmentOrder
+	userErrors: [UserError!]!
+}
+
+type FulfillmentOrderMovePayload {
+	movedFulfillmentOrder: FulfillmentOrder
+	originalFulfillmentOrder: FulfillmentOrder
+	remainingFulfillmentOrder: FulfillmentOrder
+	userErrors: [UserError!]!
+}
+
+type FulfillmentOrderReschedulePayload {
+	fulfillmentOrder: FulfillmentOrder
+	userErrors: [UserError!]!
+}
+
+type FulfillmentOrderCancelPayload {
+	fulfillmentOrder: FulfillmentOrder
+	replacementFulfillmentOrder: FulfillmentOrder
+	userErrors: [UserError!]!
+}
+
+type FulfillmentOrderClosePayload {
+	fulfillmentOrder: FulfillmentOrder
+	userErrors: [UserError!]!
+}
+
+type FulfillmentOrderAcceptFulfillmentRequestPayload {
+	fulfillmentOrder: FulfillmentOrder
+	userErrors: [UserError!]!
+}
+
+type FulfillmentOrderRejectFulfillmentRequestPayload {
+	fulfillmentOrder: FulfillmentOrder
+	userErrors: [UserError!]!
+}
+
+input MailingAddressInput {
+	id: ID
+	address1: String
+	address2: String
+	city: String
+	company: String
+	countryCode: CountryCode
+	firstName: String
+	lastName: String
+	phone: String
+	provinceCode: String
+	zip: String
+}
+
+input CustomerEmailMarketingConsentInput {
+	marketingState: CustomerEmailMarketingState!
+	marketingOptInLevel: CustomerMarketingOptInLevel
+	consentUpdatedAt: DateTime
+}
+
+input CustomerSmsMarketingConsentInput {
+	marketingState: CustomerSmsMarketingState!
+	marketingOptInLevel: CustomerMarketingOptInLevel
+	consentUpdatedAt: DateTime
+}
+
+input CustomerInput {
+	id: ID
+	firstName: String
+	lastName: String
+	email: String
+	phone: String
+	note: String
+	tags: [String!]
+	locale: String
+	taxExempt: Boolean
+	addresses: [MailingAddressInput!]
+	metafields: [MetafieldInput!]
+	emailMarketingConsent: CustomerEmailMarketingConsentInput
+	smsMarketingConsent: CustomerSmsMarketingConsentInput
+}
+
+input CustomerDeleteInput {
+	id: ID!
+}
+
+input CustomerEmailMarketingConsentUpdateInput {
+	customerId: ID!
+	emailMarketingConsent: CustomerEmailMarketingConsentInput!
+}
+
+input CustomerSmsMarketingConsentUpdateInput {
+	customerId: ID!
+	smsMarketingConsent: CustomerSmsMarketingConsentInput!
+}
+
+type CustomerCreatePayload {
+	customer: Customer
+	userErrors: [UserError!]!
+}
+
+type CustomerUpdatePayload {
+	customer: Customer
+	userErrors: [UserError!]!
+}
+
+type CustomerDeletePayload {
+	deletedCustomerId: ID
+	userErrors: [UserError!]!
+}
+
+type CustomerUpdateDefaultAddressPayload {
+	customer: Customer
+	userErrors: [UserError!]!
+}
+
+type CustomerEmailMarketingConsentUpdatePayload {
+	customer: Customer
+	userErrors: [UserError!]!
+}
+
+type CustomerSmsMarketingConsentUpdatePayload {
+	customer: Customer
+	userErrors: [UserError!]!
+}
+
+type TagsAddPayload {
+	node: Node
+	userErrors: [UserError!]!
+}
+
+type TagsRemovePayload {
+	node: Node
+	userErrors: [UserError!]!
+}
+
+input DiscountCombinesWithInput {
+	orderDiscounts: Boolean
+	productDiscounts: Boolean
+	shippingDiscounts: Boolean
+}
+
+input DiscountCustomersInput {
+	add: [ID!]
+	remove: [ID!]
+}
+
+input DiscountCustomerSelectionInput {
+	all: Boolean
+	customers: DiscountCustomersInput
+}
+
+input DiscountAmountInput {
+	amount: Decimal!
+	appliesOnEachItem: Boolean
+}
+
+input DiscountEffectInput {
+	percentage: Float
+}
+
+input DiscountOnQuantityInput {
+	quantity: UnsignedInt64!
+	effect: DiscountEffectInput!
+}
+
+input DiscountCustomerGetsValueInput {
+	percentage: Float
+	discountAmount: DiscountAmountInput
+	discountOnQuantity: DiscountOnQuantityInput
+}
+
+input DiscountProductsInput {
+	productsToAdd: [ID!]
+	productsToRemove: [ID!]
+	productVariantsToAdd: [ID!]
+	productVariantsToRemove: [ID!]
+}
+
+input DiscountCollectionsInput {
+	add: [ID!]
+	remove: [ID!]
+}
+
+input DiscountItemsInput {
+	all: Boolean
+	products: DiscountProductsInput
+	collections: DiscountCollectionsInput
+}
+
+input DiscountCustomerGetsInput {
+	value: DiscountCustomerGetsValueInput
+	items: DiscountItemsInput
+	appliesOnOneTimePurchase: Boolean
+	appliesOnSubscription: Boolean
+}
+
+input DiscountCustomerBuysValueInput {
+	quantity: UnsignedInt64
+	amount: Decimal
+}
+
+input DiscountCustomerBuysInput {
+	value: DiscountCustomerBuysValueInput
+	items: DiscountItemsInput
+}
+
+input DiscountMinimumQuantityInput {
+	greaterThanOrEqualToQuantity: UnsignedInt64!
+}
+
+input DiscountMinimumSubtotalInput {
+	greaterThanOrEqualToSubtotal: Decimal!
+}
+
+input DiscountMinimumRequirementInput {
+	quantity: DiscountMinimumQuantityInput
+	subtotal: DiscountMinimumSubtotalInput
+}
+
+input DiscountCountriesInput {
+	add: [CountryCode!]
+	remove: [CountryCode!]
+	includeRestOfWorld: Boolean
+}
+
+input DiscountShippingDestinationSelectionInput {
+	all: Boolean
+	countries: DiscountCountriesInput
+}
+
+input DiscountCodeBasicInput {
+	title: String
+	code: String
+	startsAt: DateTime
+	endsAt: DateTime
+	usageLimit: Int
+	appliesOncePerCustomer: Boolean
+	customerSelection: DiscountCustomerSelectionInput
+	customerGets: DiscountCustomerGetsInput
+	minimumRequirement: DiscountMinimumRequirementInput
+	combinesWith: DiscountCombinesWithInput
+}
+
+input DiscountCodeBxgyInput {
+	title: String
+	code: String
+	startsAt: DateTime
+	endsAt: DateTime
+	usageLimit: Int
+	usesPerOrderLimit: Int
+	appliesOncePerCustomer: Boolean
+	customerSelection: DiscountCustomerSelectionInput
+	customerBuys: DiscountCustomerBuysInput
+	customerGets: DiscountCustomerGetsInput
+	combinesWith: DiscountCombinesWithInput
+}
+
+input DiscountCodeFreeShippingInput {
+	title: String
+	code: String
+	startsAt: DateTime
+	endsAt: DateTime
+	usageLimit: Int
+	appliesOncePerCustomer: Boolean
+	customerSelection: DiscountCustomerSelectionInput
+	destination: DiscountShippingDestinationSelectionInput
+	maximumShippingPrice: Decimal
+	minimumRequirement: DiscountMinimumRequirementInput
+	appliesOnOneTimePurchase: Boolean
+	appliesOnSubscription: Boolean
+	combinesWith: DiscountCombinesWithInput
+}
+
+input DiscountAutomaticBasicInput {
+	title: String
+	startsAt: DateTime
+	endsAt: DateTime
+	customerGets: DiscountCustomerGetsInput
+	minimumRequirement: DiscountMinimumRequirementInput
+	combinesWith: DiscountCombinesWithInput
+}
+
+input DiscountAutomaticBxgyInput {
+	title: String
+	startsAt: DateTime
+	endsAt: DateTime
+	usesPerOrderLimit: Int
+	customerBuys: DiscountCustomerBuysInput
+	customerGets: DiscountCustomerGetsInput
+	combinesWith: DiscountCombinesWithInput
+}
+
+input DiscountAutomaticFreeShippingInput {
+	title: String
+	startsAt: DateTime
+	endsAt: DateTime
+	destination: DiscountShippingDestinationSelectionInput
+	maximumShippingPrice: Decimal
+	minimumRequirement: DiscountMinimumRequirementInput
+	appliesOnOneTimePurchase: Boolean
+	appliesOnSubscription: Boolean
+	combinesWith: DiscountCombinesWithInput
+}
+
+input DiscountRedeemCodeInput {
+	code: String!
+}
+
+type DiscountCodeBasicCreatePayload {
+	codeDiscountNode: DiscountCodeNode
+	userErrors: [UserError!]!
+}
+
+type DiscountCodeBasicUpdatePayload {
+	codeDiscountNode: DiscountCodeNode
+	userErrors: [UserError!]!
+}
+
+type DiscountCodeBxgyCreatePayload {
+	codeDiscountNode: DiscountCodeNode
+	userErrors: [UserError!]!
+}
+
+type DiscountCodeBxgyUpdatePayload {
+	codeDiscountNode: DiscountCodeNode
+	userErrors: [UserError!]!
+}
+
+type DiscountCodeFreeShippingCreatePayload {
+	codeDiscountNode: DiscountCodeNode
+	userErrors: [UserError!]!
+}
+
+type DiscountCodeFreeShippingUpdatePayload {
+	codeDiscountNode: DiscountCodeNode
+	userErrors: [UserError!]!
+}
+
+type DiscountCodeActivatePayload {
+	codeDiscountNode: DiscountCodeNode
+	userErrors: [UserError!]!
+}
+
+type DiscountCodeDeactivatePayload {
+	codeDiscountNode: DiscountCodeNode
+	userErrors: [UserError!]!
+}
+
+type DiscountCodeDeletePayload {
+	deletedCodeDiscountId: ID
+	userErrors: [UserError!]!
+}
+
+type DiscountRedeemCodeBulkAddPayload {
+	bulkCreation: DiscountRedeemCodeBulkCreation
+	userErrors: [UserError!]!
+}
+
+type DiscountAutomaticBasicCreatePayload {
+	automaticDiscountNode: DiscountAutomaticNode
+	userErrors: [UserError!]!
+}
+
+type DiscountAutomaticBasicUpdatePayload {
+	automaticDiscountNode: DiscountAutomaticNode
+	userErrors: [UserError!]!
+}
+
+type DiscountAutomaticBxgyCreatePayload {
+	automaticDiscountNode: DiscountAutomaticNode
+	userErrors: [UserError!]!
+}
+
+type DiscountAutomaticBxgyUpdatePayload {
+	automaticDiscountNode: DiscountAutomaticNode
+	userErrors: [UserError!]!
+}
+
+type DiscountAutomaticFreeShippingCreatePayload {
+	automaticDiscountNode: DiscountAutomaticNode
+	userErrors: [UserError!]!
+}
+
+type DiscountAutomaticFreeShippingUpdatePayload {
+	automaticDiscountNode: DiscountAutomaticNode
+	userErrors: [UserError!]!
+}
+
+type DiscountAutomaticActivatePayload {
+	automaticDiscountNode: DiscountAutomaticNode
+	userErrors: [UserError!]!
+}
+
+type DiscountAutomaticDeactivatePayload {
+	automaticDiscountNode: DiscountAutomaticNode
+	userErrors: [UserError!]!
+}
+
+type DiscountAutomaticDeletePayload {
+	deletedAutomaticDiscountId: ID
+	userErrors: [UserError!]!
+}
+
+input LocationAddAddressInput {
+	address1: String
+	address2: String
+	city: String
+	countryCode: CountryCode!
+	provinceCode: String
+	zip: String
+	phone: String
+}
+
+input LocationAddInput {
+	name: String!
+	address: LocationAddAddressInput!
+	fulfillsOnlineOrders: Boolean
+}
+
+type LocationAddPayload {
+	location: Location
+	userErrors: [UserError!]!
+}
+
+input LocationEditAddressInput {
+	address1: String
+	address2: String
+	city: String
+	countryCode: CountryCode
+	provinceCode: String
+	zip: String
+	phone: String
+}
+
+input LocationEditInput {
+	name: String
+	address: LocationEditAddressInput
+	fulfillsOnlineOrders: Boolean
+}
+
+type LocationEditPayload {
+	location: Location
+	userErrors: [UserError!]!
+}
+
+type LocationActivatePayload {
+	location: Location
+	locationActivateUserErrors: [UserError!]!
+}
+
+type LocationDeactivatePayload {
+	location: Location
+	locationDeactivateUserErrors: [UserError!]!
+}
+
+type InventoryActivatePayload {
+	inventoryLevel: InventoryLevel
+	userErrors: [UserError!]!
+}
+
+input InventorySetQuantityInput {
+	inventoryItemId: ID!
+	locationId: ID!
+	quantity: Int!
+}
+
+input InventorySetOnHandQuantitiesInput {
+	reason: String!
+	referenceDocumentUri: String
+	setQuantities: [InventorySetQuantityInput!]!
+}
+
+type InventorySetOnHandQuantitiesPayload {
+	inventoryAdjustmentGroup: InventoryAdjustmentGroup
+	userErrors: [UserError!]!
+}
+
+input InventoryChangeInput {
+	inventoryItemId: ID!
+	locationId: ID!
+	delta: Int!
+	ledgerDocumentUri: String
+}
+
+input InventoryAdjustQuantitiesInput {
+	reason: String!
+	name: String!
+	referenceDocumentUri: String
+	changes: [InventoryChangeInput!]!
+}
+
+type InventoryAdjustQuantitiesPayload {
+	inventoryAdjustmentGroup: InventoryAdjustmentGroup
+	userErrors: [UserError!]!
+}
+
+input InventoryMoveQuantityTerminalInput {
+	locationId: ID!
+	name: String!
+	ledgerDocumentUri: String
+}
+
+input InventoryMoveQuantityChange {
+	inventoryItemId: ID!
+	quantity: Int!
+	from: InventoryMoveQuantityTerminalInput!
+	to: InventoryMoveQuantityTerminalInput!
+}
+
+input InventoryMoveQuantitiesInput {
+	reason: String!
+	referenceDocumentUri: String
+	changes: [InventoryMoveQuantityChange!]!
+}
+
+type InventoryMoveQuantitiesPayload {
+	inventoryAdjustmentGroup: InventoryAdjustmentGroup
+	userErrors: [UserError!]!
+}
+
+input MetafieldDeleteInput {
+	id: ID!
+}
+
+type MetafieldDeletePayload {
+	deletedId: ID
+	userErrors: [UserError!]!
+}
+
+input WebhookSubscriptionInput {
+	callbackUrl: URL
+	format: WebhookSubscriptionFormat
+	includeFields: [String!]
+	metafieldNamespaces: [String!]
+}
+
+input PubSubWebhookSubscriptionInput {
+	pubSubProject: String
+	pubSubTopic: String
+	format: WebhookSubscriptionFormat
+	includeFields: [String!]
+	metafieldNamespaces: [String!]
+}
+
+input EventBridgeWebhookSubscriptionInput {
+	arn: String
+	format: WebhookSubscriptionFormat
+	includeFields: [String!]
+	metafieldNamespaces: [String!]
+}
+
+type WebhookSubscriptionCreatePayload {
+	webhookSubscription: WebhookSubscription
+	userErrors: [UserError!]!
+}
+
+type EventBridgeWebhookSubscriptionCreatePayload {
+	webhookSubscription: WebhookSubscription
+	userErrors: [UserError!]!
+}
+
+type WebhookSubscriptionUpdatePayload {
+	webhookSubscription: WebhookSubscription
+	userErrors: [UserError!]!
+}
+
+type EventBridgeWebhookSubscriptionUpdatePayload {
+	webhookSubscription: WebhookSubscription
+	userErrors: [UserError!]!
+}
+
+type PubSubWebhookSubscriptionCreatePayload {
+	webhookSubscription: WebhookSubscription
+	userErrors: [UserError!]!
+}
+
+type PubSubWebhookSubscriptionUpdatePayload {
+	webhookSubscription: WebhookSubscription
+	userErrors: [UserError!]!
+}
+
+type WebhookSubscriptionDeletePayload {
+	deletedWebhookSubscriptionId: ID
+	userErrors: [UserError!]!
+}
+
+type BulkOperationRunQueryPayload {
+	bulkOperation: BulkOperation
+	userErrors: [UserError!]!
+}
+
+type BulkOperationRunMutationPayload {
+	bulkOperation: BulkOperation
+	userErrors: [UserError!]!
+}
+
+type BulkOperationCancelPayload {
+	bulkOperation: BulkOperation
+	userErrors: [UserError!]!
+}
+
+enum StagedUploadTargetGenerateUploadResource { BULK_MUTATION_VARIABLES COLLECTION_IMAGE FILE IMAGE MODEL_3D PRODUCT_IMAGE SHOP_IMAGE URL_REDIRECT_IMPORT VIDEO }
+enum StagedUploadHttpMethodType { POST PUT }
+
+input StagedUploadInput {
+	resource: StagedUploadTargetGenerateUploadResource!
+	filename: String!
+	mimeType: String!
+	httpMethod: StagedUploadHttpMethodType
+	fileSize: UnsignedInt64
+}
+
+type StagedUploadParameter {
+	name: String!
+	value: String!
+}
+
+type StagedMediaUploadTarget {
+	url: URL
+	resourceUrl: URL
+	parameters: [StagedUploadParameter!]!
+}
+
+type StagedUploadsCreatePayload {
+	stagedTargets: [StagedMediaUploadTarget!]
+	userErrors: [UserError!]!
+}
+`
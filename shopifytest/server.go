@@ -0,0 +1,1055 @@
+// Package shopifytest provides an in-memory fake of the Shopify Admin GraphQL API,
+// to test code built on the shopify package without a development shop.
+//
+//	srv := shopifytest.NewServer()
+//	defer srv.Close()
+//	srv.AddProduct(&shopifytest.Product{Title: "Snowboard"})
+//
+//	client := srv.Client()
+//	products, err := client.Product.ListAll()
+//
+// The server keeps products, variants, collections, orders, customers, discounts, metafields,
+// webhook subscriptions and bulk operations in memory. Bulk queries and mutations complete
+// immediately, unless PendingBulkOperations is set, and their JSONL result is served by the
+// server itself, which also stands in for the staged upload targets of bulk mutation variables.
+package shopifytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	graphqlclient "github.com/gempages/go-shopify-graphql/graph"
+	graphqlserver "github.com/graph-gophers/graphql-go"
+)
+
+const (
+	graphqlPath       = "/admin/api/graphql.json"
+	bulkOutputPath    = "/bulk-operation-outputs/"
+	stagedUploadsPath = "/staged-uploads/"
+
+	accessTokenHeader = "X-Shopify-Access-Token"
+)
+
+// Server is a fake Shopify Admin API server. Its exported fields can be changed before
+// the first request.
+type Server struct {
+	// URL is the base URL of the server, of the form http://ipaddr:port with no trailing slash.
+	URL string
+	// AccessToken is the token clients must send, "shpat_test" by default.
+	AccessToken string
+	// ShopName is the name of the shop, "Test Shop" by default.
+	ShopName string
+	// ShopDomain is the myshopify domain of the shop, "test-shop.myshopify.com" by default.
+	ShopDomain string
+	// CurrencyCode is the currency of the shop, "USD" by default.
+	CurrencyCode string
+	// APISecret is the secret key of the app signing the webhooks, "shpss_test" by default.
+	APISecret string
+	// PendingBulkOperations keeps bulk operations RUNNING until FinishBulkOperations is called.
+	PendingBulkOperations bool
+
+	httpServer *httptest.Server
+	schema     *graphqlserver.Schema
+
+	mu               sync.Mutex
+	lastID           int
+	products         []*Product
+	locations        []*Location
+	collections      []*Collection
+	orders           []*Order
+	orderEdits       []*orderEdit
+	draftOrders      []*DraftOrder
+	customers        []*Customer
+	discounts        []*Discount
+	codeCreations    []*redeemCodeBulkCreation
+	adjustmentGroups []*inventoryAdjustmentGroup
+	shopMetafields   []*Metafield
+	webhooks         []*WebhookSubscription
+	bulkOperations   []*BulkOperation
+	stagedUploads    map[string][]byte
+	dropResponses    int
+}
+
+type rootResolver struct {
+	*queryResolver
+	*mutationResolver
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		AccessToken:  "shpat_test",
+		ShopName:     "Test Shop",
+		ShopDomain:   "test-shop.myshopify.com",
+		CurrencyCode: "USD",
+		APISecret:    "shpss_test",
+
+		stagedUploads: map[string][]byte{},
+	}
+	s.schema = graphqlserver.MustParseSchema(schema, &rootResolver{
+		queryResolver:    &queryResolver{s: s},
+		mutationResolver: &mutationResolver{s: s},
+	}, graphqlserver.UseFieldResolvers())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.serveGraphQL)
+	mux.HandleFunc(bulkOutputPath, s.serveBulkOutput)
+	mux.HandleFunc(stagedUploadsPath, s.serveStagedUpload)
+	s.httpServer = httptest.NewServer(mux)
+	s.URL = s.httpServer.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// DropResponses makes the server run the next n GraphQL mutations but reset their connections
+// instead of responding, as when the responses are lost on the way back to the client.
+func (s *Server) DropResponses(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropResponses = n
+}
+
+// Endpoint returns the URL of the GraphQL endpoint.
+func (s *Server) Endpoint() string {
+	return s.URL + graphqlPath
+}
+
+// Client returns a shopify client sending its requests to the server.
+func (s *Server) Client(opts ...graphqlclient.Option) *shopify.Client {
+	opts = append([]graphqlclient.Option{
+		graphqlclient.WithEndpoint(s.Endpoint()),
+		graphqlclient.WithToken(s.AccessToken),
+	}, opts...)
+	return shopify.NewClientWithOpts(s.ShopDomain, opts...)
+}
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, "graphql.json") && r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get(accessTokenHeader) != s.AccessToken {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{
+			"errors": "[API] Invalid API key or access token (unrecognized login or wrong password)",
+		})
+		return
+	}
+
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"errors": err.Error()})
+		return
+	}
+
+	// requests are served one at a time, resolvers don't need to lock
+	s.mu.Lock()
+	resp := s.schema.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+	drop := s.dropResponses > 0 && strings.HasPrefix(strings.TrimSpace(req.Query), "mutation")
+	if drop {
+		s.dropResponses--
+	}
+	s.mu.Unlock()
+
+	if drop {
+		resetConnection(w)
+		return
+	}
+
+	resp.Extensions = map[string]interface{}{
+		"cost": map[string]interface{}{
+			"requestedQueryCost": 1,
+			"actualQueryCost":    1,
+			"throttleStatus": map[string]interface{}{
+				"maximumAvailable":   1000.0,
+				"currentlyAvailable": 1000,
+				"restoreRate":        50.0,
+			},
+		},
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// resetConnection closes the connection of w without responding, resetting it so the client fails
+// with ECONNRESET rather than reading an EOF.
+func resetConnection(w http.ResponseWriter) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		panic(err)
+	}
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		_ = tcp.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func (s *Server) newID(resource string) string {
+	s.lastID++
+	return fmt.Sprintf("gid://shopify/%s/%d", resource, s.lastID)
+}
+
+// AddProduct stores p, and returns it once its IDs and defaults are set.
+func (s *Server) AddProduct(p *Product) *Product {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addProduct(p)
+	return p
+}
+
+// AddLocation adds l to the locations of the shop, filling its ID when empty, and returns it.
+func (s *Server) AddLocation(l *Location) *Location {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fillLocation(l)
+	s.locations = append(s.locations, l)
+	return l
+}
+
+// AddCollection stores c, and returns it once its IDs and defaults are set.
+func (s *Server) AddCollection(c *Collection) *Collection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addCollection(c)
+	return c
+}
+
+// AddOrder stores o, and returns it once its IDs and defaults are set.
+func (s *Server) AddOrder(o *Order) *Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fillOrder(o)
+	s.orders = append(s.orders, o)
+	return o
+}
+
+// AddDraftOrder stores d, and returns it once its IDs and defaults are set.
+func (s *Server) AddDraftOrder(d *DraftOrder) *DraftOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fillDraftOrder(d)
+	s.draftOrders = append(s.draftOrders, d)
+	return d
+}
+
+// AddCustomer stores c, and returns it once its IDs and defaults are set.
+func (s *Server) AddCustomer(c *Customer) *Customer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fillCustomer(c)
+	s.customers = append(s.customers, c)
+	return c
+}
+
+// AddDiscount stores d, and returns it once its IDs and defaults are set.
+func (s *Server) AddDiscount(d *Discount) *Discount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fillDiscount(d)
+	s.discounts = append(s.discounts, d)
+	return d
+}
+
+// AddShopMetafield stores a metafield owned by the shop, and returns it once its ID is set.
+func (s *Server) AddShopMetafield(m *Metafield) *Metafield {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fillMetafield(m)
+	s.shopMetafields = append(s.shopMetafields, m)
+	return m
+}
+
+// AddWebhookSubscription stores w, and returns it once its ID is set.
+func (s *Server) AddWebhookSubscription(w *WebhookSubscription) *WebhookSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addWebhookSubscription(w)
+	return w
+}
+
+// Products returns the stored products.
+func (s *Server) Products() []*Product {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Product{}, s.products...)
+}
+
+// Locations returns the locations of the shop.
+func (s *Server) Locations() []*Location {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Location(nil), s.locations...)
+}
+
+// Collections returns the stored collections.
+func (s *Server) Collections() []*Collection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Collection{}, s.collections...)
+}
+
+// Orders returns the stored orders.
+func (s *Server) Orders() []*Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Order{}, s.orders...)
+}
+
+// DraftOrders returns the stored draft orders.
+func (s *Server) DraftOrders() []*DraftOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*DraftOrder{}, s.draftOrders...)
+}
+
+// Customers returns the stored customers.
+func (s *Server) Customers() []*Customer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Customer{}, s.customers...)
+}
+
+// Discounts returns the stored discounts.
+func (s *Server) Discounts() []*Discount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Discount{}, s.discounts...)
+}
+
+// ShopMetafields returns the stored metafields owned by the shop.
+func (s *Server) ShopMetafields() []*Metafield {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Metafield{}, s.shopMetafields...)
+}
+
+// WebhookSubscriptions returns the stored webhook subscriptions.
+func (s *Server) WebhookSubscriptions() []*WebhookSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*WebhookSubscription{}, s.webhooks...)
+}
+
+// BulkOperations returns the bulk operations run so far, oldest first.
+func (s *Server) BulkOperations() []*BulkOperation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*BulkOperation{}, s.bulkOperations...)
+}
+
+func (s *Server) addProduct(p *Product) {
+	s.fillProduct(p)
+	s.products = append(s.products, p)
+}
+
+func (s *Server) addCollection(c *Collection) {
+	s.fillCollection(c)
+	s.collections = append(s.collections, c)
+}
+
+func (s *Server) addWebhookSubscription(w *WebhookSubscription) {
+	if w.ID == "" {
+		w.ID = s.newID("WebhookSubscription")
+	}
+	if w.Format == "" {
+		w.Format = "JSON"
+	}
+	if w.CreatedAt.IsZero() {
+		w.CreatedAt = s.now()
+	}
+	if w.UpdatedAt.IsZero() {
+		w.UpdatedAt = w.CreatedAt
+	}
+	s.webhooks = append(s.webhooks, w)
+}
+
+var mediaResources = map[string]string{
+	"IMAGE":          "MediaImage",
+	"VIDEO":          "Video",
+	"MODEL_3D":       "Model3d",
+	"EXTERNAL_VIDEO": "ExternalVideo",
+}
+
+var nonHandleChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func handleize(title string) string {
+	return strings.Trim(nonHandleChars.ReplaceAllString(strings.ToLower(title), "-"), "-")
+}
+
+func (s *Server) fillProduct(p *Product) {
+	if p.ID == "" {
+		p.ID = s.newID("Product")
+	}
+	if p.Handle == "" {
+		p.Handle = handleize(p.Title)
+	}
+	if p.Status == "" {
+		p.Status = "ACTIVE"
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = s.now()
+	}
+	if p.UpdatedAt.IsZero() {
+		p.UpdatedAt = p.CreatedAt
+	}
+	for _, v := range p.Variants {
+		if v.ID == "" {
+			v.ID = s.newID("ProductVariant")
+		}
+		if v.Title == "" {
+			v.Title = "Default Title"
+		}
+		if v.Price == "" {
+			v.Price = "0.00"
+		}
+		if v.InventoryPolicy == "" {
+			v.InventoryPolicy = "DENY"
+		}
+		if v.WeightUnit == "" {
+			v.WeightUnit = "KILOGRAMS"
+		}
+		if v.InventoryItemID == "" {
+			v.InventoryItemID = s.newID("InventoryItem")
+		}
+		if v.CreatedAt.IsZero() {
+			v.CreatedAt = p.UpdatedAt
+		}
+		if v.UpdatedAt.IsZero() {
+			v.UpdatedAt = v.CreatedAt
+		}
+		for _, l := range v.InventoryLevels {
+			s.fillInventoryLevel(l, v.UpdatedAt)
+		}
+		if len(v.InventoryLevels) > 0 {
+			v.InventoryQuantity = availableQuantity(v)
+		}
+		for _, m := range v.Metafields {
+			s.fillMetafield(m)
+		}
+	}
+	for _, img := range p.Images {
+		s.fillImage(img)
+	}
+	for _, m := range p.Media {
+		if m.ContentType == "" {
+			m.ContentType = "IMAGE"
+		}
+		if m.ID == "" {
+			m.ID = s.newID(mediaResources[m.ContentType])
+		}
+		if m.Image != nil {
+			s.fillImage(m.Image)
+		}
+	}
+	for _, m := range p.Metafields {
+		s.fillMetafield(m)
+	}
+}
+
+func (s *Server) fillLocation(l *Location) {
+	if l.ID == "" {
+		l.ID = s.newID("Location")
+	}
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = s.now()
+	}
+	if l.UpdatedAt.IsZero() {
+		l.UpdatedAt = l.CreatedAt
+	}
+}
+
+func (s *Server) fillInventoryLevel(l *InventoryLevel, createdAt time.Time) {
+	if l.ID == "" {
+		l.ID = s.newID("InventoryLevel")
+	}
+	if l.Quantities == nil {
+		l.Quantities = map[string]int{}
+	}
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = createdAt
+	}
+	if l.UpdatedAt.IsZero() {
+		l.UpdatedAt = l.CreatedAt
+	}
+}
+
+func (s *Server) fillCollection(c *Collection) {
+	if c.ID == "" {
+		c.ID = s.newID("Collection")
+	}
+	if c.Handle == "" {
+		c.Handle = handleize(c.Title)
+	}
+	if c.UpdatedAt.IsZero() {
+		c.UpdatedAt = s.now()
+	}
+	if c.Image != nil {
+		s.fillImage(c.Image)
+	}
+	for _, m := range c.Metafields {
+		s.fillMetafield(m)
+	}
+}
+
+func (s *Server) fillOrder(o *Order) {
+	if o.ID == "" {
+		o.ID = s.newID("Order")
+	}
+	if o.Name == "" {
+		o.Name = fmt.Sprintf("#%d", 1001+len(s.orders))
+	}
+	if o.DisplayFulfillmentStatus == "" {
+		o.DisplayFulfillmentStatus = "UNFULFILLED"
+	}
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = s.now()
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = o.CreatedAt
+	}
+	if o.Customer != nil && o.Customer.ID == "" {
+		o.Customer.ID = s.newID("Customer")
+	}
+	if o.ShippingAddress != nil && o.ShippingAddress.ID == "" {
+		o.ShippingAddress.ID = s.newID("MailingAddress")
+	}
+	for _, li := range o.LineItems {
+		if li.ID == "" {
+			li.ID = s.newID("LineItem")
+		}
+		if li.FulfillmentStatus == "" {
+			li.FulfillmentStatus = "unfulfilled"
+		}
+	}
+	for _, fo := range o.FulfillmentOrders {
+		if fo.ID == "" {
+			fo.ID = s.newID("FulfillmentOrder")
+		}
+		if fo.Status == "" {
+			fo.Status = "OPEN"
+		}
+		if fo.RequestStatus == "" {
+			fo.RequestStatus = "UNSUBMITTED"
+		}
+		if fo.CreatedAt.IsZero() {
+			fo.CreatedAt = o.CreatedAt
+		}
+		if fo.UpdatedAt.IsZero() {
+			fo.UpdatedAt = fo.CreatedAt
+		}
+		for _, li := range fo.LineItems {
+			if li.ID == "" {
+				li.ID = s.newID("FulfillmentOrderLineItem")
+			}
+		}
+	}
+	for i, f := range o.Fulfillments {
+		if f.ID == "" {
+			f.ID = s.newID("Fulfillment")
+		}
+		if f.Name == "" {
+			f.Name = fmt.Sprintf("%s.%d", o.Name, i+1)
+		}
+		if f.Status == "" {
+			f.Status = "SUCCESS"
+		}
+		if f.CreatedAt.IsZero() {
+			f.CreatedAt = s.now()
+		}
+		if f.UpdatedAt.IsZero() {
+			f.UpdatedAt = f.CreatedAt
+		}
+		for _, li := range f.LineItems {
+			if li.ID == "" {
+				li.ID = s.newID("FulfillmentLineItem")
+			}
+		}
+	}
+	for _, t := range o.Transactions {
+		if t.ID == "" {
+			t.ID = s.newID("OrderTransaction")
+		}
+		if t.Status == "" {
+			t.Status = "SUCCESS"
+		}
+		if t.Gateway == "" {
+			t.Gateway = "manual"
+		}
+	}
+	for _, r := range o.Refunds {
+		if r.ID == "" {
+			r.ID = s.newID("Refund")
+		}
+		if r.CreatedAt.IsZero() {
+			r.CreatedAt = s.now()
+		}
+		for _, li := range r.LineItems {
+			if li.ID == "" {
+				li.ID = s.newID("RefundLineItem")
+			}
+			if li.RestockType == "" {
+				li.RestockType = "NO_RESTOCK"
+			}
+		}
+	}
+	for i, rt := range o.Returns {
+		if rt.ID == "" {
+			rt.ID = s.newID("Return")
+		}
+		if rt.Name == "" {
+			rt.Name = fmt.Sprintf("%s-R%d", o.Name, i+1)
+		}
+		if rt.Status == "" {
+			rt.Status = "OPEN"
+		}
+		if rt.CreatedAt.IsZero() {
+			rt.CreatedAt = s.now()
+		}
+		if rt.UpdatedAt.IsZero() {
+			rt.UpdatedAt = rt.CreatedAt
+		}
+		for _, li := range rt.LineItems {
+			if li.ID == "" {
+				li.ID = s.newID("ReturnLineItem")
+			}
+		}
+	}
+	for _, m := range o.Metafields {
+		s.fillMetafield(m)
+	}
+}
+
+func (s *Server) fillDraftOrder(d *DraftOrder) {
+	if d.ID == "" {
+		d.ID = s.newID("DraftOrder")
+	}
+	if d.Name == "" {
+		d.Name = fmt.Sprintf("#D%d", len(s.draftOrders)+1)
+	}
+	if d.Status == "" {
+		d.Status = "OPEN"
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = s.now()
+	}
+	if d.UpdatedAt.IsZero() {
+		d.UpdatedAt = d.CreatedAt
+	}
+	if d.ShippingAddress != nil && d.ShippingAddress.ID == "" {
+		d.ShippingAddress.ID = s.newID("MailingAddress")
+	}
+	if d.BillingAddress != nil && d.BillingAddress.ID == "" {
+		d.BillingAddress.ID = s.newID("MailingAddress")
+	}
+	for _, li := range d.LineItems {
+		if li.ID == "" {
+			li.ID = s.newID("DraftOrderLineItem")
+		}
+	}
+}
+
+func (s *Server) fillCustomer(c *Customer) {
+	if c.ID == "" {
+		c.ID = s.newID("Customer")
+	}
+	if c.State == "" {
+		c.State = "DISABLED"
+	}
+	if c.Locale == "" {
+		c.Locale = "en"
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = s.now()
+	}
+	if c.UpdatedAt.IsZero() {
+		c.UpdatedAt = c.CreatedAt
+	}
+	for _, a := range c.Addresses {
+		if a.ID == "" {
+			a.ID = s.newID("MailingAddress")
+		}
+	}
+	if c.DefaultAddressID == "" && len(c.Addresses) > 0 {
+		c.DefaultAddressID = c.Addresses[0].ID
+	}
+	for _, m := range c.Metafields {
+		s.fillMetafield(m)
+	}
+}
+
+func (s *Server) fillDiscount(d *Discount) {
+	if d.ID == "" {
+		if d.Automatic {
+			d.ID = s.newID("DiscountAutomaticNode")
+		} else {
+			d.ID = s.newID("DiscountCodeNode")
+		}
+	}
+	if d.Type == "" {
+		d.Type = "BASIC"
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = s.now()
+	}
+	if d.StartsAt.IsZero() {
+		d.StartsAt = d.CreatedAt
+	}
+	if d.UpdatedAt.IsZero() {
+		d.UpdatedAt = d.CreatedAt
+	}
+	for _, c := range d.Codes {
+		if c.ID == "" {
+			c.ID = s.newID("DiscountRedeemCode")
+		}
+	}
+}
+
+func (s *Server) fillMetafield(m *Metafield) {
+	if m.ID == "" {
+		m.ID = s.newID("Metafield")
+	}
+	if m.Type == "" {
+		m.Type = "single_line_text_field"
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = s.now()
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = m.CreatedAt
+	}
+}
+
+func (s *Server) fillImage(img *Image) {
+	if img.ID == "" {
+		img.ID = s.newID("ProductImage")
+	}
+}
+
+func (s *Server) product(id string) *Product {
+	for _, p := range s.products {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) variant(id string) (*Product, *Variant) {
+	for _, p := range s.products {
+		for _, v := range p.Variants {
+			if v.ID == id {
+				return p, v
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) inventoryItem(id string) (*Product, *Variant) {
+	for _, p := range s.products {
+		for _, v := range p.Variants {
+			if v.InventoryItemID == id {
+				return p, v
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) location(id string) *Location {
+	for _, l := range s.locations {
+		if l.ID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+func (s *Server) fulfillmentOrder(id string) (*FulfillmentOrder, *Order) {
+	for _, o := range s.orders {
+		for _, fo := range o.FulfillmentOrders {
+			if fo.ID == id {
+				return fo, o
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) fulfillment(id string) (*Fulfillment, *Order) {
+	for _, o := range s.orders {
+		for _, f := range o.Fulfillments {
+			if f.ID == id {
+				return f, o
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) refund(id string) (*Refund, *Order) {
+	for _, o := range s.orders {
+		for _, r := range o.Refunds {
+			if r.ID == id {
+				return r, o
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) orderReturn(id string) (*Return, *Order) {
+	for _, o := range s.orders {
+		for _, rt := range o.Returns {
+			if rt.ID == id {
+				return rt, o
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) collection(id string) *Collection {
+	for _, c := range s.collections {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Server) order(id string) *Order {
+	for _, o := range s.orders {
+		if o.ID == id {
+			return o
+		}
+	}
+	return nil
+}
+
+func (s *Server) draftOrder(id string) *DraftOrder {
+	for _, d := range s.draftOrders {
+		if d.ID == id {
+			return d
+		}
+	}
+	return nil
+}
+
+func (s *Server) customer(id string) *Customer {
+	for _, c := range s.customers {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Server) discount(id string) *Discount {
+	for _, d := range s.discounts {
+		if d.ID == id {
+			return d
+		}
+	}
+	return nil
+}
+
+func (s *Server) redeemCodeBulkCreation(id string) *redeemCodeBulkCreation {
+	for _, c := range s.codeCreations {
+		if c.ID() == graphqlserver.ID(id) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Server) webhookSubscription(id string) *WebhookSubscription {
+	for _, w := range s.webhooks {
+		if w.ID == id {
+			return w
+		}
+	}
+	return nil
+}
+
+func (s *Server) bulkOperation(id string) *BulkOperation {
+	for _, op := range s.bulkOperations {
+		if op.ID == id {
+			return op
+		}
+	}
+	return nil
+}
+
+// metafieldOwners returns the metafield lists of all the resources owning metafields.
+func (s *Server) metafieldOwners() []*[]*Metafield {
+	owners := []*[]*Metafield{&s.shopMetafields}
+	for _, p := range s.products {
+		owners = append(owners, &p.Metafields)
+		for _, v := range p.Variants {
+			owners = append(owners, &v.Metafields)
+		}
+	}
+	for _, c := range s.collections {
+		owners = append(owners, &c.Metafields)
+	}
+	for _, o := range s.orders {
+		owners = append(owners, &o.Metafields)
+	}
+	for _, c := range s.customers {
+		owners = append(owners, &c.Metafields)
+	}
+	return owners
+}
+
+func (s *Server) node(id string) *nodeResolver {
+	if p := s.product(id); p != nil {
+		return &nodeResolver{&productResolver{p: p, s: s}}
+	}
+	if p, v := s.variant(id); v != nil {
+		return &nodeResolver{&variantResolver{v: v, p: p, s: s}}
+	}
+	if p, v := s.inventoryItem(id); v != nil {
+		return &nodeResolver{&inventoryItemResolver{v: v, p: p, s: s}}
+	}
+	for _, p := range s.products {
+		for _, v := range p.Variants {
+			for _, l := range v.InventoryLevels {
+				if l.ID == id {
+					return &nodeResolver{&inventoryLevelResolver{l: l, v: v, p: p, s: s}}
+				}
+			}
+		}
+	}
+	if l := s.location(id); l != nil {
+		return &nodeResolver{&locationResolver{l: l, s: s}}
+	}
+	if c := s.collection(id); c != nil {
+		return &nodeResolver{&collectionResolver{c: c, s: s}}
+	}
+	for _, o := range s.orders {
+		if o.ID == id {
+			return &nodeResolver{&orderResolver{o: o, s: s}}
+		}
+		for _, fo := range o.FulfillmentOrders {
+			if fo.ID == id {
+				return &nodeResolver{&fulfillmentOrderResolver{fo: fo, o: o, s: s}}
+			}
+		}
+		for _, f := range o.Fulfillments {
+			if f.ID == id {
+				return &nodeResolver{&fulfillmentResolver{f: f, o: o, s: s}}
+			}
+		}
+		for _, r := range o.Refunds {
+			if r.ID == id {
+				return &nodeResolver{&refundResolver{r: r, o: o, s: s}}
+			}
+		}
+		for _, rt := range o.Returns {
+			if rt.ID == id {
+				return &nodeResolver{&returnResolver{rt: rt, o: o, s: s}}
+			}
+		}
+	}
+	if d := s.draftOrder(id); d != nil {
+		return &nodeResolver{&draftOrderResolver{d: d, s: s}}
+	}
+	if c := s.customer(id); c != nil {
+		return &nodeResolver{&customerResolver{c: c, s: s}}
+	}
+	if d := s.discount(id); d != nil {
+		return &nodeResolver{&discountNodeResolver{d: d, s: s}}
+	}
+	if c := s.redeemCodeBulkCreation(id); c != nil {
+		return &nodeResolver{c}
+	}
+	for _, g := range s.adjustmentGroups {
+		if g.id == id {
+			return &nodeResolver{g}
+		}
+	}
+	if w := s.webhookSubscription(id); w != nil {
+		return &nodeResolver{&webhookSubscriptionResolver{w: w}}
+	}
+	if op := s.bulkOperation(id); op != nil {
+		return &nodeResolver{&bulkOperationResolver{op: op}}
+	}
+	for _, owner := range s.metafieldOwners() {
+		for _, m := range *owner {
+			if m.ID == id {
+				return &nodeResolver{&metafieldResolver{m: m, ownerType: s.metafieldOwnerType(m)}}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) metafieldOwnerType(m *Metafield) string {
+	for _, p := range s.products {
+		for _, v := range p.Variants {
+			if containsMetafield(v.Metafields, m) {
+				return "PRODUCTVARIANT"
+			}
+		}
+		if containsMetafield(p.Metafields, m) {
+			return "PRODUCT"
+		}
+	}
+	for _, c := range s.collections {
+		if containsMetafield(c.Metafields, m) {
+			return "COLLECTION"
+		}
+	}
+	for _, o := range s.orders {
+		if containsMetafield(o.Metafields, m) {
+			return "ORDER"
+		}
+	}
+	for _, c := range s.customers {
+		if containsMetafield(c.Metafields, m) {
+			return "CUSTOMER"
+		}
+	}
+	return "SHOP"
+}
+
+func containsMetafield(metafields []*Metafield, m *Metafield) bool {
+	for _, mf := range metafields {
+		if mf == m {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,552 @@
+package shopifytest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	graphqlclient "github.com/gempages/go-shopify-graphql/graph"
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestProducts(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	snowboard := srv.AddProduct(&shopifytest.Product{
+		Title:    "Snowboard",
+		Options:  []shopifytest.ProductOption{{Name: "Size", Values: []string{"S", "M"}}},
+		Variants: []*shopifytest.Variant{{Title: "S", SKU: "SB-S", Price: "10.00"}, {Title: "M", SKU: "SB-M", Price: "12.00"}},
+	})
+
+	err := client.Product.Create(&shopify.ProductCreate{
+		ProductInput: shopify.ProductInput{Title: "Ski", Vendor: "Acme"},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	err = client.Product.Create(&shopify.ProductCreate{})
+	if !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors creating a product without title, got %v", err)
+	}
+
+	got, err := client.Product.Get(graphql.ID(snowboard.ID))
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Title != "Snowboard" || len(got.ProductVariants.Edges) != 2 || got.ProductVariants.Edges[1].Variant.SKU != "SB-M" {
+		t.Errorf("unexpected product: %+v", got)
+	}
+
+	products, err := client.Product.ListAll()
+	if err != nil {
+		t.Fatalf("list all: %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("expected 2 products, got %d", len(products))
+	}
+	if products[0].ID != snowboard.ID || len(products[0].ProductVariants) != 2 {
+		t.Errorf("expected the bulk result to include the variants of %s, got %+v", snowboard.ID, products[0])
+	}
+
+	err = client.Product.Delete(&shopify.ProductDelete{ProductInput: shopify.ProductDeleteInput{ID: snowboard.ID}})
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if n := len(srv.Products()); n != 1 {
+		t.Errorf("expected 1 product left, got %d", n)
+	}
+}
+
+func TestCollections(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	p := srv.AddProduct(&shopifytest.Product{Title: "Snowboard"})
+	id, err := client.Collection.Create(&shopify.CollectionCreate{
+		CollectionInput: shopify.CollectionInput{Title: "Winter", Products: []graphql.ID{p.ID}},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	collections, err := client.Collection.List("title:Winter")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(collections) != 1 || collections[0].ID != id || collections[0].Handle != "winter" {
+		t.Fatalf("unexpected collections: %+v", collections)
+	}
+	if len(collections[0].Products) != 1 || collections[0].Products[0].ID != p.ID {
+		t.Errorf("expected the collection to include %s, got %+v", p.ID, collections[0].Products)
+	}
+}
+
+func TestOrders(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	o := srv.AddOrder(&shopifytest.Order{
+		Email:     "buyer@example.com",
+		Customer:  &shopifytest.Customer{FirstName: "Jane", LastName: "Doe"},
+		LineItems: []*shopifytest.LineItem{{ID: "gid://shopify/LineItem/1", Title: "Snowboard", SKU: "SB-S", Quantity: 2, Price: "10.00"}},
+		FulfillmentOrders: []*shopifytest.FulfillmentOrder{{
+			AssignedLocationID: "gid://shopify/Location/1",
+			LineItems:          []*shopifytest.FulfillmentOrderLineItem{{LineItemID: "gid://shopify/LineItem/1", TotalQuantity: 2, RemainingQuantity: 2}},
+		}},
+	})
+
+	got, err := client.Order.Get(graphql.ID(o.ID))
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Name != "#1001" || got.Customer.DisplayName != "Jane Doe" || len(got.LineItems.Edges) != 1 {
+		t.Errorf("unexpected order: %+v", got)
+	}
+
+	err = client.Order.Update(shopify.OrderInput{ID: o.ID, Note: "gift"})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if o.Note != "gift" {
+		t.Errorf("expected the note to be updated, got %q", o.Note)
+	}
+
+	orders, err := client.Order.ListAll()
+	if err != nil {
+		t.Fatalf("list all: %v", err)
+	}
+	if len(orders) != 1 || len(orders[0].LineItems) != 1 || orders[0].LineItems[0].Quantity != 2 {
+		t.Errorf("unexpected orders: %+v", orders)
+	}
+
+	fulfillmentOrders, err := client.Order.GetFulfillmentOrdersAtLocation(graphql.ID(o.ID), "gid://shopify/Location/1")
+	if err != nil {
+		t.Fatalf("fulfillment orders: %v", err)
+	}
+	if len(fulfillmentOrders) != 1 || len(fulfillmentOrders[0].FulfillmentOrderLineItems) != 1 {
+		t.Errorf("unexpected fulfillment orders: %+v", fulfillmentOrders)
+	}
+}
+
+func TestShopMetafields(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	m := srv.AddShopMetafield(&shopifytest.Metafield{Namespace: "app", Key: "plan", Value: "pro"})
+	srv.AddShopMetafield(&shopifytest.Metafield{Namespace: "other", Key: "key", Value: "value"})
+
+	metafields, err := client.Metafield.ListShopMetafieldsByNamespace("app")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(metafields) != 1 || metafields[0].Value != "pro" {
+		t.Errorf("unexpected metafields: %+v", metafields)
+	}
+
+	got, err := client.Metafield.GetShopMetafieldByKey("app", "plan")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.ID != m.ID {
+		t.Errorf("expected metafield %s, got %+v", m.ID, got)
+	}
+
+	err = client.Metafield.Delete(shopify.MetafieldDeleteInput{ID: m.ID})
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if n := len(srv.ShopMetafields()); n != 1 {
+		t.Errorf("expected 1 metafield left, got %d", n)
+	}
+}
+
+func TestWebhookSubscriptions(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	topic := shopify.WebhookTopic{WebhookSubscriptionTopic: shopify.WebhookSubscriptionTopicProductsCreate}
+	_, err := client.Webhook.NewWebhookSubscription(topic, shopify.WebhookTopicSubscription{
+		WebhookSubscriptionInput: shopify.WebhookSubscriptionInput{CallbackURL: "https://example.com/webhooks", Format: "JSON"},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	// the same address can't be subscribed twice to a topic
+	_, err = client.Webhook.NewWebhookSubscription(topic, shopify.WebhookTopicSubscription{
+		WebhookSubscriptionInput: shopify.WebhookSubscriptionInput{CallbackURL: "https://example.com/webhooks", Format: "JSON"},
+	})
+	if !errors.Is(err, shopify.ErrUserErrors) {
+		t.Errorf("expected user errors, got %v", err)
+	}
+
+	pubSub, err := client.Webhook.NewPubSubWebhookSubscription(topic, shopify.WebhookTopicSubscription{
+		PubSubWebhookSubscriptionInput: shopify.PubSubWebhookSubscriptionInput{PubSubProject: "my-project", PubSubTopic: "products", Format: "JSON"},
+	})
+	if err != nil {
+		t.Fatalf("create pubsub: %v", err)
+	}
+	if pubSub.WebhookSubscription.Endpoint.PubSubTopic != "products" {
+		t.Errorf("unexpected pubsub subscription %+v", pubSub.WebhookSubscription)
+	}
+	if _, err = client.Webhook.DeleteWebhook(pubSub.WebhookSubscription.ID.(string)); err != nil {
+		t.Fatalf("delete pubsub: %v", err)
+	}
+
+	webhooks, err := client.Webhook.ListWebhookSubscriptions([]shopify.WebhookSubscriptionTopic{shopify.WebhookSubscriptionTopicProductsCreate})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(webhooks) != 1 || webhooks[0].CallbackURL != "https://example.com/webhooks" {
+		t.Fatalf("unexpected webhooks: %+v", webhooks)
+	}
+
+	_, err = client.Webhook.DeleteWebhook(webhooks[0].ID.(string))
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if n := len(srv.WebhookSubscriptions()); n != 0 {
+		t.Errorf("expected no webhook subscription left, got %d", n)
+	}
+}
+
+func TestInvalidAccessToken(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client(graphqlclient.WithToken("wrong"))
+
+	_, err := client.Product.Get("gid://shopify/Product/1")
+	var httpErr *graphql.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("expected an HTTP error, got %v", err)
+	}
+}
+
+func TestIter(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	for _, title := range []string{"Snowboard", "Ski", "Boots"} {
+		srv.AddProduct(&shopifytest.Product{Title: title})
+	}
+
+	it := client.Product.IterWithContext(context.Background(), shopify.ListOptions{First: 2})
+	var titles []string
+	for it.Next() {
+		titles = append(titles, string(it.Node().Title))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iter: %v", err)
+	}
+	if strings.Join(titles, ",") != "Snowboard,Ski,Boots" {
+		t.Errorf("unexpected products: %v", titles)
+	}
+
+	backward, err := client.Product.IterWithContext(context.Background(), shopify.ListOptions{Last: 2}).All()
+	if err != nil {
+		t.Fatalf("iter backward: %v", err)
+	}
+	if len(backward) != 3 || backward[0].Title != "Boots" {
+		t.Errorf("expected products from last to first, got %+v", backward)
+	}
+
+	srv.AddCollection(&shopifytest.Collection{Title: "Winter"})
+	collections, err := client.Collection.IterWithContext(context.Background(), shopify.ListOptions{}).All()
+	if err != nil || len(collections) != 1 {
+		t.Errorf("iter collections: %v, %+v", err, collections)
+	}
+	srv.AddOrder(&shopifytest.Order{LineItems: []*shopifytest.LineItem{{Title: "Ski", Quantity: 1}}})
+	orders, err := client.Order.IterWithContext(context.Background(), shopify.ListOptions{}).All()
+	if err != nil || len(orders) != 1 || len(orders[0].LineItems.Edges) != 1 {
+		t.Errorf("iter orders: %v, %+v", err, orders)
+	}
+	srv.AddShopMetafield(&shopifytest.Metafield{Namespace: "app", Key: "plan", Value: "pro"})
+	metafields, err := client.Metafield.IterWithContext(context.Background(), "app", shopify.ListOptions{}).All()
+	if err != nil || len(metafields) != 1 {
+		t.Errorf("iter metafields: %v, %+v", err, metafields)
+	}
+}
+
+func TestStreamBulkQuery(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	srv.AddProduct(&shopifytest.Product{Title: "Snowboard", Variants: []*shopifytest.Variant{
+		{SKU: "SB-S"},
+		{SKU: "SB-M", Metafields: []*shopifytest.Metafield{{Namespace: "custom", Key: "weight", Value: "3", Type: "number_integer"}}},
+	}})
+	srv.AddProduct(&shopifytest.Product{Title: "Ski", Variants: []*shopifytest.Variant{{SKU: "SKI"}}})
+
+	query := `{ products { edges { node { id title variants { edges { node { id sku metafields { edges { node { id key value } } } } } } } } } }`
+	var skus []string
+	err := shopify.StreamBulkQuery(context.Background(), client.BulkOperation, query, func(p *shopify.ProductBulkResult) error {
+		for _, v := range p.ProductVariants {
+			skus = append(skus, string(p.Title)+"/"+string(v.SKU))
+			for _, m := range v.Metafields {
+				skus = append(skus, string(v.SKU)+"."+string(m.Key)+"="+string(m.Value))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	if got := strings.Join(skus, ","); got != "Snowboard/SB-S,Snowboard/SB-M,SB-M.weight=3,Ski/SKI" {
+		t.Errorf("unexpected variants %s", got)
+	}
+
+	stop := errors.New("stop")
+	n := 0
+	err = shopify.StreamBulkQuery(context.Background(), client.BulkOperation, query, func(p *shopify.ProductBulkResult) error {
+		n++
+		return stop
+	})
+	if !errors.Is(err, stop) || n != 1 {
+		t.Errorf("expected to stop after the first product with (%v), got (%v) after %d", stop, err, n)
+	}
+}
+
+func TestBulkMutations(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	err := client.Product.CreateBulk([]*shopify.ProductCreate{
+		{ProductInput: shopify.ProductInput{Title: "Snowboard"}},
+		{ProductInput: shopify.ProductInput{}},
+		{ProductInput: shopify.ProductInput{Title: "Ski"}},
+	})
+	var bulkErr *shopify.BulkMutationError
+	if !errors.As(err, &bulkErr) || !errors.Is(err, shopify.ErrUserErrors) {
+		t.Fatalf("expected a bulk mutation error with user errors, got %v", err)
+	}
+	if len(bulkErr.Failures) != 1 || bulkErr.Failures[0].Index != 1 {
+		t.Errorf("expected the product without title to fail, got %+v", bulkErr.Failures)
+	}
+	products := srv.Products()
+	if len(products) != 2 {
+		t.Fatalf("expected 2 products, got %d", len(products))
+	}
+
+	err = client.Product.UpdateBulk([]*shopify.ProductUpdate{
+		{ProductInput: shopify.ProductInput{ID: products[0].ID, Vendor: "Acme"}},
+		{ProductInput: shopify.ProductInput{ID: products[1].ID, Vendor: "Acme"}},
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if v := srv.Products()[1].Vendor; v != "Acme" {
+		t.Errorf("expected vendor (%v), got (%v)", "Acme", v)
+	}
+
+	err = client.Product.DeleteBulk([]*shopify.ProductDelete{
+		{ProductInput: shopify.ProductDeleteInput{ID: "gid://shopify/Product/999"}},
+		{ProductInput: shopify.ProductDeleteInput{ID: products[0].ID}},
+	})
+	if !errors.As(err, &bulkErr) || len(bulkErr.Failures) != 1 || bulkErr.Failures[0].Index != 0 {
+		t.Errorf("expected the unknown product to fail, got %v", err)
+	}
+	if n := len(srv.Products()); n != 1 {
+		t.Errorf("expected 1 product left, got %d", n)
+	}
+
+	m := srv.AddShopMetafield(&shopifytest.Metafield{Namespace: "app", Key: "plan", Value: "pro"})
+	err = client.Metafield.DeleteBulk([]shopify.MetafieldDeleteInput{{ID: m.ID}})
+	if err != nil {
+		t.Fatalf("delete metafields: %v", err)
+	}
+	if n := len(srv.ShopMetafields()); n != 0 {
+		t.Errorf("expected no metafield left, got %d", n)
+	}
+
+	ops := srv.BulkOperations()
+	if len(ops) != 4 || ops[0].Type != "MUTATION" {
+		t.Errorf("expected 4 bulk mutations, got %+v", ops)
+	}
+}
+
+func TestBulkMutate(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	type productCreate struct {
+		ProductCreate struct {
+			Product struct {
+				Title string
+			}
+		}
+	}
+	mutation := `mutation call($input: ProductInput!) { productCreate(input: $input) { product { title } } }`
+	results, err := shopify.BulkMutate[productCreate](context.Background(), client.BulkOperation, mutation, []map[string]interface{}{
+		{"input": map[string]interface{}{"title": "Snowboard"}},
+		{"input": map[string]interface{}{"title": 1}},
+	})
+	if err != nil {
+		t.Fatalf("bulk mutate: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Err != nil || results[0].Data.ProductCreate.Product.Title != "Snowboard" {
+		t.Errorf("unexpected first result %+v", results[0])
+	}
+	var gqlErrs graphql.Errors
+	if results[1].Index != 1 || !errors.As(results[1].Err, &gqlErrs) {
+		t.Errorf("expected GraphQL errors for an invalid title, got %+v", results[1])
+	}
+
+	results, err = shopify.BulkMutate[productCreate](context.Background(), client.BulkOperation, mutation, nil)
+	if err != nil || results != nil {
+		t.Errorf("expected no result for no input, got (%v, %v)", results, err)
+	}
+}
+
+func TestBulkOperationGet(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	srv.AddProduct(&shopifytest.Product{Title: "Snowboard"})
+	srv.AddCollection(&shopifytest.Collection{Title: "Winter"})
+	id, err := client.Product.TriggerListAll()
+	if err != nil {
+		t.Fatalf("trigger: %v", err)
+	}
+	// another operation becomes the current one
+	if _, err = client.Collection.ListAll(); err != nil {
+		t.Fatalf("list collections: %v", err)
+	}
+
+	op, err := client.BulkOperation.WaitWithContext(ctx, id)
+	if err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if op.ID != id || op.Status != "COMPLETED" || op.URL == "" {
+		t.Fatalf("unexpected operation %+v", op)
+	}
+	var products []*shopify.ProductBulkResult
+	if err = client.BulkOperation.MarshalBulkResultWithContext(ctx, string(op.URL), &products); err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	if len(products) != 1 {
+		t.Errorf("expected 1 product, got %d", len(products))
+	}
+
+	if _, err = client.BulkOperation.GetWithContext(ctx, "gid://shopify/BulkOperation/999"); err == nil {
+		t.Error("expected an error for an unknown operation")
+	}
+}
+
+func TestBulkJobsResume(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	srv.AddProduct(&shopifytest.Product{Title: "Snowboard"})
+	srv.AddProduct(&shopifytest.Product{Title: "Ski"})
+	store := shopify.NewFileBulkJobStore(filepath.Join(t.TempDir(), "bulk_jobs.json"))
+
+	jobs := shopify.NewBulkJobs(client.BulkOperation, store)
+	job, err := jobs.Start(ctx, "products", `{ products { edges { node { id title } } } }`)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if _, err = jobs.Start(ctx, "unknown", `{ products { edges { node { id } } } }`); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	// after a restart, the pending jobs are processed by new handlers
+	jobs = shopify.NewBulkJobs(srv.Client().BulkOperation, store)
+	var titles []string
+	jobs.Handle("products", shopify.HandleBulkResult(func(ctx context.Context, j *shopify.BulkJob, p *shopify.ProductBulkResult) error {
+		titles = append(titles, string(p.Title))
+		return nil
+	}))
+	err = jobs.Resume(ctx)
+	if err == nil || !strings.Contains(err.Error(), "unknown") {
+		t.Errorf("expected an error for the job without handler, got %v", err)
+	}
+	if strings.Join(titles, ",") != "Snowboard,Ski" {
+		t.Errorf("unexpected titles %v", titles)
+	}
+
+	got, err := store.Get(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("get job: %v", err)
+	}
+	if got.Status != shopify.BulkJobStatusDone || got.OperationStatus != "COMPLETED" || got.URL == "" {
+		t.Errorf("unexpected job %+v", got)
+	}
+
+	// done jobs are not handled again
+	titles = nil
+	jobs.Handle("unknown", func(ctx context.Context, j *shopify.BulkJob, result io.Reader) error { return nil })
+	if err = jobs.Resume(ctx); err != nil {
+		t.Errorf("resume: %v", err)
+	}
+	if len(titles) != 0 {
+		t.Errorf("expected no product handled again, got %v", titles)
+	}
+}
+
+func TestBulkOperationWebhook(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	srv.PendingBulkOperations = true
+	client := srv.Client()
+	ctx := context.Background()
+
+	srv.AddProduct(&shopifytest.Product{Title: "Snowboard"})
+	notifier := shopify.NewBulkOperationNotifier(srv.APISecret)
+	callback := httptest.NewServer(notifier)
+	defer callback.Close()
+
+	if err := client.BulkOperation.UseWebhookWithContext(ctx, callback.URL, notifier); err != nil {
+		t.Fatalf("use webhook: %v", err)
+	}
+	// the existing subscription is reused
+	if err := client.BulkOperation.UseWebhookWithContext(ctx, callback.URL, notifier); err != nil {
+		t.Fatalf("use webhook again: %v", err)
+	}
+	if subs := srv.WebhookSubscriptions(); len(subs) != 1 || subs[0].Topic != "BULK_OPERATIONS_FINISH" || subs[0].CallbackURL != callback.URL {
+		t.Fatalf("unexpected subscriptions %+v", subs)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		errs <- srv.FinishBulkOperations()
+	}()
+
+	start := time.Now()
+	products, err := client.Product.ListAll()
+	if err != nil {
+		t.Fatalf("list products: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("expected the webhook to end the wait before the next poll, took %s", elapsed)
+	}
+	if len(products) != 1 {
+		t.Errorf("expected 1 product, got %d", len(products))
+	}
+	if err = <-errs; err != nil {
+		t.Errorf("finish bulk operations: %v", err)
+	}
+}
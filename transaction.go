@@ -0,0 +1,153 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
+)
+
+type OrderCaptureInput struct {
+	// ID is the ID of the order.
+	ID                  graphql.ID   `json:"id"`
+	ParentTransactionID graphql.ID   `json:"parentTransactionId"`
+	Amount              Money        `json:"amount"`
+	Currency            CurrencyCode `json:"currency,omitempty"`
+	// FinalCapture releases the rest of the authorized amount, when the gateway supports
+	// multiple captures.
+	FinalCapture graphql.Boolean `json:"finalCapture,omitempty"`
+}
+
+const orderTransactionQuery = `
+	id
+	kind
+	status
+	gateway
+	test
+	processedAt
+	amountSet{
+		presentmentMoney{
+			amount
+			currencyCode
+		}
+		shopMoney{
+			amount
+			currencyCode
+		}
+	}
+	parentTransaction{
+		id
+	}
+`
+
+var (
+	orderCaptureMutation = fmt.Sprintf(`
+		mutation orderCapture($input: OrderCaptureInput!) {
+			orderCapture(input: $input){
+				transaction{
+					%s
+				}
+				userErrors{
+					field
+					message
+				}
+			}
+		}
+	`, orderTransactionQuery)
+
+	orderMarkAsPaidMutation = fmt.Sprintf(`
+		mutation orderMarkAsPaid($input: OrderMarkAsPaidInput!) {
+			orderMarkAsPaid(input: $input){
+				order{
+					id
+					name
+					displayFinancialStatus
+					totalReceivedSet{
+						presentmentMoney{
+							amount
+							currencyCode
+						}
+						shopMoney{
+							amount
+							currencyCode
+						}
+					}
+					transactions{
+						%s
+					}
+				}
+				userErrors{
+					field
+					message
+				}
+			}
+		}
+	`, orderTransactionQuery)
+)
+
+func (s *OrderServiceOp) Transactions(id graphql.ID) ([]OrderTransaction, error) {
+	return s.TransactionsWithContext(s.client.gql.Context(), id)
+}
+
+func (s *OrderServiceOp) TransactionsWithContext(ctx context.Context, id graphql.ID) ([]OrderTransaction, error) {
+	q := fmt.Sprintf(`
+		query orderTransactions($id: ID!) {
+			order(id: $id){
+				transactions{
+					%s
+				}
+			}
+		}
+	`, orderTransactionQuery)
+
+	out := struct {
+		Order *struct {
+			Transactions []OrderTransaction `json:"transactions"`
+		} `json:"order"`
+	}{}
+	err := utils.ExecWithRetries(s.client.retries, func() error {
+		return s.client.gql.QueryString(ctx, q, map[string]interface{}{"id": id}, &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.Order == nil {
+		return nil, fmt.Errorf("order %v not found", id)
+	}
+
+	return out.Order.Transactions, nil
+}
+
+func (s *OrderServiceOp) Capture(input OrderCaptureInput) (*OrderTransaction, error) {
+	return s.CaptureWithContext(s.client.gql.Context(), input)
+}
+
+func (s *OrderServiceOp) CaptureWithContext(ctx context.Context, input OrderCaptureInput) (*OrderTransaction, error) {
+	payload := struct {
+		Transaction *OrderTransaction `json:"transaction"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, orderCaptureMutation, map[string]interface{}{"input": input}, &payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload.Transaction, nil
+}
+
+func (s *OrderServiceOp) MarkAsPaid(id graphql.ID) (*OrderBase, error) {
+	return s.MarkAsPaidWithContext(s.client.gql.Context(), id)
+}
+
+func (s *OrderServiceOp) MarkAsPaidWithContext(ctx context.Context, id graphql.ID) (*OrderBase, error) {
+	vars := map[string]interface{}{
+		"input": map[string]interface{}{"id": id},
+	}
+	payload := struct {
+		Order *OrderBase `json:"order"`
+	}{}
+	err := s.client.mutate(ctx, retryThrottled, orderMarkAsPaidMutation, vars, &payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload.Order, nil
+}
@@ -25,6 +25,11 @@ func ReadFile(file string) (data string, err error) {
 }
 
 func DownloadFile(ctx context.Context, filepath string, url string) error {
+	return DownloadFileWithClient(ctx, http.DefaultClient, filepath, url)
+}
+
+// DownloadFileWithClient downloads url to filepath using httpClient.
+func DownloadFileWithClient(ctx context.Context, httpClient *http.Client, filepath string, url string) error {
 	var err error
 
 	span := sentry.StartSpan(ctx, "shopify.download_file")
@@ -33,7 +38,11 @@ func DownloadFile(ctx context.Context, filepath string, url string) error {
 		tracing.FinishSpan(span, err)
 	}()
 
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -1,10 +1,14 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
+	"syscall"
 	"time"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
 )
 
 func IsOperationUrlEmptyError(err error) bool {
@@ -12,19 +16,19 @@ func IsOperationUrlEmptyError(err error) bool {
 }
 
 func IsInvalidTokenError(err error) bool {
-	return err != nil && strings.Contains(err.Error(), "Invalid API key or access token")
+	return errors.Is(err, graphql.ErrUnauthorized)
 }
 
 func IsInvalidStorefrontTokenError(err error) bool {
-	return err != nil && strings.Contains(err.Error(), "401 Unauthorized body")
+	return errors.Is(err, graphql.ErrUnauthorized)
 }
 
 func IsMaxCostLimitError(err error) bool {
-	return err != nil && strings.Contains(err.Error(), "max cost limit")
+	return errors.Is(err, graphql.ErrMaxCostExceeded)
 }
 
 func IsPermissionError(err error) bool {
-	return err != nil && strings.Contains(err.Error(), "403 Forbidden")
+	return errors.Is(err, graphql.ErrAccessDenied)
 }
 
 func IsNoHostInRequestError(err error) bool {
@@ -32,14 +36,30 @@ func IsNoHostInRequestError(err error) bool {
 }
 
 func IsThrottledError(err error) bool {
-	return err != nil && err.Error() == "Throttled"
+	return errors.Is(err, graphql.ErrThrottled)
 }
 
 func IsConnectionError(err error) bool {
-	return err != nil && (strings.Contains(err.Error(), "connection reset by peer") || strings.Contains(err.Error(), "broken pipe"))
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
 }
 
+// ExecWithRetries runs f, running it again after a timeout, a lost connection or throttling, at
+// most retryCount more times.
 func ExecWithRetries(retryCount int, f func() error) error {
+	return execWithRetries(retryCount, f, func(err error) bool {
+		var uerr *url.Error
+		return errors.As(err, &uerr) && (uerr.Timeout() || uerr.Temporary()) || IsThrottledError(err) || IsConnectionError(err)
+	})
+}
+
+// ExecWithThrottleRetries runs f, running it again only after throttling, at most retryCount more
+// times. Shopify rejects throttled requests before running them, so it suits mutations that a retry
+// after a lost response would apply twice.
+func ExecWithThrottleRetries(retryCount int, f func() error) error {
+	return execWithRetries(retryCount, f, IsThrottledError)
+}
+
+func execWithRetries(retryCount int, f func() error, retryable func(error) bool) error {
 	var (
 		retries = 0
 		err     error
@@ -47,7 +67,7 @@ func ExecWithRetries(retryCount int, f func() error) error {
 	for {
 		err = f()
 		if err != nil {
-			if uerr, isURLErr := err.(*url.Error); isURLErr && (uerr.Timeout() || uerr.Temporary()) || IsThrottledError(err) || IsConnectionError(err) {
+			if retryable(err) {
 				retries++
 				if retries > retryCount {
 					return fmt.Errorf("after %v tries: %w", retries, err)
@@ -1,35 +1,10 @@
 package utils
 
-import (
-	"encoding/json"
-	"strings"
-
-	"github.com/vektah/gqlparser/v2/ast"
-	"github.com/vektah/gqlparser/v2/parser"
-)
+import "github.com/gempages/go-shopify-graphql/graphql"
 
+// GetDescriptionFromQuery returns the comma separated names of the top level fields selected by query.
+//
+// Deprecated: use graphql.QueryDescription.
 func GetDescriptionFromQuery(query string) string {
-	var description string
-	queryDoc, err := parser.ParseQuery(&ast.Source{Input: query})
-	if err != nil {
-		description = ""
-	} else {
-		for _, op := range queryDoc.Operations {
-			// Get all selection in an operation
-			for _, selectionSet := range op.SelectionSet {
-				field := &ast.Field{}
-				data, err := json.Marshal(selectionSet)
-				if err != nil {
-					break
-				}
-				err = json.Unmarshal(data, field)
-				if err != nil {
-					break
-				}
-				description += "," + field.Name
-			}
-		}
-		description = strings.Trim(description, ",")
-	}
-	return description
+	return graphql.QueryDescription(query)
 }
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// VerifyWebhookHmac reports whether signature is the base64 HMAC-SHA256 of body keyed with secret, as
+// Shopify sends it in the X-Shopify-Hmac-Sha256 header of a webhook.
+func VerifyWebhookHmac(secret string, body []byte, signature string) bool {
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
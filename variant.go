@@ -2,7 +2,6 @@ package shopify
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/gempages/go-shopify-graphql/graphql"
@@ -10,6 +9,7 @@ import (
 
 type VariantService interface {
 	Update(variant *ProductVariantUpdate) error
+	UpdateWithContext(ctx context.Context, variant *ProductVariantUpdate) error
 }
 
 type VariantServiceOp struct {
@@ -39,6 +39,7 @@ type ProductVariant struct {
 	CreatedAt           time.Time                        `json:"createdAt,omitempty"`
 	UpdatedAt           time.Time                        `json:"updatedAt,omitempty"`
 	CurrentlyNotInStock graphql.Boolean                  `json:"currentlyNotInStock,omitempty"`
+	Metafields          []Metafield                      `json:"metafields,omitempty"`
 }
 
 type SelectedOption struct {
@@ -156,18 +157,22 @@ type productVariantUpdateResult struct {
 }
 
 func (s *VariantServiceOp) Update(variant *ProductVariantUpdate) error {
+	return s.UpdateWithContext(s.client.gql.Context(), variant)
+}
+
+func (s *VariantServiceOp) UpdateWithContext(ctx context.Context, variant *ProductVariantUpdate) error {
 	m := mutationProductVariantUpdate{}
 
 	vars := map[string]interface{}{
 		"input": variant.ProductVariantInput,
 	}
-	err := s.client.gql.Mutate(context.Background(), &m, vars)
+	err := s.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		return err
 	}
 
 	if len(m.ProductVariantUpdateResult.UserErrors) > 0 {
-		return fmt.Errorf("%+v", m.ProductVariantUpdateResult.UserErrors)
+		return &UserErrorsError{UserErrors: m.ProductVariantUpdateResult.UserErrors}
 	}
 
 	return nil
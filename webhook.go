@@ -11,11 +11,24 @@ import (
 )
 
 type WebhookService interface {
-	NewWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output WebhookSubscriptionCreatePayload)
-	NewEventBridgeWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output EventBridgeWebhookSubscriptionCreatePayload)
+	NewWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output WebhookSubscriptionCreatePayload, err error)
+	NewWebhookSubscriptionWithContext(ctx context.Context, topic WebhookTopic, input WebhookTopicSubscription) (output WebhookSubscriptionCreatePayload, err error)
+	NewEventBridgeWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output EventBridgeWebhookSubscriptionCreatePayload, err error)
+	NewEventBridgeWebhookSubscriptionWithContext(ctx context.Context, topic WebhookTopic, input WebhookTopicSubscription) (output EventBridgeWebhookSubscriptionCreatePayload, err error)
+	NewPubSubWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output PubSubWebhookSubscriptionCreatePayload, err error)
+	NewPubSubWebhookSubscriptionWithContext(ctx context.Context, topic WebhookTopic, input WebhookTopicSubscription) (output PubSubWebhookSubscriptionCreatePayload, err error)
 
 	ListWebhookSubscriptions(topics []WebhookSubscriptionTopic) (output []*WebhookSubscription, err error)
+	ListWebhookSubscriptionsWithContext(ctx context.Context, topics []WebhookSubscriptionTopic) (output []*WebhookSubscription, err error)
+	// Iter returns an iterator over the webhook subscriptions to topics, or to all topics when it's empty,
+	// fetching pages on demand.
+	Iter(topics []WebhookSubscriptionTopic, opts ListOptions) *Iterator[*WebhookSubscription]
+	IterWithContext(ctx context.Context, topics []WebhookSubscriptionTopic, opts ListOptions) *Iterator[*WebhookSubscription]
 	DeleteWebhook(webhookID string) (output WebhookSubscriptionDeletePayload, err error)
+	DeleteWebhookWithContext(ctx context.Context, webhookID string) (output WebhookSubscriptionDeletePayload, err error)
+	// Sync creates, updates and deletes webhook subscriptions so they match desired.
+	Sync(desired []WebhookSpec, opts WebhookSyncOptions) (*WebhookSyncReport, error)
+	SyncWithContext(ctx context.Context, desired []WebhookSpec, opts WebhookSyncOptions) (*WebhookSyncReport, error)
 }
 
 type WebhookServiceOp struct {
@@ -29,6 +42,13 @@ type EventBridgeWebhookSubscriptionCreatePayload struct {
 	WebhookSubscription WebhookSubscription `json:"webhookSubscription,omitempty"`
 }
 
+type PubSubWebhookSubscriptionCreatePayload struct {
+	// The list of errors that occurred from executing the mutation.
+	UserErrors []UserErrors `json:"userErrors,omitempty"`
+	// The webhook subscription that was created.
+	WebhookSubscription WebhookSubscription `json:"webhookSubscription,omitempty"`
+}
+
 type WebhookSubscriptionCreatePayload struct {
 	// The list of errors that occurred from executing the mutation.
 	UserErrors []UserErrors `json:"userErrors,omitempty"`
@@ -88,6 +108,7 @@ type WebhookSubscription struct {
 type WebhookSubscriptionEndpoint struct {
 	WebhookHTTPEndpoint        `graphql:"... on WebhookHttpEndpoint"`
 	WebhookEventBridgeEndpoint `graphql:"... on WebhookEventBridgeEndpoint"`
+	WebhookPubSubEndpoint      `graphql:"... on WebhookPubSubEndpoint"`
 }
 
 // Amazon EventBridge event source.
@@ -137,6 +158,19 @@ type EventBridgeWebhookSubscriptionInput struct {
 	MetafieldNamespaces []string `json:"metafieldNamespaces,omitempty"`
 }
 
+type PubSubWebhookSubscriptionInput struct {
+	// The Google Cloud Pub/Sub project ID.
+	PubSubProject graphql.String `json:"pubSubProject,omitempty"`
+	// The Google Cloud Pub/Sub topic ID.
+	PubSubTopic graphql.String `json:"pubSubTopic,omitempty"`
+	// The format in which the webhook subscription should send the data.
+	Format WebhookSubscriptionFormat `json:"format,omitempty"`
+	// The list of fields to be included in the webhook subscription.
+	IncludeFields []string `json:"includeFields,omitempty"`
+	// The list of namespaces for any metafields that should be included in the webhook subscription.
+	MetafieldNamespaces []string `json:"metafieldNamespaces,omitempty"`
+}
+
 type mutationWebhookCreate struct {
 	WebhookCreateResult WebhookSubscriptionCreatePayload `graphql:"webhookSubscriptionCreate(topic: $topic, webhookSubscription: $webhookSubscription)" json:"webhookSubscriptionCreate"`
 }
@@ -149,6 +183,10 @@ type mutationEventBridgeWebhookCreate struct {
 	EventBridgeWebhookCreateResult EventBridgeWebhookSubscriptionCreatePayload `graphql:"eventBridgeWebhookSubscriptionCreate(topic: $topic, webhookSubscription: $webhookSubscription)" json:"eventBridgeWebhookSubscriptionCreate"`
 }
 
+type mutationPubSubWebhookCreate struct {
+	PubSubWebhookCreateResult PubSubWebhookSubscriptionCreatePayload `graphql:"pubSubWebhookSubscriptionCreate(topic: $topic, webhookSubscription: $webhookSubscription)" json:"pubSubWebhookSubscriptionCreate"`
+}
+
 type WebhookTopic struct {
 	WebhookSubscriptionTopic WebhookSubscriptionTopic
 }
@@ -156,93 +194,157 @@ type WebhookTopic struct {
 type WebhookTopicSubscription struct {
 	WebhookSubscriptionInput            WebhookSubscriptionInput
 	EventBridgeWebhookSubscriptionInput EventBridgeWebhookSubscriptionInput
+	PubSubWebhookSubscriptionInput      PubSubWebhookSubscriptionInput
 }
 
 const (
-	WebhookSubscriptionTopicProductsCreate      = WebhookSubscriptionTopic("PRODUCTS_CREATE")
-	WebhookSubscriptionTopicProductsUpdate      = WebhookSubscriptionTopic("PRODUCTS_UPDATE")
-	WebhookSubscriptionTopicProductsDelete      = WebhookSubscriptionTopic("PRODUCTS_DELETE")
-	WebhookSubscriptionTopicCollectionsCreate   = WebhookSubscriptionTopic("COLLECTIONS_CREATE")
-	WebhookSubscriptionTopicCollectionsUpdate   = WebhookSubscriptionTopic("COLLECTIONS_UPDATE")
-	WebhookSubscriptionTopicCollectionsDelete   = WebhookSubscriptionTopic("COLLECTIONS_DELETE")
-	WebhookSubscriptionTopicShopUpdate          = WebhookSubscriptionTopic("SHOP_UPDATE")
-	WebhookSubscriptionTopicAppUninstall        = WebhookSubscriptionTopic("APP_UNINSTALLED")
-	WebhookSubscriptionTopicThemesPublish       = WebhookSubscriptionTopic("THEMES_PUBLISH")
-	WebhookSubscriptionTopicThemesCreate        = WebhookSubscriptionTopic("THEMES_CREATE")
-	WebhookSubscriptionTopicThemesUpdate        = WebhookSubscriptionTopic("THEMES_UPDATE")
-	WebhookSubscriptionTopicCustomersUpdate     = WebhookSubscriptionTopic("CUSTOMERS_UPDATE")
-	WebhookSubscriptionTopicCustomersCreate     = WebhookSubscriptionTopic("CUSTOMERS_CREATE")
-	WebhookSubscriptionTopicCustomersDelete     = WebhookSubscriptionTopic("CUSTOMERS_DELETE")
-	WebhookSubscriptionTopicCustomerGroupCreate = WebhookSubscriptionTopic("CUSTOMER_GROUPS_CREATE")
-	WebhookSubscriptionTopicCustomerGroupUpdate = WebhookSubscriptionTopic("CUSTOMER_GROUPS_UPDATE")
-	WebhookSubscriptionTopicCartCreate          = WebhookSubscriptionTopic("CARTS_CREATE")
-	WebhookSubscriptionTopicCartUpdate          = WebhookSubscriptionTopic("CARTS_UPDATE")
-	WebhookSubscriptionTopicCheckoutUpdate      = WebhookSubscriptionTopic("CHECKOUTS_UPDATE")
+	WebhookSubscriptionTopicProductsCreate       = WebhookSubscriptionTopic("PRODUCTS_CREATE")
+	WebhookSubscriptionTopicProductsUpdate       = WebhookSubscriptionTopic("PRODUCTS_UPDATE")
+	WebhookSubscriptionTopicProductsDelete       = WebhookSubscriptionTopic("PRODUCTS_DELETE")
+	WebhookSubscriptionTopicCollectionsCreate    = WebhookSubscriptionTopic("COLLECTIONS_CREATE")
+	WebhookSubscriptionTopicCollectionsUpdate    = WebhookSubscriptionTopic("COLLECTIONS_UPDATE")
+	WebhookSubscriptionTopicCollectionsDelete    = WebhookSubscriptionTopic("COLLECTIONS_DELETE")
+	WebhookSubscriptionTopicShopUpdate           = WebhookSubscriptionTopic("SHOP_UPDATE")
+	WebhookSubscriptionTopicAppUninstall         = WebhookSubscriptionTopic("APP_UNINSTALLED")
+	WebhookSubscriptionTopicThemesPublish        = WebhookSubscriptionTopic("THEMES_PUBLISH")
+	WebhookSubscriptionTopicThemesCreate         = WebhookSubscriptionTopic("THEMES_CREATE")
+	WebhookSubscriptionTopicThemesUpdate         = WebhookSubscriptionTopic("THEMES_UPDATE")
+	WebhookSubscriptionTopicCustomersUpdate      = WebhookSubscriptionTopic("CUSTOMERS_UPDATE")
+	WebhookSubscriptionTopicCustomersCreate      = WebhookSubscriptionTopic("CUSTOMERS_CREATE")
+	WebhookSubscriptionTopicCustomersDelete      = WebhookSubscriptionTopic("CUSTOMERS_DELETE")
+	WebhookSubscriptionTopicCustomerGroupCreate  = WebhookSubscriptionTopic("CUSTOMER_GROUPS_CREATE")
+	WebhookSubscriptionTopicCustomerGroupUpdate  = WebhookSubscriptionTopic("CUSTOMER_GROUPS_UPDATE")
+	WebhookSubscriptionTopicCartCreate           = WebhookSubscriptionTopic("CARTS_CREATE")
+	WebhookSubscriptionTopicCartUpdate           = WebhookSubscriptionTopic("CARTS_UPDATE")
+	WebhookSubscriptionTopicCheckoutUpdate       = WebhookSubscriptionTopic("CHECKOUTS_UPDATE")
+	WebhookSubscriptionTopicOrdersCreate         = WebhookSubscriptionTopic("ORDERS_CREATE")
+	WebhookSubscriptionTopicOrdersUpdated        = WebhookSubscriptionTopic("ORDERS_UPDATED")
+	WebhookSubscriptionTopicOrdersPaid           = WebhookSubscriptionTopic("ORDERS_PAID")
+	WebhookSubscriptionTopicOrdersCancelled      = WebhookSubscriptionTopic("ORDERS_CANCELLED")
+	WebhookSubscriptionTopicOrdersFulfilled      = WebhookSubscriptionTopic("ORDERS_FULFILLED")
+	WebhookSubscriptionTopicOrdersDelete         = WebhookSubscriptionTopic("ORDERS_DELETE")
+	WebhookSubscriptionTopicBulkOperationsFinish = WebhookSubscriptionTopic("BULK_OPERATIONS_FINISH")
 )
 
-func (w WebhookServiceOp) NewWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output WebhookSubscriptionCreatePayload) {
+func (w WebhookServiceOp) NewWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output WebhookSubscriptionCreatePayload, err error) {
+	return w.NewWebhookSubscriptionWithContext(w.client.gql.Context(), topic, input)
+}
+
+// NewWebhookSubscriptionWithContext subscribes the HTTPS endpoint of input.WebhookSubscriptionInput to topic.
+// User errors are returned as a *UserErrorsError.
+func (w WebhookServiceOp) NewWebhookSubscriptionWithContext(ctx context.Context, topic WebhookTopic, input WebhookTopicSubscription) (output WebhookSubscriptionCreatePayload, err error) {
 	m := mutationWebhookCreate{}
 	vars := map[string]interface{}{
 		"topic":               topic.WebhookSubscriptionTopic,
 		"webhookSubscription": input.WebhookSubscriptionInput,
 	}
-	err := w.client.gql.Mutate(context.Background(), &m, vars)
+	err = w.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
-		return m.WebhookCreateResult
+		return m.WebhookCreateResult, err
 	}
 
 	if len(m.WebhookCreateResult.UserErrors) > 0 {
-		err = fmt.Errorf("%+v", m.WebhookCreateResult.UserErrors)
-		logrus.Info(err)
-		return m.WebhookCreateResult
+		return m.WebhookCreateResult, &UserErrorsError{UserErrors: m.WebhookCreateResult.UserErrors}
 	}
 
-	return m.WebhookCreateResult
+	return m.WebhookCreateResult, nil
+}
+
+func (w WebhookServiceOp) NewEventBridgeWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output EventBridgeWebhookSubscriptionCreatePayload, err error) {
+	return w.NewEventBridgeWebhookSubscriptionWithContext(w.client.gql.Context(), topic, input)
 }
 
-func (w WebhookServiceOp) NewEventBridgeWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output EventBridgeWebhookSubscriptionCreatePayload) {
+// NewEventBridgeWebhookSubscriptionWithContext subscribes the Amazon EventBridge event source of
+// input.EventBridgeWebhookSubscriptionInput to topic. User errors are returned as a *UserErrorsError.
+func (w WebhookServiceOp) NewEventBridgeWebhookSubscriptionWithContext(ctx context.Context, topic WebhookTopic, input WebhookTopicSubscription) (output EventBridgeWebhookSubscriptionCreatePayload, err error) {
 	m := mutationEventBridgeWebhookCreate{}
 	vars := map[string]interface{}{
 		"topic":               topic.WebhookSubscriptionTopic,
 		"webhookSubscription": input.EventBridgeWebhookSubscriptionInput,
 	}
-
-	err := w.client.gql.Mutate(context.Background(), &m, vars)
+	err = w.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
-		logrus.Info(err)
-		return m.EventBridgeWebhookCreateResult
+		return m.EventBridgeWebhookCreateResult, err
 	}
 
 	if len(m.EventBridgeWebhookCreateResult.UserErrors) > 0 {
-		err = fmt.Errorf("%+v", m.EventBridgeWebhookCreateResult.UserErrors)
-		logrus.Info(err)
-		return m.EventBridgeWebhookCreateResult
+		return m.EventBridgeWebhookCreateResult, &UserErrorsError{UserErrors: m.EventBridgeWebhookCreateResult.UserErrors}
 	}
 
-	return m.EventBridgeWebhookCreateResult
+	return m.EventBridgeWebhookCreateResult, nil
+}
+
+func (w WebhookServiceOp) NewPubSubWebhookSubscription(topic WebhookTopic, input WebhookTopicSubscription) (output PubSubWebhookSubscriptionCreatePayload, err error) {
+	return w.NewPubSubWebhookSubscriptionWithContext(w.client.gql.Context(), topic, input)
+}
+
+// NewPubSubWebhookSubscriptionWithContext subscribes the Google Cloud Pub/Sub topic of
+// input.PubSubWebhookSubscriptionInput to topic. User errors are returned as a *UserErrorsError.
+func (w WebhookServiceOp) NewPubSubWebhookSubscriptionWithContext(ctx context.Context, topic WebhookTopic, input WebhookTopicSubscription) (output PubSubWebhookSubscriptionCreatePayload, err error) {
+	m := mutationPubSubWebhookCreate{}
+	vars := map[string]interface{}{
+		"topic":               topic.WebhookSubscriptionTopic,
+		"webhookSubscription": input.PubSubWebhookSubscriptionInput,
+	}
+	err = w.client.gql.Mutate(ctx, &m, vars)
+	if err != nil {
+		return m.PubSubWebhookCreateResult, err
+	}
+
+	if len(m.PubSubWebhookCreateResult.UserErrors) > 0 {
+		return m.PubSubWebhookCreateResult, &UserErrorsError{UserErrors: m.PubSubWebhookCreateResult.UserErrors}
+	}
+
+	return m.PubSubWebhookCreateResult, nil
 }
 
 func (w WebhookServiceOp) DeleteWebhook(webhookID string) (output WebhookSubscriptionDeletePayload, err error) {
+	return w.DeleteWebhookWithContext(w.client.gql.Context(), webhookID)
+}
+
+func (w WebhookServiceOp) DeleteWebhookWithContext(ctx context.Context, webhookID string) (output WebhookSubscriptionDeletePayload, err error) {
 	m := mutationWebhookDelete{}
 	vars := map[string]interface{}{
 		"id": webhookID,
 	}
-	err = w.client.gql.Mutate(context.Background(), &m, vars)
+	err = w.client.gql.Mutate(ctx, &m, vars)
 	if err != nil {
 		logrus.Info(err)
 		return m.WebhookDeleteResult, err
 	}
 
 	if len(m.WebhookDeleteResult.UserErrors) > 0 {
-		err = fmt.Errorf("%+v", m.WebhookDeleteResult.UserErrors)
+		userErrors := make([]UserErrors, 0, len(m.WebhookDeleteResult.UserErrors))
+		for _, e := range m.WebhookDeleteResult.UserErrors {
+			userErrors = append(userErrors, *e)
+		}
+		err = &UserErrorsError{UserErrors: userErrors}
 		logrus.Info(err)
 	}
 	return m.WebhookDeleteResult, err
 }
 
 func (w WebhookServiceOp) ListWebhookSubscriptions(topics []WebhookSubscriptionTopic) (output []*WebhookSubscription, err error) {
-	queryFormat := `query webhookSubscriptions($first: Int!, $topics: [WebhookSubscriptionTopic!]%s) {
-    webhookSubscriptions(first: $first, topics: $topics%s) {
+	return w.ListWebhookSubscriptionsWithContext(w.client.gql.Context(), topics)
+}
+
+func (w WebhookServiceOp) ListWebhookSubscriptionsWithContext(ctx context.Context, topics []WebhookSubscriptionTopic) (output []*WebhookSubscription, err error) {
+	return w.IterWithContext(ctx, topics, ListOptions{First: 200}).All()
+}
+
+func (w WebhookServiceOp) Iter(topics []WebhookSubscriptionTopic, opts ListOptions) *Iterator[*WebhookSubscription] {
+	return w.IterWithContext(w.client.gql.Context(), topics, opts)
+}
+
+func (w WebhookServiceOp) IterWithContext(ctx context.Context, topics []WebhookSubscriptionTopic, opts ListOptions) *Iterator[*WebhookSubscription] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts ListOptions) (*Page[*WebhookSubscription], error) {
+		return w.listPage(ctx, topics, opts)
+	})
+}
+
+func (w WebhookServiceOp) listPage(ctx context.Context, topics []WebhookSubscriptionTopic, opts ListOptions) (*Page[*WebhookSubscription], error) {
+	query := fmt.Sprintf(`query webhookSubscriptions($topics: [WebhookSubscriptionTopic!], $first: Int, $last: Int, $before: String, $after: String, $reverse: Boolean) {
+    webhookSubscriptions(topics: $topics, first: $first, last: $last, before: $before, after: $after, reverse: $reverse) {
       edges {
         cursor
         node {
@@ -253,54 +355,45 @@ func (w WebhookServiceOp) ListWebhookSubscriptions(topics []WebhookSubscriptionT
             ... on WebhookHttpEndpoint {
               callbackUrl
             }
-            ... on WebhookEventBridgeEndpoint{
+            ... on WebhookEventBridgeEndpoint {
               arn
             }
+            ... on WebhookPubSubEndpoint {
+              pubSubProject
+              pubSubTopic
+            }
           }
           callbackUrl
           format
           topic
           includeFields
+          metafieldNamespaces
           createdAt
           updatedAt
         }
       }
-      pageInfo {
-        hasNextPage
-      }
+      %s
     }
-  }`
+  }`, pageInfoQuery)
 
-	var (
-		cursor graphql.String
-		vars   = map[string]interface{}{
-			"first":  200,
-			"topics": topics,
-		}
-	)
-	for {
-		var (
-			query string
-			out   QueryRoot
-		)
-		if cursor != "" {
-			vars["after"] = cursor
-			query = fmt.Sprintf(queryFormat, ", $after: String", ", after: $after")
-		} else {
-			query = fmt.Sprintf(queryFormat, "", "")
-		}
-		err = w.client.gql.QueryString(context.Background(), query, vars, &out)
-		if err != nil {
-			return
-		}
-		for _, wh := range out.WebhookSubscriptions.Edges {
-			output = append(output, wh.Node)
-		}
-		if out.WebhookSubscriptions.PageInfo.HasNextPage {
-			cursor = out.WebhookSubscriptions.Edges[len(out.WebhookSubscriptions.Edges)-1].Cursor
-		} else {
-			break
-		}
+	vars := pageVars(opts)
+	if len(topics) > 0 {
+		vars["topics"] = topics
+	}
+
+	var out QueryRoot
+	err := w.client.query(ctx, query, vars, &out)
+	if err != nil {
+		return nil, err
 	}
-	return
+
+	page := &Page[*WebhookSubscription]{}
+	if out.WebhookSubscriptions.PageInfo != nil {
+		page.PageInfo = *out.WebhookSubscriptions.PageInfo
+	}
+	for _, wh := range out.WebhookSubscriptions.Edges {
+		page.Nodes = append(page.Nodes, wh.Node)
+	}
+
+	return page, nil
 }
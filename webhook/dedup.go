@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DedupStore remembers the IDs of the handled webhooks, so the Receiver drops their other deliveries.
+// Implementations must be safe for concurrent use.
+type DedupStore interface {
+	// Claim records the webhook id unless it is already recorded, atomically, and reports whether it
+	// did, i.e. whether the caller should handle the webhook.
+	Claim(ctx context.Context, id string) (bool, error)
+	// Release forgets the webhook id claimed by a caller that failed to handle it, so that it's handled
+	// again when redelivered.
+	Release(ctx context.Context, id string) error
+}
+
+// MemoryDedupStore is a DedupStore keeping IDs in memory for a time, which is enough for a single
+// process receiving the webhooks.
+type MemoryDedupStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu  sync.Mutex
+	ids map[string]time.Time
+}
+
+// NewMemoryDedupStore returns a store forgetting IDs after ttl.
+func NewMemoryDedupStore(ttl time.Duration) *MemoryDedupStore {
+	return &MemoryDedupStore{ttl: ttl, now: time.Now, ids: map[string]time.Time{}}
+}
+
+func (s *MemoryDedupStore) Claim(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for k, expiresAt := range s.ids {
+		if !now.Before(expiresAt) {
+			delete(s.ids, k)
+		}
+	}
+	if _, ok := s.ids[id]; ok {
+		return false, nil
+	}
+	s.ids[id] = now.Add(s.ttl)
+	return true, nil
+}
+
+func (s *MemoryDedupStore) Release(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.ids, id)
+	return nil
+}
@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+)
+
+var (
+	payloadTypesMu sync.RWMutex
+	payloadTypes   = map[shopify.WebhookSubscriptionTopic]reflect.Type{}
+)
+
+// RegisterPayload makes the Receiver decode the payloads of topic into the type of v, e.g.
+// RegisterPayload(topic, MyProduct{}). It replaces the type registered for topic, if any.
+func RegisterPayload(topic shopify.WebhookSubscriptionTopic, v interface{}) {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	payloadTypesMu.Lock()
+	defer payloadTypesMu.Unlock()
+	payloadTypes[topic] = t
+}
+
+// newPayload returns a pointer to a new value of the type registered for topic.
+func newPayload(topic shopify.WebhookSubscriptionTopic) interface{} {
+	payloadTypesMu.RLock()
+	t, ok := payloadTypes[topic]
+	payloadTypesMu.RUnlock()
+	if !ok {
+		return &map[string]interface{}{}
+	}
+	return reflect.New(t).Interface()
+}
+
+func init() {
+	for topic, v := range map[shopify.WebhookSubscriptionTopic]interface{}{
+		shopify.WebhookSubscriptionTopicProductsCreate:       Product{},
+		shopify.WebhookSubscriptionTopicProductsUpdate:       Product{},
+		shopify.WebhookSubscriptionTopicProductsDelete:       Deletion{},
+		shopify.WebhookSubscriptionTopicCollectionsCreate:    Collection{},
+		shopify.WebhookSubscriptionTopicCollectionsUpdate:    Collection{},
+		shopify.WebhookSubscriptionTopicCollectionsDelete:    Deletion{},
+		shopify.WebhookSubscriptionTopicOrdersCreate:         Order{},
+		shopify.WebhookSubscriptionTopicOrdersUpdated:        Order{},
+		shopify.WebhookSubscriptionTopicOrdersPaid:           Order{},
+		shopify.WebhookSubscriptionTopicOrdersCancelled:      Order{},
+		shopify.WebhookSubscriptionTopicOrdersFulfilled:      Order{},
+		shopify.WebhookSubscriptionTopicOrdersDelete:         Deletion{},
+		shopify.WebhookSubscriptionTopicCustomersCreate:      Customer{},
+		shopify.WebhookSubscriptionTopicCustomersUpdate:      Customer{},
+		shopify.WebhookSubscriptionTopicCustomersDelete:      Deletion{},
+		shopify.WebhookSubscriptionTopicShopUpdate:           Shop{},
+		shopify.WebhookSubscriptionTopicAppUninstall:         Shop{},
+		shopify.WebhookSubscriptionTopicBulkOperationsFinish: shopify.BulkOperationsFinishWebhook{},
+	} {
+		RegisterPayload(topic, v)
+	}
+}
+
+// Deletion is the payload of the *_DELETE topics.
+type Deletion struct {
+	ID int64 `json:"id"`
+}
+
+// Product is the payload of the PRODUCTS_CREATE and PRODUCTS_UPDATE topics.
+type Product struct {
+	ID                int64  `json:"id"`
+	AdminGraphqlAPIID string `json:"admin_graphql_api_id"`
+	Title             string `json:"title"`
+	BodyHTML          string `json:"body_html"`
+	Vendor            string `json:"vendor"`
+	ProductType       string `json:"product_type"`
+	Handle            string `json:"handle"`
+	Status            string `json:"status"`
+	// Tags is a comma-separated list.
+	Tags        string          `json:"tags"`
+	Variants    []Variant       `json:"variants"`
+	Options     []ProductOption `json:"options"`
+	Images      []ProductImage  `json:"images"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	PublishedAt *time.Time      `json:"published_at"`
+}
+
+type Variant struct {
+	ID                int64     `json:"id"`
+	AdminGraphqlAPIID string    `json:"admin_graphql_api_id"`
+	ProductID         int64     `json:"product_id"`
+	Title             string    `json:"title"`
+	Price             string    `json:"price"`
+	CompareAtPrice    *string   `json:"compare_at_price"`
+	SKU               string    `json:"sku"`
+	Barcode           *string   `json:"barcode"`
+	Position          int       `json:"position"`
+	InventoryItemID   int64     `json:"inventory_item_id"`
+	InventoryQuantity int       `json:"inventory_quantity"`
+	Option1           *string   `json:"option1"`
+	Option2           *string   `json:"option2"`
+	Option3           *string   `json:"option3"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type ProductOption struct {
+	ID       int64    `json:"id"`
+	Name     string   `json:"name"`
+	Position int      `json:"position"`
+	Values   []string `json:"values"`
+}
+
+type ProductImage struct {
+	ID                int64   `json:"id"`
+	AdminGraphqlAPIID string  `json:"admin_graphql_api_id"`
+	Src               string  `json:"src"`
+	Alt               *string `json:"alt"`
+	Position          int     `json:"position"`
+	Width             int     `json:"width"`
+	Height            int     `json:"height"`
+	VariantIDs        []int64 `json:"variant_ids"`
+}
+
+// Collection is the payload of the COLLECTIONS_CREATE and COLLECTIONS_UPDATE topics.
+type Collection struct {
+	ID                int64      `json:"id"`
+	AdminGraphqlAPIID string     `json:"admin_graphql_api_id"`
+	Title             string     `json:"title"`
+	Handle            string     `json:"handle"`
+	BodyHTML          *string    `json:"body_html"`
+	SortOrder         string     `json:"sort_order"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	PublishedAt       *time.Time `json:"published_at"`
+}
+
+// Order is the payload of the ORDERS_* topics but ORDERS_DELETE.
+type Order struct {
+	ID                int64      `json:"id"`
+	AdminGraphqlAPIID string     `json:"admin_graphql_api_id"`
+	Name              string     `json:"name"`
+	Email             string     `json:"email"`
+	Currency          string     `json:"currency"`
+	SubtotalPrice     string     `json:"subtotal_price"`
+	TotalTax          string     `json:"total_tax"`
+	TotalDiscounts    string     `json:"total_discounts"`
+	TotalPrice        string     `json:"total_price"`
+	FinancialStatus   string     `json:"financial_status"`
+	FulfillmentStatus *string    `json:"fulfillment_status"`
+	Tags              string     `json:"tags"`
+	Customer          *Customer  `json:"customer"`
+	LineItems         []LineItem `json:"line_items"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	CancelledAt       *time.Time `json:"cancelled_at"`
+	CancelReason      *string    `json:"cancel_reason"`
+	ClosedAt          *time.Time `json:"closed_at"`
+}
+
+type LineItem struct {
+	ID                int64   `json:"id"`
+	AdminGraphqlAPIID string  `json:"admin_graphql_api_id"`
+	ProductID         *int64  `json:"product_id"`
+	VariantID         *int64  `json:"variant_id"`
+	Title             string  `json:"title"`
+	VariantTitle      *string `json:"variant_title"`
+	SKU               *string `json:"sku"`
+	Quantity          int     `json:"quantity"`
+	Price             string  `json:"price"`
+}
+
+// Customer is the payload of the CUSTOMERS_CREATE and CUSTOMERS_UPDATE topics.
+type Customer struct {
+	ID                int64     `json:"id"`
+	AdminGraphqlAPIID string    `json:"admin_graphql_api_id"`
+	Email             *string   `json:"email"`
+	FirstName         *string   `json:"first_name"`
+	LastName          *string   `json:"last_name"`
+	Phone             *string   `json:"phone"`
+	State             string    `json:"state"`
+	Tags              string    `json:"tags"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// Shop is the payload of the SHOP_UPDATE and APP_UNINSTALLED topics.
+type Shop struct {
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	Domain          string `json:"domain"`
+	MyshopifyDomain string `json:"myshopify_domain"`
+	Currency        string `json:"currency"`
+	PlanName        string `json:"plan_name"`
+	IanaTimezone    string `json:"iana_timezone"`
+}
@@ -0,0 +1,220 @@
+// Package webhook receives the webhooks Shopify sends to the subscriptions of shopify.WebhookService.
+//
+//	r := webhook.NewReceiver(apiSecret)
+//	webhook.Handle(r, shopify.WebhookSubscriptionTopicProductsUpdate, func(ctx context.Context, w *webhook.Webhook, p *webhook.Product) error {
+//		return reindex(p.ID)
+//	})
+//	http.Handle("/webhooks", r)
+//
+// The Receiver verifies the HMAC signature of each delivery, decodes its payload into the type
+// registered for its topic, and drops the deliveries of a webhook it already handled.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	HeaderHmac        = "X-Shopify-Hmac-Sha256"
+	HeaderTopic       = "X-Shopify-Topic"
+	HeaderShopDomain  = "X-Shopify-Shop-Domain"
+	HeaderWebhookID   = "X-Shopify-Webhook-Id"
+	HeaderAPIVersion  = "X-Shopify-API-Version"
+	HeaderTriggeredAt = "X-Shopify-Triggered-At"
+
+	maxBodyLength = 5 << 20
+)
+
+// Webhook is a delivery received by a Receiver.
+type Webhook struct {
+	// Topic is the topic of the X-Shopify-Topic header, e.g. PRODUCTS_UPDATE for products/update.
+	Topic      shopify.WebhookSubscriptionTopic
+	ShopDomain string
+	// ID is the same for all the deliveries of a webhook.
+	ID          string
+	APIVersion  string
+	TriggeredAt time.Time
+	Body        []byte
+	// Payload is the body decoded into a pointer to the type registered for the topic, see
+	// RegisterPayload, or into a map[string]interface{} for other topics.
+	Payload interface{}
+}
+
+// Handler processes a webhook. Returning an error answers the delivery with a 500 status, so
+// Shopify retries it later.
+type Handler func(ctx context.Context, w *Webhook) error
+
+// Option configures a Receiver.
+type Option func(r *Receiver)
+
+// WithDedupStore sets the store of the IDs of the handled webhooks. A nil store disables the
+// deduplication.
+func WithDedupStore(store DedupStore) Option {
+	return func(r *Receiver) {
+		r.dedup = store
+	}
+}
+
+// Receiver is the http.Handler of the webhook endpoint of an app.
+type Receiver struct {
+	secret string
+	dedup  DedupStore
+
+	mu       sync.RWMutex
+	handlers map[shopify.WebhookSubscriptionTopic][]Handler
+}
+
+// NewReceiver returns a receiver accepting the webhooks signed with secret, the API secret key of
+// the app. Handled webhooks are remembered for 48 hours, the time Shopify retries failed deliveries.
+func NewReceiver(secret string, opts ...Option) *Receiver {
+	r := &Receiver{
+		secret:   secret,
+		dedup:    NewMemoryDedupStore(48 * time.Hour),
+		handlers: map[shopify.WebhookSubscriptionTopic][]Handler{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// HandleFunc registers h for the webhooks of topic. The handlers of a topic are called in the order
+// they were registered, until one fails.
+func (r *Receiver) HandleFunc(topic shopify.WebhookSubscriptionTopic, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[topic] = append(r.handlers[topic], h)
+}
+
+// Handle registers fn for the webhooks of topic, with their payload decoded into T.
+func Handle[T any](r *Receiver, topic shopify.WebhookSubscriptionTopic, fn func(ctx context.Context, w *Webhook, payload *T) error) {
+	r.HandleFunc(topic, func(ctx context.Context, w *Webhook) error {
+		payload, ok := w.Payload.(*T)
+		if !ok {
+			payload = new(T)
+			if err := json.Unmarshal(w.Body, payload); err != nil {
+				return fmt.Errorf("decode %s payload: %w", w.Topic, err)
+			}
+		}
+		return fn(ctx, w, payload)
+	})
+}
+
+// ServeHTTP verifies and dispatches a webhook delivery.
+func (r *Receiver) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBodyLength))
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !Verify(r.secret, body, req.Header.Get(HeaderHmac)) {
+		http.Error(rw, "invalid HMAC", http.StatusUnauthorized)
+		return
+	}
+
+	w, err := parse(req.Header, body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = r.Dispatch(req.Context(), w)
+	if err != nil {
+		log.Warnf("Couldn't handle webhook %s %s from %s: %s", w.Topic, w.ID, w.ShopDomain, err)
+		http.Error(rw, "couldn't handle webhook", http.StatusInternalServerError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+}
+
+// Dispatch calls the handlers of the topic of w, unless w was already handled or is being handled.
+// w is claimed before calling them and released if one of them returns an error, so that it's handled
+// again when Shopify retries it.
+func (r *Receiver) Dispatch(ctx context.Context, w *Webhook) (err error) {
+	if r.dedup != nil && w.ID != "" {
+		claimed, claimErr := r.dedup.Claim(ctx, w.ID)
+		if claimErr != nil {
+			return claimErr
+		}
+		if !claimed {
+			log.Debugf("Dropping duplicate webhook %s %s", w.Topic, w.ID)
+			return nil
+		}
+		defer func() {
+			if err == nil {
+				return
+			}
+			if releaseErr := r.dedup.Release(ctx, w.ID); releaseErr != nil {
+				log.Warnf("Couldn't release webhook %s %s: %s", w.Topic, w.ID, releaseErr)
+			}
+		}()
+	}
+
+	r.mu.RLock()
+	handlers := r.handlers[w.Topic]
+	r.mu.RUnlock()
+	for _, h := range handlers {
+		if err = h(ctx, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify reports whether signature is the base64 HMAC-SHA256 of body keyed with secret, as sent in
+// the X-Shopify-Hmac-Sha256 header.
+func Verify(secret string, body []byte, signature string) bool {
+	return utils.VerifyWebhookHmac(secret, body, signature)
+}
+
+// TopicFromHeader converts a topic as sent in the X-Shopify-Topic header, e.g. products/update,
+// into a WebhookSubscriptionTopic, e.g. PRODUCTS_UPDATE.
+func TopicFromHeader(topic string) shopify.WebhookSubscriptionTopic {
+	return shopify.WebhookSubscriptionTopic(strings.ToUpper(strings.ReplaceAll(topic, "/", "_")))
+}
+
+func parse(header http.Header, body []byte) (*Webhook, error) {
+	topic := header.Get(HeaderTopic)
+	if topic == "" {
+		return nil, fmt.Errorf("missing %s header", HeaderTopic)
+	}
+
+	w := &Webhook{
+		Topic:      TopicFromHeader(topic),
+		ShopDomain: header.Get(HeaderShopDomain),
+		ID:         header.Get(HeaderWebhookID),
+		APIVersion: header.Get(HeaderAPIVersion),
+		Body:       body,
+	}
+	if t := header.Get(HeaderTriggeredAt); t != "" {
+		w.TriggeredAt, _ = time.Parse(time.RFC3339Nano, t)
+	}
+
+	payload := newPayload(w.Topic)
+	if err := json.Unmarshal(body, payload); err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", w.Topic, err)
+	}
+	if m, ok := payload.(*map[string]interface{}); ok {
+		w.Payload = *m
+	} else {
+		w.Payload = payload
+	}
+
+	return w, nil
+}
@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+)
+
+const testSecret = "shpss_test"
+
+func deliver(r http.Handler, topic, id, body, secret string) int {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set(HeaderHmac, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	req.Header.Set(HeaderTopic, topic)
+	req.Header.Set(HeaderShopDomain, "test-shop.myshopify.com")
+	req.Header.Set(HeaderWebhookID, id)
+	req.Header.Set(HeaderAPIVersion, "2022-10")
+	req.Header.Set(HeaderTriggeredAt, "2022-10-01T10:00:00.123456Z")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestReceiver(t *testing.T) {
+	r := NewReceiver(testSecret)
+
+	var products []*Product
+	var got *Webhook
+	Handle(r, shopify.WebhookSubscriptionTopicProductsUpdate, func(ctx context.Context, w *Webhook, p *Product) error {
+		got = w
+		products = append(products, p)
+		return nil
+	})
+	var uninstalled []string
+	r.HandleFunc(shopify.WebhookSubscriptionTopicAppUninstall, func(ctx context.Context, w *Webhook) error {
+		uninstalled = append(uninstalled, w.Payload.(*Shop).MyshopifyDomain)
+		return nil
+	})
+
+	product := `{"id":632910392,"admin_graphql_api_id":"gid://shopify/Product/632910392","title":"IPod Nano","variants":[{"id":808950810,"price":"199.00","sku":"IPOD2008PINK"}],"created_at":"2022-10-01T09:00:00-04:00","updated_at":"2022-10-01T10:00:00-04:00","published_at":null}`
+	if code := deliver(r, "products/update", "b54557e4-1", product, testSecret); code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if len(products) != 1 || products[0].Title != "IPod Nano" || len(products[0].Variants) != 1 || products[0].Variants[0].SKU != "IPOD2008PINK" {
+		t.Fatalf("unexpected products %+v", products)
+	}
+	if got.Topic != shopify.WebhookSubscriptionTopicProductsUpdate || got.ShopDomain != "test-shop.myshopify.com" || got.ID != "b54557e4-1" || got.APIVersion != "2022-10" || got.TriggeredAt.IsZero() {
+		t.Errorf("unexpected webhook %+v", got)
+	}
+
+	// another delivery of the same webhook is dropped
+	if code := deliver(r, "products/update", "b54557e4-1", product, testSecret); code != http.StatusOK {
+		t.Errorf("expected status %d for a duplicate, got %d", http.StatusOK, code)
+	}
+	if len(products) != 1 {
+		t.Errorf("expected the duplicate to be dropped, got %d products", len(products))
+	}
+
+	if code := deliver(r, "products/update", "b54557e4-2", product, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("expected status %d for a wrong HMAC, got %d", http.StatusUnauthorized, code)
+	}
+
+	if code := deliver(r, "app/uninstalled", "b54557e4-3", `{"id":1,"myshopify_domain":"test-shop.myshopify.com"}`, testSecret); code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if len(uninstalled) != 1 || uninstalled[0] != "test-shop.myshopify.com" {
+		t.Errorf("unexpected uninstalled shops %v", uninstalled)
+	}
+
+	// topics without handler are acknowledged
+	if code := deliver(r, "carts/create", "b54557e4-4", `{"id":"c1"}`, testSecret); code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if code := deliver(r, "products/update", "b54557e4-5", `{"id":`, testSecret); code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid payload, got %d", http.StatusBadRequest, code)
+	}
+}
+
+func TestReceiverRetriesFailedWebhooks(t *testing.T) {
+	r := NewReceiver(testSecret)
+	calls := 0
+	r.HandleFunc(shopify.WebhookSubscriptionTopicOrdersCreate, func(ctx context.Context, w *Webhook) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database unavailable")
+		}
+		if o := w.Payload.(*Order); o.Name != "#1001" || len(o.LineItems) != 1 {
+			t.Errorf("unexpected order %+v", o)
+		}
+		return nil
+	})
+
+	order := `{"id":820982911946154508,"name":"#1001","line_items":[{"id":866550311766439020,"quantity":1,"price":"199.00"}]}`
+	if code := deliver(r, "orders/create", "c2f4e8a0-1", order, testSecret); code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, code)
+	}
+	// the failed webhook is handled again when Shopify retries it
+	if code := deliver(r, "orders/create", "c2f4e8a0-1", order, testSecret); code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestReceiverHandlesConcurrentDeliveriesOnce(t *testing.T) {
+	r := NewReceiver(testSecret)
+	var calls int32
+	release := make(chan struct{})
+	r.HandleFunc(shopify.WebhookSubscriptionTopicOrdersCreate, func(ctx context.Context, w *Webhook) error {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil
+	})
+
+	order := `{"id":820982911946154508,"name":"#1001"}`
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if code := deliver(r, "orders/create", "d3a5f9b1-1", order, testSecret); code != http.StatusOK {
+				t.Errorf("expected status %d, got %d", http.StatusOK, code)
+			}
+		}()
+	}
+	// the duplicates are dropped while the first delivery is being handled
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
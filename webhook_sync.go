@@ -0,0 +1,363 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gempages/go-shopify-graphql/graphql"
+)
+
+// WebhookSpec is a webhook subscription Sync makes exist. Its endpoint is either CallbackURL, ARN, or
+// PubSubProject and PubSubTopic.
+type WebhookSpec struct {
+	Topic WebhookSubscriptionTopic
+	// CallbackURL is the HTTPS endpoint of the subscription.
+	CallbackURL string
+	// ARN is the Amazon EventBridge endpoint of the subscription.
+	ARN string
+	// PubSubProject and PubSubTopic are the Google Cloud Pub/Sub endpoint of the subscription.
+	PubSubProject string
+	PubSubTopic   string
+	// Format is JSON when empty.
+	Format              WebhookSubscriptionFormat
+	IncludeFields       []string
+	MetafieldNamespaces []string
+}
+
+// endpoint returns the address of the endpoint of s, the way Shopify writes it in callbackUrl.
+func (s WebhookSpec) endpoint() string {
+	switch {
+	case s.ARN != "":
+		return s.ARN
+	case s.PubSubProject != "" || s.PubSubTopic != "":
+		return pubSubAddress(s.PubSubProject, s.PubSubTopic)
+	default:
+		return s.CallbackURL
+	}
+}
+
+type WebhookSyncAction string
+
+const (
+	WebhookSyncCreate WebhookSyncAction = "create"
+	WebhookSyncUpdate WebhookSyncAction = "update"
+	WebhookSyncDelete WebhookSyncAction = "delete"
+)
+
+// WebhookSyncChange is a change of the plan of Sync.
+type WebhookSyncChange struct {
+	Action WebhookSyncAction
+	// Spec is the desired subscription, nil for a deletion.
+	Spec *WebhookSpec
+	// Subscription is the existing subscription, nil for a creation. After a creation or an update
+	// applied without error, it is the subscription returned by Shopify.
+	Subscription *WebhookSubscription
+	// Err is the error met applying the change.
+	Err error
+}
+
+// String describes the change, e.g. "create PRODUCTS_UPDATE https://example.com/webhooks", followed
+// by its error if any.
+func (c WebhookSyncChange) String() string {
+	if c.Err != nil {
+		return c.describe() + ": " + c.Err.Error()
+	}
+	return c.describe()
+}
+
+func (c WebhookSyncChange) describe() string {
+	if c.Spec != nil {
+		return fmt.Sprintf("%s %s %s", c.Action, c.Spec.Topic, c.Spec.endpoint())
+	}
+	return fmt.Sprintf("%s %s %s", c.Action, c.Subscription.Topic, webhookSubscriptionEndpoint(c.Subscription))
+}
+
+// WebhookSyncOptions configures Sync.
+type WebhookSyncOptions struct {
+	// DryRun only plans the changes, leaving the subscriptions untouched.
+	DryRun bool
+}
+
+// WebhookSyncReport is the plan of Sync, and the outcome of its changes unless it was a dry run.
+type WebhookSyncReport struct {
+	DryRun  bool
+	Changes []*WebhookSyncChange
+	// Unchanged are the existing subscriptions matching a spec.
+	Unchanged []*WebhookSubscription
+}
+
+// Failed returns the changes that couldn't be applied.
+func (r *WebhookSyncReport) Failed() []*WebhookSyncChange {
+	var failed []*WebhookSyncChange
+	for _, c := range r.Changes {
+		if c.Err != nil {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// String lists the changes, one per line.
+func (r *WebhookSyncReport) String() string {
+	var b strings.Builder
+	for _, c := range r.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+type mutationWebhookUpdate struct {
+	WebhookUpdateResult WebhookSubscriptionUpdatePayload `graphql:"webhookSubscriptionUpdate(id: $id, webhookSubscription: $webhookSubscription)" json:"webhookSubscriptionUpdate"`
+}
+
+type mutationEventBridgeWebhookUpdate struct {
+	EventBridgeWebhookUpdateResult WebhookSubscriptionUpdatePayload `graphql:"eventBridgeWebhookSubscriptionUpdate(id: $id, webhookSubscription: $webhookSubscription)" json:"eventBridgeWebhookSubscriptionUpdate"`
+}
+
+type mutationPubSubWebhookUpdate struct {
+	PubSubWebhookUpdateResult WebhookSubscriptionUpdatePayload `graphql:"pubSubWebhookSubscriptionUpdate(id: $id, webhookSubscription: $webhookSubscription)" json:"pubSubWebhookSubscriptionUpdate"`
+}
+
+// Return type for the `webhookSubscriptionUpdate`, `eventBridgeWebhookSubscriptionUpdate` and
+// `pubSubWebhookSubscriptionUpdate` mutations.
+type WebhookSubscriptionUpdatePayload struct {
+	// The list of errors that occurred from executing the mutation.
+	UserErrors []UserErrors `json:"userErrors,omitempty"`
+	// The webhook subscription that was updated.
+	WebhookSubscription WebhookSubscription `json:"webhookSubscription,omitempty"`
+}
+
+// Sync converges the webhook subscriptions of the shop to desired. Subscriptions are matched on their
+// topic and endpoint: the missing ones are created, the ones whose format, include fields or metafield
+// namespaces differ are updated, and the ones not desired are deleted. All the changes are attempted,
+// creations first so no topic goes without subscription; the report records the error of each one and
+// the first of them is returned.
+func (w WebhookServiceOp) Sync(desired []WebhookSpec, opts WebhookSyncOptions) (*WebhookSyncReport, error) {
+	return w.SyncWithContext(w.client.gql.Context(), desired, opts)
+}
+
+func (w WebhookServiceOp) SyncWithContext(ctx context.Context, desired []WebhookSpec, opts WebhookSyncOptions) (*WebhookSyncReport, error) {
+	existing, err := w.ListWebhookSubscriptionsWithContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := planWebhookSync(existing, desired)
+	report.DryRun = opts.DryRun
+	if opts.DryRun {
+		return report, nil
+	}
+
+	var firstErr error
+	for _, c := range report.Changes {
+		switch c.Action {
+		case WebhookSyncCreate:
+			c.Subscription, c.Err = w.createSubscription(ctx, *c.Spec)
+		case WebhookSyncUpdate:
+			var updated *WebhookSubscription
+			updated, c.Err = w.updateSubscription(ctx, c.Subscription.ID, *c.Spec)
+			if c.Err == nil {
+				c.Subscription = updated
+			}
+		case WebhookSyncDelete:
+			_, c.Err = w.DeleteWebhookWithContext(ctx, fmt.Sprint(c.Subscription.ID))
+		}
+		if c.Err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", c.describe(), c.Err)
+		}
+	}
+
+	return report, firstErr
+}
+
+func planWebhookSync(existing []*WebhookSubscription, desired []WebhookSpec) *WebhookSyncReport {
+	byKey := map[string][]*WebhookSubscription{}
+	for _, sub := range existing {
+		key := webhookKey(sub.Topic, webhookSubscriptionEndpoint(sub))
+		byKey[key] = append(byKey[key], sub)
+	}
+
+	report := &WebhookSyncReport{}
+	var creates, updates, deletes []*WebhookSyncChange
+	planned := map[string]bool{}
+	for i := range desired {
+		spec := desired[i]
+		if spec.Format == "" {
+			spec.Format = "JSON"
+		}
+		key := webhookKey(spec.Topic, spec.endpoint())
+		if planned[key] {
+			continue
+		}
+		planned[key] = true
+
+		subs := byKey[key]
+		delete(byKey, key)
+		if len(subs) == 0 {
+			creates = append(creates, &WebhookSyncChange{Action: WebhookSyncCreate, Spec: &spec})
+			continue
+		}
+		// duplicates of a subscription are deleted
+		for _, sub := range subs[1:] {
+			deletes = append(deletes, &WebhookSyncChange{Action: WebhookSyncDelete, Subscription: sub})
+		}
+		if webhookSpecMatches(subs[0], spec) {
+			report.Unchanged = append(report.Unchanged, subs[0])
+		} else {
+			updates = append(updates, &WebhookSyncChange{Action: WebhookSyncUpdate, Spec: &spec, Subscription: subs[0]})
+		}
+	}
+
+	for _, sub := range existing {
+		key := webhookKey(sub.Topic, webhookSubscriptionEndpoint(sub))
+		if _, ok := byKey[key]; ok {
+			deletes = append(deletes, &WebhookSyncChange{Action: WebhookSyncDelete, Subscription: sub})
+		}
+	}
+
+	report.Changes = append(append(creates, updates...), deletes...)
+	return report
+}
+
+func (w WebhookServiceOp) createSubscription(ctx context.Context, spec WebhookSpec) (*WebhookSubscription, error) {
+	topic := WebhookTopic{WebhookSubscriptionTopic: spec.Topic}
+	switch {
+	case spec.ARN != "":
+		out, err := w.NewEventBridgeWebhookSubscriptionWithContext(ctx, topic, WebhookTopicSubscription{EventBridgeWebhookSubscriptionInput: eventBridgeWebhookSubscriptionInput(spec)})
+		return &out.WebhookSubscription, err
+	case spec.PubSubProject != "" || spec.PubSubTopic != "":
+		out, err := w.NewPubSubWebhookSubscriptionWithContext(ctx, topic, WebhookTopicSubscription{PubSubWebhookSubscriptionInput: pubSubWebhookSubscriptionInput(spec)})
+		return &out.WebhookSubscription, err
+	default:
+		out, err := w.NewWebhookSubscriptionWithContext(ctx, topic, WebhookTopicSubscription{WebhookSubscriptionInput: webhookSubscriptionInput(spec)})
+		return &out.WebhookSubscription, err
+	}
+}
+
+func (w WebhookServiceOp) updateSubscription(ctx context.Context, id graphql.ID, spec WebhookSpec) (*WebhookSubscription, error) {
+	vars := map[string]interface{}{"id": id}
+	var out WebhookSubscriptionUpdatePayload
+	var err error
+	switch {
+	case spec.ARN != "":
+		m := mutationEventBridgeWebhookUpdate{}
+		vars["webhookSubscription"] = eventBridgeWebhookSubscriptionInput(spec)
+		err = w.client.gql.Mutate(ctx, &m, vars)
+		out = m.EventBridgeWebhookUpdateResult
+	case spec.PubSubProject != "" || spec.PubSubTopic != "":
+		m := mutationPubSubWebhookUpdate{}
+		vars["webhookSubscription"] = pubSubWebhookSubscriptionInput(spec)
+		err = w.client.gql.Mutate(ctx, &m, vars)
+		out = m.PubSubWebhookUpdateResult
+	default:
+		m := mutationWebhookUpdate{}
+		vars["webhookSubscription"] = webhookSubscriptionInput(spec)
+		err = w.client.gql.Mutate(ctx, &m, vars)
+		out = m.WebhookUpdateResult
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out.UserErrors) > 0 {
+		return nil, &UserErrorsError{UserErrors: out.UserErrors}
+	}
+	return &out.WebhookSubscription, nil
+}
+
+func webhookSubscriptionInput(spec WebhookSpec) WebhookSubscriptionInput {
+	return WebhookSubscriptionInput{
+		CallbackURL:         graphql.String(spec.CallbackURL),
+		Format:              spec.Format,
+		IncludeFields:       spec.IncludeFields,
+		MetafieldNamespaces: spec.MetafieldNamespaces,
+	}
+}
+
+func eventBridgeWebhookSubscriptionInput(spec WebhookSpec) EventBridgeWebhookSubscriptionInput {
+	return EventBridgeWebhookSubscriptionInput{
+		ARN:                 graphql.String(spec.ARN),
+		Format:              spec.Format,
+		IncludeFields:       spec.IncludeFields,
+		MetafieldNamespaces: spec.MetafieldNamespaces,
+	}
+}
+
+func pubSubWebhookSubscriptionInput(spec WebhookSpec) PubSubWebhookSubscriptionInput {
+	return PubSubWebhookSubscriptionInput{
+		PubSubProject:       graphql.String(spec.PubSubProject),
+		PubSubTopic:         graphql.String(spec.PubSubTopic),
+		Format:              spec.Format,
+		IncludeFields:       spec.IncludeFields,
+		MetafieldNamespaces: spec.MetafieldNamespaces,
+	}
+}
+
+// pubSubAddress returns the address of a Pub/Sub endpoint, e.g. pubsub://my-project:my-topic.
+func pubSubAddress(project, topic string) string {
+	return "pubsub://" + project + ":" + topic
+}
+
+func webhookKey(topic WebhookSubscriptionTopic, endpoint string) string {
+	return string(topic) + " " + endpoint
+}
+
+// webhookSubscriptionEndpoint returns the address of the endpoint of sub, see WebhookSpec.endpoint.
+func webhookSubscriptionEndpoint(sub *WebhookSubscription) string {
+	e := sub.Endpoint
+	switch {
+	case e.WebhookEventBridgeEndpoint.Arn != "":
+		return string(e.WebhookEventBridgeEndpoint.Arn)
+	case e.WebhookPubSubEndpoint.PubSubProject != "" || e.WebhookPubSubEndpoint.PubSubTopic != "":
+		return pubSubAddress(string(e.WebhookPubSubEndpoint.PubSubProject), string(e.WebhookPubSubEndpoint.PubSubTopic))
+	case e.WebhookHTTPEndpoint.CallbackURL != "":
+		return string(e.WebhookHTTPEndpoint.CallbackURL)
+	default:
+		return string(sub.CallbackURL)
+	}
+}
+
+// webhookSpecMatches reports whether sub has the format, include fields and metafield namespaces of spec.
+func webhookSpecMatches(sub *WebhookSubscription, spec WebhookSpec) bool {
+	return sub.Format == spec.Format &&
+		sameStringSet(graphqlStrings(sub.IncludeFields), spec.IncludeFields) &&
+		sameStringSet(graphqlStrings(sub.MetafieldNamespaces), spec.MetafieldNamespaces)
+}
+
+func graphqlStrings(values []graphql.String) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, string(v))
+	}
+	return out
+}
+
+func sameStringSet(a, b []string) bool {
+	a = sortedUnique(a)
+	b = sortedUnique(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedUnique(values []string) []string {
+	out := append([]string{}, values...)
+	sort.Strings(out)
+	n := 0
+	for i, v := range out {
+		if i == 0 || v != out[n-1] {
+			out[n] = v
+			n++
+		}
+	}
+	return out[:n]
+}
@@ -0,0 +1,86 @@
+package shopify_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	shopify "github.com/gempages/go-shopify-graphql"
+	"github.com/gempages/go-shopify-graphql/shopifytest"
+)
+
+func TestWebhookSync(t *testing.T) {
+	srv := shopifytest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	const callbackURL = "https://example.com/webhooks"
+	srv.AddWebhookSubscription(&shopifytest.WebhookSubscription{Topic: "PRODUCTS_CREATE", CallbackURL: callbackURL})
+	srv.AddWebhookSubscription(&shopifytest.WebhookSubscription{Topic: "PRODUCTS_UPDATE", CallbackURL: callbackURL, IncludeFields: []string{"id"}})
+	srv.AddWebhookSubscription(&shopifytest.WebhookSubscription{Topic: "CUSTOMERS_CREATE", CallbackURL: callbackURL})
+
+	desired := []shopify.WebhookSpec{
+		{Topic: shopify.WebhookSubscriptionTopicProductsCreate, CallbackURL: callbackURL},
+		{Topic: shopify.WebhookSubscriptionTopicProductsUpdate, CallbackURL: callbackURL, IncludeFields: []string{"title", "id"}},
+		{Topic: shopify.WebhookSubscriptionTopicOrdersCreate, CallbackURL: callbackURL, MetafieldNamespaces: []string{"custom"}},
+		{Topic: shopify.WebhookSubscriptionTopicAppUninstall, ARN: "arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/1/test"},
+		{Topic: shopify.WebhookSubscriptionTopicShopUpdate, PubSubProject: "my-project", PubSubTopic: "shop"},
+	}
+	const plan = `create ORDERS_CREATE https://example.com/webhooks
+create APP_UNINSTALLED arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/1/test
+create SHOP_UPDATE pubsub://my-project:shop
+update PRODUCTS_UPDATE https://example.com/webhooks
+delete CUSTOMERS_CREATE https://example.com/webhooks
+`
+
+	report, err := client.Webhook.SyncWithContext(ctx, desired, shopify.WebhookSyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if report.String() != plan || len(report.Unchanged) != 1 {
+		t.Fatalf("unexpected plan:\n%s", report)
+	}
+	if n := len(srv.WebhookSubscriptions()); n != 3 {
+		t.Fatalf("expected the dry run to change nothing, got %d subscriptions", n)
+	}
+
+	report, err = client.Webhook.SyncWithContext(ctx, desired, shopify.WebhookSyncOptions{})
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if report.String() != plan || len(report.Failed()) != 0 {
+		t.Fatalf("unexpected report:\n%s", report)
+	}
+	topics := map[string]*shopifytest.WebhookSubscription{}
+	for _, w := range srv.WebhookSubscriptions() {
+		topics[w.Topic] = w
+	}
+	if len(topics) != 5 || topics["CUSTOMERS_CREATE"] != nil {
+		t.Fatalf("unexpected subscriptions %+v", topics)
+	}
+	if w := topics["PRODUCTS_UPDATE"]; strings.Join(w.IncludeFields, ",") != "title,id" {
+		t.Errorf("expected PRODUCTS_UPDATE to be updated, got %+v", w)
+	}
+	if w := topics["ORDERS_CREATE"]; strings.Join(w.MetafieldNamespaces, ",") != "custom" {
+		t.Errorf("unexpected ORDERS_CREATE subscription %+v", w)
+	}
+
+	// the subscriptions have converged
+	report, err = client.Webhook.SyncWithContext(ctx, desired, shopify.WebhookSyncOptions{})
+	if err != nil {
+		t.Fatalf("sync again: %v", err)
+	}
+	if len(report.Changes) != 0 || len(report.Unchanged) != 5 {
+		t.Errorf("expected no change, got:\n%s", report)
+	}
+
+	// all the pages of subscriptions are listed
+	webhooks, err := client.Webhook.IterWithContext(ctx, nil, shopify.ListOptions{First: 2}).All()
+	if err != nil {
+		t.Fatalf("iter: %v", err)
+	}
+	if len(webhooks) != 5 {
+		t.Errorf("expected 5 subscriptions, got %d", len(webhooks))
+	}
+}